@@ -3,9 +3,14 @@ package exchanges
 import (
 	"context"
 	"fmt"
+	"log"
+	"net"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/adshao/go-binance"
@@ -14,7 +19,9 @@ import (
 const (
 	BINANCE_API_KEY    = "BINANCE_API_KEY"
 	BINANCE_API_SECRET = "BINANCE_API_SECRET"
-	BINANCE_EXCHANGE   = "binance"
+	// BINANCE_API_URL - optional base URL of the api eg. a local emulator, the real api if not set
+	BINANCE_API_URL  = "BINANCE_API_URL"
+	BINANCE_EXCHANGE = "binance"
 )
 
 const (
@@ -25,8 +32,21 @@ const (
 	USDT = "USDT"
 )
 
+// TRADING_PAIRS_TTL - how long exchange info is cached before it is fetched again
+const TRADING_PAIRS_TTL = time.Duration(1 * time.Hour)
+
 type binanceClient struct {
+	*pairCache
 	client *binance.Client
+	ctx    context.Context // requests are cancelled when it is done
+}
+
+// pairCache - trading pairs shared by a client and the copies made by WithContext
+type pairCache struct {
+	sync.RWMutex
+	pairs        map[string]TradingPair // trading pairs by binance symbol
+	pairsFetched time.Time
+	pairsTried   time.Time // when pairs were last requested even if it failed
 }
 
 func NewBinanceClient() (ExchangeClient, error) {
@@ -40,21 +60,110 @@ func NewBinanceClient() (ExchangeClient, error) {
 		return nil, fmt.Errorf("You must set environment variable %s with your secret", BINANCE_API_SECRET)
 	}
 
+	return NewBinanceClientWithURL(os.Getenv(BINANCE_API_URL), apiKey, secretKey)
+}
+
+// NewBinanceClientWithURL - returns a client for the binance api at baseURL, the real api if baseURL is empty
+func NewBinanceClientWithURL(baseURL, apiKey, secretKey string) (ExchangeClient, error) {
 	client := &binanceClient{
-		client: binance.NewClient(apiKey, secretKey),
+		pairCache: &pairCache{},
+		client:    binance.NewClient(apiKey, secretKey),
+		ctx:       context.Background(),
+	}
+
+	if baseURL != "" {
+		if _, err := url.ParseRequestURI(baseURL); err != nil {
+			return nil, fmt.Errorf("Invalid binance api url: %s - %s", baseURL, err)
+		}
+		client.client.BaseURL = strings.TrimSuffix(baseURL, "/")
 	}
 
 	return client, nil
 }
 
+// WithContext - returns a copy of the client whose requests are cancelled when ctx is done
+func (b *binanceClient) WithContext(ctx context.Context) ExchangeClient {
+	c := *b
+	c.ctx = ctx
+	return &c
+}
+
+// BINANCE_WEIGHT_LIMIT - request weight binance allows a minute
+const BINANCE_WEIGHT_LIMIT = 1200
+
+// EXCHANGE_INFO_WEIGHT - weight of the exchange info request trading pairs are fetched with
+const EXCHANGE_INFO_WEIGHT = 10
+
+// RequestCost - returns the number of requests a call makes and their weight on binance.
+// Calls that look up trading pairs include exchange info when the cached pairs have expired,
+// a fetch for a new market isn't included as it happens at most once a minute
+func (b *binanceClient) RequestCost(method string) (int, int) {
+	requests, weight := 1, 1
+	switch method {
+	case "GetCoinBalances":
+		weight = 5
+	case "GetLatestPrices":
+		weight = 2
+	case "GetDaySummaries":
+		// 24 hour ticker for all symbols
+		weight = 40
+	case "GetOpenOrders":
+		// assume all symbols
+		weight = 40
+	case "GetTradingPairs":
+		// exchange info is the only request
+		if b.pairsExpired() {
+			weight = EXCHANGE_INFO_WEIGHT
+		}
+		return requests, weight
+	}
+
+	switch method {
+	case "GetLatestPrices", "GetDaySummaries", "GetOpenOrders":
+		if b.pairsExpired() {
+			requests++
+			weight += EXCHANGE_INFO_WEIGHT
+		}
+	}
+
+	return requests, weight
+}
+
+// binanceTransientCodes - binance error codes a retry may fix, disconnected, too many
+// requests, unexpected response and timeout
+var binanceTransientCodes = []string{"code=-1001", "code=-1003", "code=-1006", "code=-1007"}
+
+// classify - returns wrapped as a TransientError if err from a binance request may not
+// happen when it is tried again
+func classify(err error, wrapped error) error {
+	if err == context.DeadlineExceeded {
+		return &TransientError{Err: wrapped}
+	}
+	switch e := err.(type) {
+	case *url.Error:
+		// failed to reach the api
+		return &TransientError{Err: wrapped}
+	case net.Error:
+		if e.Timeout() {
+			return &TransientError{Err: wrapped}
+		}
+	}
+	for _, code := range binanceTransientCodes {
+		if strings.Contains(err.Error(), code) {
+			return &TransientError{Err: wrapped}
+		}
+	}
+	return wrapped
+}
+
 func (b *binanceClient) GetExchange() string {
 	return "binance"
 }
 
 func (b *binanceClient) GetCoinBalances() ([]CoinBalance, error) {
-	res, err := b.client.NewGetAccountService().Do(context.Background())
+	res, err := b.client.NewGetAccountService().Do(b.ctx)
 	if err != nil {
-		return nil, err
+		return nil, classify(err, err)
 	}
 
 	balances := make([]CoinBalance, 0)
@@ -82,7 +191,16 @@ func (b *binanceClient) GetCoinBalances() ([]CoinBalance, error) {
 }
 
 func (b *binanceClient) GetLatestPrices() ([]Price, error) {
-	res, err := b.client.NewListPricesService().Do(context.Background())
+	res, err := b.client.NewListPricesService().Do(b.ctx)
+	if err != nil {
+		return nil, classify(err, err)
+	}
+
+	symbols := make([]string, len(res))
+	for i, binancePrice := range res {
+		symbols[i] = binancePrice.Symbol
+	}
+	pairs, err := b.knownPairs(symbols)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +208,12 @@ func (b *binanceClient) GetLatestPrices() ([]Price, error) {
 	prices := make([]Price, 0)
 	// convert results to prices
 	for _, binancePrice := range res {
-		if binancePrice.Symbol == "123456" {
+
+		// split LTCBTC symbol into base and as symbols
+		// LTC/BTC
+		pair, ok := pairs[binancePrice.Symbol]
+		if !ok {
+			logSkipped("price", fmt.Errorf("Unexpected symbol type %s", binancePrice.Symbol))
 			continue
 		}
 
@@ -99,144 +222,636 @@ func (b *binanceClient) GetLatestPrices() ([]Price, error) {
 			return nil, fmt.Errorf("Failed to parse symbol price: %s - %s. %s", binancePrice.Symbol, err, binancePrice.Price)
 		}
 
-		// split LTCBTC symbol into base and as symbols
-		// LTC/BTC
-
 		price := Price{
+			Base:     pair.Base,
+			As:       pair.As,
 			Price:    symbolPrice,
 			At:       time.Now(),
 			Exchange: BINANCE_EXCHANGE,
 		}
 
-		if strings.HasSuffix(binancePrice.Symbol, BTC) {
-			// Bitcoin price
-			price.As = BTC
-		} else if strings.HasSuffix(binancePrice.Symbol, BNB) {
-			// Binance coin price
-			price.As = BNB
-		} else if strings.HasSuffix(binancePrice.Symbol, ETH) {
-			// Ether price
-			price.As = ETH
-		} else if strings.HasSuffix(binancePrice.Symbol, LTC) {
-			// Litecoin price
-			price.As = LTC
-		} else if strings.HasSuffix(binancePrice.Symbol, USDT) {
-			// US Dollar price
-			price.As = USDT
+		prices = append(prices, price)
+
+	}
+
+	return prices, nil
+}
+
+// STREAM_BUFFER - prices held for a slow subscriber before the stream waits for it
+const STREAM_BUFFER = 1000
+
+// SubscribePrices - streams last prices from binance's all market ticker stream, it sends
+// the tickers that changed about once a second
+func (b *binanceClient) SubscribePrices(ctx context.Context) (<-chan Price, error) {
+	// fetch trading pairs before the first tickers arrive
+	if _, err := b.tradingPairs(false); err != nil {
+		return nil, err
+	}
+
+	prices := make(chan Price, STREAM_BUFFER)
+
+	handler := func(event binance.WsAllMarketsStatEvent) {
+		symbols := make([]string, len(event))
+		for i, stat := range event {
+			symbols[i] = stat.Symbol
+		}
+		pairs, err := b.knownPairs(symbols)
+		if err != nil {
+			logSkipped("streamed prices", err)
+			return
 		}
 
-		// only use price
-		if price.As == "" {
-			return nil, fmt.Errorf("Unexpected symbol type %s - %#v", binancePrice.Symbol, binancePrice)
+		for _, stat := range event {
+			price, err := fromMarketStat(pairs, stat)
+			if err != nil {
+				logSkipped("streamed price", err)
+				continue
+			}
+			select {
+			case prices <- price:
+			case <-ctx.Done():
+				return
+			}
 		}
-		price.Base = strings.Replace(binancePrice.Symbol, string(price.As), "", 1)
+	}
 
-		prices = append(prices, price)
+	errHandler := func(err error) {
+		log.Printf("Binance price stream error - %s", err)
+	}
 
+	doneC, stopC, err := binance.WsAllMarketsStatServe(handler, errHandler)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to subscribe to binance prices - %s", err)
 	}
 
+	go func() {
+		// the handler is not called again once doneC is closed
+		defer close(prices)
+		select {
+		case <-ctx.Done():
+			close(stopC)
+			<-doneC
+		case <-doneC:
+		}
+	}()
+
 	return prices, nil
 }
 
+// fromMarketStat - converts a ticker from the all market stream into a price
+func fromMarketStat(pairs map[string]TradingPair, stat *binance.WsMarketStatEvent) (Price, error) {
+	pair, ok := pairs[stat.Symbol]
+	if !ok {
+		return Price{}, fmt.Errorf("Unexpected symbol type %s", stat.Symbol)
+	}
+
+	lastPrice, err := strconv.ParseFloat(stat.LastPrice, 64)
+	if err != nil {
+		return Price{}, fmt.Errorf("Failed to parse symbol price: %s - %s. %s", stat.Symbol, err, stat.LastPrice)
+	}
+
+	return Price{
+		Base:     pair.Base,
+		As:       pair.As,
+		Price:    lastPrice,
+		At:       fromMillis(stat.Time),
+		Exchange: BINANCE_EXCHANGE,
+	}, nil
+}
+
 // func (b *binanceClient) GetHistoricPrices() ([]Price, error) {
 // 	// TODO - get some old price data
 // 	prices := make([]Price, 0)
 // 	return prices, nil
 // }
 
+// GetDaySummaries - returns the 24 hour ticker of every trading pair in one request,
+// tickers that cannot be parsed are skipped
 func (b *binanceClient) GetDaySummaries() ([]DaySummary, error) {
 
-	// Get latest prices for every coin
-	info, err := b.client.NewExchangeInfoService().Do(context.Background())
+	stats, err := b.client.NewListPriceChangeStatsService().Do(b.ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get exchange info - %s", err)
+		return nil, classify(err, fmt.Errorf("Failed to get 24 hour tickers - %s", err))
 	}
 
-	// for each symbol, fetch last days price
+	symbols := make([]string, len(stats))
+	for i, stat := range stats {
+		symbols[i] = stat.Symbol
+	}
+	pairs, err := b.knownPairs(symbols)
+	if err != nil {
+		return nil, err
+	}
 
-	days := make([]DaySummary, len(info.Symbols))
+	days := make([]DaySummary, 0, len(stats))
+	for _, stat := range stats {
+		day, err := fromPriceChangeStats(pairs, stat)
+		if err != nil {
+			logSkipped("day summary", err)
+			continue
+		}
+		days = append(days, day)
+	}
 
-	fmt.Printf("Exchange currently supports %d different symbols\n", len(info.Symbols))
+	return days, nil
+}
+
+// fromPriceChangeStats - converts a 24 hour ticker into a day summary
+func fromPriceChangeStats(pairs map[string]TradingPair, stats *binance.PriceChangeStats) (DaySummary, error) {
+	pair, ok := pairs[stats.Symbol]
+	if !ok {
+		return DaySummary{}, fmt.Errorf("Unexpected symbol type %s", stats.Symbol)
+	}
 
-	for i, symbol := range info.Symbols {
-		key := symbol.Symbol
-		stats, err := b.client.NewPriceChangeStatsService().Symbol(key).Do(context.Background())
+	var err error
+
+	// parse keeps the first error
+	parse := func(name, value string) float64 {
 		if err != nil {
-			return nil, fmt.Errorf("Failed to get price change info for symbol %s - %s", key, err)
-		}
-
-		var as string
-		if strings.HasSuffix(symbol.Symbol, BTC) {
-			// Bitcoin price
-			as = BTC
-		} else if strings.HasSuffix(symbol.Symbol, BNB) {
-			// Binance coin price
-			as = BNB
-		} else if strings.HasSuffix(symbol.Symbol, ETH) {
-			// Ether price
-			as = ETH
-		} else if strings.HasSuffix(symbol.Symbol, LTC) {
-			// Litecoin price
-			as = LTC
-		} else if strings.HasSuffix(symbol.Symbol, USDT) {
-			// US Dollar price
-			as = USDT
-		}
-
-		if as == "" {
-			fmt.Printf("Unexpected symbol type %s - %#v", symbol.Symbol, symbol)
+			return 0
+		}
+		f, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil {
+			err = fmt.Errorf("Failed to parse symbol %s: %s - %s. %s", name, stats.Symbol, value, parseErr)
+		}
+		return f
+	}
+
+	day := DaySummary{
+		Base:             pair.Base,
+		As:               pair.As,
+		OpenPrice:        parse("open price", stats.OpenPrice),
+		ClosePrice:       parse("close price", stats.LastPrice),
+		WeightedAvgPrice: parse("weighted avg price", stats.WeightedAvgPrice),
+		HighestPrice:     parse("highest price", stats.HighPrice),
+		LowestPrice:      parse("lowest price", stats.LowPrice),
+		ChangePrice:      parse("change price", stats.PriceChange),
+		ChangePercent:    parse("change percent", stats.PriceChangePercent),
+		Volume:           parse("volume", stats.Volume),
+		QuoteVolume:      parse("quote volume", stats.QuoteVolume),
+		BidPrice:         parse("bid price", stats.BidPrice),
+		BidQuantity:      parse("bid quantity", stats.BidQty),
+		AskPrice:         parse("ask price", stats.AskPrice),
+		AskQuantity:      parse("ask quantity", stats.AskQty),
+		Trades:           stats.Count,
+		At:               fromMillis(stats.CloseTime),
+		Exchange:         BINANCE_EXCHANGE,
+	}
+	if err != nil {
+		return DaySummary{}, err
+	}
+
+	return day, nil
+}
+
+// GetTradingPairs - returns every trading pair on the exchange sorted by symbol
+func (b *binanceClient) GetTradingPairs() ([]TradingPair, error) {
+	pairs, err := b.tradingPairs(false)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]TradingPair, 0, len(pairs))
+	for _, pair := range pairs {
+		list = append(list, pair)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Symbol < list[j].Symbol })
+
+	return list, nil
+}
+
+// pairsExpired - returns true if trading pairs will be fetched from exchange info when next used
+func (b *binanceClient) pairsExpired() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.pairs == nil || time.Since(b.pairsFetched) >= TRADING_PAIRS_TTL
+}
+
+// tradingPairs - returns cached trading pairs by symbol, fetching them from exchange info
+// when they are older than TRADING_PAIRS_TTL or refresh is true
+func (b *binanceClient) tradingPairs(refresh bool) (map[string]TradingPair, error) {
+	b.RLock()
+	pairs := b.pairs
+	fetched := b.pairsFetched
+	b.RUnlock()
+
+	if pairs != nil && !refresh && time.Since(fetched) < TRADING_PAIRS_TTL {
+		return pairs, nil
+	}
+
+	b.Lock()
+	b.pairsTried = time.Now()
+	b.Unlock()
+
+	info, err := b.client.NewExchangeInfoService().Do(b.ctx)
+	if err != nil {
+		return nil, classify(err, fmt.Errorf("Failed to get exchange info - %s", err))
+	}
+
+	pairs = make(map[string]TradingPair, len(info.Symbols))
+	for _, symbol := range info.Symbols {
+		pair, err := toTradingPair(symbol)
+		if err != nil {
+			logSkipped("trading pair", err)
+			continue
+		}
+		pairs[pair.Symbol] = pair
+	}
+
+	b.Lock()
+	b.pairs = pairs
+	b.pairsFetched = time.Now()
+	b.Unlock()
+
+	return pairs, nil
+}
+
+// tradingPair - returns the trading pair for a binance symbol eg. LTCBTC
+func (b *binanceClient) tradingPair(symbol string) (TradingPair, error) {
+	pairs, err := b.knownPairs([]string{symbol})
+	if err != nil {
+		return TradingPair{}, err
+	}
+
+	if pair, ok := pairs[symbol]; ok {
+		return pair, nil
+	}
+
+	return TradingPair{}, fmt.Errorf("Unexpected symbol type %s", symbol)
+}
+
+// knownPairs - returns trading pairs to look up the symbols of a response in. Exchange info
+// is fetched again once if any symbol is not known in case it is a new market, but not
+// if it was requested in the last minute
+func (b *binanceClient) knownPairs(symbols []string) (map[string]TradingPair, error) {
+	pairs, err := b.tradingPairs(false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, symbol := range symbols {
+		if _, ok := pairs[symbol]; ok {
 			continue
 		}
 
-		days[i] = DaySummary{
-			Base: symbol.BaseAsset,
-			As:   as,
+		b.RLock()
+		recent := time.Since(b.pairsTried) < time.Minute
+		b.RUnlock()
+		if recent {
+			break
 		}
 
-		openPrice, err := strconv.ParseFloat(stats.OpenPrice, 64)
+		refreshed, err := b.tradingPairs(true)
 		if err != nil {
-			return nil, fmt.Errorf("Failed to parse symbol open price: %s - %s. %s", symbol.BaseAsset, stats.OpenPrice, err)
+			// the pairs already known can still be used
+			logSkipped("new trading pairs", err)
+			break
 		}
-		days[i].OpenPrice = openPrice
+		return refreshed, nil
+	}
+
+	return pairs, nil
+}
 
-		closePrice, err := strconv.ParseFloat(stats.LastPrice, 64)
+// MAX_CANDLES_PER_REQUEST - binance limit on the number of klines returned by a request
+const MAX_CANDLES_PER_REQUEST = 1000
+
+func (b *binanceClient) GetCandles(base, as string, interval CandleInterval, from, to time.Time) ([]Candle, error) {
+	duration, err := interval.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]Candle, 0)
+
+	// fetch a page of klines at a time
+	for start := from; start.Before(to); {
+		klines, err := b.client.NewKlinesService().
+			Symbol(base + as).
+			Interval(string(interval)).
+			StartTime(toMillis(start)).
+			EndTime(toMillis(to) - 1).
+			Limit(MAX_CANDLES_PER_REQUEST).
+			Do(b.ctx)
 		if err != nil {
-			return nil, fmt.Errorf("Failed to parse symbol close price: %s - %s. %s", symbol.BaseAsset, stats.LastPrice, err)
+			return nil, classify(err, fmt.Errorf("Failed to get candles for %s%s - %s", base, as, err))
 		}
-		days[i].ClosePrice = closePrice
 
-		weightedAvgPrice, err := strconv.ParseFloat(stats.WeightedAvgPrice, 64)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse symbol weighted avg price: %s - %s. %s", symbol.BaseAsset, stats.WeightedAvgPrice, err)
+		for _, kline := range klines {
+			candle, err := b.toCandle(base, as, interval, kline)
+			if err != nil {
+				return nil, err
+			}
+			candles = append(candles, candle)
 		}
-		days[i].WeightedAvgPrice = weightedAvgPrice
 
-		highestPrice, err := strconv.ParseFloat(stats.HighPrice, 64)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse symbol highest price: %s - %s. %s", symbol.BaseAsset, stats.HighPrice, err)
+		if len(klines) < MAX_CANDLES_PER_REQUEST {
+			break
 		}
-		days[i].HighestPrice = highestPrice
 
-		lowestPrice, err := strconv.ParseFloat(stats.LowPrice, 64)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse symbol lowest price: %s - %s. %s", symbol.BaseAsset, stats.LowPrice, err)
+		// next page starts after the last kline
+		start = fromMillis(klines[len(klines)-1].OpenTime).Add(duration)
+	}
+
+	return candles, nil
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// toCandle - converts a binance kline into a candle
+func (b *binanceClient) toCandle(base, as string, interval CandleInterval, kline *binance.Kline) (Candle, error) {
+	var err error
+
+	candle := Candle{
+		Base:      base,
+		As:        as,
+		Interval:  interval,
+		OpenTime:  fromMillis(kline.OpenTime),
+		CloseTime: fromMillis(kline.CloseTime),
+		Exchange:  b.GetExchange(),
+	}
+
+	if candle.Open, err = strconv.ParseFloat(kline.Open, 64); err != nil {
+		return Candle{}, fmt.Errorf("Failed to parse candle open price: %s - %s", kline.Open, err)
+	}
+	if candle.High, err = strconv.ParseFloat(kline.High, 64); err != nil {
+		return Candle{}, fmt.Errorf("Failed to parse candle high price: %s - %s", kline.High, err)
+	}
+	if candle.Low, err = strconv.ParseFloat(kline.Low, 64); err != nil {
+		return Candle{}, fmt.Errorf("Failed to parse candle low price: %s - %s", kline.Low, err)
+	}
+	if candle.Close, err = strconv.ParseFloat(kline.Close, 64); err != nil {
+		return Candle{}, fmt.Errorf("Failed to parse candle close price: %s - %s", kline.Close, err)
+	}
+	if candle.Volume, err = parseAmount(kline.Volume); err != nil {
+		return Candle{}, err
+	}
+	if candle.QuoteVolume, err = parseAmount(kline.QuoteAssetVolume); err != nil {
+		return Candle{}, err
+	}
+
+	return candle, nil
+}
+
+// toTradingPair - converts exchange info for a symbol including its price, lot size and notional filters
+func toTradingPair(symbol binance.Symbol) (TradingPair, error) {
+	pair := TradingPair{
+		Symbol:        symbol.Symbol,
+		Base:          symbol.BaseAsset,
+		As:            symbol.QuoteAsset,
+		Status:        symbol.Status,
+		BasePrecision: symbol.BaseAssetPrecision,
+		AsPrecision:   symbol.QuotePrecision,
+		Exchange:      BINANCE_EXCHANGE,
+	}
+
+	for _, filter := range symbol.Filters {
+		var err error
+		switch filter["filterType"] {
+		case "PRICE_FILTER":
+			if pair.MinPrice, err = filterValue(filter, "minPrice"); err != nil {
+				return TradingPair{}, fmt.Errorf("Invalid price filter for %s - %s", symbol.Symbol, err)
+			}
+			if pair.MaxPrice, err = filterValue(filter, "maxPrice"); err != nil {
+				return TradingPair{}, fmt.Errorf("Invalid price filter for %s - %s", symbol.Symbol, err)
+			}
+			if pair.TickSize, err = filterValue(filter, "tickSize"); err != nil {
+				return TradingPair{}, fmt.Errorf("Invalid price filter for %s - %s", symbol.Symbol, err)
+			}
+		case "LOT_SIZE":
+			if pair.MinQuantity, err = filterValue(filter, "minQty"); err != nil {
+				return TradingPair{}, fmt.Errorf("Invalid lot size filter for %s - %s", symbol.Symbol, err)
+			}
+			if pair.MaxQuantity, err = filterValue(filter, "maxQty"); err != nil {
+				return TradingPair{}, fmt.Errorf("Invalid lot size filter for %s - %s", symbol.Symbol, err)
+			}
+			if pair.StepSize, err = filterValue(filter, "stepSize"); err != nil {
+				return TradingPair{}, fmt.Errorf("Invalid lot size filter for %s - %s", symbol.Symbol, err)
+			}
+		case "MIN_NOTIONAL", "NOTIONAL":
+			if pair.MinNotional, err = filterValue(filter, "minNotional"); err != nil {
+				return TradingPair{}, fmt.Errorf("Invalid notional filter for %s - %s", symbol.Symbol, err)
+			}
 		}
-		days[i].LowestPrice = lowestPrice
+	}
 
-		changePrice, err := strconv.ParseFloat(stats.PriceChange, 64)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse symbol change price: %s - %s. %s", symbol.BaseAsset, stats.PriceChange, err)
+	return pair, nil
+}
+
+// filterValue - parses a value from an exchange info filter, missing values are 0
+func filterValue(filter map[string]interface{}, name string) (float64, error) {
+	value, ok := filter[name]
+	if !ok {
+		return 0, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("%s value %v is not a string", name, value)
+	}
+	return parseAmount(str)
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
+
+func parseAmount(amount string) (float64, error) {
+	if amount == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to parse order amount: %s - %s", amount, err)
+	}
+	return value, nil
+}
+
+// toOrder - converts a binance order into an exchange order
+func (b *binanceClient) toOrder(base, as string, o *binance.Order) (Order, error) {
+	var err error
+
+	order := Order{
+		ID:       strconv.FormatInt(o.OrderID, 10),
+		Base:     base,
+		As:       as,
+		Side:     OrderSide(o.Side),
+		Type:     OrderType(o.Type),
+		Status:   OrderStatus(o.Status),
+		Fills:    make([]Fill, 0),
+		Exchange: b.GetExchange(),
+		At:       time.Unix(0, o.UpdateTime*int64(time.Millisecond)),
+	}
+
+	if order.Price, err = parseAmount(o.Price); err != nil {
+		return Order{}, err
+	}
+	if order.Quantity, err = parseAmount(o.OrigQuantity); err != nil {
+		return Order{}, err
+	}
+	if order.ExecutedQuantity, err = parseAmount(o.ExecutedQuantity); err != nil {
+		return Order{}, err
+	}
+	if order.QuoteQuantity, err = parseAmount(o.CummulativeQuoteQuantity); err != nil {
+		return Order{}, err
+	}
+
+	return order, nil
+}
+
+func (b *binanceClient) createResponseToOrder(base, as string, res *binance.CreateOrderResponse) (Order, error) {
+	order, err := b.toOrder(base, as, &binance.Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  res.OrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		UpdateTime:               res.TransactTime,
+	})
+	if err != nil {
+		return Order{}, err
+	}
+
+	for _, f := range res.Fills {
+		fill := Fill{CommissionAsset: f.CommissionAsset}
+		if fill.Price, err = parseAmount(f.Price); err != nil {
+			return Order{}, err
+		}
+		if fill.Quantity, err = parseAmount(f.Quantity); err != nil {
+			return Order{}, err
 		}
-		days[i].ChangePrice = changePrice
+		if fill.Commission, err = parseAmount(f.Commission); err != nil {
+			return Order{}, err
+		}
+		order.Fills = append(order.Fills, fill)
+	}
 
-		changePercent, err := strconv.ParseFloat(stats.PriceChangePercent, 64)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse symbol change percent: %s - %s. %s", symbol.BaseAsset, stats.PriceChangePercent, err)
+	return order, nil
+}
+
+func (b *binanceClient) PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error) {
+	res, err := b.client.NewCreateOrderService().
+		Symbol(base + as).
+		Side(binance.SideType(side)).
+		Type(binance.OrderTypeMarket).
+		Quantity(formatQuantity(quantity)).
+		NewOrderRespType(binance.NewOrderRespTypeFULL).
+		Do(b.ctx)
+	if err != nil {
+		return Order{}, classify(err, fmt.Errorf("Failed to place market order for %s%s - %s", base, as, err))
+	}
+
+	return b.createResponseToOrder(base, as, res)
+}
+
+func (b *binanceClient) PlaceLimitOrder(side OrderSide, base, as string, quantity, price float64) (Order, error) {
+	res, err := b.client.NewCreateOrderService().
+		Symbol(base + as).
+		Side(binance.SideType(side)).
+		Type(binance.OrderTypeLimit).
+		TimeInForce(binance.TimeInForceTypeGTC).
+		Quantity(formatQuantity(quantity)).
+		Price(formatQuantity(price)).
+		NewOrderRespType(binance.NewOrderRespTypeFULL).
+		Do(b.ctx)
+	if err != nil {
+		return Order{}, classify(err, fmt.Errorf("Failed to place limit order for %s%s - %s", base, as, err))
+	}
+
+	return b.createResponseToOrder(base, as, res)
+}
+
+func (b *binanceClient) CancelOrder(base, as, id string) (Order, error) {
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return Order{}, fmt.Errorf("Order id %s is not valid - %s", id, err)
+	}
+
+	res, err := b.client.NewCancelOrderService().Symbol(base + as).OrderID(orderID).Do(b.ctx)
+	if err != nil {
+		return Order{}, classify(err, fmt.Errorf("Failed to cancel order %s for %s%s - %s", id, base, as, err))
+	}
+
+	return b.toOrder(base, as, &binance.Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  res.OrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		UpdateTime:               res.TransactTime,
+	})
+}
+
+func (b *binanceClient) GetOrder(base, as, id string) (Order, error) {
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return Order{}, fmt.Errorf("Order id %s is not valid - %s", id, err)
+	}
+
+	res, err := b.client.NewGetOrderService().Symbol(base + as).OrderID(orderID).Do(b.ctx)
+	if err != nil {
+		return Order{}, classify(err, fmt.Errorf("Failed to get order %s for %s%s - %s", id, base, as, err))
+	}
+
+	return b.toOrder(base, as, res)
+}
+
+// GetOpenOrders - returns open orders for base and as, or for every symbol if they are empty
+func (b *binanceClient) GetOpenOrders(base, as string) ([]Order, error) {
+	service := b.client.NewListOpenOrdersService()
+	if base != "" || as != "" {
+		service = service.Symbol(base + as)
+	}
+
+	res, err := service.Do(b.ctx)
+	if err != nil {
+		return nil, classify(err, fmt.Errorf("Failed to get open orders - %s", err))
+	}
+
+	// orders of every symbol are split into base and as with the trading pairs, fetched once
+	var pairs map[string]TradingPair
+	if base == "" && as == "" {
+		symbols := make([]string, len(res))
+		for i, o := range res {
+			symbols[i] = o.Symbol
+		}
+		if pairs, err = b.knownPairs(symbols); err != nil {
+			return nil, err
+		}
+	}
+
+	orders := make([]Order, 0, len(res))
+	for _, o := range res {
+		orderBase, orderAs := base, as
+		if pairs != nil {
+			pair, ok := pairs[o.Symbol]
+			if !ok {
+				logSkipped("open order", fmt.Errorf("Unexpected symbol type %s", o.Symbol))
+				continue
+			}
+			orderBase, orderAs = pair.Base, pair.As
 		}
-		days[i].ChangePercent = changePercent
 
-		days[i].At = time.Unix(stats.CloseTime, 0)
-		days[i].Exchange = b.GetExchange()
+		order, err := b.toOrder(orderBase, orderAs, o)
+		if err != nil {
+			logSkipped("open order", err)
+			continue
+		}
+		orders = append(orders, order)
 	}
-	return days, nil
+
+	return orders, nil
 }
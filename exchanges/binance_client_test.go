@@ -0,0 +1,547 @@
+package exchanges
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTradingPair(t *testing.T) {
+
+	symbol := binance.Symbol{
+		Symbol:             "ETHTUSD",
+		Status:             TRADING_STATUS,
+		BaseAsset:          "ETH",
+		BaseAssetPrecision: 8,
+		QuoteAsset:         "TUSD",
+		QuotePrecision:     2,
+		Filters: []map[string]interface{}{
+			{"filterType": "PRICE_FILTER", "minPrice": "0.01000000", "maxPrice": "100000.00000000", "tickSize": "0.01000000"},
+			{"filterType": "LOT_SIZE", "minQty": "0.00010000", "maxQty": "9000.00000000", "stepSize": "0.00010000"},
+			{"filterType": "MIN_NOTIONAL", "minNotional": "10.00000000"},
+			{"filterType": "MAX_NUM_ORDERS", "maxNumOrders": 200.0},
+		},
+	}
+
+	pair, err := toTradingPair(symbol)
+	assert.NoError(t, err)
+	assert.Equal(t, TradingPair{
+		Symbol:        "ETHTUSD",
+		Base:          "ETH",
+		As:            "TUSD",
+		Status:        TRADING_STATUS,
+		BasePrecision: 8,
+		AsPrecision:   2,
+		MinPrice:      0.01,
+		MaxPrice:      100000,
+		TickSize:      0.01,
+		MinQuantity:   0.0001,
+		MaxQuantity:   9000,
+		StepSize:      0.0001,
+		MinNotional:   10,
+		Exchange:      BINANCE_EXCHANGE,
+	}, pair)
+	assert.True(t, pair.IsTrading())
+
+	symbol.Status = "BREAK"
+	pair, err = toTradingPair(symbol)
+	assert.NoError(t, err)
+	assert.False(t, pair.IsTrading())
+
+	symbol.Filters = []map[string]interface{}{{"filterType": "LOT_SIZE", "minQty": 1.0}}
+	_, err = toTradingPair(symbol)
+	assert.Error(t, err)
+}
+
+func TestRoundQuantity(t *testing.T) {
+
+	pair := TradingPair{Symbol: "ETHTUSD", MinQuantity: 0.001, StepSize: 0.001}
+
+	quantity, err := pair.RoundQuantity(1.23456789)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.234, quantity)
+	assert.Equal(t, "1.234", formatQuantity(quantity))
+
+	// already a multiple of the step size
+	quantity, err = pair.RoundQuantity(0.3)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.3, quantity)
+
+	_, err = pair.RoundQuantity(0.0009)
+	assert.Error(t, err)
+
+	pair.StepSize = 1
+	quantity, err = pair.RoundQuantity(10.9)
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, quantity)
+}
+
+func TestToCandle(t *testing.T) {
+
+	client := &binanceClient{}
+
+	kline := &binance.Kline{
+		OpenTime:         1516233600000,
+		Open:             "0.09000000",
+		High:             "0.09500000",
+		Low:              "0.08500000",
+		Close:            "0.09100000",
+		Volume:           "1000.50000000",
+		CloseTime:        1516233659999,
+		QuoteAssetVolume: "90.04500000",
+	}
+
+	candle, err := client.toCandle(ETH, BTC, CANDLE_1M, kline)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 1, 18, 0, 0, 0, 0, time.UTC), candle.OpenTime)
+	assert.Equal(t, 0.09, candle.Open)
+	assert.Equal(t, 0.095, candle.High)
+	assert.Equal(t, 0.085, candle.Low)
+	assert.Equal(t, 0.091, candle.Close)
+	assert.Equal(t, 1000.5, candle.Volume)
+	assert.Equal(t, 90.045, candle.QuoteVolume)
+	assert.Equal(t, BINANCE_EXCHANGE, candle.Exchange)
+
+	kline.Close = "bad"
+	_, err = client.toCandle(ETH, BTC, CANDLE_1M, kline)
+	assert.Error(t, err)
+}
+
+func TestFromMarketStat(t *testing.T) {
+
+	pairs := map[string]TradingPair{
+		"ETHBTC": {Symbol: "ETHBTC", Base: ETH, As: BTC, Status: TRADING_STATUS, Exchange: BINANCE_EXCHANGE},
+	}
+
+	stat := &binance.WsMarketStatEvent{
+		Event:     "24hrTicker",
+		Time:      1516233600000,
+		Symbol:    "ETHBTC",
+		LastPrice: "0.09100000",
+	}
+
+	price, err := fromMarketStat(pairs, stat)
+	assert.NoError(t, err)
+	assert.Equal(t, Price{
+		Base:     ETH,
+		As:       BTC,
+		Price:    0.091,
+		At:       time.Date(2018, 1, 18, 0, 0, 0, 0, time.UTC),
+		Exchange: BINANCE_EXCHANGE,
+	}, price)
+
+	stat.LastPrice = "bad"
+	_, err = fromMarketStat(pairs, stat)
+	assert.Error(t, err)
+
+	// not a known trading pair
+	stat.Symbol = "XRPBTC"
+	stat.LastPrice = "0.00010000"
+	_, err = fromMarketStat(pairs, stat)
+	assert.Error(t, err)
+}
+
+func TestFromPriceChangeStats(t *testing.T) {
+
+	pairs := map[string]TradingPair{
+		"BTCUSDT": {Symbol: "BTCUSDT", Base: BTC, As: USDT, Status: TRADING_STATUS, Exchange: BINANCE_EXCHANGE},
+	}
+
+	stats := &binance.PriceChangeStats{
+		Symbol:             "BTCUSDT",
+		PriceChange:        "405.35000000",
+		PriceChangePercent: "3.534",
+		WeightedAvgPrice:   "11855.42965188",
+		PrevClosePrice:     "11497.99000000",
+		LastPrice:          "11876.06000000",
+		LastQty:            "0.08374300",
+		BidPrice:           "11875.50000000",
+		BidQty:             "0.00088100",
+		AskPrice:           "11875.70000000",
+		AskQty:             "0.00010100",
+		OpenPrice:          "11470.71000000",
+		HighPrice:          "12244.00000000",
+		LowPrice:           "11408.00000000",
+		Volume:             "16891.27751600",
+		QuoteVolume:        "200253352.32137449",
+		OpenTime:           1517097500866,
+		CloseTime:          1517183900866,
+		FristID:            10640313,
+		LastID:             10846665,
+		Count:              206353,
+	}
+
+	day, err := fromPriceChangeStats(pairs, stats)
+	assert.NoError(t, err)
+	assert.Equal(t, DaySummary{
+		Base:             BTC,
+		As:               USDT,
+		OpenPrice:        11470.71,
+		ClosePrice:       11876.06,
+		WeightedAvgPrice: 11855.42965188,
+		HighestPrice:     12244.0,
+		LowestPrice:      11408.0,
+		ChangePrice:      405.35,
+		ChangePercent:    3.534,
+		Volume:           16891.277516,
+		QuoteVolume:      200253352.32137449,
+		BidPrice:         11875.5,
+		BidQuantity:      0.000881,
+		AskPrice:         11875.7,
+		AskQuantity:      0.000101,
+		Trades:           206353,
+		At:               fromMillis(1517183900866),
+		Exchange:         BINANCE_EXCHANGE,
+	}, day)
+
+	stats.Volume = "bad"
+	_, err = fromPriceChangeStats(pairs, stats)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "volume")
+
+	// not a known trading pair
+	stats.Symbol = "XRPBTC"
+	stats.Volume = "1.0"
+	_, err = fromPriceChangeStats(pairs, stats)
+	assert.Error(t, err)
+}
+
+func TestNewBinanceClientWithURL(t *testing.T) {
+	client, err := NewBinanceClientWithURL("", "key", "secret")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "", client.(*binanceClient).client.BaseURL)
+
+	client, err = NewBinanceClientWithURL("http://localhost:1234/", "key", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:1234", client.(*binanceClient).client.BaseURL)
+
+	_, err = NewBinanceClientWithURL("not a url", "key", "secret")
+	assert.Error(t, err)
+}
+
+func TestBinanceBalances(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	defer emulator.Close()
+
+	balances, err := client.GetCoinBalances()
+	assert.NoError(t, err)
+	// empty balances are not returned
+	assert.Equal(t, []CoinBalance{
+		{Symbol: BTC, Exchange: BINANCE_EXCHANGE, Free: 1.5, Locked: 0.5},
+		{Symbol: ETH, Exchange: BINANCE_EXCHANGE, Free: 10},
+	}, balances)
+
+	emulator.script("GET account", emulatedOK(`{"balances":[{"asset":"BTC","free":"1.5O","locked":"0"}]}`))
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `Failed to parse balance quantity free: 1.5O - strconv.ParseFloat: parsing "1.5O": invalid syntax`)
+
+	emulator.script("GET account", emulatedOK(`{"balances":[{"asset":"BTC","free":"1.5","locked":""}]}`))
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to parse balance quantity locked")
+
+	emulator.script("GET account", emulatedOK(`{"balances":[`))
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+
+	// a bad key is rejected by binance
+	badClient, err := NewBinanceClientWithURL(emulator.URL, "bad-key", testBinanceSecret)
+	assert.NoError(t, err)
+	_, err = badClient.GetCoinBalances()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "code=-2015")
+	assert.False(t, IsTransient(err))
+}
+
+func TestBinancePrices(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	defer emulator.Close()
+
+	emulator.script("GET ticker/price", emulatedOK(`[
+		{"symbol":"ETHBTC","price":"0.05000000"},
+		{"symbol":"BTCUSDT","price":"30000.00"},
+		{"symbol":"NEWCOINBTC","price":"1.00000000"}]`))
+
+	prices, err := client.GetLatestPrices()
+	assert.NoError(t, err)
+	// symbols are split using exchange info and unknown symbols are skipped
+	if assert.Len(t, prices, 2) {
+		assert.Equal(t, ETH, prices[0].Base)
+		assert.Equal(t, BTC, prices[0].As)
+		assert.Equal(t, 0.05, prices[0].Price)
+		assert.Equal(t, BTC, prices[1].Base)
+		assert.Equal(t, USDT, prices[1].As)
+		assert.Equal(t, 30000.0, prices[1].Price)
+		assert.Equal(t, BINANCE_EXCHANGE, prices[1].Exchange)
+	}
+	// exchange info was fetched once, the unknown symbol was too recent a miss to fetch it again
+	assert.Len(t, emulator.requested("GET exchangeInfo"), 1)
+
+	emulator.script("GET ticker/price", emulatedOK(`[{"symbol":"ETHBTC","price":"0,05"}]`))
+	_, err = client.GetLatestPrices()
+	assert.EqualError(t, err, `Failed to parse symbol price: ETHBTC - strconv.ParseFloat: parsing "0,05": invalid syntax. 0,05`)
+
+	// prices fail when trading pairs can't be fetched, exchange info is only requested once
+	failing, failingEmulator := newTestBinanceClient(t)
+	defer failingEmulator.Close()
+	failingEmulator.respond("GET exchangeInfo", emulatedError(http.StatusInternalServerError, -1000, "Unknown error."))
+	_, err = failing.GetLatestPrices()
+	assert.Error(t, err)
+	assert.Len(t, failingEmulator.requested("GET exchangeInfo"), 1)
+	_, err = failing.GetDaySummaries()
+	assert.Error(t, err)
+	assert.Len(t, failingEmulator.requested("GET exchangeInfo"), 2)
+}
+
+func TestBinanceDaySummaries(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	defer emulator.Close()
+
+	days, err := client.GetDaySummaries()
+	assert.NoError(t, err)
+	if assert.Len(t, days, 2) {
+		assert.Equal(t, ETH, days[0].Base)
+		assert.Equal(t, BTC, days[0].As)
+		assert.Equal(t, 0.049, days[0].OpenPrice)
+		assert.Equal(t, 0.05, days[0].ClosePrice)
+		assert.Equal(t, 2.041, days[0].ChangePercent)
+		assert.Equal(t, int64(500), days[0].Trades)
+		assert.Equal(t, fromMillis(1600000000000), days[0].At)
+		assert.Equal(t, USDT, days[1].As)
+		assert.Equal(t, -100.0, days[1].ChangePrice)
+	}
+	assert.Len(t, emulator.requested("GET ticker/24hr"), 1)
+
+	// a ticker with a malformed number is skipped
+	emulator.script("GET ticker/24hr", emulatedOK(`[
+		{"symbol":"ETHBTC","lastPrice":"0.05","openPrice":"0.049","highPrice":"0.051","lowPrice":"0.048","weightedAvgPrice":"0.0495","priceChange":"0.001",
+		 "priceChangePercent":"2.0","volume":"1","quoteVolume":"1","bidPrice":"1","bidQty":"1","askPrice":"1","askQty":"1"},
+		{"symbol":"BTCUSDT","lastPrice":"NaNish","openPrice":"1","highPrice":"1","lowPrice":"1","weightedAvgPrice":"1","priceChange":"1",
+		 "priceChangePercent":"1","volume":"1","quoteVolume":"1","bidPrice":"1","bidQty":"1","askPrice":"1","askQty":"1"}]`))
+	days, err = client.GetDaySummaries()
+	assert.NoError(t, err)
+	if assert.Len(t, days, 1) {
+		assert.Equal(t, ETH, days[0].Base)
+	}
+
+	// rate limits can be retried
+	emulator.script("GET ticker/24hr", emulatedError(http.StatusTooManyRequests, -1003, "Too many requests."))
+	_, err = client.GetDaySummaries()
+	assert.Error(t, err)
+	assert.True(t, IsTransient(err))
+}
+
+func TestBinanceTradingPairs(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	defer emulator.Close()
+
+	pairs, err := client.GetTradingPairs()
+	assert.NoError(t, err)
+	if assert.Len(t, pairs, 4) {
+		assert.Equal(t, "BNBBTC", pairs[0].Symbol)
+		assert.Equal(t, "BREAK", pairs[0].Status)
+		assert.Equal(t, "ETHBTC", pairs[2].Symbol)
+		assert.Equal(t, 0.000001, pairs[2].TickSize)
+		assert.Equal(t, 0.001, pairs[2].StepSize)
+		assert.Equal(t, 0.0001, pairs[2].MinNotional)
+	}
+
+	// pairs are cached
+	_, err = client.GetTradingPairs()
+	assert.NoError(t, err)
+	assert.Len(t, emulator.requested("GET exchangeInfo"), 1)
+
+	// a pair with a malformed filter is skipped
+	emulator.respond("GET exchangeInfo", emulatedOK(`{"symbols":[
+		{"symbol":"ETHBTC","status":"TRADING","baseAsset":"ETH","quoteAsset":"BTC",
+		 "filters":[{"filterType":"LOT_SIZE","minQty":"0.001","maxQty":"lots","stepSize":"0.001"}]},
+		{"symbol":"LTCBTC","status":"TRADING","baseAsset":"LTC","quoteAsset":"BTC","filters":[]}]}`))
+	refreshed, err := client.tradingPairs(true)
+	assert.NoError(t, err)
+	assert.Len(t, refreshed, 1)
+	assert.Contains(t, refreshed, "LTCBTC")
+}
+
+func TestBinanceRequestCost(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	defer emulator.Close()
+
+	// exchange info is reserved until trading pairs are cached
+	requests, weight := client.RequestCost("GetLatestPrices")
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 2+EXCHANGE_INFO_WEIGHT, weight)
+	_, weight = client.RequestCost("GetTradingPairs")
+	assert.Equal(t, EXCHANGE_INFO_WEIGHT, weight)
+
+	_, err := client.GetTradingPairs()
+	assert.NoError(t, err)
+
+	requests, weight = client.RequestCost("GetLatestPrices")
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 2, weight)
+	requests, weight = client.RequestCost("GetDaySummaries")
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 40, weight)
+	_, weight = client.RequestCost("GetCoinBalances")
+	assert.Equal(t, 5, weight)
+}
+
+func TestBinanceCandles(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	defer emulator.Close()
+
+	from := fromMillis(1600000000000)
+	to := from.Add(2 * time.Minute)
+
+	candles, err := client.GetCandles(ETH, BTC, CANDLE_1M, from, to)
+	assert.NoError(t, err)
+	if assert.Len(t, candles, 2) {
+		assert.Equal(t, Candle{
+			Base:        ETH,
+			As:          BTC,
+			Interval:    CANDLE_1M,
+			OpenTime:    from,
+			CloseTime:   fromMillis(1600000059999),
+			Open:        0.049,
+			High:        0.051,
+			Low:         0.048,
+			Close:       0.05,
+			Volume:      100,
+			QuoteVolume: 4.95,
+			Exchange:    BINANCE_EXCHANGE,
+		}, candles[0])
+	}
+
+	requests := emulator.requested("GET klines")
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "ETHBTC", requests[0].Get("symbol"))
+		assert.Equal(t, "1m", requests[0].Get("interval"))
+		assert.Equal(t, "1600000000000", requests[0].Get("startTime"))
+		assert.Equal(t, "1600000119999", requests[0].Get("endTime"))
+		assert.Equal(t, "1000", requests[0].Get("limit"))
+	}
+
+	// a full page is followed by the next page
+	to = from.Add(1500 * time.Minute)
+	emulator.script("GET klines", emulatedOK(klineRows(1600000000000, MAX_CANDLES_PER_REQUEST)), emulatedOK(klineRows(1600060000000, 500)))
+	candles, err = client.GetCandles(ETH, BTC, CANDLE_1M, from, to)
+	assert.NoError(t, err)
+	assert.Len(t, candles, 1500)
+	requests = emulator.requested("GET klines")
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, "1600060000000", requests[2].Get("startTime"))
+	}
+
+	emulator.script("GET klines", emulatedOK(`[[1600000000000,"0.049","high","0.048","0.05","100.0",1600000059999,"4.95",10,"50.0","2.5","0"]]`))
+	_, err = client.GetCandles(ETH, BTC, CANDLE_1M, from, to)
+	assert.EqualError(t, err, `Failed to parse candle high price: high - strconv.ParseFloat: parsing "high": invalid syntax`)
+
+	emulator.script("GET klines", emulatedError(http.StatusBadRequest, -1121, "Invalid symbol."))
+	_, err = client.GetCandles("XXX", BTC, CANDLE_1M, from, to)
+	assert.Error(t, err)
+	assert.False(t, IsTransient(err))
+}
+
+func TestBinanceOrders(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	defer emulator.Close()
+
+	order, err := client.PlaceMarketOrder(BUY_ORDER, ETH, BTC, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", order.ID)
+	assert.Equal(t, ORDER_FILLED, order.Status)
+	assert.Equal(t, 2.0, order.ExecutedQuantity)
+	if assert.Len(t, order.Fills, 2) {
+		assert.Equal(t, 0.0499, order.Fills[0].Price)
+		assert.Equal(t, 0.001, order.Fills[1].Commission)
+		assert.Equal(t, ETH, order.Fills[1].CommissionAsset)
+	}
+	placed := emulator.requested("POST order")
+	if assert.Len(t, placed, 1) {
+		assert.Equal(t, "ETHBTC", placed[0].Get("symbol"))
+		assert.Equal(t, "BUY", placed[0].Get("side"))
+		assert.Equal(t, "MARKET", placed[0].Get("type"))
+		assert.Equal(t, "2", placed[0].Get("quantity"))
+	}
+
+	_, err = client.PlaceLimitOrder(SELL_ORDER, ETH, BTC, 1.25, 0.0512)
+	assert.NoError(t, err)
+	placed = emulator.requested("POST order")
+	if assert.Len(t, placed, 2) {
+		assert.Equal(t, "LIMIT", placed[1].Get("type"))
+		assert.Equal(t, "GTC", placed[1].Get("timeInForce"))
+		assert.Equal(t, "1.25", placed[1].Get("quantity"))
+		assert.Equal(t, "0.0512", placed[1].Get("price"))
+	}
+
+	emulator.script("POST order", emulatedError(http.StatusBadRequest, -2010, "Account has insufficient balance for requested action."))
+	_, err = client.PlaceMarketOrder(BUY_ORDER, ETH, BTC, 2000)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient balance")
+	assert.False(t, IsTransient(err))
+
+	emulator.script("POST order", emulatedOK(`{"symbol":"ETHBTC","orderId":1,"status":"FILLED","fills":[{"price":"cheap","qty":"1","commission":"0"}]}`))
+	_, err = client.PlaceMarketOrder(BUY_ORDER, ETH, BTC, 1)
+	assert.EqualError(t, err, `Failed to parse order amount: cheap - strconv.ParseFloat: parsing "cheap": invalid syntax`)
+
+	order, err = client.GetOrder(ETH, BTC, "12345")
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_PARTIALLY_FILLED, order.Status)
+	assert.Equal(t, 0.04, order.Price)
+	assert.Equal(t, 0.5, order.ExecutedQuantity)
+	assert.Equal(t, "12345", emulator.requested("GET order")[0].Get("orderId"))
+
+	_, err = client.GetOrder(ETH, BTC, "not-a-number")
+	assert.Error(t, err)
+
+	emulator.script("GET order", emulatedError(http.StatusBadRequest, -2013, "Order does not exist."))
+	_, err = client.GetOrder(ETH, BTC, "999")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "code=-2013")
+
+	order, err = client.CancelOrder(ETH, BTC, "12345")
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_CANCELED, order.Status)
+	assert.Equal(t, "12345", emulator.requested("DELETE order")[0].Get("orderId"))
+
+	// open orders of every symbol are split into base and as
+	orders, err := client.GetOpenOrders("", "")
+	assert.NoError(t, err)
+	if assert.Len(t, orders, 2) {
+		assert.Equal(t, ETH, orders[0].Base)
+		assert.Equal(t, BTC, orders[0].As)
+		assert.Equal(t, BTC, orders[1].Base)
+		assert.Equal(t, USDT, orders[1].As)
+		assert.Equal(t, SELL_ORDER, orders[1].Side)
+	}
+	assert.Equal(t, "", emulator.requested("GET openOrders")[0].Get("symbol"))
+
+	_, err = client.GetOpenOrders(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, "ETHBTC", emulator.requested("GET openOrders")[1].Get("symbol"))
+
+	// an order of an unknown symbol doesn't hide the others
+	emulator.script("GET openOrders", emulatedOK(`[
+		{"symbol":"NOPEBTC","orderId":3,"price":"1.0","origQty":"1.0","executedQty":"0.0","cummulativeQuoteQty":"0.0",
+		 "status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","updateTime":1600000000000},
+		{"symbol":"ETHBTC","orderId":4,"price":"0.04000000","origQty":"2.0","executedQty":"0.0","cummulativeQuoteQty":"0.0",
+		 "status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","updateTime":1600000000000}]`))
+	orders, err = client.GetOpenOrders("", "")
+	assert.NoError(t, err)
+	if assert.Len(t, orders, 1) {
+		assert.Equal(t, "4", orders[0].ID)
+	}
+}
+
+func TestBinanceUnreachable(t *testing.T) {
+	client, emulator := newTestBinanceClient(t)
+	emulator.Close()
+
+	_, err := client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.True(t, IsTransient(err))
+}
@@ -0,0 +1,200 @@
+package exchanges
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+
+The binance emulator is a local http server answering the binance REST
+endpoints the binance client uses, so the real adapter can be tested offline
+through go-binance.
+
+Endpoints are named by method and path without the api version eg. "GET
+account" or "POST order". Each has a canned response which can be replaced,
+responses can also be scripted to be returned once each in order before the
+canned one, eg. a rate limit error then a good response.
+
+Signed endpoints fail with binance's invalid key error unless the request has
+the emulator's api key, a timestamp and a signature.
+
+*/
+
+const (
+	testBinanceKey    = "emulator-key"
+	testBinanceSecret = "emulator-secret"
+)
+
+// emulatedResponse - the status and body of a response
+type emulatedResponse struct {
+	status int
+	body   string
+}
+
+// emulatedOK - a successful response
+func emulatedOK(body string) emulatedResponse {
+	return emulatedResponse{status: http.StatusOK, body: body}
+}
+
+// emulatedError - a binance api error
+func emulatedError(status int, code int, msg string) emulatedResponse {
+	return emulatedResponse{status: status, body: fmt.Sprintf(`{"code":%d,"msg":%q}`, code, msg)}
+}
+
+// apiVersion - prefix of binance paths, endpoints are named without it
+var apiVersion = regexp.MustCompile(`^/api/v[0-9]+/`)
+
+// signedEndpoints - endpoints that need an api key and signature
+var signedEndpoints = map[string]bool{
+	"GET account":    true,
+	"GET order":      true,
+	"POST order":     true,
+	"DELETE order":   true,
+	"GET openOrders": true,
+}
+
+type binanceEmulator struct {
+	*httptest.Server
+	sync.Mutex
+	canned   map[string]emulatedResponse   // by endpoint
+	scripted map[string][]emulatedResponse // by endpoint, returned before the canned response
+	requests map[string][]url.Values       // params of each request by endpoint
+}
+
+func newBinanceEmulator() *binanceEmulator {
+	e := &binanceEmulator{
+		canned: map[string]emulatedResponse{
+			"GET exchangeInfo": emulatedOK(`{"timezone":"UTC","serverTime":1600000000000,"symbols":[
+				{"symbol":"ETHBTC","status":"TRADING","baseAsset":"ETH","baseAssetPrecision":8,"quoteAsset":"BTC","quotePrecision":8,"filters":[
+					{"filterType":"PRICE_FILTER","minPrice":"0.00000100","maxPrice":"100000.00000000","tickSize":"0.00000100"},
+					{"filterType":"LOT_SIZE","minQty":"0.00100000","maxQty":"100000.00000000","stepSize":"0.00100000"},
+					{"filterType":"MIN_NOTIONAL","minNotional":"0.00010000"}]},
+				{"symbol":"LTCBTC","status":"TRADING","baseAsset":"LTC","baseAssetPrecision":8,"quoteAsset":"BTC","quotePrecision":8,"filters":[]},
+				{"symbol":"BTCUSDT","status":"TRADING","baseAsset":"BTC","baseAssetPrecision":8,"quoteAsset":"USDT","quotePrecision":2,"filters":[]},
+				{"symbol":"BNBBTC","status":"BREAK","baseAsset":"BNB","baseAssetPrecision":8,"quoteAsset":"BTC","quotePrecision":8,"filters":[]}]}`),
+			"GET account": emulatedOK(`{"makerCommission":10,"takerCommission":10,"balances":[
+				{"asset":"BTC","free":"1.50000000","locked":"0.50000000"},
+				{"asset":"ETH","free":"10.00000000","locked":"0.00000000"},
+				{"asset":"LTC","free":"0.00000000","locked":"0.00000000"}]}`),
+			"GET ticker/price": emulatedOK(`[
+				{"symbol":"ETHBTC","price":"0.05000000"},
+				{"symbol":"LTCBTC","price":"0.01000000"},
+				{"symbol":"BTCUSDT","price":"30000.00"}]`),
+			"GET ticker/24hr": emulatedOK(`[
+				{"symbol":"ETHBTC","priceChange":"0.00100000","priceChangePercent":"2.041","weightedAvgPrice":"0.04950000","prevClosePrice":"0.04900000",
+				 "lastPrice":"0.05000000","lastQty":"1.0","bidPrice":"0.04999000","bidQty":"3.0","askPrice":"0.05001000","askQty":"4.0",
+				 "openPrice":"0.04900000","highPrice":"0.05100000","lowPrice":"0.04800000","volume":"1000.0","quoteVolume":"49.5",
+				 "openTime":1599913600000,"closeTime":1600000000000,"firstId":1,"lastId":500,"count":500},
+				{"symbol":"BTCUSDT","priceChange":"-100.00","priceChangePercent":"-0.332","weightedAvgPrice":"30050.00","prevClosePrice":"30100.00",
+				 "lastPrice":"30000.00","lastQty":"0.1","bidPrice":"29999.00","bidQty":"1.0","askPrice":"30001.00","askQty":"2.0",
+				 "openPrice":"30100.00","highPrice":"30500.00","lowPrice":"29500.00","volume":"200.0","quoteVolume":"6010000.0",
+				 "openTime":1599913600000,"closeTime":1600000000000,"firstId":1,"lastId":900,"count":900}]`),
+			"GET klines": emulatedOK(`[
+				[1600000000000,"0.04900000","0.05100000","0.04800000","0.05000000","100.0",1600000059999,"4.95",10,"50.0","2.5","0"],
+				[1600000060000,"0.05000000","0.05200000","0.04900000","0.05100000","200.0",1600000119999,"10.1",20,"100.0","5.0","0"]]`),
+			"POST order": emulatedOK(`{"symbol":"ETHBTC","orderId":12345,"clientOrderId":"c1","transactTime":1600000000000,
+				"price":"0.00000000","origQty":"2.00000000","executedQty":"2.00000000","cummulativeQuoteQty":"0.10000000",
+				"status":"FILLED","timeInForce":"GTC","type":"MARKET","side":"BUY","fills":[
+				{"price":"0.04990000","qty":"1.00000000","commission":"0.00100000","commissionAsset":"ETH"},
+				{"price":"0.05010000","qty":"1.00000000","commission":"0.00100000","commissionAsset":"ETH"}]}`),
+			"GET order": emulatedOK(`{"symbol":"ETHBTC","orderId":12345,"clientOrderId":"c1","price":"0.04000000","origQty":"2.00000000",
+				"executedQty":"0.50000000","cummulativeQuoteQty":"0.02000000","status":"PARTIALLY_FILLED","timeInForce":"GTC","type":"LIMIT",
+				"side":"BUY","stopPrice":"0","icebergQty":"0","time":1600000000000,"updateTime":1600000060000,"isWorking":true}`),
+			"DELETE order": emulatedOK(`{"symbol":"ETHBTC","origClientOrderId":"c1","orderId":12345,"clientOrderId":"c2","transactTime":1600000120000,
+				"price":"0.04000000","origQty":"2.00000000","executedQty":"0.50000000","cummulativeQuoteQty":"0.02000000",
+				"status":"CANCELED","timeInForce":"GTC","type":"LIMIT","side":"BUY"}`),
+			"GET openOrders": emulatedOK(`[
+				{"symbol":"ETHBTC","orderId":1,"price":"0.04000000","origQty":"2.0","executedQty":"0.0","cummulativeQuoteQty":"0.0",
+				 "status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","updateTime":1600000000000},
+				{"symbol":"BTCUSDT","orderId":2,"price":"35000.00","origQty":"0.1","executedQty":"0.0","cummulativeQuoteQty":"0.0",
+				 "status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"SELL","updateTime":1600000000000}]`),
+		},
+		scripted: make(map[string][]emulatedResponse),
+		requests: make(map[string][]url.Values),
+	}
+
+	e.Server = httptest.NewServer(http.HandlerFunc(e.handle))
+	return e
+}
+
+// endpoint - names the endpoint of a request eg. "GET account"
+func endpoint(r *http.Request) string {
+	return r.Method + " " + apiVersion.ReplaceAllString(r.URL.Path, "")
+}
+
+func (e *binanceEmulator) handle(w http.ResponseWriter, r *http.Request) {
+	name := endpoint(r)
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	e.Lock()
+	e.requests[name] = append(e.requests[name], r.Form)
+	response, ok := e.canned[name]
+	if scripted := e.scripted[name]; len(scripted) > 0 {
+		response, ok = scripted[0], true
+		e.scripted[name] = scripted[1:]
+	}
+	e.Unlock()
+
+	if !ok {
+		response = emulatedError(http.StatusNotFound, -1000, "Unknown endpoint "+name)
+	}
+
+	if signedEndpoints[name] && (r.Header.Get("X-MBX-APIKEY") != testBinanceKey || r.Form.Get("timestamp") == "" || r.Form.Get("signature") == "") {
+		response = emulatedError(http.StatusUnauthorized, -2015, "Invalid API-key, IP, or permissions for action.")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.status)
+	fmt.Fprint(w, response.body)
+}
+
+// respond - replaces the canned response of an endpoint
+func (e *binanceEmulator) respond(name string, response emulatedResponse) {
+	e.Lock()
+	defer e.Unlock()
+	e.canned[name] = response
+}
+
+// script - returns responses once each in order before the canned response of an endpoint
+func (e *binanceEmulator) script(name string, responses ...emulatedResponse) {
+	e.Lock()
+	defer e.Unlock()
+	e.scripted[name] = append(e.scripted[name], responses...)
+}
+
+// requested - returns the params of each request made to an endpoint
+func (e *binanceEmulator) requested(name string) []url.Values {
+	e.Lock()
+	defer e.Unlock()
+	return e.requests[name]
+}
+
+// klineRows - returns a page of count emulated klines a minute apart
+func klineRows(from int64, count int) string {
+	rows := make([]string, count)
+	for i := range rows {
+		open := from + int64(i)*60000
+		rows[i] = fmt.Sprintf(`[%d,"1.0","2.0","0.5","1.5","10.0",%d,"15.0",5,"5.0","7.5","0"]`, open, open+59999)
+	}
+	return "[" + strings.Join(rows, ",") + "]"
+}
+
+func newTestBinanceClient(t *testing.T) (*binanceClient, *binanceEmulator) {
+	emulator := newBinanceEmulator()
+	client, err := NewBinanceClientWithURL(emulator.URL, testBinanceKey, testBinanceSecret)
+	assert.NoError(t, err)
+	return client.(*binanceClient), emulator
+}
@@ -0,0 +1,51 @@
+package exchanges
+
+import (
+	"fmt"
+	"time"
+)
+
+// CandleInterval - the period each candle covers eg. 1m, 1h
+type CandleInterval string
+
+const (
+	CANDLE_1M  CandleInterval = "1m"
+	CANDLE_5M  CandleInterval = "5m"
+	CANDLE_15M CandleInterval = "15m"
+	CANDLE_1H  CandleInterval = "1h"
+	CANDLE_4H  CandleInterval = "4h"
+	CANDLE_1D  CandleInterval = "1d"
+)
+
+var candleDurations = map[CandleInterval]time.Duration{
+	CANDLE_1M:  time.Minute,
+	CANDLE_5M:  5 * time.Minute,
+	CANDLE_15M: 15 * time.Minute,
+	CANDLE_1H:  time.Hour,
+	CANDLE_4H:  4 * time.Hour,
+	CANDLE_1D:  24 * time.Hour,
+}
+
+// Duration - returns the period a candle covers
+func (c CandleInterval) Duration() (time.Duration, error) {
+	if d, ok := candleDurations[c]; ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf("Candle interval %q is not valid", c)
+}
+
+// Candle - open, high, low & close prices for a trading pair over an interval
+type Candle struct {
+	Base        string
+	As          string
+	Interval    CandleInterval
+	OpenTime    time.Time
+	CloseTime   time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64 // traded in the base symbol
+	QuoteVolume float64 // traded in the as symbol
+	Exchange    string
+}
@@ -1,6 +1,14 @@
 package exchanges
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+const TRADING_STATUS = "TRADING"
 
 type ExchangeClient interface {
 	GetCoinBalances() ([]CoinBalance, error)
@@ -8,6 +16,22 @@ type ExchangeClient interface {
 	//GetHistoricPrices() ([]Price, error)
 	GetDaySummaries() ([]DaySummary, error)
 	GetExchange() string
+	GetTradingPairs() ([]TradingPair, error)
+	// GetCandles - returns candles opened from (inclusive) up to to (exclusive), oldest first
+	GetCandles(base, as string, interval CandleInterval, from, to time.Time) ([]Candle, error)
+	// Orders
+	PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error)
+	PlaceLimitOrder(side OrderSide, base, as string, quantity, price float64) (Order, error)
+	CancelOrder(base, as, id string) (Order, error)
+	GetOrder(base, as, id string) (Order, error)
+	GetOpenOrders(base, as string) ([]Order, error)
+}
+
+// PriceStreamer - implemented by exchange clients that can push prices as they change
+type PriceStreamer interface {
+	// SubscribePrices - streams prices until ctx is done. The channel is closed when
+	// the stream ends, including when the connection drops
+	SubscribePrices(ctx context.Context) (<-chan Price, error)
 }
 
 type CoinBalance struct {
@@ -25,6 +49,52 @@ type Price struct {
 	At       time.Time
 }
 
+// TradingPair - a market on an exchange and the limits on orders for it
+type TradingPair struct {
+	Symbol        string // This is the exchange's symbol eg. NEOBTC
+	Base          string // This is the base symbol eg. NEO
+	As            string // This is trading pair symbol eg. BTC, ETH etc
+	Status        string // eg. TRADING, BREAK
+	BasePrecision int    // decimal places of the base symbol
+	AsPrecision   int    // decimal places of the as symbol
+	MinPrice      float64
+	MaxPrice      float64
+	TickSize      float64 // prices must be a multiple of the tick size
+	MinQuantity   float64
+	MaxQuantity   float64
+	StepSize      float64 // quantities must be a multiple of the step size
+	MinNotional   float64 // min value of an order (price * quantity)
+	Exchange      string
+}
+
+// IsTrading - returns true if orders can be placed for the pair
+func (t TradingPair) IsTrading() bool {
+	return t.Status == TRADING_STATUS
+}
+
+// RoundQuantity - rounds a quantity down to a multiple of the pair's step size, an
+// error is returned if what is left is below the pair's minimum quantity
+func (t TradingPair) RoundQuantity(quantity float64) (float64, error) {
+	if t.StepSize > 0 {
+		// the tolerance stops a quantity already on a step being rounded down by float error
+		steps := math.Floor(quantity/t.StepSize + 1e-9)
+		decimals := math.Pow10(int(math.Max(0, math.Ceil(-math.Log10(t.StepSize)-1e-9))))
+		quantity = math.Round(steps*t.StepSize*decimals) / decimals
+	}
+
+	if quantity <= 0 || quantity < t.MinQuantity {
+		return 0, fmt.Errorf("Quantity %f is below the minimum %f for %s", quantity, t.MinQuantity, t.Symbol)
+	}
+
+	return quantity, nil
+}
+
+// logSkipped - logs an item of an exchange response that is skipped so one bad
+// item doesn't stop the rest being used, eg. a price of an unknown market
+func logSkipped(item string, err error) {
+	log.Printf("Skipping %s - %s", item, err)
+}
+
 type DaySummary struct {
 	Base             string
 	As               string
@@ -35,6 +105,13 @@ type DaySummary struct {
 	LowestPrice      float64
 	ChangePrice      float64
 	ChangePercent    float64
+	Volume           float64 // traded in the base symbol
+	QuoteVolume      float64 // traded in the as symbol
+	BidPrice         float64 // best bid when the summary was taken
+	BidQuantity      float64
+	AskPrice         float64 // best ask when the summary was taken
+	AskQuantity      float64
+	Trades           int64
 	At               time.Time
 	Exchange         string
 }
@@ -0,0 +1,610 @@
+package exchanges
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+/*
+
+The kraken client uses the kraken REST api directly. It can read balances,
+prices, day summaries, trading pairs and candles so kraken balances can be
+tracked alongside the primary exchange, placing orders is not supported.
+
+Kraken names some assets differently, the legacy 4 letter codes have their X or
+Z prefix removed eg. XXBT, XETH and ZUSD are XBT, ETH and USD, then XBT is BTC
+and XDG is DOGE so symbols match other exchanges.
+
+Balances on kraken include funds held by open orders, they are all reported as
+free.
+
+*/
+
+const (
+	KRAKEN_API_KEY    = "KRAKEN_API_KEY"
+	KRAKEN_API_SECRET = "KRAKEN_API_SECRET"
+	KRAKEN_EXCHANGE   = "kraken"
+	KRAKEN_API_URL    = "https://api.kraken.com"
+
+	KRAKEN_REQUEST_TIMEOUT = time.Duration(30 * time.Second)
+)
+
+// krakenAssets - kraken asset codes that are not the usual symbol once the X or Z prefix is removed
+var krakenAssets = map[string]string{
+	"XBT": BTC,
+	"XDG": "DOGE",
+}
+
+// krakenTransientErrors - kraken errors a retry may fix
+var krakenTransientErrors = []string{"EAPI:Rate limit exceeded", "EService:Unavailable", "EService:Busy", "EGeneral:Temporary lockout"}
+
+type krakenClient struct {
+	*pairCache
+	http      *http.Client
+	baseURL   string
+	apiKey    string
+	apiSecret []byte
+	lastNonce int64 // accessed atomically
+}
+
+// NewKrakenClient - returns a client for kraken using keys from the environment
+func NewKrakenClient() (ExchangeClient, error) {
+	apiKey := os.Getenv(KRAKEN_API_KEY)
+	if apiKey == "" {
+		return nil, fmt.Errorf("You must set environment variable %s with your key", KRAKEN_API_KEY)
+	}
+	apiSecret := os.Getenv(KRAKEN_API_SECRET)
+	if apiSecret == "" {
+		return nil, fmt.Errorf("You must set environment variable %s with your secret", KRAKEN_API_SECRET)
+	}
+
+	return NewKrakenClientWithURL(KRAKEN_API_URL, apiKey, apiSecret)
+}
+
+// NewKrakenClientWithURL - returns a client for the kraken api at baseURL, the secret is base64 encoded
+func NewKrakenClientWithURL(baseURL, apiKey, apiSecret string) (ExchangeClient, error) {
+	secret, err := base64.StdEncoding.DecodeString(apiSecret)
+	if err != nil {
+		return nil, fmt.Errorf("Kraken secret is not base64 encoded - %s", err)
+	}
+
+	return &krakenClient{
+		pairCache: &pairCache{},
+		http:      &http.Client{Timeout: KRAKEN_REQUEST_TIMEOUT},
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		apiKey:    apiKey,
+		apiSecret: secret,
+	}, nil
+}
+
+// krakenSymbol - converts a kraken asset code into a symbol
+func krakenSymbol(asset string) string {
+	if len(asset) == 4 && (asset[0] == 'X' || asset[0] == 'Z') {
+		asset = asset[1:]
+	}
+	if symbol, ok := krakenAssets[asset]; ok {
+		return symbol
+	}
+	return asset
+}
+
+// krakenResponse - every kraken response has errors or a result
+type krakenResponse struct {
+	Error  []string        `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+// public - calls a public endpoint and decodes its result into result
+func (k *krakenClient) public(method string, params url.Values, result interface{}) error {
+	reqURL := k.baseURL + "/0/public/" + method
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	resp, err := k.http.Get(reqURL)
+	if err != nil {
+		return classify(err, fmt.Errorf("Failed to call kraken %s - %s", method, err))
+	}
+
+	return decodeKraken(method, resp, result)
+}
+
+// private - calls an endpoint that needs the api keys and decodes its result into result
+func (k *krakenClient) private(method string, params url.Values, result interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("nonce", strconv.FormatInt(k.nonce(), 10))
+
+	path := "/0/private/" + method
+	req, err := http.NewRequest(http.MethodPost, k.baseURL+path, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("API-Key", k.apiKey)
+	req.Header.Set("API-Sign", k.sign(path, params))
+
+	resp, err := k.http.Do(req)
+	if err != nil {
+		return classify(err, fmt.Errorf("Failed to call kraken %s - %s", method, err))
+	}
+
+	return decodeKraken(method, resp, result)
+}
+
+// nonce - returns a number that is higher every call
+func (k *krakenClient) nonce() int64 {
+	for {
+		last := atomic.LoadInt64(&k.lastNonce)
+		next := time.Now().UnixNano()
+		if next <= last {
+			next = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&k.lastNonce, last, next) {
+			return next
+		}
+	}
+}
+
+// sign - returns the signature of a private request, the HMAC-SHA512 of the path and
+// the SHA256 of the nonce and body using the secret
+func (k *krakenClient) sign(path string, params url.Values) string {
+	body := sha256.Sum256([]byte(params.Get("nonce") + params.Encode()))
+
+	mac := hmac.New(sha512.New, k.apiSecret)
+	mac.Write([]byte(path))
+	mac.Write(body[:])
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func decodeKraken(method string, resp *http.Response, result interface{}) error {
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return classify(err, fmt.Errorf("Failed to read kraken %s - %s", method, err))
+	}
+
+	krakenResp := krakenResponse{}
+	if err := json.Unmarshal(body, &krakenResp); err != nil {
+		err = fmt.Errorf("Failed to parse kraken %s, status %s - %s", method, resp.Status, err)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return &TransientError{Err: err}
+		}
+		return err
+	}
+
+	if len(krakenResp.Error) > 0 {
+		err := fmt.Errorf("Kraken %s failed - %s", method, strings.Join(krakenResp.Error, ", "))
+		for _, krakenErr := range krakenResp.Error {
+			for _, transient := range krakenTransientErrors {
+				if strings.HasPrefix(krakenErr, transient) {
+					return &TransientError{Err: err}
+				}
+			}
+		}
+		return err
+	}
+
+	if err := json.Unmarshal(krakenResp.Result, result); err != nil {
+		return fmt.Errorf("Failed to parse kraken %s result - %s", method, err)
+	}
+
+	return nil
+}
+
+func (k *krakenClient) GetExchange() string {
+	return KRAKEN_EXCHANGE
+}
+
+func (k *krakenClient) GetCoinBalances() ([]CoinBalance, error) {
+	result := make(map[string]string)
+	if err := k.private("Balance", nil, &result); err != nil {
+		return nil, err
+	}
+
+	assets := make([]string, 0, len(result))
+	for asset := range result {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	balances := make([]CoinBalance, 0, len(assets))
+	for _, asset := range assets {
+		free, err := strconv.ParseFloat(result[asset], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse balance quantity: %s - %s. %s", asset, result[asset], err)
+		}
+		balances = append(balances, CoinBalance{
+			Symbol:   krakenSymbol(asset),
+			Exchange: KRAKEN_EXCHANGE,
+			Free:     free,
+		})
+	}
+
+	return balances, nil
+}
+
+// krakenTicker - kraken's 24 hour ticker of a pair, arrays hold today's value then the last 24 hours'
+type krakenTicker struct {
+	Ask    []string `json:"a"` // price, whole lot volume, lot volume
+	Bid    []string `json:"b"` // price, whole lot volume, lot volume
+	Close  []string `json:"c"` // price, lot volume
+	Volume []string `json:"v"`
+	VWAP   []string `json:"p"`
+	Trades []int64  `json:"t"`
+	Low    []string `json:"l"`
+	High   []string `json:"h"`
+	Open   string   `json:"o"` // today's opening price
+}
+
+// tickers - returns the ticker of every pair by kraken pair name
+func (k *krakenClient) tickers() (map[string]krakenTicker, error) {
+	tickers := make(map[string]krakenTicker)
+	if err := k.public("Ticker", nil, &tickers); err != nil {
+		return nil, err
+	}
+	return tickers, nil
+}
+
+func (k *krakenClient) GetLatestPrices() ([]Price, error) {
+	tickers, err := k.tickers()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := k.tradingPairs(false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	prices := make([]Price, 0, len(tickers))
+	for name, ticker := range tickers {
+		pair, ok := pairs[name]
+		if !ok {
+			logSkipped("price", fmt.Errorf("Unexpected kraken pair %s", name))
+			continue
+		}
+		if len(ticker.Close) == 0 {
+			continue
+		}
+		price, err := strconv.ParseFloat(ticker.Close[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse symbol price: %s - %s. %s", name, err, ticker.Close[0])
+		}
+		prices = append(prices, Price{
+			Base:     pair.Base,
+			As:       pair.As,
+			Price:    price,
+			At:       now,
+			Exchange: KRAKEN_EXCHANGE,
+		})
+	}
+
+	return prices, nil
+}
+
+// GetDaySummaries - returns a summary of the last 24 hours of every pair, tickers that
+// cannot be parsed are skipped
+func (k *krakenClient) GetDaySummaries() ([]DaySummary, error) {
+	tickers, err := k.tickers()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := k.tradingPairs(false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	days := make([]DaySummary, 0, len(tickers))
+	for name, ticker := range tickers {
+		day, err := fromKrakenTicker(pairs, name, ticker, now)
+		if err != nil {
+			logSkipped("day summary", err)
+			continue
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// fromKrakenTicker - converts a kraken ticker into a day summary
+func fromKrakenTicker(pairs map[string]TradingPair, name string, ticker krakenTicker, at time.Time) (DaySummary, error) {
+	pair, ok := pairs[name]
+	if !ok {
+		return DaySummary{}, fmt.Errorf("Unexpected kraken pair %s", name)
+	}
+
+	var err error
+
+	// parse keeps the first error
+	parse := func(field string, values []string, i int) float64 {
+		if err != nil {
+			return 0
+		}
+		if i >= len(values) {
+			err = fmt.Errorf("Ticker for %s has no %s", name, field)
+			return 0
+		}
+		f, parseErr := strconv.ParseFloat(values[i], 64)
+		if parseErr != nil {
+			err = fmt.Errorf("Failed to parse symbol %s: %s - %s. %s", field, name, values[i], parseErr)
+		}
+		return f
+	}
+
+	day := DaySummary{
+		Base:             pair.Base,
+		As:               pair.As,
+		OpenPrice:        parse("open price", []string{ticker.Open}, 0),
+		ClosePrice:       parse("close price", ticker.Close, 0),
+		WeightedAvgPrice: parse("weighted avg price", ticker.VWAP, 1),
+		HighestPrice:     parse("highest price", ticker.High, 1),
+		LowestPrice:      parse("lowest price", ticker.Low, 1),
+		Volume:           parse("volume", ticker.Volume, 1),
+		BidPrice:         parse("bid price", ticker.Bid, 0),
+		BidQuantity:      parse("bid quantity", ticker.Bid, 2),
+		AskPrice:         parse("ask price", ticker.Ask, 0),
+		AskQuantity:      parse("ask quantity", ticker.Ask, 2),
+		At:               at,
+		Exchange:         KRAKEN_EXCHANGE,
+	}
+	if err != nil {
+		return DaySummary{}, err
+	}
+
+	day.QuoteVolume = day.Volume * day.WeightedAvgPrice
+	day.ChangePrice = day.ClosePrice - day.OpenPrice
+	if day.OpenPrice != 0 {
+		day.ChangePercent = day.ChangePrice / day.OpenPrice * 100.0
+	}
+	if len(ticker.Trades) > 1 {
+		day.Trades = ticker.Trades[1]
+	}
+
+	return day, nil
+}
+
+// GetTradingPairs - returns every trading pair on the exchange sorted by symbol
+func (k *krakenClient) GetTradingPairs() ([]TradingPair, error) {
+	pairs, err := k.tradingPairs(false)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]TradingPair, 0, len(pairs))
+	for _, pair := range pairs {
+		list = append(list, pair)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Symbol < list[j].Symbol })
+
+	return list, nil
+}
+
+// krakenAssetPair - kraken's description of a pair
+type krakenAssetPair struct {
+	Altname      string `json:"altname"`
+	Base         string `json:"base"`
+	Quote        string `json:"quote"`
+	PairDecimals int    `json:"pair_decimals"`
+	LotDecimals  int    `json:"lot_decimals"`
+	OrderMin     string `json:"ordermin"`
+	CostMin      string `json:"costmin"`
+	TickSize     string `json:"tick_size"`
+	Status       string `json:"status"`
+}
+
+// tradingPairs - returns cached trading pairs by kraken pair name, fetching them when they
+// are older than TRADING_PAIRS_TTL or refresh is true
+func (k *krakenClient) tradingPairs(refresh bool) (map[string]TradingPair, error) {
+	k.RLock()
+	pairs := k.pairs
+	fetched := k.pairsFetched
+	k.RUnlock()
+
+	if pairs != nil && !refresh && time.Since(fetched) < TRADING_PAIRS_TTL {
+		return pairs, nil
+	}
+
+	assetPairs := make(map[string]krakenAssetPair)
+	if err := k.public("AssetPairs", nil, &assetPairs); err != nil {
+		return nil, err
+	}
+
+	pairs = make(map[string]TradingPair, len(assetPairs))
+	for name, assetPair := range assetPairs {
+		pair, err := toKrakenTradingPair(name, assetPair)
+		if err != nil {
+			logSkipped("trading pair", err)
+			continue
+		}
+		pairs[name] = pair
+	}
+
+	k.Lock()
+	k.pairs = pairs
+	k.pairsFetched = time.Now()
+	k.Unlock()
+
+	return pairs, nil
+}
+
+// pairFor - returns the kraken pair trading base as
+func (k *krakenClient) pairFor(base, as string) (TradingPair, error) {
+	pairs, err := k.tradingPairs(false)
+	if err != nil {
+		return TradingPair{}, err
+	}
+	for _, pair := range pairs {
+		if pair.Base == base && pair.As == as {
+			return pair, nil
+		}
+	}
+	return TradingPair{}, fmt.Errorf("Kraken does not trade %s as %s", base, as)
+}
+
+func toKrakenTradingPair(name string, assetPair krakenAssetPair) (TradingPair, error) {
+	pair := TradingPair{
+		Symbol:        name,
+		Base:          krakenSymbol(assetPair.Base),
+		As:            krakenSymbol(assetPair.Quote),
+		Status:        assetPair.Status,
+		BasePrecision: assetPair.LotDecimals,
+		AsPrecision:   assetPair.PairDecimals,
+		StepSize:      math.Pow10(-assetPair.LotDecimals),
+		TickSize:      math.Pow10(-assetPair.PairDecimals),
+		Exchange:      KRAKEN_EXCHANGE,
+	}
+
+	// pairs are online unless kraken says otherwise
+	if pair.Status == "" || pair.Status == "online" {
+		pair.Status = TRADING_STATUS
+	}
+
+	var err error
+	if pair.MinQuantity, err = parseAmount(assetPair.OrderMin); err != nil {
+		return TradingPair{}, fmt.Errorf("Invalid order min for %s - %s", name, err)
+	}
+	if pair.MinNotional, err = parseAmount(assetPair.CostMin); err != nil {
+		return TradingPair{}, fmt.Errorf("Invalid cost min for %s - %s", name, err)
+	}
+	if assetPair.TickSize != "" {
+		if pair.TickSize, err = parseAmount(assetPair.TickSize); err != nil {
+			return TradingPair{}, fmt.Errorf("Invalid tick size for %s - %s", name, err)
+		}
+	}
+
+	return pair, nil
+}
+
+// GetCandles - returns candles opened from (inclusive) up to to (exclusive), oldest first.
+// Kraken only keeps the latest 720 candles of each interval
+func (k *krakenClient) GetCandles(base, as string, interval CandleInterval, from, to time.Time) ([]Candle, error) {
+	duration, err := interval.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := k.pairFor(base, as)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("pair", pair.Symbol)
+	params.Set("interval", strconv.Itoa(int(duration/time.Minute)))
+	// since is exclusive
+	params.Set("since", strconv.FormatInt(from.Add(-time.Second).Unix(), 10))
+
+	result := make(map[string]json.RawMessage)
+	if err := k.public("OHLC", params, &result); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]interface{}, 0)
+	for name, raw := range result {
+		if name == "last" {
+			continue
+		}
+		if err := json.Unmarshal(raw, &rows); err != nil {
+			return nil, fmt.Errorf("Failed to parse kraken candles for %s - %s", pair.Symbol, err)
+		}
+	}
+
+	candles := make([]Candle, 0, len(rows))
+	for _, row := range rows {
+		candle, err := toKrakenCandle(base, as, interval, duration, row)
+		if err != nil {
+			return nil, err
+		}
+		if candle.OpenTime.Before(from) || !candle.OpenTime.Before(to) {
+			continue
+		}
+		candles = append(candles, candle)
+	}
+
+	return candles, nil
+}
+
+// toKrakenCandle - converts a kraken OHLC row of time, open, high, low, close, vwap,
+// volume and count into a candle
+func toKrakenCandle(base, as string, interval CandleInterval, duration time.Duration, row []interface{}) (Candle, error) {
+	if len(row) < 7 {
+		return Candle{}, fmt.Errorf("Kraken candle for %s%s has %d values", base, as, len(row))
+	}
+
+	openTime, ok := row[0].(float64)
+	if !ok {
+		return Candle{}, fmt.Errorf("Kraken candle time %v is not a number", row[0])
+	}
+
+	values := make([]float64, 6)
+	for i := range values {
+		str, ok := row[i+1].(string)
+		if !ok {
+			return Candle{}, fmt.Errorf("Kraken candle value %v is not a string", row[i+1])
+		}
+		value, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return Candle{}, fmt.Errorf("Failed to parse kraken candle value: %s - %s", str, err)
+		}
+		values[i] = value
+	}
+
+	open := time.Unix(int64(openTime), 0).UTC()
+
+	return Candle{
+		Base:        base,
+		As:          as,
+		Interval:    interval,
+		OpenTime:    open,
+		CloseTime:   open.Add(duration - time.Millisecond),
+		Open:        values[0],
+		High:        values[1],
+		Low:         values[2],
+		Close:       values[3],
+		Volume:      values[5],
+		QuoteVolume: values[4] * values[5],
+		Exchange:    KRAKEN_EXCHANGE,
+	}, nil
+}
+
+func (k *krakenClient) PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error) {
+	return Order{}, fmt.Errorf("Placing orders on %s is not supported", KRAKEN_EXCHANGE)
+}
+
+func (k *krakenClient) PlaceLimitOrder(side OrderSide, base, as string, quantity, price float64) (Order, error) {
+	return Order{}, fmt.Errorf("Placing orders on %s is not supported", KRAKEN_EXCHANGE)
+}
+
+func (k *krakenClient) CancelOrder(base, as, id string) (Order, error) {
+	return Order{}, fmt.Errorf("Cancelling orders on %s is not supported", KRAKEN_EXCHANGE)
+}
+
+func (k *krakenClient) GetOrder(base, as, id string) (Order, error) {
+	return Order{}, fmt.Errorf("Getting orders from %s is not supported", KRAKEN_EXCHANGE)
+}
+
+func (k *krakenClient) GetOpenOrders(base, as string) ([]Order, error) {
+	return nil, fmt.Errorf("Getting orders from %s is not supported", KRAKEN_EXCHANGE)
+}
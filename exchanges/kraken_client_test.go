@@ -0,0 +1,274 @@
+package exchanges
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testKrakenKey    = "test-key"
+	testKrakenSecret = "c2VjcmV0LWtleS1mb3ItdGVzdGluZw=="
+)
+
+// krakenStandIn - a local http server answering like the kraken api
+type krakenStandIn struct {
+	*httptest.Server
+	t         *testing.T
+	responses map[string]string // result json by path
+	errors    map[string]string // kraken error by path
+	requests  map[string]*http.Request
+	counts    map[string]int // requests by path
+}
+
+func newKrakenStandIn(t *testing.T) *krakenStandIn {
+	k := &krakenStandIn{
+		t: t,
+		responses: map[string]string{
+			"/0/public/AssetPairs": `{
+				"XXBTZUSD": {"altname":"XBTUSD","base":"XXBT","quote":"ZUSD","pair_decimals":1,"lot_decimals":8,"ordermin":"0.0001","costmin":"0.5","tick_size":"0.1","status":"online"},
+				"XETHXXBT": {"altname":"ETHXBT","base":"XETH","quote":"XXBT","pair_decimals":5,"lot_decimals":8,"ordermin":"0.002","costmin":"0.00002","tick_size":"0.00001","status":"cancel_only"}
+			}`,
+			"/0/public/Ticker": `{
+				"XXBTZUSD": {"a":["30010.0","1","1.500"],"b":["30000.0","2","2.500"],"c":["30005.0","0.1"],"v":["100.0","200.0"],"p":["29900.0","29500.0"],"t":[1000,2500],"l":["29000.0","28000.0"],"h":["31000.0","32000.0"],"o":"29000.0"},
+				"XETHXXBT": {"a":["0.06","1","3"],"b":["0.05","2","4"],"c":["0.055","1"],"v":["10","20"],"p":["0.05","0.05"],"t":[10,25],"l":["0.04","0.04"],"h":["0.06","0.06"],"o":"0.05"}
+			}`,
+			"/0/public/OHLC": `{
+				"XXBTZUSD": [
+					[1600000000,"10.0","12.0","9.0","11.0","10.5","2.0",5],
+					[1600000060,"11.0","13.0","10.0","12.0","11.5","4.0",7],
+					[1600000120,"12.0","14.0","11.0","13.0","12.5","6.0",9]
+				],
+				"last": 1600000120
+			}`,
+			"/0/private/Balance": `{"XXBT":"1.5","ZUSD":"250.25","XXDG":"1000"}`,
+		},
+		errors:   make(map[string]string),
+		requests: make(map[string]*http.Request),
+		counts:   make(map[string]int),
+	}
+
+	k.Server = httptest.NewServer(http.HandlerFunc(k.handle))
+	return k
+}
+
+func (k *krakenStandIn) handle(w http.ResponseWriter, r *http.Request) {
+	k.requests[r.URL.Path] = r
+	k.counts[r.URL.Path]++
+
+	if err, ok := k.errors[r.URL.Path]; ok {
+		fmt.Fprintf(w, `{"error":[%q]}`, err)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(k.t, err)
+		values, err := url.ParseQuery(string(body))
+		assert.NoError(k.t, err)
+
+		// check the request was signed as kraken expects
+		secret, _ := base64.StdEncoding.DecodeString(testKrakenSecret)
+		sha := sha256.Sum256([]byte(values.Get("nonce") + string(body)))
+		mac := hmac.New(sha512.New, secret)
+		mac.Write(append([]byte(r.URL.Path), sha[:]...))
+		if r.Header.Get("API-Key") != testKrakenKey || r.Header.Get("API-Sign") != base64.StdEncoding.EncodeToString(mac.Sum(nil)) {
+			fmt.Fprint(w, `{"error":["EAPI:Invalid signature"]}`)
+			return
+		}
+	}
+
+	result, ok := k.responses[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	fmt.Fprintf(w, `{"error":[],"result":%s}`, result)
+}
+
+func newTestKrakenClient(t *testing.T) (ExchangeClient, *krakenStandIn) {
+	standIn := newKrakenStandIn(t)
+	client, err := NewKrakenClientWithURL(standIn.URL, testKrakenKey, testKrakenSecret)
+	assert.NoError(t, err)
+	return client, standIn
+}
+
+func TestKrakenSymbol(t *testing.T) {
+	assert.Equal(t, BTC, krakenSymbol("XXBT"))
+	assert.Equal(t, "ETH", krakenSymbol("XETH"))
+	assert.Equal(t, "USD", krakenSymbol("ZUSD"))
+	assert.Equal(t, "DOGE", krakenSymbol("XXDG"))
+	assert.Equal(t, "DOT", krakenSymbol("DOT"))
+	assert.Equal(t, "USDT", krakenSymbol("USDT"))
+}
+
+func TestKrakenBalances(t *testing.T) {
+	client, standIn := newTestKrakenClient(t)
+	defer standIn.Close()
+
+	balances, err := client.GetCoinBalances()
+	assert.NoError(t, err)
+	assert.Equal(t, []CoinBalance{
+		{Symbol: BTC, Exchange: KRAKEN_EXCHANGE, Free: 1.5},
+		{Symbol: "DOGE", Exchange: KRAKEN_EXCHANGE, Free: 1000},
+		{Symbol: "USD", Exchange: KRAKEN_EXCHANGE, Free: 250.25},
+	}, balances)
+
+	// a bad secret is rejected by kraken
+	badClient, err := NewKrakenClientWithURL(standIn.URL, testKrakenKey, base64.StdEncoding.EncodeToString([]byte("wrong")))
+	assert.NoError(t, err)
+	_, err = badClient.GetCoinBalances()
+	assert.Error(t, err)
+	assert.False(t, IsTransient(err))
+}
+
+func TestKrakenPricesAndPairs(t *testing.T) {
+	client, standIn := newTestKrakenClient(t)
+	defer standIn.Close()
+
+	pairs, err := client.GetTradingPairs()
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, "XETHXXBT", pairs[0].Symbol)
+	assert.Equal(t, "ETH", pairs[0].Base)
+	assert.Equal(t, BTC, pairs[0].As)
+	assert.Equal(t, "cancel_only", pairs[0].Status)
+	assert.Equal(t, "XXBTZUSD", pairs[1].Symbol)
+	assert.Equal(t, BTC, pairs[1].Base)
+	assert.Equal(t, "USD", pairs[1].As)
+	assert.Equal(t, TRADING_STATUS, pairs[1].Status)
+	assert.Equal(t, 0.0001, pairs[1].MinQuantity)
+	assert.Equal(t, 0.5, pairs[1].MinNotional)
+	assert.Equal(t, 0.1, pairs[1].TickSize)
+	assert.Equal(t, 8, pairs[1].BasePrecision)
+
+	prices, err := client.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Len(t, prices, 2)
+	for _, price := range prices {
+		assert.Equal(t, KRAKEN_EXCHANGE, price.Exchange)
+		switch price.Base {
+		case BTC:
+			assert.Equal(t, "USD", price.As)
+			assert.Equal(t, 30005.0, price.Price)
+		case "ETH":
+			assert.Equal(t, BTC, price.As)
+			assert.Equal(t, 0.055, price.Price)
+		default:
+			t.Errorf("Unexpected price %#v", price)
+		}
+	}
+
+	days, err := client.GetDaySummaries()
+	assert.NoError(t, err)
+	assert.Len(t, days, 2)
+	for _, day := range days {
+		if day.Base != BTC {
+			continue
+		}
+		assert.Equal(t, "USD", day.As)
+		assert.Equal(t, 29000.0, day.OpenPrice)
+		assert.Equal(t, 30005.0, day.ClosePrice)
+		assert.Equal(t, 1005.0, day.ChangePrice)
+		assert.InDelta(t, 3.4655, day.ChangePercent, 0.0001)
+		assert.Equal(t, 29500.0, day.WeightedAvgPrice)
+		assert.Equal(t, 32000.0, day.HighestPrice)
+		assert.Equal(t, 28000.0, day.LowestPrice)
+		assert.Equal(t, 200.0, day.Volume)
+		assert.Equal(t, 200.0*29500.0, day.QuoteVolume)
+		assert.Equal(t, 30000.0, day.BidPrice)
+		assert.Equal(t, 2.5, day.BidQuantity)
+		assert.Equal(t, 30010.0, day.AskPrice)
+		assert.Equal(t, 1.5, day.AskQuantity)
+		assert.Equal(t, int64(2500), day.Trades)
+	}
+
+	// a pair that can't be parsed is skipped rather than failing every pair
+	failing, failingStandIn := newTestKrakenClient(t)
+	defer failingStandIn.Close()
+	failingStandIn.responses["/0/public/AssetPairs"] = `{
+		"XXBTZUSD": {"altname":"XBTUSD","base":"XXBT","quote":"ZUSD","pair_decimals":1,"lot_decimals":8,"ordermin":"lots","status":"online"},
+		"XETHXXBT": {"altname":"ETHXBT","base":"XETH","quote":"XXBT","pair_decimals":5,"lot_decimals":8,"ordermin":"0.002","status":"online"}
+	}`
+	prices, err = failing.GetLatestPrices()
+	assert.NoError(t, err)
+	if assert.Len(t, prices, 1) {
+		assert.Equal(t, "ETH", prices[0].Base)
+	}
+
+	// prices fail when pairs can't be fetched, asset pairs are only requested once
+	failingStandIn.responses["/0/public/AssetPairs"] = `[]`
+	failing, err = NewKrakenClientWithURL(failingStandIn.URL, testKrakenKey, testKrakenSecret)
+	assert.NoError(t, err)
+	failingStandIn.counts["/0/public/AssetPairs"] = 0
+	_, err = failing.GetLatestPrices()
+	assert.Error(t, err)
+	_, err = failing.GetDaySummaries()
+	assert.Error(t, err)
+	assert.Equal(t, 2, failingStandIn.counts["/0/public/AssetPairs"])
+}
+
+func TestKrakenCandles(t *testing.T) {
+	client, standIn := newTestKrakenClient(t)
+	defer standIn.Close()
+
+	from := time.Unix(1600000060, 0)
+	to := time.Unix(1600000180, 0)
+	candles, err := client.GetCandles(BTC, "USD", CANDLE_1M, from, to)
+	assert.NoError(t, err)
+
+	params := standIn.requests["/0/public/OHLC"].URL.Query()
+	assert.Equal(t, "XXBTZUSD", params.Get("pair"))
+	assert.Equal(t, "1", params.Get("interval"))
+	assert.Equal(t, "1600000059", params.Get("since"))
+
+	// the candle before from is not returned
+	if assert.Len(t, candles, 2) {
+		assert.Equal(t, from.UTC(), candles[0].OpenTime)
+		assert.Equal(t, from.UTC().Add(time.Minute-time.Millisecond), candles[0].CloseTime)
+		assert.Equal(t, 11.0, candles[0].Open)
+		assert.Equal(t, 13.0, candles[0].High)
+		assert.Equal(t, 10.0, candles[0].Low)
+		assert.Equal(t, 12.0, candles[0].Close)
+		assert.Equal(t, 4.0, candles[0].Volume)
+		assert.Equal(t, 46.0, candles[0].QuoteVolume)
+		assert.Equal(t, 13.0, candles[1].Close)
+	}
+
+	_, err = client.GetCandles("XRP", "USD", CANDLE_1M, from, to)
+	assert.Error(t, err)
+}
+
+func TestKrakenErrors(t *testing.T) {
+	client, standIn := newTestKrakenClient(t)
+	defer standIn.Close()
+
+	standIn.errors["/0/private/Balance"] = "EAPI:Rate limit exceeded"
+	_, err := client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.True(t, IsTransient(err))
+
+	standIn.errors["/0/private/Balance"] = "EGeneral:Permission denied"
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.False(t, IsTransient(err))
+
+	_, err = client.PlaceMarketOrder(BUY_ORDER, BTC, "USD", 1)
+	assert.Error(t, err)
+
+	// an unreachable exchange can be retried
+	standIn.Close()
+	_, err = client.GetLatestPrices()
+	assert.Error(t, err)
+	assert.True(t, IsTransient(err))
+}
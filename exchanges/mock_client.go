@@ -1,14 +1,25 @@
 package exchanges
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
 )
 
 type mockClient struct {
+	sync.RWMutex
 	balances     []CoinBalance
 	prices       []Price
 	daySummaries []DaySummary
 	serverTime   func() time.Time
+	orders       map[string]*Order
+	lastOrderID  int64
+	scripts      [][]Price // price streams for the next subscribers
 }
 
 const (
@@ -16,9 +27,14 @@ const (
 )
 
 func NewMockClient(coinBalances []CoinBalance, exchangePrices []Price) (ExchangeClient, error) {
+	// copied so fills don't change the caller's balances
+	balances := make([]CoinBalance, len(coinBalances))
+	copy(balances, coinBalances)
+
 	mock := &mockClient{
-		balances: coinBalances,
+		balances: balances,
 		prices:   exchangePrices,
+		orders:   make(map[string]*Order),
 	}
 
 	return mock, nil
@@ -73,11 +89,21 @@ func (m *mockClient) GetExchange() string {
 // }
 
 func (m *mockClient) GetCoinBalances() ([]CoinBalance, error) {
-	return m.balances, nil
+	m.RLock()
+	defer m.RUnlock()
+
+	balances := make([]CoinBalance, len(m.balances))
+	copy(balances, m.balances)
+	return balances, nil
 }
 
 func (m *mockClient) GetLatestPrices() ([]Price, error) {
-	return m.prices, nil
+	m.RLock()
+	defer m.RUnlock()
+
+	prices := make([]Price, len(m.prices))
+	copy(prices, m.prices)
+	return prices, nil
 }
 
 // func (m *mockClient) GetHistoricPrices() ([]Price, error) {
@@ -96,3 +122,457 @@ func (m *mockClient) GetLatestPrices() ([]Price, error) {
 func (m *mockClient) GetDaySummaries() ([]DaySummary, error) {
 	return m.daySummaries, nil
 }
+
+// MOCK_PRECISION - decimal places of every symbol on the mock exchange
+const MOCK_PRECISION = 8
+
+// GetTradingPairs - returns a trading pair for every mock price sorted by symbol
+func (m *mockClient) GetTradingPairs() ([]TradingPair, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	smallest := math.Pow10(-MOCK_PRECISION)
+
+	pairs := make([]TradingPair, 0, len(m.prices))
+	for _, price := range m.prices {
+		if price.Base == price.As {
+			continue
+		}
+		pairs = append(pairs, TradingPair{
+			Symbol:        price.Base + price.As,
+			Base:          price.Base,
+			As:            price.As,
+			Status:        TRADING_STATUS,
+			BasePrecision: MOCK_PRECISION,
+			AsPrecision:   MOCK_PRECISION,
+			MinPrice:      smallest,
+			TickSize:      smallest,
+			MinQuantity:   smallest,
+			StepSize:      smallest,
+			Exchange:      MOCK_EXCHANGE,
+		})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Symbol < pairs[j].Symbol })
+
+	return pairs, nil
+}
+
+// GetCandles - returns flat candles at the current mock price, there is no price history
+func (m *mockClient) GetCandles(base, as string, interval CandleInterval, from, to time.Time) ([]Candle, error) {
+	duration, err := interval.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	price, err := m.getPrice(base, as)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]Candle, 0)
+	for openTime := from.Truncate(duration); openTime.Before(to); openTime = openTime.Add(duration) {
+		if openTime.Before(from) {
+			continue
+		}
+		candles = append(candles, Candle{
+			Base:      base,
+			As:        as,
+			Interval:  interval,
+			OpenTime:  openTime,
+			CloseTime: openTime.Add(duration - time.Millisecond),
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+			Exchange:  MOCK_EXCHANGE,
+		})
+	}
+
+	return candles, nil
+}
+
+/*
+
+Orders on the mock exchange are filled against its configured prices:-
+
+	market - filled straight away at the current price
+	limit  - filled straight away at the current price if it is as good as
+	         the limit, otherwise the funds are locked until a price update
+	         reaches the limit or the order is cancelled
+
+Balances are updated by every fill. No commission is charged.
+
+*/
+
+// SetPrices - replaces the mock prices and fills any open limit orders the new prices reach
+func (m *mockClient) SetPrices(prices []Price) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.prices = prices
+
+	return m.fillLimitOrders()
+}
+
+// setPrice - changes the price of one trading pair and fills any open limit orders it reaches
+func (m *mockClient) setPrice(price Price) error {
+	m.Lock()
+	defer m.Unlock()
+
+	// copy so the slice passed to SetPrices is not changed
+	prices := make([]Price, 0, len(m.prices)+1)
+	found := false
+	for _, existing := range m.prices {
+		if existing.Base == price.Base && existing.As == price.As {
+			existing = price
+			found = true
+		}
+		prices = append(prices, existing)
+	}
+	if !found {
+		prices = append(prices, price)
+	}
+	m.prices = prices
+
+	return m.fillLimitOrders()
+}
+
+// fillLimitOrders - fills open limit orders reached by the current prices
+func (m *mockClient) fillLimitOrders() error {
+	for _, order := range m.orders {
+		if !order.IsOpen() {
+			continue
+		}
+		price, err := m.getPrice(order.Base, order.As)
+		if err != nil {
+			continue
+		}
+		if !limitReached(order.Side, order.Price, price) {
+			continue
+		}
+
+		// release locked funds then fill at the limit price
+		m.unlockFunds(order)
+		if err := m.fill(order, order.Price); err != nil {
+			m.lockFunds(order)
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+
+The mock price stream sends scripted prices so tests control exactly what a
+subscriber receives. Each subscriber takes the next script queued, every price
+becomes the mock's current price as it is sent and the stream is closed after
+the last one as if the connection had dropped.
+
+A subscriber with no script queued gets a stream that stays open without
+sending any prices until its context is done.
+
+*/
+
+// MockPriceStreamer - the mock client's scripted price stream
+type MockPriceStreamer interface {
+	PriceStreamer
+	ScriptPriceStream(prices []Price)
+}
+
+// ScriptPriceStream - queues prices for the next subscriber to the price stream
+func (m *mockClient) ScriptPriceStream(prices []Price) {
+	m.Lock()
+	defer m.Unlock()
+	m.scripts = append(m.scripts, prices)
+}
+
+func (m *mockClient) SubscribePrices(ctx context.Context) (<-chan Price, error) {
+	m.Lock()
+	var script []Price
+	scripted := len(m.scripts) > 0
+	if scripted {
+		script = m.scripts[0]
+		m.scripts = m.scripts[1:]
+	}
+	m.Unlock()
+
+	prices := make(chan Price)
+
+	go func() {
+		defer close(prices)
+
+		if !scripted {
+			<-ctx.Done()
+			return
+		}
+
+		for _, price := range script {
+			if err := m.setPrice(price); err != nil {
+				log.Printf("Mock price stream failed to set price - %s", err)
+			}
+			select {
+			case prices <- price:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return prices, nil
+}
+
+func (m *mockClient) now() time.Time {
+	if m.serverTime != nil {
+		return m.serverTime()
+	}
+	return time.Now()
+}
+
+func (m *mockClient) getPrice(base, as string) (float64, error) {
+	for _, price := range m.prices {
+		if price.Base == base && price.As == as {
+			return price.Price, nil
+		}
+	}
+	return 0, fmt.Errorf("No price for %s%s on %s", base, as, MOCK_EXCHANGE)
+}
+
+// balanceIndex - returns the index of a symbol's balance, adding an empty balance if not held
+func (m *mockClient) balanceIndex(symbol string) int {
+	for i := range m.balances {
+		if m.balances[i].Symbol == symbol {
+			return i
+		}
+	}
+	m.balances = append(m.balances, CoinBalance{Symbol: symbol, Exchange: MOCK_EXCHANGE})
+	return len(m.balances) - 1
+}
+
+// getBalance - returns a symbol's balance, only valid until another balance is added
+func (m *mockClient) getBalance(symbol string) *CoinBalance {
+	return &m.balances[m.balanceIndex(symbol)]
+}
+
+// limitReached - returns true if a limit order would fill at price
+func limitReached(side OrderSide, limit, price float64) bool {
+	if side == BUY_ORDER {
+		return price <= limit
+	}
+	return price >= limit
+}
+
+func (m *mockClient) newOrder(side OrderSide, orderType OrderType, base, as string, quantity, price float64) (*Order, error) {
+	if side != BUY_ORDER && side != SELL_ORDER {
+		return nil, fmt.Errorf("Order side %q is not valid", side)
+	}
+	if quantity <= 0 {
+		return nil, fmt.Errorf("Order quantity must be greater than 0")
+	}
+
+	m.lastOrderID++
+	return &Order{
+		ID:       strconv.FormatInt(m.lastOrderID, 10),
+		Base:     base,
+		As:       as,
+		Side:     side,
+		Type:     orderType,
+		Status:   ORDER_NEW,
+		Price:    price,
+		Quantity: quantity,
+		Fills:    make([]Fill, 0),
+		Exchange: MOCK_EXCHANGE,
+		At:       m.now(),
+	}, nil
+}
+
+// checkFunds - returns an error if there are not enough free funds to fill an order at price
+func (m *mockClient) checkFunds(order *Order, price float64) error {
+	if order.Side == BUY_ORDER {
+		if cost := order.Quantity * price; m.getBalance(order.As).Free < cost {
+			return fmt.Errorf("Insufficient %s balance to buy %f %s", order.As, order.Quantity, order.Base)
+		}
+		return nil
+	}
+	if m.getBalance(order.Base).Free < order.Quantity {
+		return fmt.Errorf("Insufficient %s balance to sell %f", order.Base, order.Quantity)
+	}
+	return nil
+}
+
+// fill - fills the remainder of an order at price and updates balances
+func (m *mockClient) fill(order *Order, price float64) error {
+	if err := m.checkFunds(order, price); err != nil {
+		return err
+	}
+
+	quantity := order.Quantity - order.ExecutedQuantity
+	value := quantity * price
+
+	// both balances are added before taking pointers as adding one can move the other
+	baseIndex, asIndex := m.balanceIndex(order.Base), m.balanceIndex(order.As)
+	base, as := &m.balances[baseIndex], &m.balances[asIndex]
+	if order.Side == BUY_ORDER {
+		as.Free -= value
+		base.Free += quantity
+	} else {
+		base.Free -= quantity
+		as.Free += value
+	}
+
+	order.Fills = append(order.Fills, Fill{Price: price, Quantity: quantity, CommissionAsset: order.As})
+	order.ExecutedQuantity += quantity
+	order.QuoteQuantity += value
+	order.Status = ORDER_FILLED
+	order.At = m.now()
+
+	return nil
+}
+
+func (m *mockClient) lockFunds(order *Order) {
+	if order.Side == BUY_ORDER {
+		as := m.getBalance(order.As)
+		as.Free -= order.Quantity * order.Price
+		as.Locked += order.Quantity * order.Price
+	} else {
+		base := m.getBalance(order.Base)
+		base.Free -= order.Quantity
+		base.Locked += order.Quantity
+	}
+}
+
+func (m *mockClient) unlockFunds(order *Order) {
+	if order.Side == BUY_ORDER {
+		as := m.getBalance(order.As)
+		as.Free += order.Quantity * order.Price
+		as.Locked -= order.Quantity * order.Price
+	} else {
+		base := m.getBalance(order.Base)
+		base.Free += order.Quantity
+		base.Locked -= order.Quantity
+	}
+}
+
+func (m *mockClient) PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	price, err := m.getPrice(base, as)
+	if err != nil {
+		return Order{}, err
+	}
+
+	order, err := m.newOrder(side, MARKET_ORDER, base, as, quantity, 0)
+	if err != nil {
+		return Order{}, err
+	}
+
+	if err := m.fill(order, price); err != nil {
+		return Order{}, err
+	}
+
+	m.orders[order.ID] = order
+	return order.copy(), nil
+}
+
+func (m *mockClient) PlaceLimitOrder(side OrderSide, base, as string, quantity, price float64) (Order, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if price <= 0 {
+		return Order{}, fmt.Errorf("Limit price must be greater than 0")
+	}
+
+	marketPrice, err := m.getPrice(base, as)
+	if err != nil {
+		return Order{}, err
+	}
+
+	order, err := m.newOrder(side, LIMIT_ORDER, base, as, quantity, price)
+	if err != nil {
+		return Order{}, err
+	}
+
+	if limitReached(side, price, marketPrice) {
+		// fills straight away at the better market price
+		if err := m.fill(order, marketPrice); err != nil {
+			return Order{}, err
+		}
+	} else {
+		if err := m.checkFunds(order, price); err != nil {
+			return Order{}, err
+		}
+		m.lockFunds(order)
+	}
+
+	m.orders[order.ID] = order
+	return order.copy(), nil
+}
+
+func (m *mockClient) getOrder(base, as, id string) (*Order, error) {
+	order, ok := m.orders[id]
+	if !ok || order.Base != base || order.As != as {
+		return nil, fmt.Errorf("Order %s for %s%s not found", id, base, as)
+	}
+	return order, nil
+}
+
+func (m *mockClient) CancelOrder(base, as, id string) (Order, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	order, err := m.getOrder(base, as, id)
+	if err != nil {
+		return Order{}, err
+	}
+
+	if !order.IsOpen() {
+		return Order{}, fmt.Errorf("Order %s cannot be cancelled as it is %s", id, order.Status)
+	}
+
+	m.unlockFunds(order)
+	order.Status = ORDER_CANCELED
+	order.At = m.now()
+
+	return order.copy(), nil
+}
+
+func (m *mockClient) GetOrder(base, as, id string) (Order, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	order, err := m.getOrder(base, as, id)
+	if err != nil {
+		return Order{}, err
+	}
+	return order.copy(), nil
+}
+
+// GetOpenOrders - returns open orders for base and as, or for every symbol if they are empty
+func (m *mockClient) GetOpenOrders(base, as string) ([]Order, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	orders := make([]Order, 0)
+	for _, order := range m.orders {
+		if !order.IsOpen() {
+			continue
+		}
+		if (base != "" || as != "") && (order.Base != base || order.As != as) {
+			continue
+		}
+		orders = append(orders, order.copy())
+	}
+
+	// oldest first
+	sort.Slice(orders, func(i, j int) bool {
+		idI, _ := strconv.ParseInt(orders[i].ID, 10, 64)
+		idJ, _ := strconv.ParseInt(orders[j].ID, 10, 64)
+		return idI < idJ
+	})
+
+	return orders, nil
+}
@@ -0,0 +1,253 @@
+package exchanges
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMockClient(t *testing.T) *mockClient {
+	balances := []CoinBalance{
+		{Symbol: BTC, Free: 1.0, Exchange: MOCK_EXCHANGE},
+		{Symbol: ETH, Free: 10.0, Exchange: MOCK_EXCHANGE},
+	}
+	prices := []Price{
+		{Base: ETH, As: BTC, Price: 0.1, Exchange: MOCK_EXCHANGE},
+	}
+	client, err := NewMockClient(balances, prices)
+	assert.NoError(t, err)
+	return client.(*mockClient)
+}
+
+func balanceOf(t *testing.T, client ExchangeClient, symbol string) CoinBalance {
+	balances, err := client.GetCoinBalances()
+	assert.NoError(t, err)
+	for _, balance := range balances {
+		if balance.Symbol == symbol {
+			return balance
+		}
+	}
+	return CoinBalance{Symbol: symbol}
+}
+
+func TestMockMarketOrders(t *testing.T) {
+	client := newTestMockClient(t)
+
+	order, err := client.PlaceMarketOrder(BUY_ORDER, ETH, BTC, 5.0)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_FILLED, order.Status)
+	assert.Equal(t, MARKET_ORDER, order.Type)
+	assert.Equal(t, 5.0, order.ExecutedQuantity)
+	assert.InDelta(t, 0.5, order.QuoteQuantity, 0.0000001)
+	assert.InDelta(t, 0.1, order.AvgPrice(), 0.0000001)
+	assert.Equal(t, 1, len(order.Fills))
+
+	assert.InDelta(t, 15.0, balanceOf(t, client, ETH).Free, 0.0000001)
+	assert.InDelta(t, 0.5, balanceOf(t, client, BTC).Free, 0.0000001)
+
+	order, err = client.PlaceMarketOrder(SELL_ORDER, ETH, BTC, 15.0)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_FILLED, order.Status)
+	assert.InDelta(t, 0.0, balanceOf(t, client, ETH).Free, 0.0000001)
+	assert.InDelta(t, 2.0, balanceOf(t, client, BTC).Free, 0.0000001)
+
+	// insufficient balance
+	_, err = client.PlaceMarketOrder(SELL_ORDER, ETH, BTC, 1.0)
+	assert.Error(t, err)
+	_, err = client.PlaceMarketOrder(BUY_ORDER, ETH, BTC, 100.0)
+	assert.Error(t, err)
+
+	// no price
+	_, err = client.PlaceMarketOrder(BUY_ORDER, LTC, BTC, 1.0)
+	assert.Error(t, err)
+
+	// invalid quantity
+	_, err = client.PlaceMarketOrder(BUY_ORDER, ETH, BTC, 0)
+	assert.Error(t, err)
+
+	fetched, err := client.GetOrder(ETH, BTC, order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, order, fetched)
+
+	_, err = client.GetOrder(ETH, USDT, order.ID)
+	assert.Error(t, err)
+}
+
+func TestMockSellIntoNewAsset(t *testing.T) {
+	balances := []CoinBalance{
+		{Symbol: ETH, Free: 10.0, Exchange: MOCK_EXCHANGE},
+	}
+	prices := []Price{
+		{Base: ETH, As: USDT, Price: 40.0, Exchange: MOCK_EXCHANGE},
+	}
+	client, err := NewMockClient(balances, prices)
+	assert.NoError(t, err)
+
+	// selling into an asset not held yet adds its balance without losing the debit
+	_, err = client.PlaceMarketOrder(SELL_ORDER, ETH, USDT, 10.0)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0, balanceOf(t, client, ETH).Free, 0.0000001)
+	assert.InDelta(t, 400.0, balanceOf(t, client, USDT).Free, 0.0000001)
+
+	// the caller's balances are not changed
+	assert.Equal(t, []CoinBalance{{Symbol: ETH, Free: 10.0, Exchange: MOCK_EXCHANGE}}, balances)
+}
+
+func TestMockLimitOrders(t *testing.T) {
+	client := newTestMockClient(t)
+
+	// marketable limit fills at the better market price
+	order, err := client.PlaceLimitOrder(BUY_ORDER, ETH, BTC, 1.0, 0.2)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_FILLED, order.Status)
+	assert.InDelta(t, 0.1, order.AvgPrice(), 0.0000001)
+
+	// resting buy locks the as funds
+	buy, err := client.PlaceLimitOrder(BUY_ORDER, ETH, BTC, 2.0, 0.05)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_NEW, buy.Status)
+	assert.InDelta(t, 0.8, balanceOf(t, client, BTC).Free, 0.0000001)
+	assert.InDelta(t, 0.1, balanceOf(t, client, BTC).Locked, 0.0000001)
+
+	// resting sell locks the base funds
+	sell, err := client.PlaceLimitOrder(SELL_ORDER, ETH, BTC, 3.0, 0.5)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_NEW, sell.Status)
+	assert.InDelta(t, 8.0, balanceOf(t, client, ETH).Free, 0.0000001)
+	assert.InDelta(t, 3.0, balanceOf(t, client, ETH).Locked, 0.0000001)
+
+	open, err := client.GetOpenOrders(ETH, BTC)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(open)) {
+		assert.Equal(t, buy.ID, open[0].ID)
+		assert.Equal(t, sell.ID, open[1].ID)
+	}
+
+	open, err = client.GetOpenOrders("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(open))
+
+	// cancel releases the funds
+	cancelled, err := client.CancelOrder(ETH, BTC, sell.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_CANCELED, cancelled.Status)
+	assert.InDelta(t, 11.0, balanceOf(t, client, ETH).Free, 0.0000001)
+	assert.InDelta(t, 0.0, balanceOf(t, client, ETH).Locked, 0.0000001)
+
+	_, err = client.CancelOrder(ETH, BTC, sell.ID)
+	assert.Error(t, err)
+
+	// price drops to the limit and fills the resting buy
+	assert.NoError(t, client.SetPrices([]Price{{Base: ETH, As: BTC, Price: 0.04, Exchange: MOCK_EXCHANGE}}))
+
+	filled, err := client.GetOrder(ETH, BTC, buy.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_FILLED, filled.Status)
+	assert.InDelta(t, 0.05, filled.AvgPrice(), 0.0000001)
+	assert.InDelta(t, 13.0, balanceOf(t, client, ETH).Free, 0.0000001)
+	assert.InDelta(t, 0.8, balanceOf(t, client, BTC).Free, 0.0000001)
+	assert.InDelta(t, 0.0, balanceOf(t, client, BTC).Locked, 0.0000001)
+
+	open, err = client.GetOpenOrders(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(open))
+
+	// insufficient funds to rest an order
+	_, err = client.PlaceLimitOrder(BUY_ORDER, ETH, BTC, 100.0, 0.01)
+	assert.Error(t, err)
+}
+
+func TestMockTradingPairs(t *testing.T) {
+	client := newTestMockClient(t)
+
+	assert.NoError(t, client.SetPrices([]Price{
+		{Base: LTC, As: BTC, Price: 0.01, Exchange: MOCK_EXCHANGE},
+		{Base: BTC, As: BTC, Price: 1.0, Exchange: MOCK_EXCHANGE},
+		{Base: ETH, As: "TUSD", Price: 500.0, Exchange: MOCK_EXCHANGE},
+	}))
+
+	pairs, err := client.GetTradingPairs()
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(pairs)) {
+		assert.Equal(t, "ETHTUSD", pairs[0].Symbol)
+		assert.Equal(t, ETH, pairs[0].Base)
+		assert.Equal(t, "TUSD", pairs[0].As)
+		assert.True(t, pairs[0].IsTrading())
+		assert.Equal(t, "LTCBTC", pairs[1].Symbol)
+	}
+}
+
+func TestMockCandles(t *testing.T) {
+	client := newTestMockClient(t)
+
+	from := time.Date(2018, 1, 1, 0, 0, 30, 0, time.UTC)
+	to := time.Date(2018, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	candles, err := client.GetCandles(ETH, BTC, CANDLE_15M, from, to)
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(candles)) {
+		assert.Equal(t, from.Add(15*time.Minute-30*time.Second), candles[0].OpenTime)
+		assert.Equal(t, 0.1, candles[0].Open)
+		assert.Equal(t, 0.1, candles[2].Close)
+	}
+
+	_, err = client.GetCandles(ETH, BTC, CandleInterval("2m"), from, to)
+	assert.Error(t, err)
+
+	_, err = client.GetCandles(LTC, BTC, CANDLE_1M, from, to)
+	assert.Error(t, err)
+}
+
+func TestMockPriceStream(t *testing.T) {
+	client := newTestMockClient(t)
+
+	var streamer PriceStreamer = client
+	_, ok := streamer.(MockPriceStreamer)
+	assert.True(t, ok)
+
+	// resting sell reached by a streamed price
+	sell, err := client.PlaceLimitOrder(SELL_ORDER, ETH, BTC, 1.0, 0.15)
+	assert.NoError(t, err)
+
+	script := []Price{
+		{Base: ETH, As: BTC, Price: 0.12, Exchange: MOCK_EXCHANGE},
+		{Base: LTC, As: BTC, Price: 0.01, Exchange: MOCK_EXCHANGE},
+		{Base: ETH, As: BTC, Price: 0.16, Exchange: MOCK_EXCHANGE},
+	}
+	client.ScriptPriceStream(script)
+
+	prices, err := client.SubscribePrices(context.Background())
+	assert.NoError(t, err)
+
+	streamed := make([]Price, 0)
+	for price := range prices {
+		streamed = append(streamed, price)
+	}
+	// closed after the script as if the stream dropped
+	assert.Equal(t, script, streamed)
+
+	latest, err := client.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, []Price{script[2], script[1]}, latest)
+
+	filled, err := client.GetOrder(ETH, BTC, sell.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_FILLED, filled.Status)
+
+	// no script stays open until cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	prices, err = client.SubscribePrices(ctx)
+	assert.NoError(t, err)
+
+	select {
+	case <-prices:
+		t.Fatal("Unscripted stream sent a price")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	_, open := <-prices
+	assert.False(t, open)
+}
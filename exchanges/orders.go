@@ -0,0 +1,74 @@
+package exchanges
+
+import (
+	"time"
+)
+
+type OrderSide string
+
+const (
+	BUY_ORDER  OrderSide = "BUY"
+	SELL_ORDER OrderSide = "SELL"
+)
+
+type OrderType string
+
+const (
+	MARKET_ORDER OrderType = "MARKET"
+	LIMIT_ORDER  OrderType = "LIMIT"
+)
+
+type OrderStatus string
+
+const (
+	ORDER_NEW              OrderStatus = "NEW"
+	ORDER_PARTIALLY_FILLED OrderStatus = "PARTIALLY_FILLED"
+	ORDER_FILLED           OrderStatus = "FILLED"
+	ORDER_CANCELED         OrderStatus = "CANCELED"
+	ORDER_REJECTED         OrderStatus = "REJECTED"
+	ORDER_EXPIRED          OrderStatus = "EXPIRED"
+)
+
+type Order struct {
+	ID               string
+	Base             string // This is the symbol being bought or sold eg. NEO
+	As               string // This is the symbol it is paid for in eg. BTC
+	Side             OrderSide
+	Type             OrderType
+	Status           OrderStatus
+	Price            float64 // Limit price, 0 for market orders
+	Quantity         float64 // Quantity of base requested
+	ExecutedQuantity float64 // Quantity of base filled so far
+	QuoteQuantity    float64 // Total of as symbol spent or received by fills
+	Fills            []Fill
+	Exchange         string
+	At               time.Time
+}
+
+type Fill struct {
+	Price           float64
+	Quantity        float64
+	Commission      float64
+	CommissionAsset string
+}
+
+// AvgPrice - returns the average price the order has been filled at
+func (o Order) AvgPrice() float64 {
+	if o.ExecutedQuantity == 0 {
+		return 0
+	}
+	return o.QuoteQuantity / o.ExecutedQuantity
+}
+
+// IsOpen - returns true if the order can still be filled
+func (o Order) IsOpen() bool {
+	return o.Status == ORDER_NEW || o.Status == ORDER_PARTIALLY_FILLED
+}
+
+// copy - returns a copy of the order that doesn't share its fills
+func (o *Order) copy() Order {
+	c := *o
+	c.Fills = make([]Fill, len(o.Fills))
+	copy(c.Fills, o.Fills)
+	return c
+}
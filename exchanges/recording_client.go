@@ -0,0 +1,210 @@
+package exchanges
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+/*
+
+A recording client wraps another exchange client and saves its responses so
+they can be replayed offline by a replay client.
+
+A recording is a directory containing:-
+
+	recording.json      - the exchange recorded and when recording started
+	balances/<time>.json
+	prices/<time>.json
+	daysummaries/<time>.json
+	tradingpairs/<time>.json
+
+Each file holds one response as JSON named by the time it was received, a
+response the same as the last one of its kind is not saved again. Candles and
+orders are passed to the wrapped client without being recorded.
+
+The recording client does not stream prices so a server polls the exchange
+every update while it records.
+
+*/
+
+const (
+	RECORDING_FILE = "recording.json"
+	// RECORDING_TIME_FORMAT - names of recorded files sort in time order
+	RECORDING_TIME_FORMAT = "20060102T150405.000000000Z"
+
+	RECORDED_BALANCES      = "balances"
+	RECORDED_PRICES        = "prices"
+	RECORDED_DAY_SUMMARIES = "daysummaries"
+	RECORDED_TRADING_PAIRS = "tradingpairs"
+)
+
+var recordedKinds = []string{RECORDED_BALANCES, RECORDED_PRICES, RECORDED_DAY_SUMMARIES, RECORDED_TRADING_PAIRS}
+
+// Recording - describes a recording directory
+type Recording struct {
+	Exchange string
+	Started  time.Time
+}
+
+type recordingClient struct {
+	sync.Mutex
+	client ExchangeClient
+	dir    string
+	now    func() time.Time
+	last   map[string][]byte // last response saved of each kind
+}
+
+// NewRecordingClient - returns a client that saves the responses of client in dir using now
+// to time them. Recording into a dir again adds to the recording if it is of the same exchange
+func NewRecordingClient(client ExchangeClient, dir string, now func() time.Time) (ExchangeClient, error) {
+
+	for _, kind := range recordedKinds {
+		kindDir := filepath.Join(dir, kind)
+		if err := os.MkdirAll(kindDir, 0755); err != nil {
+			return nil, fmt.Errorf("Failed to create recording dir: %s - %s", kindDir, err)
+		}
+	}
+
+	recording, err := ReadRecording(dir)
+	switch {
+	case os.IsNotExist(err):
+		recording = Recording{Exchange: client.GetExchange(), Started: now().UTC()}
+		recordingJSON, err := json.MarshalIndent(recording, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeRecordingFile(filepath.Join(dir, RECORDING_FILE), recordingJSON); err != nil {
+			return nil, fmt.Errorf("Failed to save recording: %s - %s", dir, err)
+		}
+	case err != nil:
+		return nil, err
+	case recording.Exchange != client.GetExchange():
+		return nil, fmt.Errorf("Cannot record %s into %s, it is a recording of %s", client.GetExchange(), dir, recording.Exchange)
+	}
+
+	return &recordingClient{
+		client: client,
+		dir:    dir,
+		now:    now,
+		last:   make(map[string][]byte),
+	}, nil
+}
+
+// ReadRecording - returns the description of the recording in dir
+func ReadRecording(dir string) (Recording, error) {
+	recording := Recording{}
+
+	recordingJSON, err := ioutil.ReadFile(filepath.Join(dir, RECORDING_FILE))
+	if err != nil {
+		return recording, err
+	}
+
+	if err := json.Unmarshal(recordingJSON, &recording); err != nil {
+		return recording, fmt.Errorf("Failed to read recording: %s - %s", dir, err)
+	}
+
+	return recording, nil
+}
+
+// writeRecordingFile - writes to a temp file first so a crash never leaves a partially
+// written response
+func writeRecordingFile(filePath string, data []byte) error {
+	tempPath := filePath + ".tmp"
+	if err := ioutil.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, filePath)
+}
+
+// record - saves a response of a kind unless it is the same as the last one
+func (r *recordingClient) record(kind string, response interface{}) {
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("Failed to record %s - %s", kind, err)
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if bytes.Equal(responseJSON, r.last[kind]) {
+		return
+	}
+
+	filePath := filepath.Join(r.dir, kind, r.now().UTC().Format(RECORDING_TIME_FORMAT)+".json")
+	if err := writeRecordingFile(filePath, responseJSON); err != nil {
+		// the response is still returned, it is only missing from the recording
+		log.Printf("Failed to record %s - %s", kind, err)
+		return
+	}
+
+	r.last[kind] = responseJSON
+}
+
+func (r *recordingClient) GetExchange() string {
+	return r.client.GetExchange()
+}
+
+func (r *recordingClient) GetCoinBalances() ([]CoinBalance, error) {
+	balances, err := r.client.GetCoinBalances()
+	if err == nil {
+		r.record(RECORDED_BALANCES, balances)
+	}
+	return balances, err
+}
+
+func (r *recordingClient) GetLatestPrices() ([]Price, error) {
+	prices, err := r.client.GetLatestPrices()
+	if err == nil {
+		r.record(RECORDED_PRICES, prices)
+	}
+	return prices, err
+}
+
+func (r *recordingClient) GetDaySummaries() ([]DaySummary, error) {
+	summaries, err := r.client.GetDaySummaries()
+	if err == nil {
+		r.record(RECORDED_DAY_SUMMARIES, summaries)
+	}
+	return summaries, err
+}
+
+func (r *recordingClient) GetTradingPairs() ([]TradingPair, error) {
+	pairs, err := r.client.GetTradingPairs()
+	if err == nil {
+		r.record(RECORDED_TRADING_PAIRS, pairs)
+	}
+	return pairs, err
+}
+
+func (r *recordingClient) GetCandles(base, as string, interval CandleInterval, from, to time.Time) ([]Candle, error) {
+	return r.client.GetCandles(base, as, interval, from, to)
+}
+
+func (r *recordingClient) PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error) {
+	return r.client.PlaceMarketOrder(side, base, as, quantity)
+}
+
+func (r *recordingClient) PlaceLimitOrder(side OrderSide, base, as string, quantity, price float64) (Order, error) {
+	return r.client.PlaceLimitOrder(side, base, as, quantity, price)
+}
+
+func (r *recordingClient) CancelOrder(base, as, id string) (Order, error) {
+	return r.client.CancelOrder(base, as, id)
+}
+
+func (r *recordingClient) GetOrder(base, as, id string) (Order, error) {
+	return r.client.GetOrder(base, as, id)
+}
+
+func (r *recordingClient) GetOpenOrders(base, as string) ([]Order, error) {
+	return r.client.GetOpenOrders(base, as)
+}
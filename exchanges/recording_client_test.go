@@ -0,0 +1,122 @@
+package exchanges
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "teletrada-recording")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	start := time.Date(2018, 1, 18, 0, 0, 0, 0, time.UTC)
+	now := start
+	clock := func() time.Time { return now }
+
+	mock := newTestMockClient(t)
+	recorder, err := NewRecordingClient(mock, dir, clock)
+	assert.NoError(t, err)
+
+	// record two updates a minute apart
+	first := []Price{{Base: ETH, As: BTC, Price: 0.1, At: now, Exchange: MOCK_EXCHANGE}}
+	assert.NoError(t, mock.SetPrices(first))
+	_, err = recorder.GetLatestPrices()
+	assert.NoError(t, err)
+	_, err = recorder.GetCoinBalances()
+	assert.NoError(t, err)
+	_, err = recorder.GetTradingPairs()
+	assert.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	second := []Price{{Base: ETH, As: BTC, Price: 0.2, At: now, Exchange: MOCK_EXCHANGE}}
+	assert.NoError(t, mock.SetPrices(second))
+	_, err = recorder.GetLatestPrices()
+	assert.NoError(t, err)
+	// unchanged responses are not recorded again
+	_, err = recorder.GetCoinBalances()
+	assert.NoError(t, err)
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, RECORDED_PRICES))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(files))
+	files, err = ioutil.ReadDir(filepath.Join(dir, RECORDED_BALANCES))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(files))
+
+	recording, err := ReadRecording(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, Recording{Exchange: MOCK_EXCHANGE, Started: start}, recording)
+
+	// cannot record another exchange into the same dir
+	_, err = NewRecordingClient(&replayClient{recording: Recording{Exchange: BINANCE_EXCHANGE}}, dir, clock)
+	assert.Error(t, err)
+
+	// replay
+	now = start.Add(-time.Second)
+	replayer, err := NewReplayClient(dir, clock)
+	assert.NoError(t, err)
+	assert.Equal(t, MOCK_EXCHANGE, replayer.GetExchange())
+
+	from, to := replayer.(Replayer).RecordedBetween()
+	assert.Equal(t, start, from)
+	assert.Equal(t, start.Add(time.Minute), to)
+
+	_, err = replayer.GetLatestPrices()
+	assert.Error(t, err, "Nothing recorded yet")
+
+	now = start
+	prices, err := replayer.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, first, prices)
+
+	now = start.Add(30 * time.Second)
+	prices, err = replayer.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, first, prices)
+
+	now = start.Add(time.Hour)
+	prices, err = replayer.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, second, prices)
+
+	balances, err := replayer.GetCoinBalances()
+	assert.NoError(t, err)
+	assert.Equal(t, mock.balances, balances)
+
+	pairs, err := replayer.GetTradingPairs()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pairs))
+
+	_, err = replayer.GetDaySummaries()
+	assert.Error(t, err, "Day summaries were not recorded")
+
+	// nothing can be traded
+	_, err = replayer.PlaceMarketOrder(SELL_ORDER, ETH, BTC, 1.0)
+	assert.Error(t, err)
+	orders, err := replayer.GetOpenOrders(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(orders))
+}
+
+func TestReplayNoRecording(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "teletrada-recording")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewReplayClient(dir, time.Now)
+	assert.Error(t, err)
+
+	// a recording without prices
+	_, err = NewRecordingClient(newTestMockClient(t), dir, time.Now)
+	assert.NoError(t, err)
+	_, err = NewReplayClient(dir, time.Now)
+	assert.Error(t, err)
+}
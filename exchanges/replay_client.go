@@ -0,0 +1,178 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+
+A replay client serves the responses saved by a recording client. Each call
+returns the latest response of its kind recorded at or before the time of the
+replay clock, so moving the clock on replays the recording in order and the
+same clock always gets the same responses.
+
+Nothing can be traded on a replayed exchange, there are never any open orders
+and placing an order fails. Candles were not recorded so they cannot be
+downloaded.
+
+*/
+
+// Replayer - implemented by exchange clients that replay a recording
+type Replayer interface {
+	// RecordedBetween - returns the times of the first and last recorded responses
+	RecordedBetween() (time.Time, time.Time)
+}
+
+// recordedFile - a response saved at a time
+type recordedFile struct {
+	at   time.Time
+	path string
+}
+
+type replayClient struct {
+	recording Recording
+	now       func() time.Time
+	files     map[string][]recordedFile // by kind, oldest first
+}
+
+// NewReplayClient - returns a client that replays the recording in dir at the times returned by now
+func NewReplayClient(dir string, now func() time.Time) (ExchangeClient, error) {
+	recording, err := ReadRecording(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read recording: %s - %s", dir, err)
+	}
+
+	r := &replayClient{
+		recording: recording,
+		now:       now,
+		files:     make(map[string][]recordedFile, len(recordedKinds)),
+	}
+
+	for _, kind := range recordedKinds {
+		kindDir := filepath.Join(dir, kind)
+		infos, err := ioutil.ReadDir(kindDir)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read recorded %s - %s", kind, err)
+		}
+
+		files := make([]recordedFile, 0, len(infos))
+		for _, info := range infos {
+			name := info.Name()
+			if !strings.HasSuffix(name, ".json") {
+				continue
+			}
+			at, err := time.Parse(RECORDING_TIME_FORMAT, strings.TrimSuffix(name, ".json"))
+			if err != nil {
+				return nil, fmt.Errorf("Recorded file %s is not named by its time - %s", filepath.Join(kindDir, name), err)
+			}
+			files = append(files, recordedFile{at: at, path: filepath.Join(kindDir, name)})
+		}
+		sort.Slice(files, func(i, j int) bool { return files[i].at.Before(files[j].at) })
+
+		r.files[kind] = files
+	}
+
+	if len(r.files[RECORDED_PRICES]) == 0 {
+		return nil, fmt.Errorf("Recording %s has no prices", dir)
+	}
+
+	return r, nil
+}
+
+// RecordedBetween - returns the times of the first and last recorded responses
+func (r *replayClient) RecordedBetween() (time.Time, time.Time) {
+	var from, to time.Time
+	for _, files := range r.files {
+		if len(files) == 0 {
+			continue
+		}
+		if from.IsZero() || files[0].at.Before(from) {
+			from = files[0].at
+		}
+		if last := files[len(files)-1].at; last.After(to) {
+			to = last
+		}
+	}
+	return from, to
+}
+
+// replay - reads the latest response of kind recorded at or before now into response
+func (r *replayClient) replay(kind string, response interface{}) error {
+	now := r.now()
+	files := r.files[kind]
+
+	// first file recorded after now
+	i := sort.Search(len(files), func(i int) bool { return files[i].at.After(now) })
+	if i == 0 {
+		return fmt.Errorf("No %s recorded at or before %s", kind, now.Format(RECORDING_TIME_FORMAT))
+	}
+
+	responseJSON, err := ioutil.ReadFile(files[i-1].path)
+	if err != nil {
+		return fmt.Errorf("Failed to replay %s - %s", kind, err)
+	}
+
+	if err := json.Unmarshal(responseJSON, response); err != nil {
+		return fmt.Errorf("Failed to replay %s from %s - %s", kind, files[i-1].path, err)
+	}
+
+	return nil
+}
+
+func (r *replayClient) GetExchange() string {
+	return r.recording.Exchange
+}
+
+func (r *replayClient) GetCoinBalances() ([]CoinBalance, error) {
+	balances := make([]CoinBalance, 0)
+	err := r.replay(RECORDED_BALANCES, &balances)
+	return balances, err
+}
+
+func (r *replayClient) GetLatestPrices() ([]Price, error) {
+	prices := make([]Price, 0)
+	err := r.replay(RECORDED_PRICES, &prices)
+	return prices, err
+}
+
+func (r *replayClient) GetDaySummaries() ([]DaySummary, error) {
+	summaries := make([]DaySummary, 0)
+	err := r.replay(RECORDED_DAY_SUMMARIES, &summaries)
+	return summaries, err
+}
+
+func (r *replayClient) GetTradingPairs() ([]TradingPair, error) {
+	pairs := make([]TradingPair, 0)
+	err := r.replay(RECORDED_TRADING_PAIRS, &pairs)
+	return pairs, err
+}
+
+func (r *replayClient) GetCandles(base, as string, interval CandleInterval, from, to time.Time) ([]Candle, error) {
+	return nil, fmt.Errorf("Candles for %s%s were not recorded from %s", base, as, r.recording.Exchange)
+}
+
+func (r *replayClient) PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error) {
+	return Order{}, fmt.Errorf("Cannot place an order on replayed exchange %s", r.recording.Exchange)
+}
+
+func (r *replayClient) PlaceLimitOrder(side OrderSide, base, as string, quantity, price float64) (Order, error) {
+	return Order{}, fmt.Errorf("Cannot place an order on replayed exchange %s", r.recording.Exchange)
+}
+
+func (r *replayClient) CancelOrder(base, as, id string) (Order, error) {
+	return Order{}, fmt.Errorf("Order %s not found on replayed exchange %s", id, r.recording.Exchange)
+}
+
+func (r *replayClient) GetOrder(base, as, id string) (Order, error) {
+	return Order{}, fmt.Errorf("Order %s not found on replayed exchange %s", id, r.recording.Exchange)
+}
+
+func (r *replayClient) GetOpenOrders(base, as string) ([]Order, error) {
+	return []Order{}, nil
+}
@@ -0,0 +1,458 @@
+package exchanges
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+/*
+
+A resilient client decorates another exchange client:-
+
+	request weight - calls wait until the weight of the requests made in the
+	                 last minute leaves room for them under the limit
+	deadlines      - every attempt at a call has a deadline of the request
+	                 timeout for each request it makes, clients that implement
+	                 ContextClient have their requests cancelled at the deadline
+	retries        - transient failures of calls that are safe to repeat are
+	                 retried with a backoff doubling from the retry delay.
+	                 Orders are never placed twice
+	circuit breaker - opens after a number of calls in a row fail with transient
+	                 errors, while open calls fail straight away. After the
+	                 reset time one call is let through, the breaker closes if
+	                 it succeeds or opens again if it fails
+
+Errors the exchange returns for a bad request, eg. insufficient funds, are not
+transient. They are not retried and count as a success for the breaker as the
+exchange answered.
+
+*/
+
+// TransientError - a failure that may not happen if the request is tried again
+// eg. a timeout or rate limit
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+// IsTransient - returns true if a request that failed with err may succeed if tried again
+func IsTransient(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	switch e := err.(type) {
+	case *TransientError:
+		return true
+	case net.Error:
+		return e.Timeout()
+	}
+	return false
+}
+
+// ContextClient - implemented by exchange clients whose requests can be cancelled
+type ContextClient interface {
+	// WithContext - returns a client whose requests are cancelled when ctx is done
+	WithContext(ctx context.Context) ExchangeClient
+}
+
+// RequestCoster - implemented by exchange clients whose requests count against a weight limit
+type RequestCoster interface {
+	// RequestCost - returns the number of requests a call to a method eg. GetCoinBalances
+	// makes and their total weight
+	RequestCost(method string) (int, int)
+}
+
+// ClientStatusReporter - implemented by exchange clients that report their health
+type ClientStatusReporter interface {
+	GetClientStatus() ClientStatus
+}
+
+type BreakerState string
+
+const (
+	BREAKER_CLOSED    BreakerState = "closed"
+	BREAKER_OPEN      BreakerState = "open"
+	BREAKER_HALF_OPEN BreakerState = "half-open"
+)
+
+// ResilienceConfig - limits and retries of a resilient client, a limit of 0 is not enforced
+type ResilienceConfig struct {
+	WeightLimit     int           // request weight allowed a minute
+	RequestTimeout  time.Duration // deadline for each request
+	MaxRetries      int           // retries of a transient failure
+	RetryDelay      time.Duration // wait before the first retry, doubled for each retry after
+	BreakerFailures int           // transient failures in a row that open the breaker
+	BreakerReset    time.Duration // how long the breaker stays open
+}
+
+func (c ResilienceConfig) validate() error {
+	if c.WeightLimit < 0 {
+		return fmt.Errorf("Weight limit cannot be negative")
+	}
+	if c.RequestTimeout < 0 {
+		return fmt.Errorf("Request timeout cannot be negative")
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("Max retries cannot be negative")
+	}
+	if c.RetryDelay < 0 {
+		return fmt.Errorf("Retry delay cannot be negative")
+	}
+	if c.BreakerFailures < 0 {
+		return fmt.Errorf("Breaker failures cannot be negative")
+	}
+	if c.BreakerFailures > 0 && c.BreakerReset <= 0 {
+		return fmt.Errorf("Breaker reset must be more than 0 when the breaker is enabled")
+	}
+	return nil
+}
+
+// ClientStatus - health of a resilient client
+type ClientStatus struct {
+	Exchange            string
+	Breaker             BreakerState
+	ConsecutiveFailures int
+	OpenedAt            time.Time // when the breaker last opened
+	LastError           string
+	WeightUsed          int // in the last minute
+	WeightLimit         int
+	Requests            int
+	Retries             int
+	Failures            int
+}
+
+// weightUse - weight of a call made at a time
+type weightUse struct {
+	at     time.Time
+	weight int
+}
+
+type resilientClient struct {
+	sync.Mutex
+	client ExchangeClient
+	config ResilienceConfig
+	now    func() time.Time
+	// request weight in the last minute
+	used []weightUse
+	// circuit breaker
+	state    BreakerState
+	failures int // transient failures in a row
+	openedAt time.Time
+	trial    bool // a call is being tried while half-open
+	// counts
+	lastError string
+	requests  int
+	retries   int
+	failed    int
+}
+
+// resilientStreamingClient - a resilient client for an exchange client that streams prices
+type resilientStreamingClient struct {
+	*resilientClient
+	streamer PriceStreamer
+}
+
+// NewResilientClient - decorates client with request weight limits, deadlines, retries and a
+// circuit breaker. Prices are streamed straight from client if it streams them
+func NewResilientClient(client ExchangeClient, config ResilienceConfig) (ExchangeClient, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &resilientClient{
+		client: client,
+		config: config,
+		now:    time.Now,
+		used:   make([]weightUse, 0),
+		state:  BREAKER_CLOSED,
+	}
+
+	if streamer, ok := client.(PriceStreamer); ok {
+		return &resilientStreamingClient{resilientClient: r, streamer: streamer}, nil
+	}
+
+	return r, nil
+}
+
+func (r *resilientStreamingClient) SubscribePrices(ctx context.Context) (<-chan Price, error) {
+	return r.streamer.SubscribePrices(ctx)
+}
+
+// GetClientStatus - returns the state of the breaker and request weight used
+func (r *resilientClient) GetClientStatus() ClientStatus {
+	r.Lock()
+	defer r.Unlock()
+
+	r.pruneUsed()
+	used := 0
+	for _, use := range r.used {
+		used += use.weight
+	}
+
+	return ClientStatus{
+		Exchange:            r.client.GetExchange(),
+		Breaker:             r.currentState(),
+		ConsecutiveFailures: r.failures,
+		OpenedAt:            r.openedAt,
+		LastError:           r.lastError,
+		WeightUsed:          used,
+		WeightLimit:         r.config.WeightLimit,
+		Requests:            r.requests,
+		Retries:             r.retries,
+		Failures:            r.failed,
+	}
+}
+
+// currentState - the breaker is half-open once it has been open for the reset time
+func (r *resilientClient) currentState() BreakerState {
+	if r.state == BREAKER_OPEN && r.now().Sub(r.openedAt) >= r.config.BreakerReset {
+		return BREAKER_HALF_OPEN
+	}
+	return r.state
+}
+
+// allow - returns an error if the breaker stops a call being made
+func (r *resilientClient) allow() error {
+	r.Lock()
+	defer r.Unlock()
+
+	switch r.currentState() {
+	case BREAKER_OPEN:
+		return &TransientError{Err: fmt.Errorf("Circuit breaker for %s is open after %d failures - %s", r.client.GetExchange(), r.failures, r.lastError)}
+	case BREAKER_HALF_OPEN:
+		if r.trial {
+			return &TransientError{Err: fmt.Errorf("Circuit breaker for %s is half-open and already trying a call", r.client.GetExchange())}
+		}
+		r.state = BREAKER_HALF_OPEN
+		r.trial = true
+	}
+
+	return nil
+}
+
+// record - updates the breaker with the result of a call
+func (r *resilientClient) record(err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.trial = false
+
+	if err == nil || !IsTransient(err) {
+		// the exchange answered
+		r.failures = 0
+		r.state = BREAKER_CLOSED
+		return
+	}
+
+	r.failures++
+	r.failed++
+	r.lastError = err.Error()
+
+	if r.state == BREAKER_HALF_OPEN || (r.config.BreakerFailures > 0 && r.failures >= r.config.BreakerFailures) {
+		r.state = BREAKER_OPEN
+		r.openedAt = r.now()
+	}
+}
+
+// pruneUsed - forgets weight used more than a minute ago
+func (r *resilientClient) pruneUsed() {
+	from := r.now().Add(-time.Minute)
+	recent := r.used[:0]
+	for _, use := range r.used {
+		if use.at.After(from) {
+			recent = append(recent, use)
+		}
+	}
+	r.used = recent
+}
+
+// reserve - waits until weight can be used without going over the limit or ctx is done
+func (r *resilientClient) reserve(ctx context.Context, weight int) error {
+	for {
+		r.Lock()
+		r.pruneUsed()
+		used := 0
+		for _, use := range r.used {
+			used += use.weight
+		}
+		// a call heavier than the limit is made once nothing else has been
+		if r.config.WeightLimit == 0 || used+weight <= r.config.WeightLimit || len(r.used) == 0 {
+			r.used = append(r.used, weightUse{at: r.now(), weight: weight})
+			r.Unlock()
+			return nil
+		}
+		wait := r.used[0].at.Add(time.Minute).Sub(r.now())
+		r.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return &TransientError{Err: fmt.Errorf("Request weight limit of %d a minute reached for %s", r.config.WeightLimit, r.client.GetExchange())}
+		case <-time.After(wait):
+		}
+	}
+}
+
+// call - makes a request, retrying transient failures if retry is true
+func (r *resilientClient) call(method string, retry bool, request func(client ExchangeClient) error) error {
+	if err := r.allow(); err != nil {
+		return err
+	}
+
+	attempts := 1
+	if retry {
+		attempts += r.config.MaxRetries
+	}
+
+	delay := r.config.RetryDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = r.attempt(method, request)
+		if err == nil || !IsTransient(err) || attempt >= attempts {
+			break
+		}
+
+		r.Lock()
+		r.retries++
+		r.Unlock()
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	r.record(err)
+
+	return err
+}
+
+// attempt - makes a request once within its weight limit and deadline
+func (r *resilientClient) attempt(method string, request func(client ExchangeClient) error) error {
+	requests, weight := 1, 1
+	if coster, ok := r.client.(RequestCoster); ok {
+		requests, weight = coster.RequestCost(method)
+	}
+
+	ctx := context.Background()
+	if r.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.config.RequestTimeout*time.Duration(requests))
+		defer cancel()
+	}
+
+	if err := r.reserve(ctx, weight); err != nil {
+		return err
+	}
+
+	r.Lock()
+	r.requests++
+	r.Unlock()
+
+	client := r.client
+	if contextClient, ok := client.(ContextClient); ok {
+		client = contextClient.WithContext(ctx)
+	}
+
+	return request(client)
+}
+
+func (r *resilientClient) GetExchange() string {
+	return r.client.GetExchange()
+}
+
+func (r *resilientClient) GetCoinBalances() ([]CoinBalance, error) {
+	var balances []CoinBalance
+	err := r.call("GetCoinBalances", true, func(client ExchangeClient) (err error) {
+		balances, err = client.GetCoinBalances()
+		return err
+	})
+	return balances, err
+}
+
+func (r *resilientClient) GetLatestPrices() ([]Price, error) {
+	var prices []Price
+	err := r.call("GetLatestPrices", true, func(client ExchangeClient) (err error) {
+		prices, err = client.GetLatestPrices()
+		return err
+	})
+	return prices, err
+}
+
+func (r *resilientClient) GetDaySummaries() ([]DaySummary, error) {
+	var summaries []DaySummary
+	err := r.call("GetDaySummaries", true, func(client ExchangeClient) (err error) {
+		summaries, err = client.GetDaySummaries()
+		return err
+	})
+	return summaries, err
+}
+
+func (r *resilientClient) GetTradingPairs() ([]TradingPair, error) {
+	var pairs []TradingPair
+	err := r.call("GetTradingPairs", true, func(client ExchangeClient) (err error) {
+		pairs, err = client.GetTradingPairs()
+		return err
+	})
+	return pairs, err
+}
+
+func (r *resilientClient) GetCandles(base, as string, interval CandleInterval, from, to time.Time) ([]Candle, error) {
+	var candles []Candle
+	err := r.call("GetCandles", true, func(client ExchangeClient) (err error) {
+		candles, err = client.GetCandles(base, as, interval, from, to)
+		return err
+	})
+	return candles, err
+}
+
+// PlaceMarketOrder - is not retried, the order may have been placed before a failure
+func (r *resilientClient) PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error) {
+	var order Order
+	err := r.call("PlaceMarketOrder", false, func(client ExchangeClient) (err error) {
+		order, err = client.PlaceMarketOrder(side, base, as, quantity)
+		return err
+	})
+	return order, err
+}
+
+// PlaceLimitOrder - is not retried, the order may have been placed before a failure
+func (r *resilientClient) PlaceLimitOrder(side OrderSide, base, as string, quantity, price float64) (Order, error) {
+	var order Order
+	err := r.call("PlaceLimitOrder", false, func(client ExchangeClient) (err error) {
+		order, err = client.PlaceLimitOrder(side, base, as, quantity, price)
+		return err
+	})
+	return order, err
+}
+
+func (r *resilientClient) CancelOrder(base, as, id string) (Order, error) {
+	var order Order
+	err := r.call("CancelOrder", true, func(client ExchangeClient) (err error) {
+		order, err = client.CancelOrder(base, as, id)
+		return err
+	})
+	return order, err
+}
+
+func (r *resilientClient) GetOrder(base, as, id string) (Order, error) {
+	var order Order
+	err := r.call("GetOrder", true, func(client ExchangeClient) (err error) {
+		order, err = client.GetOrder(base, as, id)
+		return err
+	})
+	return order, err
+}
+
+func (r *resilientClient) GetOpenOrders(base, as string) ([]Order, error) {
+	var orders []Order
+	err := r.call("GetOpenOrders", true, func(client ExchangeClient) (err error) {
+		orders, err = client.GetOpenOrders(base, as)
+		return err
+	})
+	return orders, err
+}
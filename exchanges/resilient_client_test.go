@@ -0,0 +1,223 @@
+package exchanges
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyClient - fails calls with errors in turn then passes them to the mock
+type flakyClient struct {
+	ExchangeClient
+	errs  []error
+	calls int
+	ctx   context.Context
+}
+
+func (f *flakyClient) next() error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func (f *flakyClient) GetCoinBalances() ([]CoinBalance, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return f.ExchangeClient.GetCoinBalances()
+}
+
+func (f *flakyClient) PlaceMarketOrder(side OrderSide, base, as string, quantity float64) (Order, error) {
+	if err := f.next(); err != nil {
+		return Order{}, err
+	}
+	return f.ExchangeClient.PlaceMarketOrder(side, base, as, quantity)
+}
+
+func (f *flakyClient) WithContext(ctx context.Context) ExchangeClient {
+	f.ctx = ctx
+	return f
+}
+
+func (f *flakyClient) RequestCost(method string) (int, int) {
+	return 1, 5
+}
+
+func newTestResilientClient(t *testing.T, config ResilienceConfig, errs ...error) (*resilientClient, *flakyClient) {
+	flaky := &flakyClient{ExchangeClient: newTestMockClient(t), errs: errs}
+	client, err := NewResilientClient(flaky, config)
+	assert.NoError(t, err)
+	return client.(*resilientClient), flaky
+}
+
+func transient(msg string) error {
+	return &TransientError{Err: fmt.Errorf(msg)}
+}
+
+func TestResilienceConfig(t *testing.T) {
+	invalid := []ResilienceConfig{
+		{WeightLimit: -1},
+		{RequestTimeout: -1},
+		{MaxRetries: -1},
+		{RetryDelay: -1},
+		{BreakerFailures: 1},
+	}
+	for _, config := range invalid {
+		_, err := NewResilientClient(newTestMockClient(t), config)
+		assert.Error(t, err, "%#v", config)
+	}
+
+	// streaming clients still stream
+	client, err := NewResilientClient(newTestMockClient(t), ResilienceConfig{})
+	assert.NoError(t, err)
+	_, ok := client.(PriceStreamer)
+	assert.True(t, ok)
+}
+
+func TestResilientRetries(t *testing.T) {
+
+	config := ResilienceConfig{MaxRetries: 3, RetryDelay: time.Millisecond, RequestTimeout: time.Second}
+
+	client, flaky := newTestResilientClient(t, config, transient("timeout"), transient("timeout"))
+
+	balances, err := client.GetCoinBalances()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(balances))
+	assert.Equal(t, 3, flaky.calls)
+
+	// each attempt has a deadline
+	if assert.NotNil(t, flaky.ctx) {
+		_, ok := flaky.ctx.Deadline()
+		assert.True(t, ok)
+	}
+
+	status := client.GetClientStatus()
+	assert.Equal(t, 3, status.Requests)
+	assert.Equal(t, 2, status.Retries)
+	assert.Equal(t, BREAKER_CLOSED, status.Breaker)
+	assert.Equal(t, 0, status.ConsecutiveFailures)
+
+	// gives up after max retries
+	flaky.calls = 0
+	flaky.errs = []error{transient("1"), transient("2"), transient("3"), transient("4"), transient("5")}
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.True(t, IsTransient(err))
+	assert.Equal(t, 4, flaky.calls)
+
+	// errors that are not transient are not retried
+	flaky.calls = 0
+	flaky.errs = []error{fmt.Errorf("invalid symbol")}
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.Equal(t, 1, flaky.calls)
+
+	// orders are never retried
+	flaky.calls = 0
+	flaky.errs = []error{transient("timeout")}
+	_, err = client.PlaceMarketOrder(SELL_ORDER, ETH, BTC, 1.0)
+	assert.Error(t, err)
+	assert.Equal(t, 1, flaky.calls)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+
+	config := ResilienceConfig{BreakerFailures: 2, BreakerReset: time.Minute}
+
+	client, flaky := newTestResilientClient(t, config, transient("1"), transient("2"))
+
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	_, err := client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.Equal(t, BREAKER_CLOSED, client.GetClientStatus().Breaker)
+
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+
+	status := client.GetClientStatus()
+	assert.Equal(t, BREAKER_OPEN, status.Breaker)
+	assert.Equal(t, now, status.OpenedAt)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Equal(t, "2", status.LastError)
+
+	// fails without calling the exchange while open
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.Equal(t, 2, flaky.calls)
+
+	// a failed trial opens it again
+	now = now.Add(time.Minute)
+	assert.Equal(t, BREAKER_HALF_OPEN, client.GetClientStatus().Breaker)
+	flaky.errs = []error{transient("3")}
+	_, err = client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.Equal(t, BREAKER_OPEN, client.GetClientStatus().Breaker)
+
+	// a successful trial closes it
+	now = now.Add(time.Minute)
+	_, err = client.GetCoinBalances()
+	assert.NoError(t, err)
+
+	status = client.GetClientStatus()
+	assert.Equal(t, BREAKER_CLOSED, status.Breaker)
+	assert.Equal(t, 0, status.ConsecutiveFailures)
+	assert.Equal(t, 3, status.Failures)
+}
+
+func TestRequestWeightLimit(t *testing.T) {
+
+	config := ResilienceConfig{WeightLimit: 10, RequestTimeout: 10 * time.Millisecond}
+
+	client, flaky := newTestResilientClient(t, config)
+
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetCoinBalances()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 10, client.GetClientStatus().WeightUsed)
+
+	// no weight left before the deadline
+	_, err := client.GetCoinBalances()
+	assert.Error(t, err)
+	assert.True(t, IsTransient(err))
+	assert.Equal(t, 2, flaky.calls)
+
+	// weight is freed a minute later
+	now = now.Add(time.Minute)
+	assert.Equal(t, 0, client.GetClientStatus().WeightUsed)
+	_, err = client.GetCoinBalances()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, flaky.calls)
+}
+
+func TestClassifyBinanceErrors(t *testing.T) {
+
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{err: &url.Error{Op: "Get", URL: "https://api.binance.com", Err: fmt.Errorf("connection refused")}, transient: true},
+		{err: context.DeadlineExceeded, transient: true},
+		{err: fmt.Errorf("<APIError> code=-1003, msg=Too many requests."), transient: true},
+		{err: fmt.Errorf("<APIError> code=-2010, msg=Account has insufficient balance for requested action."), transient: false},
+	}
+
+	for _, test := range tests {
+		err := classify(test.err, fmt.Errorf("Failed - %s", test.err))
+		assert.Equal(t, test.transient, IsTransient(err), test.err.Error())
+		assert.Contains(t, err.Error(), "Failed - ")
+	}
+}
@@ -0,0 +1,431 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+/*
+
+A scenario describes a synthetic market for the mock exchange, loaded from a
+JSON file eg.
+
+	{
+		"name": "eth crash",
+		"seed": 42,
+		"interval": "1m",
+		"history": "24h",
+		"balances": [
+			{"symbol": "BTC", "free": 1.5},
+			{"symbol": "ETH", "free": 20}
+		],
+		"pairs": [
+			{
+				"base": "ETH", "as": "BTC", "price": 0.08,
+				"process": {"type": "gbm", "drift": 0.0001, "volatility": 0.002},
+				"shocks": [{"at": "20h", "changePercent": -30}],
+				"flat": [{"from": "2h", "to": "3h"}]
+			},
+			{
+				"base": "BTC", "as": "USDT", "price": 10000,
+				"process": {"type": "meanreversion", "mean": 10000, "reversion": 0.05, "volatility": 20}
+			}
+		]
+	}
+
+Prices are generated every interval starting history before the server starts,
+each pair moves by its process every interval:-
+
+	flat          - the price does not change, the default
+	trend         - step is added to the price
+	bounce        - step is added to the price until it reaches max, then taken
+	                away until it reaches min
+	randomwalk    - a normally distributed change with a standard deviation of
+	                volatility is added to the price
+	gbm           - geometric brownian motion, the price changes by a normally
+	                distributed percentage with a mean of drift and standard
+	                deviation of volatility (as fractions)
+	meanreversion - the price moves reversion (a fraction) of the way back to
+	                mean, plus a normally distributed change with a standard
+	                deviation of volatility
+
+Shocks change the price by a percentage once, a crash is negative and a pump is
+positive. During flat periods the process is paused. Times of shocks and flat
+periods are durations after the first price.
+
+Random changes come from the seed so a scenario always generates the same
+prices. Prices never fall below MIN_SCENARIO_PRICE.
+
+*/
+
+const (
+	PROCESS_FLAT           = "flat"
+	PROCESS_TREND          = "trend"
+	PROCESS_BOUNCE         = "bounce"
+	PROCESS_RANDOM_WALK    = "randomwalk"
+	PROCESS_GBM            = "gbm"
+	PROCESS_MEAN_REVERSION = "meanreversion"
+
+	MIN_SCENARIO_PRICE = 0.0000001
+
+	DEFAULT_SCENARIO_INTERVAL = time.Duration(1 * time.Minute)
+	DEFAULT_SCENARIO_HISTORY  = time.Duration(24 * time.Hour)
+)
+
+// ScenarioDuration - a duration written as a string in scenario files eg. "90m"
+type ScenarioDuration time.Duration
+
+func (d ScenarioDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *ScenarioDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Duration must be a string eg. \"90m\" - %s", err)
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = ScenarioDuration(duration)
+	return nil
+}
+
+type Scenario struct {
+	Name     string
+	Seed     int64
+	Interval ScenarioDuration // between prices, defaults to DEFAULT_SCENARIO_INTERVAL
+	History  ScenarioDuration // of prices before the server starts, defaults to DEFAULT_SCENARIO_HISTORY
+	Balances []CoinBalance
+	Pairs    []ScenarioPair
+}
+
+// ScenarioPair - how the price of a trading pair moves
+type ScenarioPair struct {
+	Base    string
+	As      string
+	Price   float64 // starting price
+	Process ScenarioProcess
+	Shocks  []ScenarioShock
+	Flat    []ScenarioPeriod
+}
+
+// ScenarioProcess - moves the price every interval, only the fields used by its type are set
+type ScenarioProcess struct {
+	Type       string
+	Step       float64 // trend and bounce
+	Min        float64 // bounce
+	Max        float64 // bounce
+	Drift      float64 // gbm
+	Volatility float64 // randomwalk, gbm and meanreversion
+	Mean       float64 // meanreversion
+	Reversion  float64 // meanreversion
+}
+
+// ScenarioShock - a sudden change in price
+type ScenarioShock struct {
+	At            ScenarioDuration
+	ChangePercent float64
+}
+
+// ScenarioPeriod - from (inclusive) to (exclusive)
+type ScenarioPeriod struct {
+	From ScenarioDuration
+	To   ScenarioDuration
+}
+
+// LoadScenario - reads a scenario from a JSON file
+func LoadScenario(path string) (Scenario, error) {
+	scenario := Scenario{}
+
+	scenarioJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return scenario, fmt.Errorf("Failed to read scenario: %s - %s", path, err)
+	}
+
+	if err := json.Unmarshal(scenarioJSON, &scenario); err != nil {
+		return scenario, fmt.Errorf("Failed to parse scenario: %s - %s", path, err)
+	}
+
+	if err := scenario.Validate(); err != nil {
+		return scenario, fmt.Errorf("Scenario %s is not valid - %s", path, err)
+	}
+
+	return scenario, nil
+}
+
+// Validate - returns an error if the scenario cannot generate prices
+func (s Scenario) Validate() error {
+	if s.Interval < 0 {
+		return fmt.Errorf("Interval cannot be negative")
+	}
+	if s.History < 0 {
+		return fmt.Errorf("History cannot be negative")
+	}
+	if len(s.Pairs) == 0 {
+		return fmt.Errorf("Scenario has no pairs")
+	}
+
+	for _, balance := range s.Balances {
+		if balance.Symbol == "" {
+			return fmt.Errorf("Balance symbol cannot be blank")
+		}
+		if balance.Free < 0 || balance.Locked < 0 {
+			return fmt.Errorf("Balance of %s cannot be negative", balance.Symbol)
+		}
+	}
+
+	seen := make(map[string]bool, len(s.Pairs))
+	for _, pair := range s.Pairs {
+		if pair.Base == "" || pair.As == "" {
+			return fmt.Errorf("Pair base and as cannot be blank")
+		}
+		if seen[pair.Base+pair.As] {
+			return fmt.Errorf("Pair %s%s is in the scenario more than once", pair.Base, pair.As)
+		}
+		seen[pair.Base+pair.As] = true
+
+		if pair.Price <= 0 {
+			return fmt.Errorf("Starting price of %s%s must be more than 0", pair.Base, pair.As)
+		}
+		if err := pair.Process.validate(); err != nil {
+			return fmt.Errorf("Process of %s%s is not valid - %s", pair.Base, pair.As, err)
+		}
+		for _, period := range pair.Flat {
+			if period.To <= period.From {
+				return fmt.Errorf("Flat period of %s%s must end after it starts", pair.Base, pair.As)
+			}
+		}
+		for _, shock := range pair.Shocks {
+			if shock.ChangePercent <= -100 {
+				return fmt.Errorf("Shock to %s%s cannot take more than the whole price", pair.Base, pair.As)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p ScenarioProcess) validate() error {
+	switch p.Type {
+	case "", PROCESS_FLAT, PROCESS_TREND:
+	case PROCESS_BOUNCE:
+		if p.Step <= 0 || p.Max <= p.Min {
+			return fmt.Errorf("Bounce needs a step more than 0 and max more than min")
+		}
+	case PROCESS_RANDOM_WALK, PROCESS_GBM, PROCESS_MEAN_REVERSION:
+		if p.Volatility < 0 {
+			return fmt.Errorf("Volatility cannot be negative")
+		}
+		if p.Type == PROCESS_MEAN_REVERSION && (p.Reversion < 0 || p.Reversion > 1) {
+			return fmt.Errorf("Reversion must be between 0 and 1")
+		}
+	default:
+		return fmt.Errorf("Unknown process type %q", p.Type)
+	}
+	return nil
+}
+
+func (s Scenario) interval() time.Duration {
+	if s.Interval == 0 {
+		return DEFAULT_SCENARIO_INTERVAL
+	}
+	return time.Duration(s.Interval)
+}
+
+func (s Scenario) history() time.Duration {
+	if s.History == 0 {
+		return DEFAULT_SCENARIO_HISTORY
+	}
+	return time.Duration(s.History)
+}
+
+// pairState - the price of a pair as it is generated
+type pairState struct {
+	ScenarioPair
+	price   float64
+	rand    *rand.Rand
+	rising  bool // bounce direction
+	shocked []bool
+}
+
+// ScenarioMarket - generates the prices of a scenario
+type ScenarioMarket struct {
+	scenario Scenario
+	start    time.Time // time of the first price
+	next     time.Time // time of the next price to generate
+	pairs    []*pairState
+	latest   []Price
+}
+
+// NewScenarioMarket - returns a market that generates the prices of scenario, the first
+// price is History before now
+func NewScenarioMarket(scenario Scenario, now time.Time) (*ScenarioMarket, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+
+	start := now.Add(-scenario.history())
+
+	m := &ScenarioMarket{
+		scenario: scenario,
+		start:    start,
+		next:     start,
+		pairs:    make([]*pairState, len(scenario.Pairs)),
+	}
+
+	for i, pair := range scenario.Pairs {
+		m.pairs[i] = &pairState{
+			ScenarioPair: pair,
+			price:        pair.Price,
+			// each pair has its own source so adding a pair doesn't change the others
+			rand:    rand.New(rand.NewSource(scenario.Seed + int64(i))),
+			rising:  true,
+			shocked: make([]bool, len(pair.Shocks)),
+		}
+	}
+
+	return m, nil
+}
+
+// Generate - returns the prices of every pair at each interval from the last generated
+// up to until (exclusive), oldest first
+func (m *ScenarioMarket) Generate(until time.Time) []Price {
+	prices := make([]Price, 0)
+
+	interval := m.scenario.interval()
+	for ; m.next.Before(until); m.next = m.next.Add(interval) {
+		elapsed := m.next.Sub(m.start)
+		latest := make([]Price, len(m.pairs))
+		for i, pair := range m.pairs {
+			latest[i] = Price{
+				Base:     pair.Base,
+				As:       pair.As,
+				Price:    pair.move(elapsed),
+				At:       m.next,
+				Exchange: MOCK_EXCHANGE,
+			}
+		}
+		prices = append(prices, latest...)
+		m.latest = latest
+	}
+
+	return prices
+}
+
+// Latest - returns the last prices generated
+func (m *ScenarioMarket) Latest() []Price {
+	latest := make([]Price, len(m.latest))
+	copy(latest, m.latest)
+	return latest
+}
+
+// move - moves the price on an interval, elapsed is the time since the first price
+func (p *pairState) move(elapsed time.Duration) float64 {
+
+	if !p.isFlat(elapsed) {
+		p.step()
+	}
+
+	for i, shock := range p.Shocks {
+		if !p.shocked[i] && elapsed >= time.Duration(shock.At) {
+			p.price *= 1.0 + shock.ChangePercent/100.0
+			p.shocked[i] = true
+		}
+	}
+
+	if p.price < MIN_SCENARIO_PRICE {
+		p.price = MIN_SCENARIO_PRICE
+	}
+
+	return p.price
+}
+
+func (p *pairState) isFlat(elapsed time.Duration) bool {
+	for _, period := range p.Flat {
+		if elapsed >= time.Duration(period.From) && elapsed < time.Duration(period.To) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pairState) step() {
+	process := p.Process
+
+	switch process.Type {
+	case PROCESS_TREND:
+		p.price += process.Step
+	case PROCESS_BOUNCE:
+		if p.rising {
+			p.price += process.Step
+			if p.price > process.Max {
+				p.price = process.Max
+				p.rising = false
+			}
+		} else {
+			p.price -= process.Step
+			if p.price < process.Min {
+				p.price = process.Min
+				p.rising = true
+			}
+		}
+	case PROCESS_RANDOM_WALK:
+		p.price += p.rand.NormFloat64() * process.Volatility
+	case PROCESS_GBM:
+		vol := process.Volatility
+		p.price *= math.Exp(process.Drift - vol*vol/2.0 + vol*p.rand.NormFloat64())
+	case PROCESS_MEAN_REVERSION:
+		p.price += process.Reversion*(process.Mean-p.price) + p.rand.NormFloat64()*process.Volatility
+	}
+}
+
+// scenarioClient - a mock exchange whose prices move with a scenario as time passes
+type scenarioClient struct {
+	*mockClient
+	marketLock sync.Mutex
+	market     *ScenarioMarket
+}
+
+// NewScenarioClient - returns a mock exchange with the balances of the scenario, prices are
+// generated by market up to the time returned by now when they are requested
+func NewScenarioClient(scenario Scenario, market *ScenarioMarket, now func() time.Time) (ExchangeClient, error) {
+	balances := make([]CoinBalance, len(scenario.Balances))
+	for i, balance := range scenario.Balances {
+		balance.Exchange = MOCK_EXCHANGE
+		balances[i] = balance
+	}
+
+	return &scenarioClient{
+		mockClient: &mockClient{
+			balances:   balances,
+			prices:     market.Latest(),
+			serverTime: now,
+			orders:     make(map[string]*Order),
+		},
+		market: market,
+	}, nil
+}
+
+// GetLatestPrices - moves the market on to now then returns its prices
+func (s *scenarioClient) GetLatestPrices() ([]Price, error) {
+	s.marketLock.Lock()
+	generated := s.market.Generate(s.now())
+	latest := s.market.Latest()
+	s.marketLock.Unlock()
+
+	if len(generated) > 0 {
+		// limit orders are filled by the latest prices
+		if err := s.SetPrices(latest); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.mockClient.GetLatestPrices()
+}
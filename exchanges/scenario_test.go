@@ -0,0 +1,195 @@
+package exchanges
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testScenario = `{
+	"name": "eth crash",
+	"seed": 42,
+	"interval": "1m",
+	"history": "1h",
+	"balances": [
+		{"symbol": "BTC", "free": 1.5},
+		{"symbol": "ETH", "free": 20}
+	],
+	"pairs": [
+		{
+			"base": "ETH", "as": "BTC", "price": 0.08,
+			"process": {"type": "gbm", "drift": 0.0001, "volatility": 0.002},
+			"shocks": [{"at": "30m", "changePercent": -30}],
+			"flat": [{"from": "10m", "to": "20m"}]
+		},
+		{
+			"base": "BTC", "as": "USDT", "price": 10000,
+			"process": {"type": "meanreversion", "mean": 10000, "reversion": 0.05, "volatility": 20}
+		}
+	]
+}`
+
+func writeTestScenario(t *testing.T, scenarioJSON string) (string, func()) {
+	dir, err := ioutil.TempDir("", "teletrada-scenario")
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "scenario.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(scenarioJSON), 0644))
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestLoadScenario(t *testing.T) {
+
+	path, cleanup := writeTestScenario(t, testScenario)
+	defer cleanup()
+
+	scenario, err := LoadScenario(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "eth crash", scenario.Name)
+	assert.Equal(t, int64(42), scenario.Seed)
+	assert.Equal(t, time.Minute, scenario.interval())
+	assert.Equal(t, time.Hour, scenario.history())
+	assert.Equal(t, []CoinBalance{{Symbol: BTC, Free: 1.5}, {Symbol: ETH, Free: 20}}, scenario.Balances)
+	assert.Equal(t, 2, len(scenario.Pairs))
+	assert.Equal(t, ScenarioProcess{Type: PROCESS_GBM, Drift: 0.0001, Volatility: 0.002}, scenario.Pairs[0].Process)
+	assert.Equal(t, []ScenarioShock{{At: ScenarioDuration(30 * time.Minute), ChangePercent: -30}}, scenario.Pairs[0].Shocks)
+
+	invalid := []string{
+		`{"pairs": []}`,
+		`{"interval": 60, "pairs": [{"base": "ETH", "as": "BTC", "price": 0.1}]}`,
+		`{"pairs": [{"base": "ETH", "as": "BTC", "price": 0}]}`,
+		`{"pairs": [{"base": "ETH", "as": "BTC", "price": 0.1, "process": {"type": "unknown"}}]}`,
+		`{"pairs": [{"base": "ETH", "as": "BTC", "price": 0.1, "process": {"type": "bounce", "step": 0.1, "min": 1, "max": 1}}]}`,
+		`{"pairs": [{"base": "ETH", "as": "BTC", "price": 0.1, "flat": [{"from": "2h", "to": "1h"}]}]}`,
+		`{"pairs": [{"base": "ETH", "as": "BTC", "price": 0.1}, {"base": "ETH", "as": "BTC", "price": 0.1}]}`,
+	}
+	for _, scenarioJSON := range invalid {
+		path, cleanup := writeTestScenario(t, scenarioJSON)
+		_, err := LoadScenario(path)
+		assert.Error(t, err, scenarioJSON)
+		cleanup()
+	}
+}
+
+func TestScenarioProcesses(t *testing.T) {
+
+	now := time.Date(2018, 1, 18, 0, 0, 0, 0, time.UTC)
+
+	scenario := Scenario{
+		Interval: ScenarioDuration(time.Minute),
+		History:  ScenarioDuration(10 * time.Minute),
+		Pairs: []ScenarioPair{
+			{Base: ETH, As: BTC, Price: 1.0, Process: ScenarioProcess{Type: PROCESS_TREND, Step: 0.5}},
+			{Base: LTC, As: BTC, Price: 1.0, Process: ScenarioProcess{Type: PROCESS_BOUNCE, Step: 1.0, Min: 0.5, Max: 2.0}},
+			{Base: BNB, As: BTC, Price: 10.0, Process: ScenarioProcess{Type: PROCESS_FLAT},
+				Shocks: []ScenarioShock{{At: ScenarioDuration(2 * time.Minute), ChangePercent: 50}}},
+			{Base: BTC, As: USDT, Price: 1.0, Process: ScenarioProcess{Type: PROCESS_TREND, Step: 1.0},
+				Flat: []ScenarioPeriod{{From: ScenarioDuration(1 * time.Minute), To: ScenarioDuration(3 * time.Minute)}}},
+		},
+	}
+
+	market, err := NewScenarioMarket(scenario, now)
+	assert.NoError(t, err)
+
+	prices := market.Generate(now.Add(-6 * time.Minute))
+	assert.Equal(t, 4*4, len(prices), "4 prices for each pair")
+
+	priceOf := func(prices []Price, base string) []float64 {
+		values := make([]float64, 0)
+		for _, price := range prices {
+			if price.Base == base {
+				values = append(values, price.Price)
+			}
+		}
+		return values
+	}
+
+	assert.Equal(t, []float64{1.5, 2.0, 2.5, 3.0}, priceOf(prices, ETH))
+	assert.Equal(t, []float64{2.0, 2.0, 1.0, 0.5}, priceOf(prices, LTC))
+	assert.Equal(t, []float64{10.0, 10.0, 15.0, 15.0}, priceOf(prices, BNB))
+	assert.Equal(t, []float64{2.0, 2.0, 2.0, 3.0}, priceOf(prices, BTC))
+	assert.Equal(t, now.Add(-10*time.Minute), prices[0].At)
+
+	// carries on from the last price generated
+	prices = market.Generate(now)
+	assert.Equal(t, 6*4, len(prices))
+	assert.Equal(t, now.Add(-time.Minute), prices[len(prices)-1].At)
+	assert.Equal(t, 4, len(market.Latest()))
+	assert.Equal(t, 0, len(market.Generate(now)), "Nothing new to generate")
+}
+
+func TestScenarioSeed(t *testing.T) {
+
+	now := time.Date(2018, 1, 18, 0, 0, 0, 0, time.UTC)
+
+	scenario := Scenario{
+		Seed: 1,
+		Pairs: []ScenarioPair{
+			{Base: ETH, As: BTC, Price: 0.1, Process: ScenarioProcess{Type: PROCESS_GBM, Volatility: 0.5}},
+			{Base: LTC, As: BTC, Price: 0.01, Process: ScenarioProcess{Type: PROCESS_RANDOM_WALK, Volatility: 0.01}},
+			{Base: BTC, As: USDT, Price: 20000, Process: ScenarioProcess{Type: PROCESS_MEAN_REVERSION, Mean: 10000, Reversion: 0.1}},
+		},
+	}
+
+	generate := func(scenario Scenario) []Price {
+		market, err := NewScenarioMarket(scenario, now)
+		assert.NoError(t, err)
+		return market.Generate(now)
+	}
+
+	first := generate(scenario)
+	assert.Equal(t, 24*60*3, len(first), "a days history a minute apart by default")
+	assert.Equal(t, first, generate(scenario), "Same seed generates the same market")
+
+	for _, price := range first {
+		assert.True(t, price.Price >= MIN_SCENARIO_PRICE)
+	}
+
+	// without volatility the price reverts to the mean
+	last := first[len(first)-1]
+	assert.Equal(t, USDT, last.As)
+	assert.InDelta(t, 10000.0, last.Price, 0.01)
+
+	scenario.Seed = 2
+	assert.NotEqual(t, first, generate(scenario), "Different seeds generate different markets")
+}
+
+func TestScenarioClient(t *testing.T) {
+
+	path, cleanup := writeTestScenario(t, testScenario)
+	defer cleanup()
+
+	scenario, err := LoadScenario(path)
+	assert.NoError(t, err)
+
+	now := time.Date(2018, 1, 18, 0, 0, 0, 0, time.UTC)
+	market, err := NewScenarioMarket(scenario, now)
+	assert.NoError(t, err)
+	history := market.Generate(now)
+	assert.Equal(t, 60*2, len(history))
+
+	client, err := NewScenarioClient(scenario, market, func() time.Time { return now })
+	assert.NoError(t, err)
+	assert.Equal(t, MOCK_EXCHANGE, client.GetExchange())
+
+	assert.Equal(t, 1.5, balanceOf(t, client, BTC).Free)
+	assert.Equal(t, MOCK_EXCHANGE, balanceOf(t, client, BTC).Exchange)
+
+	prices, err := client.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, history[len(history)-2:], prices)
+
+	// the market moves as time passes
+	now = now.Add(5 * time.Minute)
+	prices, err = client.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Minute), prices[0].At)
+
+	// orders fill at the scenario prices
+	order, err := client.PlaceMarketOrder(SELL_ORDER, ETH, BTC, 1.0)
+	assert.NoError(t, err)
+	assert.Equal(t, prices[0].Price, order.AvgPrice())
+}
@@ -1,56 +1,21 @@
-// Code generated by protoc-gen-go.
+// Code generated by protoc-gen-go. DO NOT EDIT.
 // source: api.proto
-// DO NOT EDIT!
-
-/*
-Package proto is a generated protocol buffer package.
-
-It is generated from these files:
-	api.proto
-
-It has these top-level messages:
-	Balance
-	CreateSimulationRequest
-	CreateSimulationResponse
-	GetLogRequest
-	GetLogResponse
-	GetPortfolioRequest
-	GetPortfolioResponse
-	GetPricesRequest
-	GetPricesResponse
-	GetSimulationsRequest
-	GetSimulationsResponse
-	GetStatusRequest
-	GetStatusResponse
-	GetSymbolTypesRequest
-	GetSymbolTypesResponse
-	LogEntry
-	Portfolio
-	Price
-	RebuildRequest
-	RebuildResponse
-	Simulation
-	StartSimulationRequest
-	StartSimulationResponse
-	StopSimulationRequest
-	StopSimulationResponse
-	Strategy
-	SymbolType
-*/
-package proto
 
-import proto1 "github.com/golang/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+package proto
 
 import (
-	context "golang.org/x/net/context"
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
-var _ = proto1.Marshal
+var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
@@ -58,7 +23,32 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type StrategySlot int32
+
+const (
+	StrategySlot_BUY  StrategySlot = 0
+	StrategySlot_SELL StrategySlot = 1
+)
+
+var StrategySlot_name = map[int32]string{
+	0: "BUY",
+	1: "SELL",
+}
+
+var StrategySlot_value = map[string]int32{
+	"BUY":  0,
+	"SELL": 1,
+}
+
+func (x StrategySlot) String() string {
+	return proto.EnumName(StrategySlot_name, int32(x))
+}
+
+func (StrategySlot) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
+}
 
 type StartSimulationRequestWhenOptions int32
 
@@ -68,6 +58,7 @@ const (
 	StartSimulationRequest_LAST_WEEK    StartSimulationRequestWhenOptions = 2
 	StartSimulationRequest_LAST_MONTH   StartSimulationRequestWhenOptions = 3
 	StartSimulationRequest_THE_LOT      StartSimulationRequestWhenOptions = 4
+	StartSimulationRequest_CUSTOM       StartSimulationRequestWhenOptions = 5
 )
 
 var StartSimulationRequestWhenOptions_name = map[int32]string{
@@ -76,44 +67,347 @@ var StartSimulationRequestWhenOptions_name = map[int32]string{
 	2: "LAST_WEEK",
 	3: "LAST_MONTH",
 	4: "THE_LOT",
+	5: "CUSTOM",
 }
+
 var StartSimulationRequestWhenOptions_value = map[string]int32{
 	"NOW_REALTIME": 0,
 	"LAST_DAY":     1,
 	"LAST_WEEK":    2,
 	"LAST_MONTH":   3,
 	"THE_LOT":      4,
+	"CUSTOM":       5,
 }
 
 func (x StartSimulationRequestWhenOptions) String() string {
-	return proto1.EnumName(StartSimulationRequestWhenOptions_name, int32(x))
+	return proto.EnumName(StartSimulationRequestWhenOptions_name, int32(x))
 }
+
 func (StartSimulationRequestWhenOptions) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{21, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45, 0}
+}
+
+type AttachedStrategy struct {
+	Portfolio            string       `protobuf:"bytes,1,opt,name=portfolio,proto3" json:"portfolio,omitempty"`
+	Symbol               string       `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Slot                 StrategySlot `protobuf:"varint,3,opt,name=slot,proto3,enum=proto.StrategySlot" json:"slot,omitempty"`
+	Strategy             *Strategy    `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *AttachedStrategy) Reset()         { *m = AttachedStrategy{} }
+func (m *AttachedStrategy) String() string { return proto.CompactTextString(m) }
+func (*AttachedStrategy) ProtoMessage()    {}
+func (*AttachedStrategy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
+}
+
+func (m *AttachedStrategy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachedStrategy.Unmarshal(m, b)
+}
+func (m *AttachedStrategy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachedStrategy.Marshal(b, m, deterministic)
+}
+func (m *AttachedStrategy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachedStrategy.Merge(m, src)
+}
+func (m *AttachedStrategy) XXX_Size() int {
+	return xxx_messageInfo_AttachedStrategy.Size(m)
+}
+func (m *AttachedStrategy) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachedStrategy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachedStrategy proto.InternalMessageInfo
+
+func (m *AttachedStrategy) GetPortfolio() string {
+	if m != nil {
+		return m.Portfolio
+	}
+	return ""
+}
+
+func (m *AttachedStrategy) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *AttachedStrategy) GetSlot() StrategySlot {
+	if m != nil {
+		return m.Slot
+	}
+	return StrategySlot_BUY
+}
+
+func (m *AttachedStrategy) GetStrategy() *Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return nil
+}
+
+type AttachStrategyRequest struct {
+	SimulationId         string       `protobuf:"bytes,1,opt,name=simulationId,proto3" json:"simulationId,omitempty"`
+	StrategyId           string       `protobuf:"bytes,2,opt,name=strategyId,proto3" json:"strategyId,omitempty"`
+	Slot                 StrategySlot `protobuf:"varint,3,opt,name=slot,proto3,enum=proto.StrategySlot" json:"slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *AttachStrategyRequest) Reset()         { *m = AttachStrategyRequest{} }
+func (m *AttachStrategyRequest) String() string { return proto.CompactTextString(m) }
+func (*AttachStrategyRequest) ProtoMessage()    {}
+func (*AttachStrategyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+
+func (m *AttachStrategyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachStrategyRequest.Unmarshal(m, b)
+}
+func (m *AttachStrategyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachStrategyRequest.Marshal(b, m, deterministic)
+}
+func (m *AttachStrategyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachStrategyRequest.Merge(m, src)
+}
+func (m *AttachStrategyRequest) XXX_Size() int {
+	return xxx_messageInfo_AttachStrategyRequest.Size(m)
+}
+func (m *AttachStrategyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachStrategyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachStrategyRequest proto.InternalMessageInfo
+
+func (m *AttachStrategyRequest) GetSimulationId() string {
+	if m != nil {
+		return m.SimulationId
+	}
+	return ""
+}
+
+func (m *AttachStrategyRequest) GetStrategyId() string {
+	if m != nil {
+		return m.StrategyId
+	}
+	return ""
+}
+
+func (m *AttachStrategyRequest) GetSlot() StrategySlot {
+	if m != nil {
+		return m.Slot
+	}
+	return StrategySlot_BUY
+}
+
+type AttachStrategyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttachStrategyResponse) Reset()         { *m = AttachStrategyResponse{} }
+func (m *AttachStrategyResponse) String() string { return proto.CompactTextString(m) }
+func (*AttachStrategyResponse) ProtoMessage()    {}
+func (*AttachStrategyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+}
+
+func (m *AttachStrategyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachStrategyResponse.Unmarshal(m, b)
+}
+func (m *AttachStrategyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachStrategyResponse.Marshal(b, m, deterministic)
+}
+func (m *AttachStrategyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachStrategyResponse.Merge(m, src)
+}
+func (m *AttachStrategyResponse) XXX_Size() int {
+	return xxx_messageInfo_AttachStrategyResponse.Size(m)
+}
+func (m *AttachStrategyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachStrategyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachStrategyResponse proto.InternalMessageInfo
+
+type Backfill struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pairs                []string             `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	FromTime             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime               *timestamp.Timestamp `protobuf:"bytes,4,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Interval             string               `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	State                string               `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	CurrentPair          string               `protobuf:"bytes,7,opt,name=currentPair,proto3" json:"currentPair,omitempty"`
+	Progress             float32              `protobuf:"fixed32,8,opt,name=progress,proto3" json:"progress,omitempty"`
+	PricesAdded          int32                `protobuf:"varint,9,opt,name=pricesAdded,proto3" json:"pricesAdded,omitempty"`
+	Error                string               `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	StartedTime          *timestamp.Timestamp `protobuf:"bytes,11,opt,name=startedTime,proto3" json:"startedTime,omitempty"`
+	FinishedTime         *timestamp.Timestamp `protobuf:"bytes,12,opt,name=finishedTime,proto3" json:"finishedTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Backfill) Reset()         { *m = Backfill{} }
+func (m *Backfill) String() string { return proto.CompactTextString(m) }
+func (*Backfill) ProtoMessage()    {}
+func (*Backfill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+}
+
+func (m *Backfill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backfill.Unmarshal(m, b)
+}
+func (m *Backfill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backfill.Marshal(b, m, deterministic)
+}
+func (m *Backfill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backfill.Merge(m, src)
+}
+func (m *Backfill) XXX_Size() int {
+	return xxx_messageInfo_Backfill.Size(m)
+}
+func (m *Backfill) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backfill.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backfill proto.InternalMessageInfo
+
+func (m *Backfill) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Backfill) GetPairs() []string {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+func (m *Backfill) GetFromTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.FromTime
+	}
+	return nil
+}
+
+func (m *Backfill) GetToTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ToTime
+	}
+	return nil
+}
+
+func (m *Backfill) GetInterval() string {
+	if m != nil {
+		return m.Interval
+	}
+	return ""
+}
+
+func (m *Backfill) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Backfill) GetCurrentPair() string {
+	if m != nil {
+		return m.CurrentPair
+	}
+	return ""
+}
+
+func (m *Backfill) GetProgress() float32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *Backfill) GetPricesAdded() int32 {
+	if m != nil {
+		return m.PricesAdded
+	}
+	return 0
+}
+
+func (m *Backfill) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Backfill) GetStartedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedTime
+	}
+	return nil
+}
+
+func (m *Backfill) GetFinishedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedTime
+	}
+	return nil
 }
 
 type Balance struct {
-	Symbol       string                     `protobuf:"bytes,1,opt,name=symbol" json:"symbol,omitempty"`
-	Exchange     string                     `protobuf:"bytes,2,opt,name=exchange" json:"exchange,omitempty"`
-	Free         float32                    `protobuf:"fixed32,3,opt,name=free" json:"free,omitempty"`
-	Locked       float32                    `protobuf:"fixed32,4,opt,name=locked" json:"locked,omitempty"`
-	Total        float32                    `protobuf:"fixed32,5,opt,name=total" json:"total,omitempty"`
-	As           string                     `protobuf:"bytes,6,opt,name=as" json:"as,omitempty"`
-	Price        float32                    `protobuf:"fixed32,7,opt,name=price" json:"price,omitempty"`
-	Value        float32                    `protobuf:"fixed32,8,opt,name=value" json:"value,omitempty"`
-	At           *google_protobuf.Timestamp `protobuf:"bytes,9,opt,name=at" json:"at,omitempty"`
-	Price24H     float32                    `protobuf:"fixed32,10,opt,name=price24h" json:"price24h,omitempty"`
-	Value24H     float32                    `protobuf:"fixed32,11,opt,name=value24h" json:"value24h,omitempty"`
-	Change24H    float32                    `protobuf:"fixed32,12,opt,name=change24h" json:"change24h,omitempty"`
-	ChangePct24H float32                    `protobuf:"fixed32,13,opt,name=changePct24h" json:"changePct24h,omitempty"`
-	BuyStrategy  *Strategy                  `protobuf:"bytes,14,opt,name=buyStrategy" json:"buyStrategy,omitempty"`
-	SellStrategy *Strategy                  `protobuf:"bytes,15,opt,name=sellStrategy" json:"sellStrategy,omitempty"`
-}
-
-func (m *Balance) Reset()                    { *m = Balance{} }
-func (m *Balance) String() string            { return proto1.CompactTextString(m) }
-func (*Balance) ProtoMessage()               {}
-func (*Balance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+	Symbol               string               `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Exchange             string               `protobuf:"bytes,2,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Free                 float32              `protobuf:"fixed32,3,opt,name=free,proto3" json:"free,omitempty"`
+	Locked               float32              `protobuf:"fixed32,4,opt,name=locked,proto3" json:"locked,omitempty"`
+	Total                float32              `protobuf:"fixed32,5,opt,name=total,proto3" json:"total,omitempty"`
+	As                   string               `protobuf:"bytes,6,opt,name=as,proto3" json:"as,omitempty"`
+	Price                float32              `protobuf:"fixed32,7,opt,name=price,proto3" json:"price,omitempty"`
+	Value                float32              `protobuf:"fixed32,8,opt,name=value,proto3" json:"value,omitempty"`
+	At                   *timestamp.Timestamp `protobuf:"bytes,9,opt,name=at,proto3" json:"at,omitempty"`
+	Price24H             float32              `protobuf:"fixed32,10,opt,name=price24h,proto3" json:"price24h,omitempty"`
+	Value24H             float32              `protobuf:"fixed32,11,opt,name=value24h,proto3" json:"value24h,omitempty"`
+	Change24H            float32              `protobuf:"fixed32,12,opt,name=change24h,proto3" json:"change24h,omitempty"`
+	ChangePct24H         float32              `protobuf:"fixed32,13,opt,name=changePct24h,proto3" json:"changePct24h,omitempty"`
+	BuyStrategy          *Strategy            `protobuf:"bytes,14,opt,name=buyStrategy,proto3" json:"buyStrategy,omitempty"`
+	SellStrategy         *Strategy            `protobuf:"bytes,15,opt,name=sellStrategy,proto3" json:"sellStrategy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Balance) Reset()         { *m = Balance{} }
+func (m *Balance) String() string { return proto.CompactTextString(m) }
+func (*Balance) ProtoMessage()    {}
+func (*Balance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+}
+
+func (m *Balance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Balance.Unmarshal(m, b)
+}
+func (m *Balance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Balance.Marshal(b, m, deterministic)
+}
+func (m *Balance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Balance.Merge(m, src)
+}
+func (m *Balance) XXX_Size() int {
+	return xxx_messageInfo_Balance.Size(m)
+}
+func (m *Balance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Balance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Balance proto.InternalMessageInfo
 
 func (m *Balance) GetSymbol() string {
 	if m != nil {
@@ -171,7 +465,7 @@ func (m *Balance) GetValue() float32 {
 	return 0
 }
 
-func (m *Balance) GetAt() *google_protobuf.Timestamp {
+func (m *Balance) GetAt() *timestamp.Timestamp {
 	if m != nil {
 		return m.At
 	}
@@ -221,14 +515,37 @@ func (m *Balance) GetSellStrategy() *Strategy {
 }
 
 type CreateSimulationRequest struct {
-	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateSimulationRequest) Reset()         { *m = CreateSimulationRequest{} }
+func (m *CreateSimulationRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateSimulationRequest) ProtoMessage()    {}
+func (*CreateSimulationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+}
+
+func (m *CreateSimulationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSimulationRequest.Unmarshal(m, b)
+}
+func (m *CreateSimulationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSimulationRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateSimulationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSimulationRequest.Merge(m, src)
+}
+func (m *CreateSimulationRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateSimulationRequest.Size(m)
+}
+func (m *CreateSimulationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSimulationRequest.DiscardUnknown(m)
 }
 
-func (m *CreateSimulationRequest) Reset()                    { *m = CreateSimulationRequest{} }
-func (m *CreateSimulationRequest) String() string            { return proto1.CompactTextString(m) }
-func (*CreateSimulationRequest) ProtoMessage()               {}
-func (*CreateSimulationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+var xxx_messageInfo_CreateSimulationRequest proto.InternalMessageInfo
 
 func (m *CreateSimulationRequest) GetId() string {
 	if m != nil {
@@ -245,518 +562,2226 @@ func (m *CreateSimulationRequest) GetName() string {
 }
 
 type CreateSimulationResponse struct {
-	Simulation *Simulation `protobuf:"bytes,1,opt,name=simulation" json:"simulation,omitempty"`
+	Simulation           *Simulation `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *CreateSimulationResponse) Reset()                    { *m = CreateSimulationResponse{} }
-func (m *CreateSimulationResponse) String() string            { return proto1.CompactTextString(m) }
-func (*CreateSimulationResponse) ProtoMessage()               {}
-func (*CreateSimulationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
-
-func (m *CreateSimulationResponse) GetSimulation() *Simulation {
-	if m != nil {
-		return m.Simulation
-	}
-	return nil
+func (m *CreateSimulationResponse) Reset()         { *m = CreateSimulationResponse{} }
+func (m *CreateSimulationResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateSimulationResponse) ProtoMessage()    {}
+func (*CreateSimulationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
 }
 
-type GetLogRequest struct {
+func (m *CreateSimulationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSimulationResponse.Unmarshal(m, b)
 }
-
-func (m *GetLogRequest) Reset()                    { *m = GetLogRequest{} }
-func (m *GetLogRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetLogRequest) ProtoMessage()               {}
-func (*GetLogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
-
-type GetLogResponse struct {
-	Entries []*LogEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+func (m *CreateSimulationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSimulationResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateSimulationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSimulationResponse.Merge(m, src)
+}
+func (m *CreateSimulationResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateSimulationResponse.Size(m)
+}
+func (m *CreateSimulationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSimulationResponse.DiscardUnknown(m)
 }
 
-func (m *GetLogResponse) Reset()                    { *m = GetLogResponse{} }
-func (m *GetLogResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetLogResponse) ProtoMessage()               {}
-func (*GetLogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+var xxx_messageInfo_CreateSimulationResponse proto.InternalMessageInfo
 
-func (m *GetLogResponse) GetEntries() []*LogEntry {
+func (m *CreateSimulationResponse) GetSimulation() *Simulation {
 	if m != nil {
-		return m.Entries
+		return m.Simulation
 	}
 	return nil
 }
 
-type GetPortfolioRequest struct {
-	As          string `protobuf:"bytes,1,opt,name=as" json:"as,omitempty"`
-	IgnoreSmall bool   `protobuf:"varint,2,opt,name=ignoreSmall" json:"ignoreSmall,omitempty"`
+type CreateStrategyRequest struct {
+	Id                   string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string             `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Symbol               string             `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	As                   string             `protobuf:"bytes,4,opt,name=as,proto3" json:"as,omitempty"`
+	CoinPercent          float32            `protobuf:"fixed32,5,opt,name=coinPercent,proto3" json:"coinPercent,omitempty"`
+	Params               map[string]float64 `protobuf:"bytes,6,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *GetPortfolioRequest) Reset()                    { *m = GetPortfolioRequest{} }
-func (m *GetPortfolioRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetPortfolioRequest) ProtoMessage()               {}
-func (*GetPortfolioRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
-
-func (m *GetPortfolioRequest) GetAs() string {
-	if m != nil {
-		return m.As
-	}
-	return ""
+func (m *CreateStrategyRequest) Reset()         { *m = CreateStrategyRequest{} }
+func (m *CreateStrategyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateStrategyRequest) ProtoMessage()    {}
+func (*CreateStrategyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
 }
 
-func (m *GetPortfolioRequest) GetIgnoreSmall() bool {
-	if m != nil {
-		return m.IgnoreSmall
-	}
-	return false
+func (m *CreateStrategyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateStrategyRequest.Unmarshal(m, b)
 }
-
-type GetPortfolioResponse struct {
-	Balances []*Balance `protobuf:"bytes,1,rep,name=balances" json:"balances,omitempty"`
+func (m *CreateStrategyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateStrategyRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateStrategyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateStrategyRequest.Merge(m, src)
+}
+func (m *CreateStrategyRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateStrategyRequest.Size(m)
+}
+func (m *CreateStrategyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateStrategyRequest.DiscardUnknown(m)
 }
 
-func (m *GetPortfolioResponse) Reset()                    { *m = GetPortfolioResponse{} }
-func (m *GetPortfolioResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetPortfolioResponse) ProtoMessage()               {}
-func (*GetPortfolioResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+var xxx_messageInfo_CreateStrategyRequest proto.InternalMessageInfo
 
-func (m *GetPortfolioResponse) GetBalances() []*Balance {
+func (m *CreateStrategyRequest) GetId() string {
 	if m != nil {
-		return m.Balances
+		return m.Id
 	}
-	return nil
+	return ""
 }
 
-type GetPricesRequest struct {
-	Base string `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
-	As   string `protobuf:"bytes,2,opt,name=as" json:"as,omitempty"`
+func (m *CreateStrategyRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
 }
 
-func (m *GetPricesRequest) Reset()                    { *m = GetPricesRequest{} }
-func (m *GetPricesRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetPricesRequest) ProtoMessage()               {}
-func (*GetPricesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
-
-func (m *GetPricesRequest) GetBase() string {
+func (m *CreateStrategyRequest) GetSymbol() string {
 	if m != nil {
-		return m.Base
+		return m.Symbol
 	}
 	return ""
 }
 
-func (m *GetPricesRequest) GetAs() string {
+func (m *CreateStrategyRequest) GetAs() string {
 	if m != nil {
 		return m.As
 	}
 	return ""
 }
 
-type GetPricesResponse struct {
-	Prices []*Price `protobuf:"bytes,1,rep,name=prices" json:"prices,omitempty"`
+func (m *CreateStrategyRequest) GetCoinPercent() float32 {
+	if m != nil {
+		return m.CoinPercent
+	}
+	return 0
 }
 
-func (m *GetPricesResponse) Reset()                    { *m = GetPricesResponse{} }
-func (m *GetPricesResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetPricesResponse) ProtoMessage()               {}
-func (*GetPricesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
-
-func (m *GetPricesResponse) GetPrices() []*Price {
+func (m *CreateStrategyRequest) GetParams() map[string]float64 {
 	if m != nil {
-		return m.Prices
+		return m.Params
 	}
 	return nil
 }
 
-type GetSimulationsRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+type CreateStrategyResponse struct {
+	Strategy             *Strategy `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *GetSimulationsRequest) Reset()                    { *m = GetSimulationsRequest{} }
-func (m *GetSimulationsRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetSimulationsRequest) ProtoMessage()               {}
-func (*GetSimulationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
-
-func (m *GetSimulationsRequest) GetId() string {
-	if m != nil {
-		return m.Id
-	}
-	return ""
+func (m *CreateStrategyResponse) Reset()         { *m = CreateStrategyResponse{} }
+func (m *CreateStrategyResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateStrategyResponse) ProtoMessage()    {}
+func (*CreateStrategyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
 }
 
-type GetSimulationsResponse struct {
-	Simulations []*Simulation `protobuf:"bytes,1,rep,name=simulations" json:"simulations,omitempty"`
+func (m *CreateStrategyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateStrategyResponse.Unmarshal(m, b)
+}
+func (m *CreateStrategyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateStrategyResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateStrategyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateStrategyResponse.Merge(m, src)
+}
+func (m *CreateStrategyResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateStrategyResponse.Size(m)
+}
+func (m *CreateStrategyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateStrategyResponse.DiscardUnknown(m)
 }
 
-func (m *GetSimulationsResponse) Reset()                    { *m = GetSimulationsResponse{} }
-func (m *GetSimulationsResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetSimulationsResponse) ProtoMessage()               {}
-func (*GetSimulationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+var xxx_messageInfo_CreateStrategyResponse proto.InternalMessageInfo
 
-func (m *GetSimulationsResponse) GetSimulations() []*Simulation {
+func (m *CreateStrategyResponse) GetStrategy() *Strategy {
 	if m != nil {
-		return m.Simulations
+		return m.Strategy
 	}
 	return nil
 }
 
-type GetStatusRequest struct {
+type DetachStrategyRequest struct {
+	SimulationId         string       `protobuf:"bytes,1,opt,name=simulationId,proto3" json:"simulationId,omitempty"`
+	Symbol               string       `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Slot                 StrategySlot `protobuf:"varint,3,opt,name=slot,proto3,enum=proto.StrategySlot" json:"slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *GetStatusRequest) Reset()                    { *m = GetStatusRequest{} }
-func (m *GetStatusRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetStatusRequest) ProtoMessage()               {}
-func (*GetStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (m *DetachStrategyRequest) Reset()         { *m = DetachStrategyRequest{} }
+func (m *DetachStrategyRequest) String() string { return proto.CompactTextString(m) }
+func (*DetachStrategyRequest) ProtoMessage()    {}
+func (*DetachStrategyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+}
 
-type GetStatusResponse struct {
-	ServerStarted *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=serverStarted" json:"serverStarted,omitempty"`
-	LastUpdate    *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=lastUpdate" json:"lastUpdate,omitempty"`
-	UpdateCount   int32                      `protobuf:"varint,3,opt,name=updateCount" json:"updateCount,omitempty"`
-	TotalSymbols  int32                      `protobuf:"varint,4,opt,name=totalSymbols" json:"totalSymbols,omitempty"`
+func (m *DetachStrategyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DetachStrategyRequest.Unmarshal(m, b)
+}
+func (m *DetachStrategyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DetachStrategyRequest.Marshal(b, m, deterministic)
+}
+func (m *DetachStrategyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DetachStrategyRequest.Merge(m, src)
+}
+func (m *DetachStrategyRequest) XXX_Size() int {
+	return xxx_messageInfo_DetachStrategyRequest.Size(m)
+}
+func (m *DetachStrategyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DetachStrategyRequest.DiscardUnknown(m)
 }
 
-func (m *GetStatusResponse) Reset()                    { *m = GetStatusResponse{} }
-func (m *GetStatusResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetStatusResponse) ProtoMessage()               {}
-func (*GetStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+var xxx_messageInfo_DetachStrategyRequest proto.InternalMessageInfo
 
-func (m *GetStatusResponse) GetServerStarted() *google_protobuf.Timestamp {
+func (m *DetachStrategyRequest) GetSimulationId() string {
 	if m != nil {
-		return m.ServerStarted
+		return m.SimulationId
 	}
-	return nil
+	return ""
+}
+
+func (m *DetachStrategyRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *DetachStrategyRequest) GetSlot() StrategySlot {
+	if m != nil {
+		return m.Slot
+	}
+	return StrategySlot_BUY
+}
+
+type DetachStrategyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DetachStrategyResponse) Reset()         { *m = DetachStrategyResponse{} }
+func (m *DetachStrategyResponse) String() string { return proto.CompactTextString(m) }
+func (*DetachStrategyResponse) ProtoMessage()    {}
+func (*DetachStrategyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+}
+
+func (m *DetachStrategyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DetachStrategyResponse.Unmarshal(m, b)
+}
+func (m *DetachStrategyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DetachStrategyResponse.Marshal(b, m, deterministic)
+}
+func (m *DetachStrategyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DetachStrategyResponse.Merge(m, src)
+}
+func (m *DetachStrategyResponse) XXX_Size() int {
+	return xxx_messageInfo_DetachStrategyResponse.Size(m)
+}
+func (m *DetachStrategyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DetachStrategyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DetachStrategyResponse proto.InternalMessageInfo
+
+type ExchangeStatus struct {
+	Exchange             string               `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	CircuitBreaker       string               `protobuf:"bytes,2,opt,name=circuitBreaker,proto3" json:"circuitBreaker,omitempty"`
+	ConsecutiveFailures  int32                `protobuf:"varint,3,opt,name=consecutiveFailures,proto3" json:"consecutiveFailures,omitempty"`
+	OpenedTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=openedTime,proto3" json:"openedTime,omitempty"`
+	LastError            string               `protobuf:"bytes,5,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	WeightUsed           int32                `protobuf:"varint,6,opt,name=weightUsed,proto3" json:"weightUsed,omitempty"`
+	WeightLimit          int32                `protobuf:"varint,7,opt,name=weightLimit,proto3" json:"weightLimit,omitempty"`
+	Requests             int32                `protobuf:"varint,8,opt,name=requests,proto3" json:"requests,omitempty"`
+	Retries              int32                `protobuf:"varint,9,opt,name=retries,proto3" json:"retries,omitempty"`
+	Failures             int32                `protobuf:"varint,10,opt,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ExchangeStatus) Reset()         { *m = ExchangeStatus{} }
+func (m *ExchangeStatus) String() string { return proto.CompactTextString(m) }
+func (*ExchangeStatus) ProtoMessage()    {}
+func (*ExchangeStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+
+func (m *ExchangeStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeStatus.Unmarshal(m, b)
+}
+func (m *ExchangeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeStatus.Marshal(b, m, deterministic)
+}
+func (m *ExchangeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeStatus.Merge(m, src)
+}
+func (m *ExchangeStatus) XXX_Size() int {
+	return xxx_messageInfo_ExchangeStatus.Size(m)
+}
+func (m *ExchangeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeStatus proto.InternalMessageInfo
+
+func (m *ExchangeStatus) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *ExchangeStatus) GetCircuitBreaker() string {
+	if m != nil {
+		return m.CircuitBreaker
+	}
+	return ""
+}
+
+func (m *ExchangeStatus) GetConsecutiveFailures() int32 {
+	if m != nil {
+		return m.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (m *ExchangeStatus) GetOpenedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.OpenedTime
+	}
+	return nil
+}
+
+func (m *ExchangeStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *ExchangeStatus) GetWeightUsed() int32 {
+	if m != nil {
+		return m.WeightUsed
+	}
+	return 0
+}
+
+func (m *ExchangeStatus) GetWeightLimit() int32 {
+	if m != nil {
+		return m.WeightLimit
+	}
+	return 0
+}
+
+func (m *ExchangeStatus) GetRequests() int32 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *ExchangeStatus) GetRetries() int32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+func (m *ExchangeStatus) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+type GetBackfillsRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBackfillsRequest) Reset()         { *m = GetBackfillsRequest{} }
+func (m *GetBackfillsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBackfillsRequest) ProtoMessage()    {}
+func (*GetBackfillsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+
+func (m *GetBackfillsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBackfillsRequest.Unmarshal(m, b)
+}
+func (m *GetBackfillsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBackfillsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBackfillsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBackfillsRequest.Merge(m, src)
+}
+func (m *GetBackfillsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBackfillsRequest.Size(m)
+}
+func (m *GetBackfillsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBackfillsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBackfillsRequest proto.InternalMessageInfo
+
+func (m *GetBackfillsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetBackfillsResponse struct {
+	Backfills            []*Backfill `protobuf:"bytes,1,rep,name=backfills,proto3" json:"backfills,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetBackfillsResponse) Reset()         { *m = GetBackfillsResponse{} }
+func (m *GetBackfillsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBackfillsResponse) ProtoMessage()    {}
+func (*GetBackfillsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+}
+
+func (m *GetBackfillsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBackfillsResponse.Unmarshal(m, b)
+}
+func (m *GetBackfillsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBackfillsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBackfillsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBackfillsResponse.Merge(m, src)
+}
+func (m *GetBackfillsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBackfillsResponse.Size(m)
+}
+func (m *GetBackfillsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBackfillsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBackfillsResponse proto.InternalMessageInfo
+
+func (m *GetBackfillsResponse) GetBackfills() []*Backfill {
+	if m != nil {
+		return m.Backfills
+	}
+	return nil
+}
+
+type GetLogRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLogRequest) Reset()         { *m = GetLogRequest{} }
+func (m *GetLogRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogRequest) ProtoMessage()    {}
+func (*GetLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+}
+
+func (m *GetLogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogRequest.Unmarshal(m, b)
+}
+func (m *GetLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogRequest.Merge(m, src)
+}
+func (m *GetLogRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLogRequest.Size(m)
+}
+func (m *GetLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogRequest proto.InternalMessageInfo
+
+type GetLogResponse struct {
+	Entries              []*LogEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetLogResponse) Reset()         { *m = GetLogResponse{} }
+func (m *GetLogResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLogResponse) ProtoMessage()    {}
+func (*GetLogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+
+func (m *GetLogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogResponse.Unmarshal(m, b)
+}
+func (m *GetLogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogResponse.Merge(m, src)
+}
+func (m *GetLogResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLogResponse.Size(m)
+}
+func (m *GetLogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogResponse proto.InternalMessageInfo
+
+func (m *GetLogResponse) GetEntries() []*LogEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type GetPortfolioRequest struct {
+	As                   string   `protobuf:"bytes,1,opt,name=as,proto3" json:"as,omitempty"`
+	IgnoreSmall          bool     `protobuf:"varint,2,opt,name=ignoreSmall,proto3" json:"ignoreSmall,omitempty"`
+	Exchange             string   `protobuf:"bytes,3,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	ByExchange           bool     `protobuf:"varint,4,opt,name=byExchange,proto3" json:"byExchange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPortfolioRequest) Reset()         { *m = GetPortfolioRequest{} }
+func (m *GetPortfolioRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPortfolioRequest) ProtoMessage()    {}
+func (*GetPortfolioRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+
+func (m *GetPortfolioRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPortfolioRequest.Unmarshal(m, b)
+}
+func (m *GetPortfolioRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPortfolioRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPortfolioRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPortfolioRequest.Merge(m, src)
+}
+func (m *GetPortfolioRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPortfolioRequest.Size(m)
+}
+func (m *GetPortfolioRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPortfolioRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPortfolioRequest proto.InternalMessageInfo
+
+func (m *GetPortfolioRequest) GetAs() string {
+	if m != nil {
+		return m.As
+	}
+	return ""
+}
+
+func (m *GetPortfolioRequest) GetIgnoreSmall() bool {
+	if m != nil {
+		return m.IgnoreSmall
+	}
+	return false
+}
+
+func (m *GetPortfolioRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *GetPortfolioRequest) GetByExchange() bool {
+	if m != nil {
+		return m.ByExchange
+	}
+	return false
+}
+
+type GetPortfolioResponse struct {
+	Balances             []*Balance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetPortfolioResponse) Reset()         { *m = GetPortfolioResponse{} }
+func (m *GetPortfolioResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPortfolioResponse) ProtoMessage()    {}
+func (*GetPortfolioResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+
+func (m *GetPortfolioResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPortfolioResponse.Unmarshal(m, b)
+}
+func (m *GetPortfolioResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPortfolioResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPortfolioResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPortfolioResponse.Merge(m, src)
+}
+func (m *GetPortfolioResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPortfolioResponse.Size(m)
+}
+func (m *GetPortfolioResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPortfolioResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPortfolioResponse proto.InternalMessageInfo
+
+func (m *GetPortfolioResponse) GetBalances() []*Balance {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+type GetPriceGapsRequest struct {
+	Base                 string   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	As                   string   `protobuf:"bytes,2,opt,name=as,proto3" json:"as,omitempty"`
+	Repair               bool     `protobuf:"varint,3,opt,name=repair,proto3" json:"repair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPriceGapsRequest) Reset()         { *m = GetPriceGapsRequest{} }
+func (m *GetPriceGapsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPriceGapsRequest) ProtoMessage()    {}
+func (*GetPriceGapsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+
+func (m *GetPriceGapsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPriceGapsRequest.Unmarshal(m, b)
+}
+func (m *GetPriceGapsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPriceGapsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPriceGapsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPriceGapsRequest.Merge(m, src)
+}
+func (m *GetPriceGapsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPriceGapsRequest.Size(m)
+}
+func (m *GetPriceGapsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPriceGapsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPriceGapsRequest proto.InternalMessageInfo
+
+func (m *GetPriceGapsRequest) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *GetPriceGapsRequest) GetAs() string {
+	if m != nil {
+		return m.As
+	}
+	return ""
+}
+
+func (m *GetPriceGapsRequest) GetRepair() bool {
+	if m != nil {
+		return m.Repair
+	}
+	return false
+}
+
+type GetPriceGapsResponse struct {
+	MaxGapSeconds        int64       `protobuf:"varint,1,opt,name=maxGapSeconds,proto3" json:"maxGapSeconds,omitempty"`
+	Gaps                 []*PriceGap `protobuf:"bytes,2,rep,name=gaps,proto3" json:"gaps,omitempty"`
+	BackfillIds          []string    `protobuf:"bytes,3,rep,name=backfillIds,proto3" json:"backfillIds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetPriceGapsResponse) Reset()         { *m = GetPriceGapsResponse{} }
+func (m *GetPriceGapsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPriceGapsResponse) ProtoMessage()    {}
+func (*GetPriceGapsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+}
+
+func (m *GetPriceGapsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPriceGapsResponse.Unmarshal(m, b)
+}
+func (m *GetPriceGapsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPriceGapsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPriceGapsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPriceGapsResponse.Merge(m, src)
+}
+func (m *GetPriceGapsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPriceGapsResponse.Size(m)
+}
+func (m *GetPriceGapsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPriceGapsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPriceGapsResponse proto.InternalMessageInfo
+
+func (m *GetPriceGapsResponse) GetMaxGapSeconds() int64 {
+	if m != nil {
+		return m.MaxGapSeconds
+	}
+	return 0
+}
+
+func (m *GetPriceGapsResponse) GetGaps() []*PriceGap {
+	if m != nil {
+		return m.Gaps
+	}
+	return nil
+}
+
+func (m *GetPriceGapsResponse) GetBackfillIds() []string {
+	if m != nil {
+		return m.BackfillIds
+	}
+	return nil
+}
+
+type GetPricesRequest struct {
+	Base                 string   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	As                   string   `protobuf:"bytes,2,opt,name=as,proto3" json:"as,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPricesRequest) Reset()         { *m = GetPricesRequest{} }
+func (m *GetPricesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPricesRequest) ProtoMessage()    {}
+func (*GetPricesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+
+func (m *GetPricesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPricesRequest.Unmarshal(m, b)
+}
+func (m *GetPricesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPricesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPricesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPricesRequest.Merge(m, src)
+}
+func (m *GetPricesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPricesRequest.Size(m)
+}
+func (m *GetPricesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPricesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPricesRequest proto.InternalMessageInfo
+
+func (m *GetPricesRequest) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *GetPricesRequest) GetAs() string {
+	if m != nil {
+		return m.As
+	}
+	return ""
+}
+
+type GetPricesResponse struct {
+	Prices               []*Price `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPricesResponse) Reset()         { *m = GetPricesResponse{} }
+func (m *GetPricesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPricesResponse) ProtoMessage()    {}
+func (*GetPricesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+}
+
+func (m *GetPricesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPricesResponse.Unmarshal(m, b)
+}
+func (m *GetPricesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPricesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPricesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPricesResponse.Merge(m, src)
+}
+func (m *GetPricesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPricesResponse.Size(m)
+}
+func (m *GetPricesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPricesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPricesResponse proto.InternalMessageInfo
+
+func (m *GetPricesResponse) GetPrices() []*Price {
+	if m != nil {
+		return m.Prices
+	}
+	return nil
+}
+
+type GetSimulationResultRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSimulationResultRequest) Reset()         { *m = GetSimulationResultRequest{} }
+func (m *GetSimulationResultRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSimulationResultRequest) ProtoMessage()    {}
+func (*GetSimulationResultRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+}
+
+func (m *GetSimulationResultRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSimulationResultRequest.Unmarshal(m, b)
+}
+func (m *GetSimulationResultRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSimulationResultRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSimulationResultRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSimulationResultRequest.Merge(m, src)
+}
+func (m *GetSimulationResultRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSimulationResultRequest.Size(m)
+}
+func (m *GetSimulationResultRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSimulationResultRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSimulationResultRequest proto.InternalMessageInfo
+
+func (m *GetSimulationResultRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetSimulationResultResponse struct {
+	Result               *SimulationResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetSimulationResultResponse) Reset()         { *m = GetSimulationResultResponse{} }
+func (m *GetSimulationResultResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSimulationResultResponse) ProtoMessage()    {}
+func (*GetSimulationResultResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *GetSimulationResultResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSimulationResultResponse.Unmarshal(m, b)
+}
+func (m *GetSimulationResultResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSimulationResultResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSimulationResultResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSimulationResultResponse.Merge(m, src)
+}
+func (m *GetSimulationResultResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSimulationResultResponse.Size(m)
+}
+func (m *GetSimulationResultResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSimulationResultResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSimulationResultResponse proto.InternalMessageInfo
+
+func (m *GetSimulationResultResponse) GetResult() *SimulationResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetSimulationsRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSimulationsRequest) Reset()         { *m = GetSimulationsRequest{} }
+func (m *GetSimulationsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSimulationsRequest) ProtoMessage()    {}
+func (*GetSimulationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+
+func (m *GetSimulationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSimulationsRequest.Unmarshal(m, b)
+}
+func (m *GetSimulationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSimulationsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSimulationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSimulationsRequest.Merge(m, src)
+}
+func (m *GetSimulationsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSimulationsRequest.Size(m)
+}
+func (m *GetSimulationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSimulationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSimulationsRequest proto.InternalMessageInfo
+
+func (m *GetSimulationsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetSimulationsResponse struct {
+	Simulations          []*Simulation `protobuf:"bytes,1,rep,name=simulations,proto3" json:"simulations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetSimulationsResponse) Reset()         { *m = GetSimulationsResponse{} }
+func (m *GetSimulationsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSimulationsResponse) ProtoMessage()    {}
+func (*GetSimulationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+
+func (m *GetSimulationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSimulationsResponse.Unmarshal(m, b)
+}
+func (m *GetSimulationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSimulationsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSimulationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSimulationsResponse.Merge(m, src)
+}
+func (m *GetSimulationsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSimulationsResponse.Size(m)
+}
+func (m *GetSimulationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSimulationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSimulationsResponse proto.InternalMessageInfo
+
+func (m *GetSimulationsResponse) GetSimulations() []*Simulation {
+	if m != nil {
+		return m.Simulations
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatusRequest) Reset()         { *m = GetStatusRequest{} }
+func (m *GetStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStatusRequest) ProtoMessage()    {}
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+
+func (m *GetStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatusRequest.Unmarshal(m, b)
+}
+func (m *GetStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatusRequest.Merge(m, src)
+}
+func (m *GetStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStatusRequest.Size(m)
+}
+func (m *GetStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatusRequest proto.InternalMessageInfo
+
+type GetStatusResponse struct {
+	ServerStarted        *timestamp.Timestamp `protobuf:"bytes,1,opt,name=serverStarted,proto3" json:"serverStarted,omitempty"`
+	LastUpdate           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
+	UpdateCount          int32                `protobuf:"varint,3,opt,name=updateCount,proto3" json:"updateCount,omitempty"`
+	TotalSymbols         int32                `protobuf:"varint,4,opt,name=totalSymbols,proto3" json:"totalSymbols,omitempty"`
+	Risk                 *RiskStatus          `protobuf:"bytes,5,opt,name=risk,proto3" json:"risk,omitempty"`
+	PriceStreamConnected bool                 `protobuf:"varint,6,opt,name=priceStreamConnected,proto3" json:"priceStreamConnected,omitempty"`
+	StreamedPrices       int32                `protobuf:"varint,7,opt,name=streamedPrices,proto3" json:"streamedPrices,omitempty"`
+	LastStreamedPrice    *timestamp.Timestamp `protobuf:"bytes,8,opt,name=lastStreamedPrice,proto3" json:"lastStreamedPrice,omitempty"`
+	Exchange             *ExchangeStatus      `protobuf:"bytes,9,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetStatusResponse) Reset()         { *m = GetStatusResponse{} }
+func (m *GetStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStatusResponse) ProtoMessage()    {}
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+}
+
+func (m *GetStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatusResponse.Unmarshal(m, b)
+}
+func (m *GetStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatusResponse.Merge(m, src)
+}
+func (m *GetStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStatusResponse.Size(m)
+}
+func (m *GetStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatusResponse proto.InternalMessageInfo
+
+func (m *GetStatusResponse) GetServerStarted() *timestamp.Timestamp {
+	if m != nil {
+		return m.ServerStarted
+	}
+	return nil
+}
+
+func (m *GetStatusResponse) GetLastUpdate() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastUpdate
+	}
+	return nil
+}
+
+func (m *GetStatusResponse) GetUpdateCount() int32 {
+	if m != nil {
+		return m.UpdateCount
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetTotalSymbols() int32 {
+	if m != nil {
+		return m.TotalSymbols
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetRisk() *RiskStatus {
+	if m != nil {
+		return m.Risk
+	}
+	return nil
+}
+
+func (m *GetStatusResponse) GetPriceStreamConnected() bool {
+	if m != nil {
+		return m.PriceStreamConnected
+	}
+	return false
+}
+
+func (m *GetStatusResponse) GetStreamedPrices() int32 {
+	if m != nil {
+		return m.StreamedPrices
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetLastStreamedPrice() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastStreamedPrice
+	}
+	return nil
+}
+
+func (m *GetStatusResponse) GetExchange() *ExchangeStatus {
+	if m != nil {
+		return m.Exchange
+	}
+	return nil
+}
+
+type GetStrategiesRequest struct {
+	SimulationId         string   `protobuf:"bytes,1,opt,name=simulationId,proto3" json:"simulationId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStrategiesRequest) Reset()         { *m = GetStrategiesRequest{} }
+func (m *GetStrategiesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStrategiesRequest) ProtoMessage()    {}
+func (*GetStrategiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+}
+
+func (m *GetStrategiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStrategiesRequest.Unmarshal(m, b)
+}
+func (m *GetStrategiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStrategiesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStrategiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStrategiesRequest.Merge(m, src)
+}
+func (m *GetStrategiesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStrategiesRequest.Size(m)
+}
+func (m *GetStrategiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStrategiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStrategiesRequest proto.InternalMessageInfo
+
+func (m *GetStrategiesRequest) GetSimulationId() string {
+	if m != nil {
+		return m.SimulationId
+	}
+	return ""
+}
+
+type GetStrategiesResponse struct {
+	Strategies           []*Strategy         `protobuf:"bytes,1,rep,name=strategies,proto3" json:"strategies,omitempty"`
+	Attached             []*AttachedStrategy `protobuf:"bytes,2,rep,name=attached,proto3" json:"attached,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetStrategiesResponse) Reset()         { *m = GetStrategiesResponse{} }
+func (m *GetStrategiesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStrategiesResponse) ProtoMessage()    {}
+func (*GetStrategiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+}
+
+func (m *GetStrategiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStrategiesResponse.Unmarshal(m, b)
+}
+func (m *GetStrategiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStrategiesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStrategiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStrategiesResponse.Merge(m, src)
+}
+func (m *GetStrategiesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStrategiesResponse.Size(m)
+}
+func (m *GetStrategiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStrategiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStrategiesResponse proto.InternalMessageInfo
+
+func (m *GetStrategiesResponse) GetStrategies() []*Strategy {
+	if m != nil {
+		return m.Strategies
+	}
+	return nil
+}
+
+func (m *GetStrategiesResponse) GetAttached() []*AttachedStrategy {
+	if m != nil {
+		return m.Attached
+	}
+	return nil
+}
+
+type GetSymbolTypesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSymbolTypesRequest) Reset()         { *m = GetSymbolTypesRequest{} }
+func (m *GetSymbolTypesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSymbolTypesRequest) ProtoMessage()    {}
+func (*GetSymbolTypesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+}
+
+func (m *GetSymbolTypesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSymbolTypesRequest.Unmarshal(m, b)
+}
+func (m *GetSymbolTypesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSymbolTypesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSymbolTypesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSymbolTypesRequest.Merge(m, src)
+}
+func (m *GetSymbolTypesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSymbolTypesRequest.Size(m)
+}
+func (m *GetSymbolTypesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSymbolTypesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSymbolTypesRequest proto.InternalMessageInfo
+
+type GetSymbolTypesResponse struct {
+	SymbolTypes          []*SymbolType `protobuf:"bytes,1,rep,name=symbolTypes,proto3" json:"symbolTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetSymbolTypesResponse) Reset()         { *m = GetSymbolTypesResponse{} }
+func (m *GetSymbolTypesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSymbolTypesResponse) ProtoMessage()    {}
+func (*GetSymbolTypesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+}
+
+func (m *GetSymbolTypesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSymbolTypesResponse.Unmarshal(m, b)
+}
+func (m *GetSymbolTypesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSymbolTypesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSymbolTypesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSymbolTypesResponse.Merge(m, src)
+}
+func (m *GetSymbolTypesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSymbolTypesResponse.Size(m)
+}
+func (m *GetSymbolTypesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSymbolTypesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSymbolTypesResponse proto.InternalMessageInfo
+
+func (m *GetSymbolTypesResponse) GetSymbolTypes() []*SymbolType {
+	if m != nil {
+		return m.SymbolTypes
+	}
+	return nil
+}
+
+type LogEntry struct {
+	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Text                 string               `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+}
+
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (m *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(m, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *LogEntry) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+type Portfolio struct {
+	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Balances             []*Balance `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Portfolio) Reset()         { *m = Portfolio{} }
+func (m *Portfolio) String() string { return proto.CompactTextString(m) }
+func (*Portfolio) ProtoMessage()    {}
+func (*Portfolio) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+}
+
+func (m *Portfolio) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Portfolio.Unmarshal(m, b)
+}
+func (m *Portfolio) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Portfolio.Marshal(b, m, deterministic)
+}
+func (m *Portfolio) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Portfolio.Merge(m, src)
+}
+func (m *Portfolio) XXX_Size() int {
+	return xxx_messageInfo_Portfolio.Size(m)
+}
+func (m *Portfolio) XXX_DiscardUnknown() {
+	xxx_messageInfo_Portfolio.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Portfolio proto.InternalMessageInfo
+
+func (m *Portfolio) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Portfolio) GetBalances() []*Balance {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+type Price struct {
+	Symbol               string               `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Exchange             string               `protobuf:"bytes,2,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	As                   string               `protobuf:"bytes,3,opt,name=as,proto3" json:"as,omitempty"`
+	Current              float32              `protobuf:"fixed32,4,opt,name=current,proto3" json:"current,omitempty"`
+	At                   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=at,proto3" json:"at,omitempty"`
+	ChangeToday          float32              `protobuf:"fixed32,6,opt,name=changeToday,proto3" json:"changeToday,omitempty"`
+	ChangePctToday       float32              `protobuf:"fixed32,7,opt,name=changePctToday,proto3" json:"changePctToday,omitempty"`
+	Opening              float32              `protobuf:"fixed32,8,opt,name=opening,proto3" json:"opening,omitempty"`
+	Closing              float32              `protobuf:"fixed32,9,opt,name=closing,proto3" json:"closing,omitempty"`
+	Highest              float32              `protobuf:"fixed32,10,opt,name=highest,proto3" json:"highest,omitempty"`
+	Lowest               float32              `protobuf:"fixed32,11,opt,name=lowest,proto3" json:"lowest,omitempty"`
+	Change24H            float32              `protobuf:"fixed32,12,opt,name=change24h,proto3" json:"change24h,omitempty"`
+	ChangePct24H         float32              `protobuf:"fixed32,13,opt,name=changePct24h,proto3" json:"changePct24h,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Price) Reset()         { *m = Price{} }
+func (m *Price) String() string { return proto.CompactTextString(m) }
+func (*Price) ProtoMessage()    {}
+func (*Price) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+}
+
+func (m *Price) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Price.Unmarshal(m, b)
+}
+func (m *Price) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Price.Marshal(b, m, deterministic)
+}
+func (m *Price) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Price.Merge(m, src)
+}
+func (m *Price) XXX_Size() int {
+	return xxx_messageInfo_Price.Size(m)
+}
+func (m *Price) XXX_DiscardUnknown() {
+	xxx_messageInfo_Price.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Price proto.InternalMessageInfo
+
+func (m *Price) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *Price) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *Price) GetAs() string {
+	if m != nil {
+		return m.As
+	}
+	return ""
+}
+
+func (m *Price) GetCurrent() float32 {
+	if m != nil {
+		return m.Current
+	}
+	return 0
+}
+
+func (m *Price) GetAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.At
+	}
+	return nil
+}
+
+func (m *Price) GetChangeToday() float32 {
+	if m != nil {
+		return m.ChangeToday
+	}
+	return 0
+}
+
+func (m *Price) GetChangePctToday() float32 {
+	if m != nil {
+		return m.ChangePctToday
+	}
+	return 0
+}
+
+func (m *Price) GetOpening() float32 {
+	if m != nil {
+		return m.Opening
+	}
+	return 0
+}
+
+func (m *Price) GetClosing() float32 {
+	if m != nil {
+		return m.Closing
+	}
+	return 0
+}
+
+func (m *Price) GetHighest() float32 {
+	if m != nil {
+		return m.Highest
+	}
+	return 0
+}
+
+func (m *Price) GetLowest() float32 {
+	if m != nil {
+		return m.Lowest
+	}
+	return 0
+}
+
+func (m *Price) GetChange24H() float32 {
+	if m != nil {
+		return m.Change24H
+	}
+	return 0
+}
+
+func (m *Price) GetChangePct24H() float32 {
+	if m != nil {
+		return m.ChangePct24H
+	}
+	return 0
+}
+
+type PriceGap struct {
+	Base                 string               `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	As                   string               `protobuf:"bytes,2,opt,name=as,proto3" json:"as,omitempty"`
+	FromTime             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime               *timestamp.Timestamp `protobuf:"bytes,4,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	MissingPrices        int32                `protobuf:"varint,5,opt,name=missingPrices,proto3" json:"missingPrices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PriceGap) Reset()         { *m = PriceGap{} }
+func (m *PriceGap) String() string { return proto.CompactTextString(m) }
+func (*PriceGap) ProtoMessage()    {}
+func (*PriceGap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+}
+
+func (m *PriceGap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PriceGap.Unmarshal(m, b)
+}
+func (m *PriceGap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PriceGap.Marshal(b, m, deterministic)
+}
+func (m *PriceGap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PriceGap.Merge(m, src)
+}
+func (m *PriceGap) XXX_Size() int {
+	return xxx_messageInfo_PriceGap.Size(m)
+}
+func (m *PriceGap) XXX_DiscardUnknown() {
+	xxx_messageInfo_PriceGap.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PriceGap proto.InternalMessageInfo
+
+func (m *PriceGap) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *PriceGap) GetAs() string {
+	if m != nil {
+		return m.As
+	}
+	return ""
+}
+
+func (m *PriceGap) GetFromTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.FromTime
+	}
+	return nil
+}
+
+func (m *PriceGap) GetToTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ToTime
+	}
+	return nil
+}
+
+func (m *PriceGap) GetMissingPrices() int32 {
+	if m != nil {
+		return m.MissingPrices
+	}
+	return 0
+}
+
+type RebuildRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildRequest) Reset()         { *m = RebuildRequest{} }
+func (m *RebuildRequest) String() string { return proto.CompactTextString(m) }
+func (*RebuildRequest) ProtoMessage()    {}
+func (*RebuildRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+}
+
+func (m *RebuildRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebuildRequest.Unmarshal(m, b)
+}
+func (m *RebuildRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebuildRequest.Marshal(b, m, deterministic)
+}
+func (m *RebuildRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildRequest.Merge(m, src)
+}
+func (m *RebuildRequest) XXX_Size() int {
+	return xxx_messageInfo_RebuildRequest.Size(m)
+}
+func (m *RebuildRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildRequest proto.InternalMessageInfo
+
+type RebuildResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildResponse) Reset()         { *m = RebuildResponse{} }
+func (m *RebuildResponse) String() string { return proto.CompactTextString(m) }
+func (*RebuildResponse) ProtoMessage()    {}
+func (*RebuildResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+}
+
+func (m *RebuildResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebuildResponse.Unmarshal(m, b)
+}
+func (m *RebuildResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebuildResponse.Marshal(b, m, deterministic)
+}
+func (m *RebuildResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildResponse.Merge(m, src)
+}
+func (m *RebuildResponse) XXX_Size() int {
+	return xxx_messageInfo_RebuildResponse.Size(m)
+}
+func (m *RebuildResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildResponse proto.InternalMessageInfo
+
+func (m *RebuildResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type RiskStatus struct {
+	MaxOrderSize         map[string]float32 `protobuf:"bytes,1,rep,name=maxOrderSize,proto3" json:"maxOrderSize,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
+	MaxTradePercent      float32            `protobuf:"fixed32,2,opt,name=maxTradePercent,proto3" json:"maxTradePercent,omitempty"`
+	MaxDailyLoss         float32            `protobuf:"fixed32,3,opt,name=maxDailyLoss,proto3" json:"maxDailyLoss,omitempty"`
+	MaxOrdersPerHour     int32              `protobuf:"varint,4,opt,name=maxOrdersPerHour,proto3" json:"maxOrdersPerHour,omitempty"`
+	KillSwitchEngaged    bool               `protobuf:"varint,5,opt,name=killSwitchEngaged,proto3" json:"killSwitchEngaged,omitempty"`
+	KillSwitchReason     string             `protobuf:"bytes,6,opt,name=killSwitchReason,proto3" json:"killSwitchReason,omitempty"`
+	OrdersLastHour       int32              `protobuf:"varint,7,opt,name=ordersLastHour,proto3" json:"ordersLastHour,omitempty"`
+	RealisedToday        float32            `protobuf:"fixed32,8,opt,name=realisedToday,proto3" json:"realisedToday,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *RiskStatus) Reset()         { *m = RiskStatus{} }
+func (m *RiskStatus) String() string { return proto.CompactTextString(m) }
+func (*RiskStatus) ProtoMessage()    {}
+func (*RiskStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+
+func (m *RiskStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RiskStatus.Unmarshal(m, b)
+}
+func (m *RiskStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RiskStatus.Marshal(b, m, deterministic)
+}
+func (m *RiskStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RiskStatus.Merge(m, src)
+}
+func (m *RiskStatus) XXX_Size() int {
+	return xxx_messageInfo_RiskStatus.Size(m)
+}
+func (m *RiskStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RiskStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RiskStatus proto.InternalMessageInfo
+
+func (m *RiskStatus) GetMaxOrderSize() map[string]float32 {
+	if m != nil {
+		return m.MaxOrderSize
+	}
+	return nil
+}
+
+func (m *RiskStatus) GetMaxTradePercent() float32 {
+	if m != nil {
+		return m.MaxTradePercent
+	}
+	return 0
+}
+
+func (m *RiskStatus) GetMaxDailyLoss() float32 {
+	if m != nil {
+		return m.MaxDailyLoss
+	}
+	return 0
+}
+
+func (m *RiskStatus) GetMaxOrdersPerHour() int32 {
+	if m != nil {
+		return m.MaxOrdersPerHour
+	}
+	return 0
 }
 
-func (m *GetStatusResponse) GetLastUpdate() *google_protobuf.Timestamp {
+func (m *RiskStatus) GetKillSwitchEngaged() bool {
 	if m != nil {
-		return m.LastUpdate
+		return m.KillSwitchEngaged
 	}
-	return nil
+	return false
 }
 
-func (m *GetStatusResponse) GetUpdateCount() int32 {
+func (m *RiskStatus) GetKillSwitchReason() string {
 	if m != nil {
-		return m.UpdateCount
+		return m.KillSwitchReason
+	}
+	return ""
+}
+
+func (m *RiskStatus) GetOrdersLastHour() int32 {
+	if m != nil {
+		return m.OrdersLastHour
 	}
 	return 0
 }
 
-func (m *GetStatusResponse) GetTotalSymbols() int32 {
+func (m *RiskStatus) GetRealisedToday() float32 {
 	if m != nil {
-		return m.TotalSymbols
+		return m.RealisedToday
 	}
 	return 0
 }
 
-type GetSymbolTypesRequest struct {
+type SetKillSwitchRequest struct {
+	Engaged              bool     `protobuf:"varint,1,opt,name=engaged,proto3" json:"engaged,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetSymbolTypesRequest) Reset()                    { *m = GetSymbolTypesRequest{} }
-func (m *GetSymbolTypesRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetSymbolTypesRequest) ProtoMessage()               {}
-func (*GetSymbolTypesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *SetKillSwitchRequest) Reset()         { *m = SetKillSwitchRequest{} }
+func (m *SetKillSwitchRequest) String() string { return proto.CompactTextString(m) }
+func (*SetKillSwitchRequest) ProtoMessage()    {}
+func (*SetKillSwitchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+}
 
-type GetSymbolTypesResponse struct {
-	SymbolTypes []*SymbolType `protobuf:"bytes,1,rep,name=symbolTypes" json:"symbolTypes,omitempty"`
+func (m *SetKillSwitchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetKillSwitchRequest.Unmarshal(m, b)
+}
+func (m *SetKillSwitchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetKillSwitchRequest.Marshal(b, m, deterministic)
+}
+func (m *SetKillSwitchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetKillSwitchRequest.Merge(m, src)
+}
+func (m *SetKillSwitchRequest) XXX_Size() int {
+	return xxx_messageInfo_SetKillSwitchRequest.Size(m)
+}
+func (m *SetKillSwitchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetKillSwitchRequest.DiscardUnknown(m)
 }
 
-func (m *GetSymbolTypesResponse) Reset()                    { *m = GetSymbolTypesResponse{} }
-func (m *GetSymbolTypesResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetSymbolTypesResponse) ProtoMessage()               {}
-func (*GetSymbolTypesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+var xxx_messageInfo_SetKillSwitchRequest proto.InternalMessageInfo
 
-func (m *GetSymbolTypesResponse) GetSymbolTypes() []*SymbolType {
+func (m *SetKillSwitchRequest) GetEngaged() bool {
 	if m != nil {
-		return m.SymbolTypes
+		return m.Engaged
 	}
-	return nil
+	return false
 }
 
-type LogEntry struct {
-	Time *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
-	Text string                     `protobuf:"bytes,2,opt,name=text" json:"text,omitempty"`
+func (m *SetKillSwitchRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type SetKillSwitchResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetKillSwitchResponse) Reset()         { *m = SetKillSwitchResponse{} }
+func (m *SetKillSwitchResponse) String() string { return proto.CompactTextString(m) }
+func (*SetKillSwitchResponse) ProtoMessage()    {}
+func (*SetKillSwitchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+}
+
+func (m *SetKillSwitchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetKillSwitchResponse.Unmarshal(m, b)
+}
+func (m *SetKillSwitchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetKillSwitchResponse.Marshal(b, m, deterministic)
+}
+func (m *SetKillSwitchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetKillSwitchResponse.Merge(m, src)
+}
+func (m *SetKillSwitchResponse) XXX_Size() int {
+	return xxx_messageInfo_SetKillSwitchResponse.Size(m)
+}
+func (m *SetKillSwitchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetKillSwitchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetKillSwitchResponse proto.InternalMessageInfo
+
+type Simulation struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsRunning            bool                 `protobuf:"varint,3,opt,name=isRunning,proto3" json:"isRunning,omitempty"`
+	StartedTime          *timestamp.Timestamp `protobuf:"bytes,4,opt,name=startedTime,proto3" json:"startedTime,omitempty"`
+	StoppedTime          *timestamp.Timestamp `protobuf:"bytes,5,opt,name=stoppedTime,proto3" json:"stoppedTime,omitempty"`
+	UseHistoricalData    bool                 `protobuf:"varint,6,opt,name=useHistoricalData,proto3" json:"useHistoricalData,omitempty"`
+	FromTime             *timestamp.Timestamp `protobuf:"bytes,7,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime               *timestamp.Timestamp `protobuf:"bytes,8,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	DataFrequency        int32                `protobuf:"varint,9,opt,name=dataFrequency,proto3" json:"dataFrequency,omitempty"`
+	UseRealtimeData      bool                 `protobuf:"varint,10,opt,name=useRealtimeData,proto3" json:"useRealtimeData,omitempty"`
+	Portfolio            *Portfolio           `protobuf:"bytes,11,opt,name=portfolio,proto3" json:"portfolio,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Simulation) Reset()         { *m = Simulation{} }
+func (m *Simulation) String() string { return proto.CompactTextString(m) }
+func (*Simulation) ProtoMessage()    {}
+func (*Simulation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+}
+
+func (m *Simulation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Simulation.Unmarshal(m, b)
+}
+func (m *Simulation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Simulation.Marshal(b, m, deterministic)
+}
+func (m *Simulation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Simulation.Merge(m, src)
+}
+func (m *Simulation) XXX_Size() int {
+	return xxx_messageInfo_Simulation.Size(m)
+}
+func (m *Simulation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Simulation.DiscardUnknown(m)
 }
 
-func (m *LogEntry) Reset()                    { *m = LogEntry{} }
-func (m *LogEntry) String() string            { return proto1.CompactTextString(m) }
-func (*LogEntry) ProtoMessage()               {}
-func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+var xxx_messageInfo_Simulation proto.InternalMessageInfo
 
-func (m *LogEntry) GetTime() *google_protobuf.Timestamp {
+func (m *Simulation) GetId() string {
 	if m != nil {
-		return m.Time
+		return m.Id
 	}
-	return nil
+	return ""
 }
 
-func (m *LogEntry) GetText() string {
+func (m *Simulation) GetName() string {
 	if m != nil {
-		return m.Text
+		return m.Name
 	}
 	return ""
 }
 
-type Portfolio struct {
-	Name     string     `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Balances []*Balance `protobuf:"bytes,2,rep,name=balances" json:"balances,omitempty"`
+func (m *Simulation) GetIsRunning() bool {
+	if m != nil {
+		return m.IsRunning
+	}
+	return false
 }
 
-func (m *Portfolio) Reset()                    { *m = Portfolio{} }
-func (m *Portfolio) String() string            { return proto1.CompactTextString(m) }
-func (*Portfolio) ProtoMessage()               {}
-func (*Portfolio) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (m *Simulation) GetStartedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedTime
+	}
+	return nil
+}
 
-func (m *Portfolio) GetName() string {
+func (m *Simulation) GetStoppedTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.Name
+		return m.StoppedTime
 	}
-	return ""
+	return nil
 }
 
-func (m *Portfolio) GetBalances() []*Balance {
+func (m *Simulation) GetUseHistoricalData() bool {
 	if m != nil {
-		return m.Balances
+		return m.UseHistoricalData
+	}
+	return false
+}
+
+func (m *Simulation) GetFromTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.FromTime
 	}
 	return nil
 }
 
-type Price struct {
-	Symbol         string                     `protobuf:"bytes,1,opt,name=symbol" json:"symbol,omitempty"`
-	Exchange       string                     `protobuf:"bytes,2,opt,name=exchange" json:"exchange,omitempty"`
-	As             string                     `protobuf:"bytes,3,opt,name=as" json:"as,omitempty"`
-	Current        float32                    `protobuf:"fixed32,4,opt,name=current" json:"current,omitempty"`
-	At             *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=at" json:"at,omitempty"`
-	ChangeToday    float32                    `protobuf:"fixed32,6,opt,name=changeToday" json:"changeToday,omitempty"`
-	ChangePctToday float32                    `protobuf:"fixed32,7,opt,name=changePctToday" json:"changePctToday,omitempty"`
-	Opening        float32                    `protobuf:"fixed32,8,opt,name=opening" json:"opening,omitempty"`
-	Closing        float32                    `protobuf:"fixed32,9,opt,name=closing" json:"closing,omitempty"`
-	Highest        float32                    `protobuf:"fixed32,10,opt,name=highest" json:"highest,omitempty"`
-	Lowest         float32                    `protobuf:"fixed32,11,opt,name=lowest" json:"lowest,omitempty"`
-	Change24H      float32                    `protobuf:"fixed32,12,opt,name=change24h" json:"change24h,omitempty"`
-	ChangePct24H   float32                    `protobuf:"fixed32,13,opt,name=changePct24h" json:"changePct24h,omitempty"`
-}
-
-func (m *Price) Reset()                    { *m = Price{} }
-func (m *Price) String() string            { return proto1.CompactTextString(m) }
-func (*Price) ProtoMessage()               {}
-func (*Price) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (m *Simulation) GetToTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ToTime
+	}
+	return nil
+}
 
-func (m *Price) GetSymbol() string {
+func (m *Simulation) GetDataFrequency() int32 {
 	if m != nil {
-		return m.Symbol
+		return m.DataFrequency
 	}
-	return ""
+	return 0
 }
 
-func (m *Price) GetExchange() string {
+func (m *Simulation) GetUseRealtimeData() bool {
 	if m != nil {
-		return m.Exchange
+		return m.UseRealtimeData
+	}
+	return false
+}
+
+func (m *Simulation) GetPortfolio() *Portfolio {
+	if m != nil {
+		return m.Portfolio
+	}
+	return nil
+}
+
+type SimulationResult struct {
+	Id               string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	As               string               `protobuf:"bytes,2,opt,name=as,proto3" json:"as,omitempty"`
+	FromTime         *timestamp.Timestamp `protobuf:"bytes,3,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	StartValue       float32              `protobuf:"fixed32,5,opt,name=startValue,proto3" json:"startValue,omitempty"`
+	EndValue         float32              `protobuf:"fixed32,6,opt,name=endValue,proto3" json:"endValue,omitempty"`
+	TotalReturn      float32              `protobuf:"fixed32,7,opt,name=totalReturn,proto3" json:"totalReturn,omitempty"`
+	AnnualisedReturn float32              `protobuf:"fixed32,8,opt,name=annualisedReturn,proto3" json:"annualisedReturn,omitempty"`
+	MaxDrawdown      float32              `protobuf:"fixed32,9,opt,name=maxDrawdown,proto3" json:"maxDrawdown,omitempty"`
+	Volatility       float32              `protobuf:"fixed32,10,opt,name=volatility,proto3" json:"volatility,omitempty"`
+	SharpeRatio      float32              `protobuf:"fixed32,11,opt,name=sharpeRatio,proto3" json:"sharpeRatio,omitempty"`
+	SortinoRatio     float32              `protobuf:"fixed32,12,opt,name=sortinoRatio,proto3" json:"sortinoRatio,omitempty"`
+	Trades           int32                `protobuf:"varint,13,opt,name=trades,proto3" json:"trades,omitempty"`
+	WinRate          float32              `protobuf:"fixed32,14,opt,name=winRate,proto3" json:"winRate,omitempty"`
+	// buy-and-hold benchmark, the portfolio at the start held unchanged
+	BenchmarkStartValue   float32  `protobuf:"fixed32,15,opt,name=benchmarkStartValue,proto3" json:"benchmarkStartValue,omitempty"`
+	BenchmarkEndValue     float32  `protobuf:"fixed32,16,opt,name=benchmarkEndValue,proto3" json:"benchmarkEndValue,omitempty"`
+	BenchmarkReturn       float32  `protobuf:"fixed32,17,opt,name=benchmarkReturn,proto3" json:"benchmarkReturn,omitempty"`
+	SyntheticPricePercent float32  `protobuf:"fixed32,18,opt,name=syntheticPricePercent,proto3" json:"syntheticPricePercent,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *SimulationResult) Reset()         { *m = SimulationResult{} }
+func (m *SimulationResult) String() string { return proto.CompactTextString(m) }
+func (*SimulationResult) ProtoMessage()    {}
+func (*SimulationResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+}
+
+func (m *SimulationResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulationResult.Unmarshal(m, b)
+}
+func (m *SimulationResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulationResult.Marshal(b, m, deterministic)
+}
+func (m *SimulationResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulationResult.Merge(m, src)
+}
+func (m *SimulationResult) XXX_Size() int {
+	return xxx_messageInfo_SimulationResult.Size(m)
+}
+func (m *SimulationResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulationResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulationResult proto.InternalMessageInfo
+
+func (m *SimulationResult) GetId() string {
+	if m != nil {
+		return m.Id
 	}
 	return ""
 }
 
-func (m *Price) GetAs() string {
+func (m *SimulationResult) GetAs() string {
 	if m != nil {
 		return m.As
 	}
 	return ""
 }
 
-func (m *Price) GetCurrent() float32 {
+func (m *SimulationResult) GetFromTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.Current
+		return m.FromTime
 	}
-	return 0
+	return nil
 }
 
-func (m *Price) GetAt() *google_protobuf.Timestamp {
+func (m *SimulationResult) GetToTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.At
+		return m.ToTime
 	}
 	return nil
 }
 
-func (m *Price) GetChangeToday() float32 {
+func (m *SimulationResult) GetStartValue() float32 {
 	if m != nil {
-		return m.ChangeToday
+		return m.StartValue
 	}
 	return 0
 }
 
-func (m *Price) GetChangePctToday() float32 {
+func (m *SimulationResult) GetEndValue() float32 {
 	if m != nil {
-		return m.ChangePctToday
+		return m.EndValue
 	}
 	return 0
 }
 
-func (m *Price) GetOpening() float32 {
+func (m *SimulationResult) GetTotalReturn() float32 {
 	if m != nil {
-		return m.Opening
+		return m.TotalReturn
 	}
 	return 0
 }
 
-func (m *Price) GetClosing() float32 {
+func (m *SimulationResult) GetAnnualisedReturn() float32 {
 	if m != nil {
-		return m.Closing
+		return m.AnnualisedReturn
 	}
 	return 0
 }
 
-func (m *Price) GetHighest() float32 {
+func (m *SimulationResult) GetMaxDrawdown() float32 {
 	if m != nil {
-		return m.Highest
+		return m.MaxDrawdown
 	}
 	return 0
 }
 
-func (m *Price) GetLowest() float32 {
+func (m *SimulationResult) GetVolatility() float32 {
 	if m != nil {
-		return m.Lowest
+		return m.Volatility
 	}
 	return 0
 }
 
-func (m *Price) GetChange24H() float32 {
+func (m *SimulationResult) GetSharpeRatio() float32 {
 	if m != nil {
-		return m.Change24H
+		return m.SharpeRatio
 	}
 	return 0
 }
 
-func (m *Price) GetChangePct24H() float32 {
+func (m *SimulationResult) GetSortinoRatio() float32 {
 	if m != nil {
-		return m.ChangePct24H
+		return m.SortinoRatio
 	}
 	return 0
 }
 
-type RebuildRequest struct {
-}
-
-func (m *RebuildRequest) Reset()                    { *m = RebuildRequest{} }
-func (m *RebuildRequest) String() string            { return proto1.CompactTextString(m) }
-func (*RebuildRequest) ProtoMessage()               {}
-func (*RebuildRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
-
-type RebuildResponse struct {
-	Result string `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
-}
-
-func (m *RebuildResponse) Reset()                    { *m = RebuildResponse{} }
-func (m *RebuildResponse) String() string            { return proto1.CompactTextString(m) }
-func (*RebuildResponse) ProtoMessage()               {}
-func (*RebuildResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
-
-func (m *RebuildResponse) GetResult() string {
+func (m *SimulationResult) GetTrades() int32 {
 	if m != nil {
-		return m.Result
+		return m.Trades
 	}
-	return ""
+	return 0
 }
 
-type Simulation struct {
-	Id                string                     `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	Name              string                     `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	IsRunning         bool                       `protobuf:"varint,3,opt,name=isRunning" json:"isRunning,omitempty"`
-	StartedTime       *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=startedTime" json:"startedTime,omitempty"`
-	StoppedTime       *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=stoppedTime" json:"stoppedTime,omitempty"`
-	UseHistoricalData bool                       `protobuf:"varint,6,opt,name=useHistoricalData" json:"useHistoricalData,omitempty"`
-	FromTime          *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=fromTime" json:"fromTime,omitempty"`
-	ToTime            *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=toTime" json:"toTime,omitempty"`
-	DataFrequency     int32                      `protobuf:"varint,9,opt,name=dataFrequency" json:"dataFrequency,omitempty"`
-	UseRealtimeData   bool                       `protobuf:"varint,10,opt,name=useRealtimeData" json:"useRealtimeData,omitempty"`
-	Portfolio         *Portfolio                 `protobuf:"bytes,11,opt,name=portfolio" json:"portfolio,omitempty"`
-}
-
-func (m *Simulation) Reset()                    { *m = Simulation{} }
-func (m *Simulation) String() string            { return proto1.CompactTextString(m) }
-func (*Simulation) ProtoMessage()               {}
-func (*Simulation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
-
-func (m *Simulation) GetId() string {
+func (m *SimulationResult) GetWinRate() float32 {
 	if m != nil {
-		return m.Id
+		return m.WinRate
 	}
-	return ""
+	return 0
 }
 
-func (m *Simulation) GetName() string {
+func (m *SimulationResult) GetBenchmarkStartValue() float32 {
 	if m != nil {
-		return m.Name
+		return m.BenchmarkStartValue
 	}
-	return ""
+	return 0
 }
 
-func (m *Simulation) GetIsRunning() bool {
+func (m *SimulationResult) GetBenchmarkEndValue() float32 {
 	if m != nil {
-		return m.IsRunning
+		return m.BenchmarkEndValue
 	}
-	return false
+	return 0
 }
 
-func (m *Simulation) GetStartedTime() *google_protobuf.Timestamp {
+func (m *SimulationResult) GetBenchmarkReturn() float32 {
 	if m != nil {
-		return m.StartedTime
+		return m.BenchmarkReturn
 	}
-	return nil
+	return 0
 }
 
-func (m *Simulation) GetStoppedTime() *google_protobuf.Timestamp {
+func (m *SimulationResult) GetSyntheticPricePercent() float32 {
 	if m != nil {
-		return m.StoppedTime
+		return m.SyntheticPricePercent
 	}
-	return nil
+	return 0
 }
 
-func (m *Simulation) GetUseHistoricalData() bool {
+type StartBackfillRequest struct {
+	Pairs                []string             `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	FromTime             *timestamp.Timestamp `protobuf:"bytes,2,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime               *timestamp.Timestamp `protobuf:"bytes,3,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Interval             string               `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StartBackfillRequest) Reset()         { *m = StartBackfillRequest{} }
+func (m *StartBackfillRequest) String() string { return proto.CompactTextString(m) }
+func (*StartBackfillRequest) ProtoMessage()    {}
+func (*StartBackfillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+}
+
+func (m *StartBackfillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartBackfillRequest.Unmarshal(m, b)
+}
+func (m *StartBackfillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartBackfillRequest.Marshal(b, m, deterministic)
+}
+func (m *StartBackfillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartBackfillRequest.Merge(m, src)
+}
+func (m *StartBackfillRequest) XXX_Size() int {
+	return xxx_messageInfo_StartBackfillRequest.Size(m)
+}
+func (m *StartBackfillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartBackfillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartBackfillRequest proto.InternalMessageInfo
+
+func (m *StartBackfillRequest) GetPairs() []string {
 	if m != nil {
-		return m.UseHistoricalData
+		return m.Pairs
 	}
-	return false
+	return nil
 }
 
-func (m *Simulation) GetFromTime() *google_protobuf.Timestamp {
+func (m *StartBackfillRequest) GetFromTime() *timestamp.Timestamp {
 	if m != nil {
 		return m.FromTime
 	}
 	return nil
 }
 
-func (m *Simulation) GetToTime() *google_protobuf.Timestamp {
+func (m *StartBackfillRequest) GetToTime() *timestamp.Timestamp {
 	if m != nil {
 		return m.ToTime
 	}
 	return nil
 }
 
-func (m *Simulation) GetDataFrequency() int32 {
+func (m *StartBackfillRequest) GetInterval() string {
 	if m != nil {
-		return m.DataFrequency
+		return m.Interval
 	}
-	return 0
+	return ""
 }
 
-func (m *Simulation) GetUseRealtimeData() bool {
-	if m != nil {
-		return m.UseRealtimeData
-	}
-	return false
+type StartBackfillResponse struct {
+	Backfill             *Backfill `protobuf:"bytes,1,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *Simulation) GetPortfolio() *Portfolio {
+func (m *StartBackfillResponse) Reset()         { *m = StartBackfillResponse{} }
+func (m *StartBackfillResponse) String() string { return proto.CompactTextString(m) }
+func (*StartBackfillResponse) ProtoMessage()    {}
+func (*StartBackfillResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+}
+
+func (m *StartBackfillResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartBackfillResponse.Unmarshal(m, b)
+}
+func (m *StartBackfillResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartBackfillResponse.Marshal(b, m, deterministic)
+}
+func (m *StartBackfillResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartBackfillResponse.Merge(m, src)
+}
+func (m *StartBackfillResponse) XXX_Size() int {
+	return xxx_messageInfo_StartBackfillResponse.Size(m)
+}
+func (m *StartBackfillResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartBackfillResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartBackfillResponse proto.InternalMessageInfo
+
+func (m *StartBackfillResponse) GetBackfill() *Backfill {
 	if m != nil {
-		return m.Portfolio
+		return m.Backfill
 	}
 	return nil
 }
 
 type StartSimulationRequest struct {
-	Id   string                            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	When StartSimulationRequestWhenOptions `protobuf:"varint,2,opt,name=when,enum=proto.StartSimulationRequestWhenOptions" json:"when,omitempty"`
+	Id                   string                            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	When                 StartSimulationRequestWhenOptions `protobuf:"varint,2,opt,name=when,proto3,enum=proto.StartSimulationRequestWhenOptions" json:"when,omitempty"`
+	FromTime             *timestamp.Timestamp              `protobuf:"bytes,3,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime               *timestamp.Timestamp              `protobuf:"bytes,4,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	DataFrequency        int32                             `protobuf:"varint,5,opt,name=dataFrequency,proto3" json:"dataFrequency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *StartSimulationRequest) Reset()         { *m = StartSimulationRequest{} }
+func (m *StartSimulationRequest) String() string { return proto.CompactTextString(m) }
+func (*StartSimulationRequest) ProtoMessage()    {}
+func (*StartSimulationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+}
+
+func (m *StartSimulationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartSimulationRequest.Unmarshal(m, b)
+}
+func (m *StartSimulationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartSimulationRequest.Marshal(b, m, deterministic)
+}
+func (m *StartSimulationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartSimulationRequest.Merge(m, src)
+}
+func (m *StartSimulationRequest) XXX_Size() int {
+	return xxx_messageInfo_StartSimulationRequest.Size(m)
+}
+func (m *StartSimulationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartSimulationRequest.DiscardUnknown(m)
 }
 
-func (m *StartSimulationRequest) Reset()                    { *m = StartSimulationRequest{} }
-func (m *StartSimulationRequest) String() string            { return proto1.CompactTextString(m) }
-func (*StartSimulationRequest) ProtoMessage()               {}
-func (*StartSimulationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+var xxx_messageInfo_StartSimulationRequest proto.InternalMessageInfo
 
 func (m *StartSimulationRequest) GetId() string {
 	if m != nil {
@@ -772,22 +2797,89 @@ func (m *StartSimulationRequest) GetWhen() StartSimulationRequestWhenOptions {
 	return StartSimulationRequest_NOW_REALTIME
 }
 
-type StartSimulationResponse struct {
+func (m *StartSimulationRequest) GetFromTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.FromTime
+	}
+	return nil
+}
+
+func (m *StartSimulationRequest) GetToTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ToTime
+	}
+	return nil
+}
+
+func (m *StartSimulationRequest) GetDataFrequency() int32 {
+	if m != nil {
+		return m.DataFrequency
+	}
+	return 0
+}
+
+type StartSimulationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartSimulationResponse) Reset()         { *m = StartSimulationResponse{} }
+func (m *StartSimulationResponse) String() string { return proto.CompactTextString(m) }
+func (*StartSimulationResponse) ProtoMessage()    {}
+func (*StartSimulationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+}
+
+func (m *StartSimulationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartSimulationResponse.Unmarshal(m, b)
+}
+func (m *StartSimulationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartSimulationResponse.Marshal(b, m, deterministic)
+}
+func (m *StartSimulationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartSimulationResponse.Merge(m, src)
+}
+func (m *StartSimulationResponse) XXX_Size() int {
+	return xxx_messageInfo_StartSimulationResponse.Size(m)
+}
+func (m *StartSimulationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartSimulationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartSimulationResponse proto.InternalMessageInfo
+
+type StopSimulationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopSimulationRequest) Reset()         { *m = StopSimulationRequest{} }
+func (m *StopSimulationRequest) String() string { return proto.CompactTextString(m) }
+func (*StopSimulationRequest) ProtoMessage()    {}
+func (*StopSimulationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
 }
 
-func (m *StartSimulationResponse) Reset()                    { *m = StartSimulationResponse{} }
-func (m *StartSimulationResponse) String() string            { return proto1.CompactTextString(m) }
-func (*StartSimulationResponse) ProtoMessage()               {}
-func (*StartSimulationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
-
-type StopSimulationRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+func (m *StopSimulationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopSimulationRequest.Unmarshal(m, b)
+}
+func (m *StopSimulationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopSimulationRequest.Marshal(b, m, deterministic)
+}
+func (m *StopSimulationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopSimulationRequest.Merge(m, src)
+}
+func (m *StopSimulationRequest) XXX_Size() int {
+	return xxx_messageInfo_StopSimulationRequest.Size(m)
+}
+func (m *StopSimulationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopSimulationRequest.DiscardUnknown(m)
 }
 
-func (m *StopSimulationRequest) Reset()                    { *m = StopSimulationRequest{} }
-func (m *StopSimulationRequest) String() string            { return proto1.CompactTextString(m) }
-func (*StopSimulationRequest) ProtoMessage()               {}
-func (*StopSimulationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+var xxx_messageInfo_StopSimulationRequest proto.InternalMessageInfo
 
 func (m *StopSimulationRequest) GetId() string {
 	if m != nil {
@@ -797,26 +2889,74 @@ func (m *StopSimulationRequest) GetId() string {
 }
 
 type StopSimulationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopSimulationResponse) Reset()         { *m = StopSimulationResponse{} }
+func (m *StopSimulationResponse) String() string { return proto.CompactTextString(m) }
+func (*StopSimulationResponse) ProtoMessage()    {}
+func (*StopSimulationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
+}
+
+func (m *StopSimulationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopSimulationResponse.Unmarshal(m, b)
+}
+func (m *StopSimulationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopSimulationResponse.Marshal(b, m, deterministic)
+}
+func (m *StopSimulationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopSimulationResponse.Merge(m, src)
+}
+func (m *StopSimulationResponse) XXX_Size() int {
+	return xxx_messageInfo_StopSimulationResponse.Size(m)
+}
+func (m *StopSimulationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopSimulationResponse.DiscardUnknown(m)
 }
 
-func (m *StopSimulationResponse) Reset()                    { *m = StopSimulationResponse{} }
-func (m *StopSimulationResponse) String() string            { return proto1.CompactTextString(m) }
-func (*StopSimulationResponse) ProtoMessage()               {}
-func (*StopSimulationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+var xxx_messageInfo_StopSimulationResponse proto.InternalMessageInfo
 
 type Strategy struct {
-	Id          string  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	Description string  `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
-	CoinPercent float32 `protobuf:"fixed32,3,opt,name=coinPercent" json:"coinPercent,omitempty"`
-	Symbol      string  `protobuf:"bytes,4,opt,name=symbol" json:"symbol,omitempty"`
-	As          string  `protobuf:"bytes,5,opt,name=as" json:"as,omitempty"`
-	IsRunning   bool    `protobuf:"varint,6,opt,name=isRunning" json:"isRunning,omitempty"`
+	Id                   string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description          string             `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	CoinPercent          float32            `protobuf:"fixed32,3,opt,name=coinPercent,proto3" json:"coinPercent,omitempty"`
+	Symbol               string             `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	As                   string             `protobuf:"bytes,5,opt,name=as,proto3" json:"as,omitempty"`
+	IsRunning            bool               `protobuf:"varint,6,opt,name=isRunning,proto3" json:"isRunning,omitempty"`
+	Type                 string             `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	Params               map[string]float64 `protobuf:"bytes,8,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Strategy) Reset()         { *m = Strategy{} }
+func (m *Strategy) String() string { return proto.CompactTextString(m) }
+func (*Strategy) ProtoMessage()    {}
+func (*Strategy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+}
+
+func (m *Strategy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Strategy.Unmarshal(m, b)
+}
+func (m *Strategy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Strategy.Marshal(b, m, deterministic)
+}
+func (m *Strategy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Strategy.Merge(m, src)
+}
+func (m *Strategy) XXX_Size() int {
+	return xxx_messageInfo_Strategy.Size(m)
+}
+func (m *Strategy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Strategy.DiscardUnknown(m)
 }
 
-func (m *Strategy) Reset()                    { *m = Strategy{} }
-func (m *Strategy) String() string            { return proto1.CompactTextString(m) }
-func (*Strategy) ProtoMessage()               {}
-func (*Strategy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+var xxx_messageInfo_Strategy proto.InternalMessageInfo
 
 func (m *Strategy) GetId() string {
 	if m != nil {
@@ -860,15 +3000,52 @@ func (m *Strategy) GetIsRunning() bool {
 	return false
 }
 
+func (m *Strategy) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Strategy) GetParams() map[string]float64 {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
 type SymbolType struct {
-	Base string   `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
-	As   []string `protobuf:"bytes,2,rep,name=as" json:"as,omitempty"`
+	Base                 string   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	As                   []string `protobuf:"bytes,2,rep,name=as,proto3" json:"as,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SymbolType) Reset()         { *m = SymbolType{} }
+func (m *SymbolType) String() string { return proto.CompactTextString(m) }
+func (*SymbolType) ProtoMessage()    {}
+func (*SymbolType) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
+}
+
+func (m *SymbolType) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SymbolType.Unmarshal(m, b)
+}
+func (m *SymbolType) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SymbolType.Marshal(b, m, deterministic)
+}
+func (m *SymbolType) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SymbolType.Merge(m, src)
+}
+func (m *SymbolType) XXX_Size() int {
+	return xxx_messageInfo_SymbolType.Size(m)
+}
+func (m *SymbolType) XXX_DiscardUnknown() {
+	xxx_messageInfo_SymbolType.DiscardUnknown(m)
 }
 
-func (m *SymbolType) Reset()                    { *m = SymbolType{} }
-func (m *SymbolType) String() string            { return proto1.CompactTextString(m) }
-func (*SymbolType) ProtoMessage()               {}
-func (*SymbolType) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+var xxx_messageInfo_SymbolType proto.InternalMessageInfo
 
 func (m *SymbolType) GetBase() string {
 	if m != nil {
@@ -885,75 +3062,305 @@ func (m *SymbolType) GetAs() []string {
 }
 
 func init() {
-	proto1.RegisterType((*Balance)(nil), "proto.Balance")
-	proto1.RegisterType((*CreateSimulationRequest)(nil), "proto.CreateSimulationRequest")
-	proto1.RegisterType((*CreateSimulationResponse)(nil), "proto.CreateSimulationResponse")
-	proto1.RegisterType((*GetLogRequest)(nil), "proto.GetLogRequest")
-	proto1.RegisterType((*GetLogResponse)(nil), "proto.GetLogResponse")
-	proto1.RegisterType((*GetPortfolioRequest)(nil), "proto.GetPortfolioRequest")
-	proto1.RegisterType((*GetPortfolioResponse)(nil), "proto.GetPortfolioResponse")
-	proto1.RegisterType((*GetPricesRequest)(nil), "proto.GetPricesRequest")
-	proto1.RegisterType((*GetPricesResponse)(nil), "proto.GetPricesResponse")
-	proto1.RegisterType((*GetSimulationsRequest)(nil), "proto.GetSimulationsRequest")
-	proto1.RegisterType((*GetSimulationsResponse)(nil), "proto.GetSimulationsResponse")
-	proto1.RegisterType((*GetStatusRequest)(nil), "proto.GetStatusRequest")
-	proto1.RegisterType((*GetStatusResponse)(nil), "proto.GetStatusResponse")
-	proto1.RegisterType((*GetSymbolTypesRequest)(nil), "proto.GetSymbolTypesRequest")
-	proto1.RegisterType((*GetSymbolTypesResponse)(nil), "proto.GetSymbolTypesResponse")
-	proto1.RegisterType((*LogEntry)(nil), "proto.LogEntry")
-	proto1.RegisterType((*Portfolio)(nil), "proto.Portfolio")
-	proto1.RegisterType((*Price)(nil), "proto.Price")
-	proto1.RegisterType((*RebuildRequest)(nil), "proto.RebuildRequest")
-	proto1.RegisterType((*RebuildResponse)(nil), "proto.RebuildResponse")
-	proto1.RegisterType((*Simulation)(nil), "proto.Simulation")
-	proto1.RegisterType((*StartSimulationRequest)(nil), "proto.StartSimulationRequest")
-	proto1.RegisterType((*StartSimulationResponse)(nil), "proto.StartSimulationResponse")
-	proto1.RegisterType((*StopSimulationRequest)(nil), "proto.StopSimulationRequest")
-	proto1.RegisterType((*StopSimulationResponse)(nil), "proto.StopSimulationResponse")
-	proto1.RegisterType((*Strategy)(nil), "proto.Strategy")
-	proto1.RegisterType((*SymbolType)(nil), "proto.SymbolType")
-	proto1.RegisterEnum("proto.StartSimulationRequestWhenOptions", StartSimulationRequestWhenOptions_name, StartSimulationRequestWhenOptions_value)
+	proto.RegisterEnum("proto.StrategySlot", StrategySlot_name, StrategySlot_value)
+	proto.RegisterEnum("proto.StartSimulationRequestWhenOptions", StartSimulationRequestWhenOptions_name, StartSimulationRequestWhenOptions_value)
+	proto.RegisterType((*AttachedStrategy)(nil), "proto.AttachedStrategy")
+	proto.RegisterType((*AttachStrategyRequest)(nil), "proto.AttachStrategyRequest")
+	proto.RegisterType((*AttachStrategyResponse)(nil), "proto.AttachStrategyResponse")
+	proto.RegisterType((*Backfill)(nil), "proto.Backfill")
+	proto.RegisterType((*Balance)(nil), "proto.Balance")
+	proto.RegisterType((*CreateSimulationRequest)(nil), "proto.CreateSimulationRequest")
+	proto.RegisterType((*CreateSimulationResponse)(nil), "proto.CreateSimulationResponse")
+	proto.RegisterType((*CreateStrategyRequest)(nil), "proto.CreateStrategyRequest")
+	proto.RegisterMapType((map[string]float64)(nil), "proto.CreateStrategyRequest.ParamsEntry")
+	proto.RegisterType((*CreateStrategyResponse)(nil), "proto.CreateStrategyResponse")
+	proto.RegisterType((*DetachStrategyRequest)(nil), "proto.DetachStrategyRequest")
+	proto.RegisterType((*DetachStrategyResponse)(nil), "proto.DetachStrategyResponse")
+	proto.RegisterType((*ExchangeStatus)(nil), "proto.ExchangeStatus")
+	proto.RegisterType((*GetBackfillsRequest)(nil), "proto.GetBackfillsRequest")
+	proto.RegisterType((*GetBackfillsResponse)(nil), "proto.GetBackfillsResponse")
+	proto.RegisterType((*GetLogRequest)(nil), "proto.GetLogRequest")
+	proto.RegisterType((*GetLogResponse)(nil), "proto.GetLogResponse")
+	proto.RegisterType((*GetPortfolioRequest)(nil), "proto.GetPortfolioRequest")
+	proto.RegisterType((*GetPortfolioResponse)(nil), "proto.GetPortfolioResponse")
+	proto.RegisterType((*GetPriceGapsRequest)(nil), "proto.GetPriceGapsRequest")
+	proto.RegisterType((*GetPriceGapsResponse)(nil), "proto.GetPriceGapsResponse")
+	proto.RegisterType((*GetPricesRequest)(nil), "proto.GetPricesRequest")
+	proto.RegisterType((*GetPricesResponse)(nil), "proto.GetPricesResponse")
+	proto.RegisterType((*GetSimulationResultRequest)(nil), "proto.GetSimulationResultRequest")
+	proto.RegisterType((*GetSimulationResultResponse)(nil), "proto.GetSimulationResultResponse")
+	proto.RegisterType((*GetSimulationsRequest)(nil), "proto.GetSimulationsRequest")
+	proto.RegisterType((*GetSimulationsResponse)(nil), "proto.GetSimulationsResponse")
+	proto.RegisterType((*GetStatusRequest)(nil), "proto.GetStatusRequest")
+	proto.RegisterType((*GetStatusResponse)(nil), "proto.GetStatusResponse")
+	proto.RegisterType((*GetStrategiesRequest)(nil), "proto.GetStrategiesRequest")
+	proto.RegisterType((*GetStrategiesResponse)(nil), "proto.GetStrategiesResponse")
+	proto.RegisterType((*GetSymbolTypesRequest)(nil), "proto.GetSymbolTypesRequest")
+	proto.RegisterType((*GetSymbolTypesResponse)(nil), "proto.GetSymbolTypesResponse")
+	proto.RegisterType((*LogEntry)(nil), "proto.LogEntry")
+	proto.RegisterType((*Portfolio)(nil), "proto.Portfolio")
+	proto.RegisterType((*Price)(nil), "proto.Price")
+	proto.RegisterType((*PriceGap)(nil), "proto.PriceGap")
+	proto.RegisterType((*RebuildRequest)(nil), "proto.RebuildRequest")
+	proto.RegisterType((*RebuildResponse)(nil), "proto.RebuildResponse")
+	proto.RegisterType((*RiskStatus)(nil), "proto.RiskStatus")
+	proto.RegisterMapType((map[string]float32)(nil), "proto.RiskStatus.MaxOrderSizeEntry")
+	proto.RegisterType((*SetKillSwitchRequest)(nil), "proto.SetKillSwitchRequest")
+	proto.RegisterType((*SetKillSwitchResponse)(nil), "proto.SetKillSwitchResponse")
+	proto.RegisterType((*Simulation)(nil), "proto.Simulation")
+	proto.RegisterType((*SimulationResult)(nil), "proto.SimulationResult")
+	proto.RegisterType((*StartBackfillRequest)(nil), "proto.StartBackfillRequest")
+	proto.RegisterType((*StartBackfillResponse)(nil), "proto.StartBackfillResponse")
+	proto.RegisterType((*StartSimulationRequest)(nil), "proto.StartSimulationRequest")
+	proto.RegisterType((*StartSimulationResponse)(nil), "proto.StartSimulationResponse")
+	proto.RegisterType((*StopSimulationRequest)(nil), "proto.StopSimulationRequest")
+	proto.RegisterType((*StopSimulationResponse)(nil), "proto.StopSimulationResponse")
+	proto.RegisterType((*Strategy)(nil), "proto.Strategy")
+	proto.RegisterMapType((map[string]float64)(nil), "proto.Strategy.ParamsEntry")
+	proto.RegisterType((*SymbolType)(nil), "proto.SymbolType")
+}
+
+func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+
+var fileDescriptor_00212fb1f9d3bf1c = []byte{
+	// 2790 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x1a, 0xdd, 0x76, 0x1b, 0x47,
+	0xb9, 0x5a, 0x49, 0xb6, 0xfc, 0xc9, 0xb1, 0xe5, 0x89, 0xed, 0x2c, 0x72, 0x92, 0xba, 0x5b, 0x4a,
+	0xdd, 0x50, 0xd4, 0xd6, 0xe9, 0x29, 0x34, 0x50, 0x68, 0x1c, 0xab, 0x71, 0x4e, 0x9d, 0xd8, 0xac,
+	0x1c, 0x7a, 0x7a, 0x43, 0xcf, 0x58, 0x3b, 0x96, 0xf6, 0x78, 0xb5, 0x2b, 0x76, 0x46, 0xb1, 0xc5,
+	0x81, 0x1b, 0xca, 0x73, 0x70, 0xc1, 0x15, 0x0f, 0xc0, 0x05, 0x97, 0x3d, 0xdc, 0xf1, 0x04, 0xdc,
+	0x72, 0xc1, 0x23, 0xf0, 0x00, 0x9c, 0xf9, 0xdd, 0xd9, 0xd5, 0x3a, 0x4a, 0x52, 0x4e, 0xb9, 0xb2,
+	0xbe, 0x9f, 0xf9, 0xe6, 0x9b, 0x99, 0xef, 0x7f, 0x0d, 0x4b, 0x78, 0x1c, 0x76, 0xc6, 0x69, 0xc2,
+	0x12, 0x54, 0x17, 0x7f, 0xda, 0xaf, 0x0f, 0x92, 0x64, 0x10, 0x91, 0xf7, 0x04, 0x74, 0x3a, 0x39,
+	0x7b, 0x8f, 0x85, 0x23, 0x42, 0x19, 0x1e, 0x8d, 0x25, 0x9f, 0xf7, 0xa7, 0x0a, 0xb4, 0xee, 0x33,
+	0x86, 0xfb, 0x43, 0x12, 0xf4, 0x58, 0x8a, 0x19, 0x19, 0x4c, 0xd1, 0x4d, 0x58, 0x1a, 0x27, 0x29,
+	0x3b, 0x4b, 0xa2, 0x30, 0x71, 0x2b, 0xdb, 0x95, 0x9d, 0x25, 0x3f, 0x43, 0xa0, 0x4d, 0x58, 0xa0,
+	0xd3, 0xd1, 0x69, 0x12, 0xb9, 0x8e, 0x20, 0x29, 0x08, 0xbd, 0x0d, 0x35, 0x1a, 0x25, 0xcc, 0xad,
+	0x6e, 0x57, 0x76, 0x56, 0x76, 0xaf, 0xcb, 0x0d, 0x3a, 0x5a, 0x68, 0x2f, 0x4a, 0x98, 0x2f, 0x18,
+	0xd0, 0x0f, 0xa1, 0x41, 0x15, 0xd6, 0xad, 0x6d, 0x57, 0x76, 0x9a, 0xbb, 0xab, 0x05, 0x66, 0xdf,
+	0x30, 0x78, 0x7f, 0xac, 0xc0, 0x86, 0x54, 0xd0, 0x10, 0xc9, 0x6f, 0x26, 0x84, 0x32, 0xe4, 0xc1,
+	0x32, 0x0d, 0x47, 0x93, 0x08, 0xb3, 0x30, 0x89, 0x1f, 0x05, 0x4a, 0xd1, 0x1c, 0x0e, 0xdd, 0x06,
+	0xd0, 0x92, 0x1e, 0x05, 0x4a, 0x5f, 0x0b, 0xf3, 0xc2, 0x3a, 0x7b, 0x2e, 0x6c, 0x16, 0xb5, 0xa0,
+	0xe3, 0x24, 0xa6, 0xc4, 0xfb, 0x7b, 0x15, 0x1a, 0x7b, 0xb8, 0x7f, 0x7e, 0x16, 0x46, 0x11, 0x5a,
+	0x01, 0x27, 0xd4, 0x9a, 0x38, 0x61, 0x80, 0xd6, 0xa1, 0x3e, 0xc6, 0x61, 0x4a, 0x5d, 0x67, 0xbb,
+	0xba, 0xb3, 0xe4, 0x4b, 0x00, 0x7d, 0x04, 0x8d, 0xb3, 0x34, 0x19, 0x9d, 0x84, 0x23, 0x22, 0x76,
+	0x6e, 0xee, 0xb6, 0x3b, 0xf2, 0xa1, 0x3a, 0xfa, 0xa1, 0x3a, 0x27, 0xfa, 0xa1, 0x7c, 0xc3, 0x8b,
+	0x76, 0x61, 0x81, 0x25, 0x62, 0x55, 0x6d, 0xee, 0x2a, 0xc5, 0x89, 0xda, 0xd0, 0x08, 0x63, 0x46,
+	0xd2, 0x67, 0x38, 0x72, 0xeb, 0x42, 0x2f, 0x03, 0x73, 0xed, 0x28, 0xc3, 0x8c, 0xb8, 0x0b, 0x82,
+	0x20, 0x01, 0xb4, 0x0d, 0xcd, 0xfe, 0x24, 0x4d, 0x49, 0xcc, 0x8e, 0x71, 0x98, 0xba, 0x8b, 0x82,
+	0x66, 0xa3, 0xb8, 0xcc, 0x71, 0x9a, 0x0c, 0x52, 0x42, 0xa9, 0xdb, 0xd8, 0xae, 0xec, 0x38, 0xbe,
+	0x81, 0xf9, 0xea, 0x71, 0x1a, 0xf6, 0x09, 0xbd, 0x1f, 0x04, 0x24, 0x70, 0x97, 0xb6, 0x2b, 0x3b,
+	0x75, 0xdf, 0x46, 0xf1, 0x5d, 0x49, 0x9a, 0x26, 0xa9, 0x0b, 0x72, 0x57, 0x01, 0xa0, 0x9f, 0x41,
+	0x93, 0x32, 0x9c, 0x32, 0x12, 0x88, 0x03, 0x36, 0xe7, 0x1e, 0xd0, 0x66, 0x47, 0x3f, 0x87, 0xe5,
+	0xb3, 0x30, 0x0e, 0xe9, 0x50, 0x2d, 0x5f, 0x9e, 0xbb, 0x3c, 0xc7, 0xef, 0xfd, 0xa3, 0x0a, 0x8b,
+	0x7b, 0x38, 0xc2, 0x71, 0x9f, 0x58, 0xf6, 0x5d, 0xc9, 0xd9, 0x77, 0x1b, 0x1a, 0xe4, 0xb2, 0x3f,
+	0xc4, 0xf1, 0x80, 0x28, 0x4b, 0x32, 0x30, 0x42, 0x50, 0x3b, 0x4b, 0x89, 0x7c, 0x4d, 0xc7, 0x17,
+	0xbf, 0xb9, 0x9c, 0x28, 0xe9, 0x9f, 0x93, 0x40, 0xbc, 0x96, 0xe3, 0x2b, 0x88, 0x9f, 0x9f, 0x25,
+	0x4c, 0x3d, 0x87, 0xe3, 0x4b, 0x80, 0x5b, 0x0e, 0xa6, 0xea, 0x21, 0x1c, 0x4c, 0x85, 0xe5, 0xf0,
+	0x4b, 0x13, 0xf7, 0xef, 0xf8, 0x12, 0xe0, 0xd8, 0x67, 0x38, 0x9a, 0x10, 0x75, 0xed, 0x12, 0x40,
+	0x77, 0xc0, 0xc1, 0xcc, 0x5d, 0x9a, 0x7b, 0x66, 0x07, 0x33, 0xf9, 0x76, 0x61, 0x9f, 0xec, 0x7e,
+	0x38, 0x74, 0x41, 0xbf, 0x9d, 0x84, 0x39, 0x4d, 0x08, 0xe4, 0xb4, 0xa6, 0xa4, 0x69, 0x98, 0xc7,
+	0x04, 0x79, 0x56, 0x4e, 0x5c, 0x16, 0xc4, 0x0c, 0xc1, 0x7d, 0x51, 0x02, 0xc7, 0x7d, 0xc6, 0x19,
+	0xae, 0x09, 0x86, 0x1c, 0x0e, 0x7d, 0x00, 0xcd, 0xd3, 0xc9, 0x54, 0xfb, 0x8f, 0xbb, 0x52, 0xee,
+	0xf9, 0x36, 0x0f, 0xba, 0x0b, 0xcb, 0x94, 0x44, 0x91, 0x59, 0xb3, 0x5a, 0xbe, 0x26, 0xc7, 0xe4,
+	0x7d, 0x02, 0x37, 0x1e, 0xa4, 0x04, 0x33, 0xd2, 0x33, 0x91, 0x40, 0x87, 0x8c, 0xa2, 0x7b, 0x22,
+	0xa8, 0xc5, 0x78, 0xa4, 0x9f, 0x53, 0xfc, 0xf6, 0x1e, 0x83, 0x3b, 0xbb, 0x5c, 0xfa, 0x3a, 0xfa,
+	0x00, 0x20, 0x0b, 0x2f, 0x42, 0x4e, 0x73, 0x77, 0x4d, 0x6b, 0x93, 0xb1, 0x5b, 0x4c, 0xde, 0x1f,
+	0x1c, 0xd8, 0x50, 0xf2, 0x0a, 0xf1, 0xab, 0x44, 0x19, 0x36, 0x1d, 0x1b, 0x65, 0xf8, 0x6f, 0xcb,
+	0x16, 0xab, 0x39, 0x5b, 0x94, 0xd6, 0x52, 0x33, 0xd6, 0xc2, 0x7d, 0x36, 0x09, 0xe3, 0x63, 0x92,
+	0xf6, 0x49, 0xcc, 0x94, 0x65, 0xd9, 0x28, 0xf4, 0x29, 0x2c, 0x8c, 0x71, 0x8a, 0x47, 0xdc, 0xc6,
+	0xaa, 0x3b, 0xcd, 0xdd, 0x1d, 0xa5, 0x76, 0xa9, 0x6e, 0x9d, 0x63, 0xc1, 0xda, 0x8d, 0x59, 0x3a,
+	0xf5, 0xd5, 0xba, 0xf6, 0xc7, 0xd0, 0xb4, 0xd0, 0xa8, 0x05, 0xd5, 0x73, 0x32, 0x55, 0xfa, 0xf3,
+	0x9f, 0x99, 0x71, 0xf2, 0x13, 0x54, 0x94, 0x71, 0xde, 0x73, 0x7e, 0x52, 0xf1, 0xba, 0xb0, 0x59,
+	0xdc, 0x47, 0xdd, 0xa8, 0x9d, 0x0b, 0x2a, 0xf3, 0x72, 0xc1, 0xef, 0x60, 0x63, 0x9f, 0xbc, 0x6a,
+	0x2a, 0xf8, 0xb6, 0x69, 0x8b, 0xa7, 0x80, 0xe2, 0xee, 0x2a, 0x05, 0xfc, 0xc7, 0x81, 0x95, 0xae,
+	0x0a, 0x05, 0x3d, 0x86, 0xd9, 0x84, 0xe6, 0x82, 0x45, 0xa5, 0x10, 0x2c, 0x7e, 0x00, 0x2b, 0xfd,
+	0x30, 0xed, 0x4f, 0x42, 0xb6, 0x97, 0x12, 0x7c, 0x4e, 0x52, 0xa5, 0x51, 0x01, 0x8b, 0xde, 0x87,
+	0xeb, 0x7d, 0x2e, 0xbf, 0x3f, 0x61, 0xe1, 0x33, 0xf2, 0x19, 0x0e, 0xa3, 0x49, 0x4a, 0xa8, 0x50,
+	0xb4, 0xee, 0x97, 0x91, 0xd0, 0x3d, 0x80, 0x64, 0x4c, 0x62, 0x15, 0x04, 0xe7, 0x27, 0x09, 0x8b,
+	0x9b, 0x3b, 0x78, 0x84, 0x29, 0xeb, 0x8a, 0xd0, 0x2c, 0x33, 0x45, 0x86, 0xe0, 0x89, 0xf4, 0x82,
+	0x84, 0x83, 0x21, 0x7b, 0x4a, 0x49, 0x20, 0xc2, 0x54, 0xdd, 0xb7, 0x30, 0xdc, 0x00, 0x25, 0x74,
+	0x18, 0x8e, 0x42, 0x26, 0x82, 0x56, 0xdd, 0xb7, 0x51, 0xfc, 0x46, 0x52, 0xf9, 0x5c, 0x32, 0x69,
+	0xd4, 0x7d, 0x03, 0x23, 0x17, 0x16, 0x53, 0xc2, 0xd2, 0x90, 0x50, 0x95, 0x30, 0x34, 0xc8, 0x57,
+	0x9d, 0xe9, 0x83, 0x83, 0x5c, 0xa5, 0x61, 0xef, 0x2d, 0xb8, 0xfe, 0x90, 0x30, 0x9d, 0x7b, 0xe9,
+	0x15, 0x7e, 0xe5, 0x75, 0x61, 0x3d, 0xcf, 0xa6, 0x4c, 0xef, 0x47, 0xb0, 0x74, 0xaa, 0x91, 0x6e,
+	0x65, 0xbb, 0x6a, 0xd9, 0x9e, 0x66, 0xf6, 0x33, 0x0e, 0x6f, 0x15, 0xae, 0x3d, 0x24, 0xec, 0x30,
+	0x19, 0xa8, 0x7d, 0xbc, 0x9f, 0xc2, 0x8a, 0x46, 0x28, 0x89, 0xef, 0xc0, 0x22, 0x89, 0xe5, 0x31,
+	0xf2, 0xf2, 0x0e, 0x93, 0x81, 0xf4, 0x25, 0x4d, 0xf7, 0xbe, 0xae, 0x08, 0xe5, 0x8f, 0x75, 0x55,
+	0x65, 0x29, 0x8f, 0xa9, 0x5b, 0xb1, 0x1d, 0x3b, 0x1c, 0xc4, 0x49, 0x4a, 0x7a, 0x23, 0x1c, 0x49,
+	0xd3, 0x6d, 0xf8, 0x36, 0x2a, 0x67, 0x69, 0xd5, 0x82, 0xa5, 0xdd, 0x06, 0x38, 0x9d, 0x6a, 0xcb,
+	0x14, 0xf6, 0xd0, 0xf0, 0x2d, 0x8c, 0xb7, 0x07, 0xeb, 0x79, 0x25, 0xd4, 0x41, 0xee, 0x40, 0xe3,
+	0x54, 0x66, 0x43, 0x7d, 0x92, 0x15, 0x73, 0x33, 0x02, 0xed, 0x1b, 0xba, 0xf7, 0x4b, 0x79, 0x10,
+	0x9e, 0x43, 0x1e, 0xe2, 0xb1, 0x79, 0x05, 0x04, 0xb5, 0x53, 0x4c, 0xb5, 0xf1, 0x8b, 0xdf, 0xea,
+	0x70, 0x8e, 0x39, 0xdc, 0x26, 0x2c, 0xa4, 0x84, 0x97, 0x44, 0x42, 0xf1, 0x86, 0xaf, 0x20, 0x7e,
+	0x39, 0xeb, 0x79, 0x99, 0x4a, 0xaf, 0xef, 0xc3, 0xb5, 0x11, 0xbe, 0x7c, 0x88, 0xc7, 0x3d, 0xd2,
+	0x4f, 0xe2, 0x40, 0x5e, 0x54, 0xd5, 0xcf, 0x23, 0xd1, 0x9b, 0x50, 0x1b, 0xe0, 0xb1, 0xac, 0xb9,
+	0xb2, 0x37, 0xd0, 0xd2, 0x7c, 0x41, 0xe4, 0x17, 0xab, 0xdf, 0xf6, 0x51, 0xc0, 0x9d, 0x8a, 0xd7,
+	0x67, 0x36, 0xca, 0xfb, 0x08, 0x5a, 0x5a, 0x89, 0x97, 0x39, 0x95, 0xf7, 0x31, 0xac, 0x59, 0xeb,
+	0x8c, 0xe6, 0x0b, 0xb2, 0x06, 0x52, 0xf7, 0xb9, 0x6c, 0x6b, 0xe5, 0x2b, 0x9a, 0xf7, 0x2e, 0xb4,
+	0x1f, 0x12, 0x96, 0x4b, 0x3c, 0x93, 0x88, 0x5d, 0x65, 0xd8, 0x4f, 0x60, 0xab, 0x94, 0x5b, 0x6d,
+	0xf9, 0x1e, 0xbf, 0x5d, 0x8e, 0x51, 0x81, 0xf5, 0xc6, 0x6c, 0xa2, 0x92, 0x0b, 0x14, 0x9b, 0xf7,
+	0x36, 0x6c, 0xe4, 0xe4, 0x5d, 0xe9, 0x51, 0x8f, 0x61, 0xb3, 0xc8, 0xa8, 0xf6, 0xbc, 0x0b, 0xcd,
+	0x2c, 0xe8, 0xea, 0xb3, 0x96, 0x64, 0x48, 0x9b, 0xcb, 0x43, 0xe2, 0xa2, 0x65, 0xe0, 0xd4, 0xce,
+	0xf5, 0xcf, 0x2a, 0xac, 0x59, 0x48, 0x25, 0xfe, 0x53, 0xb8, 0x46, 0x49, 0xfa, 0x8c, 0xa4, 0x3d,
+	0x59, 0xfb, 0xb9, 0x95, 0xb9, 0x21, 0x2e, 0xbf, 0x80, 0x47, 0x48, 0x1e, 0xd4, 0x9e, 0x8e, 0x03,
+	0x5e, 0xf7, 0x3a, 0x73, 0x97, 0x5b, 0xdc, 0xdc, 0x64, 0x26, 0xe2, 0xd7, 0x83, 0x64, 0x12, 0x33,
+	0x15, 0x87, 0x6d, 0x14, 0xcf, 0x43, 0xa2, 0x9a, 0xeb, 0x89, 0xd4, 0x22, 0x13, 0x74, 0xdd, 0xcf,
+	0xe1, 0xd0, 0x5b, 0x50, 0x4b, 0x43, 0x7a, 0x2e, 0x42, 0x6c, 0x76, 0x37, 0x7e, 0x48, 0xcf, 0xd5,
+	0x61, 0x05, 0x19, 0xed, 0xc2, 0xba, 0x30, 0x8a, 0x1e, 0x4b, 0x09, 0x1e, 0x3d, 0x48, 0xe2, 0x98,
+	0xf4, 0x99, 0x0a, 0xbd, 0x0d, 0xbf, 0x94, 0xc6, 0x13, 0x0b, 0x15, 0x28, 0x12, 0x48, 0xf3, 0x53,
+	0x71, 0xb8, 0x80, 0x45, 0x07, 0xb0, 0xc6, 0x8f, 0xd5, 0xb3, 0xb1, 0x6e, 0x63, 0xee, 0x5d, 0xcc,
+	0x2e, 0x42, 0x1f, 0x58, 0xc1, 0x47, 0xd6, 0x9f, 0x1b, 0xea, 0x40, 0xf9, 0x7c, 0x98, 0xc5, 0x24,
+	0xef, 0x9e, 0xf0, 0x6d, 0x95, 0x43, 0x43, 0x42, 0x5f, 0x22, 0x87, 0x7b, 0xbf, 0x87, 0x8d, 0xc2,
+	0x5a, 0x63, 0xeb, 0xba, 0xab, 0x9b, 0x0d, 0xbe, 0x26, 0x5d, 0x5b, 0x2c, 0xe8, 0x2e, 0x34, 0xb0,
+	0x6a, 0x7b, 0x55, 0x9c, 0xd0, 0xee, 0x51, 0xec, 0x86, 0x7d, 0xc3, 0xe8, 0xdd, 0x90, 0xdb, 0x8b,
+	0x87, 0x3c, 0x99, 0x8e, 0x8d, 0xee, 0xda, 0x21, 0x6c, 0x82, 0xe5, 0x10, 0x19, 0xba, 0xe8, 0x10,
+	0x86, 0xe2, 0xdb, 0x5c, 0xde, 0x13, 0x68, 0xe8, 0x8c, 0x81, 0x3a, 0x50, 0xe3, 0x3d, 0xfb, 0x0b,
+	0x58, 0xba, 0xe0, 0x13, 0x55, 0x24, 0xb9, 0x64, 0xa6, 0x8a, 0x24, 0x97, 0xcc, 0xfb, 0x1c, 0x96,
+	0x4c, 0x8c, 0x37, 0x35, 0x6f, 0x25, 0xab, 0x79, 0x73, 0xf1, 0xde, 0x99, 0x13, 0xef, 0xbf, 0xae,
+	0x42, 0x5d, 0x3e, 0xfe, 0xab, 0x34, 0x4a, 0x32, 0x58, 0x56, 0x4d, 0x0a, 0x70, 0x61, 0x51, 0x75,
+	0x96, 0xaa, 0x4b, 0xd2, 0xa0, 0x6a, 0x6a, 0xea, 0x2f, 0xd4, 0xd4, 0xf0, 0xf2, 0x57, 0xc8, 0x3f,
+	0x49, 0x02, 0x3c, 0x75, 0x17, 0x54, 0xf9, 0x9b, 0xa1, 0x44, 0xcd, 0xa5, 0x9b, 0x11, 0xc9, 0x24,
+	0xfb, 0xaa, 0x02, 0x96, 0xeb, 0xc3, 0x6b, 0xa2, 0x30, 0x1e, 0xa8, 0x16, 0x4b, 0x83, 0x42, 0xd3,
+	0x28, 0xa1, 0x9c, 0xb2, 0xa4, 0x34, 0x95, 0x20, 0xa7, 0x0c, 0xc3, 0xc1, 0x90, 0x50, 0xa6, 0x3a,
+	0x2a, 0x0d, 0xca, 0x16, 0xf0, 0x82, 0x13, 0x9a, 0xba, 0x05, 0xe4, 0xd0, 0xb7, 0x6f, 0xa6, 0xbc,
+	0x6f, 0x2a, 0xd0, 0xd0, 0x19, 0xed, 0x85, 0x72, 0xed, 0x77, 0x39, 0x73, 0xe0, 0x69, 0x3a, 0xa4,
+	0xfc, 0x6e, 0x54, 0x18, 0xaa, 0x8b, 0x30, 0x94, 0x47, 0x7a, 0x2d, 0x58, 0xf1, 0xc9, 0xe9, 0x24,
+	0x8c, 0x02, 0xed, 0x46, 0xef, 0xc0, 0xaa, 0xc1, 0x28, 0xff, 0xd9, 0xcc, 0x25, 0xb1, 0x25, 0x93,
+	0xab, 0xfe, 0x56, 0x05, 0xc8, 0x62, 0x26, 0x7a, 0x08, 0xcb, 0x23, 0x7c, 0x79, 0x94, 0x06, 0x24,
+	0xed, 0x85, 0xbf, 0x25, 0xca, 0xcf, 0xde, 0x9c, 0x09, 0xae, 0x9d, 0xc7, 0x16, 0x97, 0x2c, 0xc9,
+	0x72, 0x0b, 0xd1, 0x0e, 0xac, 0x8e, 0xf0, 0xe5, 0x49, 0x8a, 0x03, 0xa2, 0x9b, 0x29, 0x47, 0x5c,
+	0x7f, 0x11, 0xcd, 0x5f, 0x69, 0x84, 0x2f, 0xf7, 0x71, 0x18, 0x4d, 0x0f, 0x13, 0x4a, 0x55, 0xeb,
+	0x9f, 0xc3, 0xa1, 0x3b, 0xd0, 0xd2, 0xd2, 0xe9, 0x31, 0x49, 0x0f, 0x92, 0x49, 0xaa, 0x72, 0xc2,
+	0x0c, 0x1e, 0xbd, 0x0b, 0x6b, 0xe7, 0x61, 0x14, 0xf5, 0x2e, 0x42, 0xd6, 0x1f, 0x76, 0xe3, 0x01,
+	0x1e, 0x90, 0x40, 0x5c, 0x5c, 0xc3, 0x9f, 0x25, 0x70, 0xc9, 0x19, 0xd2, 0x27, 0x98, 0x26, 0xb1,
+	0x1a, 0x1e, 0xcc, 0xe0, 0xb9, 0xed, 0x27, 0x62, 0xab, 0x43, 0x4c, 0x99, 0xd0, 0x41, 0xa5, 0x85,
+	0x3c, 0x96, 0x3f, 0x5b, 0x4a, 0x70, 0x14, 0x52, 0x12, 0x48, 0x17, 0x91, 0x1e, 0x90, 0x47, 0xb6,
+	0x7f, 0x01, 0x6b, 0x33, 0x97, 0x38, 0xaf, 0x19, 0x74, 0xec, 0x66, 0xf0, 0x00, 0xd6, 0x7b, 0x84,
+	0x7d, 0x6e, 0x69, 0x29, 0x13, 0x80, 0xcb, 0xab, 0x67, 0x79, 0xec, 0x8a, 0x38, 0xb6, 0x06, 0xa5,
+	0x11, 0x88, 0x23, 0x3a, 0xda, 0x08, 0x38, 0xc4, 0xe3, 0x71, 0x41, 0x92, 0x6a, 0xc8, 0xfe, 0x5d,
+	0x05, 0xc8, 0xaa, 0x8d, 0x17, 0x69, 0xfb, 0xb9, 0x4b, 0x86, 0xd4, 0x9f, 0xc4, 0xc2, 0xf5, 0x65,
+	0x39, 0x9a, 0x21, 0x8a, 0xd3, 0xa9, 0xda, 0xcb, 0x4d, 0xa7, 0xc4, 0xea, 0x64, 0x3c, 0x56, 0xab,
+	0xeb, 0x2f, 0xb2, 0xda, 0xb0, 0x73, 0xc3, 0x98, 0x50, 0x72, 0x10, 0x52, 0x96, 0xa4, 0x61, 0x1f,
+	0x47, 0xfb, 0x98, 0x61, 0x55, 0x06, 0xcc, 0x12, 0x72, 0x7e, 0xbe, 0xf8, 0x4a, 0x7e, 0xde, 0x78,
+	0x19, 0x3f, 0x0f, 0x30, 0xc3, 0x9f, 0x89, 0x3e, 0x2e, 0xee, 0x4f, 0x55, 0xf3, 0x96, 0x47, 0x72,
+	0x97, 0x9a, 0x50, 0xe2, 0x13, 0x1c, 0xf1, 0x04, 0x25, 0xb4, 0x07, 0xa1, 0x7d, 0x11, 0x8d, 0x3a,
+	0xf6, 0xdc, 0x59, 0x4e, 0x00, 0x5b, 0xba, 0x4e, 0xd6, 0x78, 0x6b, 0x12, 0xed, 0xfd, 0xb9, 0x0e,
+	0xad, 0x62, 0x35, 0x3b, 0xf3, 0xd8, 0xff, 0xcf, 0x40, 0x28, 0xc6, 0xcf, 0x38, 0x65, 0xbf, 0x12,
+	0x9e, 0x20, 0xa7, 0x32, 0x16, 0x46, 0x64, 0xca, 0x38, 0x90, 0x54, 0x99, 0xb4, 0x0c, 0xcc, 0x73,
+	0x9a, 0xa8, 0x1b, 0x7d, 0xc2, 0x26, 0x69, 0xac, 0xd2, 0x95, 0x8d, 0xe2, 0x31, 0x00, 0xc7, 0xf1,
+	0x44, 0x3a, 0xa7, 0x62, 0x93, 0x2e, 0x3b, 0x83, 0xe7, 0xd2, 0x78, 0x64, 0x4a, 0xf1, 0x45, 0x90,
+	0x5c, 0xc4, 0x2a, 0x83, 0xd9, 0x28, 0xae, 0xeb, 0xb3, 0x84, 0xdf, 0x64, 0x14, 0xb2, 0xa9, 0x4a,
+	0x64, 0x16, 0x86, 0x4b, 0xa0, 0x43, 0x9c, 0x8e, 0x89, 0x8f, 0x99, 0x7a, 0x1e, 0xc7, 0xb7, 0x51,
+	0xa2, 0x82, 0x4b, 0x52, 0x16, 0xc6, 0x89, 0x64, 0x91, 0x89, 0x2d, 0x87, 0xe3, 0xae, 0xcc, 0x78,
+	0x14, 0xa5, 0x22, 0xab, 0xd5, 0x7d, 0x05, 0x71, 0xe7, 0xbf, 0x08, 0x63, 0x9f, 0x17, 0xe5, 0x2b,
+	0x32, 0x87, 0x2a, 0x90, 0x4f, 0x41, 0x4e, 0x49, 0xdc, 0x1f, 0x8e, 0x70, 0x7a, 0xde, 0xcb, 0x2e,
+	0x73, 0x55, 0x70, 0x95, 0x91, 0xb8, 0xc3, 0x18, 0x74, 0x57, 0x5f, 0x6f, 0x4b, 0xf0, 0xcf, 0x12,
+	0xb8, 0x79, 0x1a, 0xa4, 0xba, 0xc4, 0x35, 0x19, 0xf1, 0x0b, 0x68, 0xf4, 0x21, 0x6c, 0xd0, 0x69,
+	0xcc, 0x86, 0x84, 0x85, 0x7d, 0x91, 0xc3, 0x74, 0x86, 0x40, 0x82, 0xbf, 0x9c, 0xe8, 0xfd, 0xb5,
+	0x02, 0xeb, 0x42, 0x39, 0x33, 0x54, 0x50, 0xf1, 0xce, 0x7c, 0x1b, 0xa8, 0x5c, 0xf5, 0x6d, 0xc0,
+	0x79, 0x25, 0xf3, 0xac, 0xbe, 0xd2, 0xb7, 0x81, 0x5a, 0xfe, 0xdb, 0x80, 0xb7, 0x0f, 0x1b, 0x05,
+	0xad, 0xb3, 0x89, 0x9d, 0xee, 0x92, 0x0b, 0x13, 0x3b, 0xc3, 0x6a, 0x18, 0xbc, 0x7f, 0x39, 0xb0,
+	0x29, 0xc4, 0xcc, 0x9f, 0xc5, 0x7e, 0x02, 0xb5, 0x8b, 0x21, 0x91, 0x21, 0x7e, 0x65, 0xf7, 0x1d,
+	0x53, 0xbc, 0x97, 0x2d, 0xee, 0x70, 0xce, 0xa3, 0xb1, 0xec, 0x3d, 0xc5, 0xb2, 0xef, 0xba, 0xbe,
+	0xc9, 0xc7, 0xbd, 0x7a, 0x49, 0xdc, 0xf3, 0x08, 0x34, 0x2d, 0x35, 0x51, 0x0b, 0x96, 0x9f, 0x1c,
+	0x7d, 0xf1, 0x95, 0xdf, 0xbd, 0x7f, 0x78, 0xf2, 0xe8, 0x71, 0xb7, 0xf5, 0x1a, 0x5a, 0x86, 0xc6,
+	0xe1, 0xfd, 0xde, 0xc9, 0x57, 0xfb, 0xf7, 0xbf, 0x6c, 0x55, 0xd0, 0x35, 0x58, 0x12, 0xd0, 0x17,
+	0xdd, 0xee, 0xe7, 0x2d, 0x07, 0xad, 0x00, 0x08, 0xf0, 0xf1, 0xd1, 0x93, 0x93, 0x83, 0x56, 0x15,
+	0x35, 0x61, 0xf1, 0xe4, 0xa0, 0xfb, 0xd5, 0xe1, 0xd1, 0x49, 0xab, 0x86, 0x00, 0x16, 0x1e, 0x3c,
+	0xed, 0x9d, 0x1c, 0x3d, 0x6e, 0xd5, 0xbd, 0xef, 0xc1, 0x8d, 0x99, 0x4b, 0x52, 0x69, 0xf0, 0x6d,
+	0xfe, 0x86, 0xc9, 0x78, 0xee, 0xdd, 0xf3, 0xd1, 0x66, 0x91, 0x51, 0x89, 0xf8, 0x8b, 0x03, 0x0d,
+	0x33, 0x8e, 0x2f, 0x3e, 0xd9, 0x36, 0x34, 0x03, 0x42, 0xfb, 0x69, 0x28, 0x8e, 0xa8, 0x62, 0xac,
+	0x8d, 0x2a, 0xce, 0xa5, 0xab, 0xb3, 0x73, 0xe9, 0xac, 0x89, 0xa8, 0x95, 0x4c, 0xb8, 0xeb, 0x26,
+	0x6c, 0xe7, 0xf2, 0xf3, 0x42, 0x31, 0x3f, 0xeb, 0xd9, 0xf9, 0xa2, 0x35, 0x3b, 0xbf, 0x6b, 0x26,
+	0xde, 0x0d, 0x51, 0x0d, 0x6e, 0x15, 0xfa, 0xc1, 0xff, 0xf5, 0x90, 0xfb, 0x7d, 0x80, 0xac, 0xa1,
+	0x7b, 0x6e, 0x4d, 0x5e, 0x95, 0x67, 0xba, 0xf3, 0x06, 0x2c, 0xdb, 0x73, 0x66, 0xb4, 0x08, 0xd5,
+	0xbd, 0xa7, 0x5f, 0xb6, 0x5e, 0x43, 0x0d, 0xa8, 0xf5, 0xba, 0x87, 0x87, 0xad, 0xca, 0xee, 0x37,
+	0x4d, 0x58, 0x62, 0x24, 0x22, 0x3c, 0x4c, 0x62, 0xf4, 0x08, 0x96, 0xed, 0x51, 0x26, 0x6a, 0xab,
+	0x23, 0x95, 0x8c, 0x41, 0xdb, 0x5b, 0xa5, 0x34, 0xf5, 0xac, 0xaf, 0xa1, 0x1f, 0xc3, 0x82, 0x9c,
+	0x5e, 0xa2, 0xf5, 0x8c, 0x31, 0x9b, 0x6e, 0xb6, 0x37, 0x0a, 0x58, 0xb3, 0x50, 0xea, 0x90, 0xf5,
+	0x93, 0x96, 0x0e, 0xc5, 0x69, 0x66, 0x7b, 0xab, 0x94, 0x56, 0x14, 0xa5, 0xc7, 0x7c, 0x39, 0x51,
+	0x85, 0x79, 0x62, 0x7b, 0xab, 0x94, 0x66, 0x44, 0x7d, 0x0a, 0x4b, 0x9a, 0x42, 0xd1, 0x8d, 0x02,
+	0xaf, 0x11, 0xe2, 0xce, 0x12, 0x8c, 0x84, 0x23, 0x31, 0xce, 0xb5, 0x86, 0x5a, 0xe8, 0x66, 0xc6,
+	0x3d, 0x3b, 0x14, 0x6b, 0xdf, 0xba, 0x82, 0x6a, 0x04, 0xfe, 0x1a, 0xae, 0xe7, 0x68, 0xaa, 0x3e,
+	0x79, 0xa3, 0x6c, 0x5d, 0x6e, 0xd0, 0xd7, 0xf6, 0x9e, 0xc7, 0x52, 0x38, 0xb2, 0x6a, 0x80, 0xac,
+	0x23, 0xe7, 0x06, 0x69, 0x6d, 0x77, 0x96, 0x60, 0x24, 0x1c, 0x8a, 0x91, 0x76, 0x36, 0x4e, 0x41,
+	0x5b, 0x36, 0x73, 0x61, 0x40, 0xd3, 0xbe, 0x59, 0x4e, 0x2c, 0x5e, 0x60, 0x36, 0xc7, 0xc8, 0x5d,
+	0xe0, 0xcc, 0xd0, 0xa4, 0x7d, 0xeb, 0x0a, 0xaa, 0x11, 0xf8, 0x14, 0x5a, 0xc5, 0x2f, 0x71, 0xe8,
+	0x76, 0xfe, 0xb3, 0x55, 0x31, 0xb2, 0xb5, 0x5f, 0xbf, 0x92, 0x6e, 0xeb, 0x99, 0xff, 0x18, 0x65,
+	0xf4, 0x2c, 0xfd, 0x16, 0xd6, 0xbe, 0x75, 0x05, 0xd5, 0x16, 0x98, 0xff, 0xdf, 0x00, 0x23, 0xb0,
+	0xf4, 0x1f, 0x17, 0xda, 0xb7, 0xae, 0xa0, 0xda, 0x02, 0xf7, 0x49, 0xa9, 0xc0, 0x7d, 0xf2, 0x3c,
+	0x81, 0x57, 0x7c, 0x9e, 0x12, 0x0f, 0x9d, 0x4b, 0xe6, 0x68, 0xcb, 0x4e, 0xaf, 0x85, 0xc2, 0xa4,
+	0x7d, 0xb3, 0x9c, 0x68, 0xa4, 0xf9, 0xb0, 0x5a, 0xc8, 0x38, 0xe8, 0xd6, 0x73, 0xd3, 0x75, 0xfb,
+	0xf6, 0x55, 0x64, 0xfb, 0xc8, 0xf9, 0x0c, 0x84, 0x32, 0x2d, 0x4a, 0x32, 0x58, 0xfb, 0xd6, 0x15,
+	0xd4, 0xdc, 0x91, 0xed, 0xde, 0x30, 0x3b, 0x72, 0x49, 0xef, 0xd9, 0xbe, 0x59, 0x4e, 0x34, 0xd2,
+	0xee, 0xc1, 0xa2, 0x9a, 0x4c, 0x20, 0x1d, 0x18, 0xf3, 0xb3, 0x8b, 0xf6, 0x66, 0x11, 0xad, 0xd7,
+	0xee, 0xbd, 0x0f, 0x5b, 0x61, 0xd2, 0x19, 0xa4, 0xe3, 0x7e, 0x87, 0x5c, 0xe2, 0xd1, 0x38, 0x22,
+	0xb4, 0x33, 0x24, 0x51, 0x94, 0x5c, 0x24, 0x69, 0x14, 0xec, 0xad, 0x1e, 0xf0, 0xdf, 0x5f, 0xf0,
+	0xdf, 0xc7, 0x5c, 0xc2, 0x71, 0xe5, 0x74, 0x41, 0x88, 0xba, 0xfb, 0xdf, 0x01, 0x00, 0xd6, 0xb6,
+	0x78, 0xfb, 0xd0, 0x23, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Teletrada service
+const _ = grpc.SupportPackageIsVersion6
 
+// TeletradaClient is the client API for Teletrada service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type TeletradaClient interface {
 	// Get requests
+	GetBackfills(ctx context.Context, in *GetBackfillsRequest, opts ...grpc.CallOption) (*GetBackfillsResponse, error)
 	GetLog(ctx context.Context, in *GetLogRequest, opts ...grpc.CallOption) (*GetLogResponse, error)
 	GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*GetPortfolioResponse, error)
+	GetPriceGaps(ctx context.Context, in *GetPriceGapsRequest, opts ...grpc.CallOption) (*GetPriceGapsResponse, error)
 	GetPrices(ctx context.Context, in *GetPricesRequest, opts ...grpc.CallOption) (*GetPricesResponse, error)
 	GetSimulations(ctx context.Context, in *GetSimulationsRequest, opts ...grpc.CallOption) (*GetSimulationsResponse, error)
+	GetSimulationResult(ctx context.Context, in *GetSimulationResultRequest, opts ...grpc.CallOption) (*GetSimulationResultResponse, error)
 	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	GetStrategies(ctx context.Context, in *GetStrategiesRequest, opts ...grpc.CallOption) (*GetStrategiesResponse, error)
 	GetSymbolTypes(ctx context.Context, in *GetSymbolTypesRequest, opts ...grpc.CallOption) (*GetSymbolTypesResponse, error)
 	// Create requests
 	CreateSimulation(ctx context.Context, in *CreateSimulationRequest, opts ...grpc.CallOption) (*CreateSimulationResponse, error)
+	CreateStrategy(ctx context.Context, in *CreateStrategyRequest, opts ...grpc.CallOption) (*CreateStrategyResponse, error)
+	// Strategy requests
+	AttachStrategy(ctx context.Context, in *AttachStrategyRequest, opts ...grpc.CallOption) (*AttachStrategyResponse, error)
+	DetachStrategy(ctx context.Context, in *DetachStrategyRequest, opts ...grpc.CallOption) (*DetachStrategyResponse, error)
 	// Start requests
+	StartBackfill(ctx context.Context, in *StartBackfillRequest, opts ...grpc.CallOption) (*StartBackfillResponse, error)
 	StartSimulation(ctx context.Context, in *StartSimulationRequest, opts ...grpc.CallOption) (*StartSimulationResponse, error)
 	// Stop requests
 	StopSimulation(ctx context.Context, in *StopSimulationRequest, opts ...grpc.CallOption) (*StopSimulationResponse, error)
+	// Risk requests
+	SetKillSwitch(ctx context.Context, in *SetKillSwitchRequest, opts ...grpc.CallOption) (*SetKillSwitchResponse, error)
 	// Rebuild server
 	Rebuild(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (*RebuildResponse, error)
 }
 
 type teletradaClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewTeletradaClient(cc *grpc.ClientConn) TeletradaClient {
+func NewTeletradaClient(cc grpc.ClientConnInterface) TeletradaClient {
 	return &teletradaClient{cc}
 }
 
+func (c *teletradaClient) GetBackfills(ctx context.Context, in *GetBackfillsRequest, opts ...grpc.CallOption) (*GetBackfillsResponse, error) {
+	out := new(GetBackfillsResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetBackfills", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *teletradaClient) GetLog(ctx context.Context, in *GetLogRequest, opts ...grpc.CallOption) (*GetLogResponse, error) {
 	out := new(GetLogResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/GetLog", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetLog", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -962,7 +3369,16 @@ func (c *teletradaClient) GetLog(ctx context.Context, in *GetLogRequest, opts ..
 
 func (c *teletradaClient) GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*GetPortfolioResponse, error) {
 	out := new(GetPortfolioResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/GetPortfolio", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetPortfolio", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) GetPriceGaps(ctx context.Context, in *GetPriceGapsRequest, opts ...grpc.CallOption) (*GetPriceGapsResponse, error) {
+	out := new(GetPriceGapsResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetPriceGaps", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -971,7 +3387,7 @@ func (c *teletradaClient) GetPortfolio(ctx context.Context, in *GetPortfolioRequ
 
 func (c *teletradaClient) GetPrices(ctx context.Context, in *GetPricesRequest, opts ...grpc.CallOption) (*GetPricesResponse, error) {
 	out := new(GetPricesResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/GetPrices", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetPrices", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -980,7 +3396,16 @@ func (c *teletradaClient) GetPrices(ctx context.Context, in *GetPricesRequest, o
 
 func (c *teletradaClient) GetSimulations(ctx context.Context, in *GetSimulationsRequest, opts ...grpc.CallOption) (*GetSimulationsResponse, error) {
 	out := new(GetSimulationsResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/GetSimulations", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetSimulations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) GetSimulationResult(ctx context.Context, in *GetSimulationResultRequest, opts ...grpc.CallOption) (*GetSimulationResultResponse, error) {
+	out := new(GetSimulationResultResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetSimulationResult", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -989,7 +3414,16 @@ func (c *teletradaClient) GetSimulations(ctx context.Context, in *GetSimulations
 
 func (c *teletradaClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
 	out := new(GetStatusResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/GetStatus", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) GetStrategies(ctx context.Context, in *GetStrategiesRequest, opts ...grpc.CallOption) (*GetStrategiesResponse, error) {
+	out := new(GetStrategiesResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetStrategies", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -998,7 +3432,7 @@ func (c *teletradaClient) GetStatus(ctx context.Context, in *GetStatusRequest, o
 
 func (c *teletradaClient) GetSymbolTypes(ctx context.Context, in *GetSymbolTypesRequest, opts ...grpc.CallOption) (*GetSymbolTypesResponse, error) {
 	out := new(GetSymbolTypesResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/GetSymbolTypes", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/GetSymbolTypes", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1007,7 +3441,43 @@ func (c *teletradaClient) GetSymbolTypes(ctx context.Context, in *GetSymbolTypes
 
 func (c *teletradaClient) CreateSimulation(ctx context.Context, in *CreateSimulationRequest, opts ...grpc.CallOption) (*CreateSimulationResponse, error) {
 	out := new(CreateSimulationResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/CreateSimulation", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/CreateSimulation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) CreateStrategy(ctx context.Context, in *CreateStrategyRequest, opts ...grpc.CallOption) (*CreateStrategyResponse, error) {
+	out := new(CreateStrategyResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/CreateStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) AttachStrategy(ctx context.Context, in *AttachStrategyRequest, opts ...grpc.CallOption) (*AttachStrategyResponse, error) {
+	out := new(AttachStrategyResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/AttachStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) DetachStrategy(ctx context.Context, in *DetachStrategyRequest, opts ...grpc.CallOption) (*DetachStrategyResponse, error) {
+	out := new(DetachStrategyResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/DetachStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) StartBackfill(ctx context.Context, in *StartBackfillRequest, opts ...grpc.CallOption) (*StartBackfillResponse, error) {
+	out := new(StartBackfillResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/StartBackfill", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1016,7 +3486,7 @@ func (c *teletradaClient) CreateSimulation(ctx context.Context, in *CreateSimula
 
 func (c *teletradaClient) StartSimulation(ctx context.Context, in *StartSimulationRequest, opts ...grpc.CallOption) (*StartSimulationResponse, error) {
 	out := new(StartSimulationResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/StartSimulation", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/StartSimulation", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1025,7 +3495,16 @@ func (c *teletradaClient) StartSimulation(ctx context.Context, in *StartSimulati
 
 func (c *teletradaClient) StopSimulation(ctx context.Context, in *StopSimulationRequest, opts ...grpc.CallOption) (*StopSimulationResponse, error) {
 	out := new(StopSimulationResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/StopSimulation", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/StopSimulation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teletradaClient) SetKillSwitch(ctx context.Context, in *SetKillSwitchRequest, opts ...grpc.CallOption) (*SetKillSwitchResponse, error) {
+	out := new(SetKillSwitchResponse)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/SetKillSwitch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1034,37 +3513,127 @@ func (c *teletradaClient) StopSimulation(ctx context.Context, in *StopSimulation
 
 func (c *teletradaClient) Rebuild(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (*RebuildResponse, error) {
 	out := new(RebuildResponse)
-	err := grpc.Invoke(ctx, "/proto.teletrada/Rebuild", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/proto.teletrada/Rebuild", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for Teletrada service
-
+// TeletradaServer is the server API for Teletrada service.
 type TeletradaServer interface {
 	// Get requests
+	GetBackfills(context.Context, *GetBackfillsRequest) (*GetBackfillsResponse, error)
 	GetLog(context.Context, *GetLogRequest) (*GetLogResponse, error)
 	GetPortfolio(context.Context, *GetPortfolioRequest) (*GetPortfolioResponse, error)
+	GetPriceGaps(context.Context, *GetPriceGapsRequest) (*GetPriceGapsResponse, error)
 	GetPrices(context.Context, *GetPricesRequest) (*GetPricesResponse, error)
 	GetSimulations(context.Context, *GetSimulationsRequest) (*GetSimulationsResponse, error)
+	GetSimulationResult(context.Context, *GetSimulationResultRequest) (*GetSimulationResultResponse, error)
 	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	GetStrategies(context.Context, *GetStrategiesRequest) (*GetStrategiesResponse, error)
 	GetSymbolTypes(context.Context, *GetSymbolTypesRequest) (*GetSymbolTypesResponse, error)
 	// Create requests
 	CreateSimulation(context.Context, *CreateSimulationRequest) (*CreateSimulationResponse, error)
+	CreateStrategy(context.Context, *CreateStrategyRequest) (*CreateStrategyResponse, error)
+	// Strategy requests
+	AttachStrategy(context.Context, *AttachStrategyRequest) (*AttachStrategyResponse, error)
+	DetachStrategy(context.Context, *DetachStrategyRequest) (*DetachStrategyResponse, error)
 	// Start requests
+	StartBackfill(context.Context, *StartBackfillRequest) (*StartBackfillResponse, error)
 	StartSimulation(context.Context, *StartSimulationRequest) (*StartSimulationResponse, error)
 	// Stop requests
 	StopSimulation(context.Context, *StopSimulationRequest) (*StopSimulationResponse, error)
+	// Risk requests
+	SetKillSwitch(context.Context, *SetKillSwitchRequest) (*SetKillSwitchResponse, error)
 	// Rebuild server
 	Rebuild(context.Context, *RebuildRequest) (*RebuildResponse, error)
 }
 
+// UnimplementedTeletradaServer can be embedded to have forward compatible implementations.
+type UnimplementedTeletradaServer struct {
+}
+
+func (*UnimplementedTeletradaServer) GetBackfills(ctx context.Context, req *GetBackfillsRequest) (*GetBackfillsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackfills not implemented")
+}
+func (*UnimplementedTeletradaServer) GetLog(ctx context.Context, req *GetLogRequest) (*GetLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLog not implemented")
+}
+func (*UnimplementedTeletradaServer) GetPortfolio(ctx context.Context, req *GetPortfolioRequest) (*GetPortfolioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolio not implemented")
+}
+func (*UnimplementedTeletradaServer) GetPriceGaps(ctx context.Context, req *GetPriceGapsRequest) (*GetPriceGapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceGaps not implemented")
+}
+func (*UnimplementedTeletradaServer) GetPrices(ctx context.Context, req *GetPricesRequest) (*GetPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrices not implemented")
+}
+func (*UnimplementedTeletradaServer) GetSimulations(ctx context.Context, req *GetSimulationsRequest) (*GetSimulationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimulations not implemented")
+}
+func (*UnimplementedTeletradaServer) GetSimulationResult(ctx context.Context, req *GetSimulationResultRequest) (*GetSimulationResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimulationResult not implemented")
+}
+func (*UnimplementedTeletradaServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (*UnimplementedTeletradaServer) GetStrategies(ctx context.Context, req *GetStrategiesRequest) (*GetStrategiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrategies not implemented")
+}
+func (*UnimplementedTeletradaServer) GetSymbolTypes(ctx context.Context, req *GetSymbolTypesRequest) (*GetSymbolTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSymbolTypes not implemented")
+}
+func (*UnimplementedTeletradaServer) CreateSimulation(ctx context.Context, req *CreateSimulationRequest) (*CreateSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSimulation not implemented")
+}
+func (*UnimplementedTeletradaServer) CreateStrategy(ctx context.Context, req *CreateStrategyRequest) (*CreateStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStrategy not implemented")
+}
+func (*UnimplementedTeletradaServer) AttachStrategy(ctx context.Context, req *AttachStrategyRequest) (*AttachStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachStrategy not implemented")
+}
+func (*UnimplementedTeletradaServer) DetachStrategy(ctx context.Context, req *DetachStrategyRequest) (*DetachStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachStrategy not implemented")
+}
+func (*UnimplementedTeletradaServer) StartBackfill(ctx context.Context, req *StartBackfillRequest) (*StartBackfillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBackfill not implemented")
+}
+func (*UnimplementedTeletradaServer) StartSimulation(ctx context.Context, req *StartSimulationRequest) (*StartSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSimulation not implemented")
+}
+func (*UnimplementedTeletradaServer) StopSimulation(ctx context.Context, req *StopSimulationRequest) (*StopSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopSimulation not implemented")
+}
+func (*UnimplementedTeletradaServer) SetKillSwitch(ctx context.Context, req *SetKillSwitchRequest) (*SetKillSwitchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKillSwitch not implemented")
+}
+func (*UnimplementedTeletradaServer) Rebuild(ctx context.Context, req *RebuildRequest) (*RebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rebuild not implemented")
+}
+
 func RegisterTeletradaServer(s *grpc.Server, srv TeletradaServer) {
 	s.RegisterService(&_Teletrada_serviceDesc, srv)
 }
 
+func _Teletrada_GetBackfills_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackfillsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).GetBackfills(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/GetBackfills",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).GetBackfills(ctx, req.(*GetBackfillsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Teletrada_GetLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLogRequest)
 	if err := dec(in); err != nil {
@@ -1101,6 +3670,24 @@ func _Teletrada_GetPortfolio_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Teletrada_GetPriceGaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceGapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).GetPriceGaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/GetPriceGaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).GetPriceGaps(ctx, req.(*GetPriceGapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Teletrada_GetPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPricesRequest)
 	if err := dec(in); err != nil {
@@ -1137,6 +3724,24 @@ func _Teletrada_GetSimulations_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Teletrada_GetSimulationResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimulationResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).GetSimulationResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/GetSimulationResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).GetSimulationResult(ctx, req.(*GetSimulationResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Teletrada_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStatusRequest)
 	if err := dec(in); err != nil {
@@ -1155,6 +3760,24 @@ func _Teletrada_GetStatus_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Teletrada_GetStrategies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStrategiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).GetStrategies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/GetStrategies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).GetStrategies(ctx, req.(*GetStrategiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Teletrada_GetSymbolTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSymbolTypesRequest)
 	if err := dec(in); err != nil {
@@ -1191,6 +3814,78 @@ func _Teletrada_CreateSimulation_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Teletrada_CreateStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).CreateStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/CreateStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).CreateStrategy(ctx, req.(*CreateStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Teletrada_AttachStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).AttachStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/AttachStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).AttachStrategy(ctx, req.(*AttachStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Teletrada_DetachStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).DetachStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/DetachStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).DetachStrategy(ctx, req.(*DetachStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Teletrada_StartBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartBackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).StartBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/StartBackfill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).StartBackfill(ctx, req.(*StartBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Teletrada_StartSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartSimulationRequest)
 	if err := dec(in); err != nil {
@@ -1227,6 +3922,24 @@ func _Teletrada_StopSimulation_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Teletrada_SetKillSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKillSwitchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeletradaServer).SetKillSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.teletrada/SetKillSwitch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeletradaServer).SetKillSwitch(ctx, req.(*SetKillSwitchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Teletrada_Rebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RebuildRequest)
 	if err := dec(in); err != nil {
@@ -1249,6 +3962,10 @@ var _Teletrada_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.teletrada",
 	HandlerType: (*TeletradaServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBackfills",
+			Handler:    _Teletrada_GetBackfills_Handler,
+		},
 		{
 			MethodName: "GetLog",
 			Handler:    _Teletrada_GetLog_Handler,
@@ -1257,6 +3974,10 @@ var _Teletrada_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPortfolio",
 			Handler:    _Teletrada_GetPortfolio_Handler,
 		},
+		{
+			MethodName: "GetPriceGaps",
+			Handler:    _Teletrada_GetPriceGaps_Handler,
+		},
 		{
 			MethodName: "GetPrices",
 			Handler:    _Teletrada_GetPrices_Handler,
@@ -1265,10 +3986,18 @@ var _Teletrada_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSimulations",
 			Handler:    _Teletrada_GetSimulations_Handler,
 		},
+		{
+			MethodName: "GetSimulationResult",
+			Handler:    _Teletrada_GetSimulationResult_Handler,
+		},
 		{
 			MethodName: "GetStatus",
 			Handler:    _Teletrada_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetStrategies",
+			Handler:    _Teletrada_GetStrategies_Handler,
+		},
 		{
 			MethodName: "GetSymbolTypes",
 			Handler:    _Teletrada_GetSymbolTypes_Handler,
@@ -1277,6 +4006,22 @@ var _Teletrada_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSimulation",
 			Handler:    _Teletrada_CreateSimulation_Handler,
 		},
+		{
+			MethodName: "CreateStrategy",
+			Handler:    _Teletrada_CreateStrategy_Handler,
+		},
+		{
+			MethodName: "AttachStrategy",
+			Handler:    _Teletrada_AttachStrategy_Handler,
+		},
+		{
+			MethodName: "DetachStrategy",
+			Handler:    _Teletrada_DetachStrategy_Handler,
+		},
+		{
+			MethodName: "StartBackfill",
+			Handler:    _Teletrada_StartBackfill_Handler,
+		},
 		{
 			MethodName: "StartSimulation",
 			Handler:    _Teletrada_StartSimulation_Handler,
@@ -1285,6 +4030,10 @@ var _Teletrada_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StopSimulation",
 			Handler:    _Teletrada_StopSimulation_Handler,
 		},
+		{
+			MethodName: "SetKillSwitch",
+			Handler:    _Teletrada_SetKillSwitch_Handler,
+		},
 		{
 			MethodName: "Rebuild",
 			Handler:    _Teletrada_Rebuild_Handler,
@@ -1293,95 +4042,3 @@ var _Teletrada_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
-
-func init() { proto1.RegisterFile("api.proto", fileDescriptor0) }
-
-var fileDescriptor0 = []byte{
-	// 1371 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x17, 0xe9, 0x8e, 0xd3, 0x56,
-	0x97, 0x24, 0x93, 0xed, 0x24, 0x93, 0x84, 0xfb, 0xc1, 0x8c, 0xbf, 0xb0, 0xca, 0xaa, 0x5a, 0x40,
-	0x55, 0xa0, 0xa1, 0xa2, 0x2a, 0x2d, 0x12, 0x0c, 0x4c, 0x19, 0xc4, 0x32, 0x23, 0x27, 0x08, 0xf1,
-	0x0b, 0x39, 0xc9, 0x9d, 0x8c, 0x55, 0xc7, 0x76, 0xed, 0x6b, 0x20, 0xbf, 0xfb, 0x2a, 0x7d, 0x92,
-	0xfe, 0xeb, 0x13, 0xf4, 0x05, 0xfa, 0xb7, 0xef, 0xd0, 0x7b, 0xcf, 0x5d, 0x6c, 0x27, 0xb3, 0xa9,
-	0xfd, 0x95, 0x7b, 0x56, 0x9f, 0xfd, 0x9c, 0x40, 0xd3, 0x8d, 0xbc, 0x41, 0x14, 0x87, 0x2c, 0x24,
-	0x55, 0xfc, 0xe9, 0xdf, 0x98, 0x87, 0xe1, 0xdc, 0xa7, 0x77, 0x11, 0x9a, 0xa4, 0x87, 0x77, 0x99,
-	0xb7, 0xa0, 0x09, 0x73, 0x17, 0x91, 0xe4, 0xb3, 0xff, 0xa8, 0x40, 0x7d, 0xc7, 0xf5, 0xdd, 0x60,
-	0x4a, 0xc9, 0x16, 0xd4, 0x92, 0xe5, 0x62, 0x12, 0xfa, 0x56, 0xe9, 0x66, 0xe9, 0x56, 0xd3, 0x51,
-	0x10, 0xe9, 0x43, 0x83, 0x7e, 0x9e, 0x1e, 0xb9, 0xc1, 0x9c, 0x5a, 0x65, 0xa4, 0x18, 0x98, 0x10,
-	0xd8, 0x38, 0x8c, 0x29, 0xb5, 0x2a, 0x1c, 0x5f, 0x76, 0xf0, 0x2d, 0xf4, 0xf8, 0xe1, 0xf4, 0x67,
-	0x3a, 0xb3, 0x36, 0x10, 0xab, 0x20, 0x72, 0x09, 0xaa, 0x2c, 0x64, 0xae, 0x6f, 0x55, 0x11, 0x2d,
-	0x01, 0xd2, 0x81, 0xb2, 0x9b, 0x58, 0x35, 0xd4, 0xcb, 0x5f, 0x82, 0x2b, 0x8a, 0xbd, 0x29, 0xb5,
-	0xea, 0x92, 0x0b, 0x01, 0x81, 0xfd, 0xe8, 0xfa, 0x29, 0xb5, 0x1a, 0x12, 0x8b, 0x00, 0xb9, 0xc3,
-	0x65, 0x99, 0xd5, 0xe4, 0xa8, 0xd6, 0xb0, 0x3f, 0x90, 0xbe, 0x0e, 0xb4, 0xaf, 0x83, 0xb1, 0xf6,
-	0x95, 0xeb, 0x65, 0xc2, 0x0b, 0x54, 0x35, 0xfc, 0xf6, 0xc8, 0x02, 0x54, 0x62, 0x60, 0x41, 0x43,
-	0x85, 0x82, 0xd6, 0x92, 0x34, 0x0d, 0x93, 0xab, 0xd0, 0x94, 0xbe, 0x0a, 0x62, 0x1b, 0x89, 0x19,
-	0x82, 0xd8, 0xd0, 0x96, 0xc0, 0xc1, 0x94, 0x09, 0x86, 0x4d, 0x64, 0x28, 0xe0, 0xc8, 0x37, 0xd0,
-	0x9a, 0xa4, 0xcb, 0x11, 0x8b, 0x5d, 0x46, 0xe7, 0x4b, 0xab, 0x83, 0xe6, 0x76, 0xa5, 0x9d, 0x03,
-	0x8d, 0x76, 0xf2, 0x3c, 0xe4, 0x3e, 0xb4, 0x13, 0xea, 0xfb, 0x46, 0xa6, 0x7b, 0xbc, 0x4c, 0x81,
-	0xc9, 0x7e, 0x04, 0xdb, 0x4f, 0x63, 0xca, 0xdf, 0x23, 0x6f, 0x91, 0xfa, 0x2e, 0xf3, 0xc2, 0xc0,
-	0xa1, 0xbf, 0xa4, 0x3c, 0x06, 0x22, 0xc8, 0xde, 0x4c, 0xa5, 0x95, 0xbf, 0x44, 0xda, 0x02, 0x77,
-	0xa1, 0xd3, 0x89, 0x6f, 0xfb, 0x35, 0x58, 0xeb, 0xe2, 0x49, 0x14, 0x06, 0x09, 0xe5, 0x2e, 0x40,
-	0x62, 0xb0, 0xa8, 0xa7, 0x35, 0xbc, 0xa8, 0xad, 0xc9, 0xd8, 0x73, 0x4c, 0x76, 0x17, 0x36, 0x9f,
-	0x53, 0xf6, 0x2a, 0x9c, 0x2b, 0x1b, 0xec, 0x1f, 0xa0, 0xa3, 0x11, 0x4a, 0xeb, 0x6d, 0xa8, 0xd3,
-	0x80, 0xc5, 0x1e, 0x4d, 0xb8, 0xca, 0x4a, 0xce, 0x41, 0xce, 0xb4, 0xcb, 0x09, 0x4b, 0x47, 0xd3,
-	0xed, 0xe7, 0xf0, 0x3f, 0x2e, 0x7c, 0x10, 0xc6, 0xec, 0x30, 0xf4, 0xbd, 0x30, 0xe7, 0x97, 0x9b,
-	0x68, 0xbf, 0x78, 0xf1, 0xdc, 0x84, 0x96, 0x37, 0x0f, 0xc2, 0x98, 0x8e, 0x16, 0xae, 0xef, 0xa3,
-	0x7b, 0x0d, 0x27, 0x8f, 0xb2, 0x77, 0xe0, 0x52, 0x51, 0x91, 0xb2, 0xe5, 0x0e, 0x34, 0x26, 0xb2,
-	0x0f, 0xb4, 0x31, 0x1d, 0x65, 0x8c, 0x6a, 0x0f, 0xc7, 0xd0, 0xed, 0x07, 0xd0, 0x13, 0x3a, 0x44,
-	0xf5, 0x24, 0xda, 0x12, 0x1e, 0xd1, 0x89, 0x9b, 0x50, 0x65, 0x0b, 0xbe, 0x95, 0x75, 0x65, 0x6d,
-	0x9d, 0xfd, 0x3d, 0x5c, 0xcc, 0xc9, 0xa9, 0x0f, 0x7f, 0x01, 0x35, 0xac, 0x43, 0xfd, 0xd9, 0xb6,
-	0xfa, 0x2c, 0xb2, 0x39, 0x8a, 0x66, 0x7f, 0x05, 0x97, 0xb9, 0x68, 0x16, 0xea, 0xe4, 0x84, 0xcc,
-	0xf2, 0x2c, 0x6e, 0xad, 0x32, 0xaa, 0x0f, 0xdd, 0x87, 0x56, 0x96, 0x1e, 0xfd, 0xb5, 0x63, 0x92,
-	0x98, 0xe7, 0xb2, 0x09, 0xba, 0x3a, 0x62, 0x2e, 0x4b, 0xf5, 0x27, 0xed, 0x3f, 0x4b, 0xe8, 0x87,
-	0x46, 0x2a, 0xf5, 0x8f, 0x61, 0x33, 0xa1, 0xf1, 0x47, 0x1a, 0x73, 0x7c, 0xcc, 0xe8, 0x4c, 0x55,
-	0xc9, 0x69, 0x6d, 0x59, 0x14, 0x20, 0x0f, 0x01, 0x7c, 0x37, 0x61, 0x6f, 0xa3, 0x19, 0x2f, 0x42,
-	0x0c, 0xdb, 0xe9, 0xe2, 0x39, 0x6e, 0x91, 0xf8, 0x14, 0x5f, 0x4f, 0xc3, 0x34, 0x60, 0x38, 0x8e,
-	0xaa, 0x4e, 0x1e, 0x25, 0x3a, 0x15, 0x07, 0xce, 0x08, 0x87, 0x5a, 0x82, 0xb3, 0xa9, 0xea, 0x14,
-	0x70, 0xf6, 0xb6, 0x8c, 0x32, 0x42, 0xe3, 0x65, 0x64, 0xb2, 0xab, 0xa3, 0x9a, 0x27, 0xe4, 0xa2,
-	0x9a, 0xa1, 0x57, 0xa3, 0x6a, 0x28, 0x4e, 0x9e, 0xcb, 0x7e, 0x03, 0x0d, 0x5d, 0xe2, 0x64, 0x00,
-	0x1b, 0x62, 0x28, 0x9f, 0x23, 0x5c, 0xc8, 0x27, 0x0a, 0x8d, 0xd1, 0xcf, 0x4c, 0xb7, 0xae, 0x78,
-	0xdb, 0x2f, 0xa1, 0x69, 0x2a, 0xda, 0xf4, 0x76, 0x29, 0xeb, 0xed, 0x42, 0x75, 0x97, 0xcf, 0xa8,
-	0xee, 0x5f, 0x2b, 0x50, 0xc5, 0xe2, 0xfb, 0x57, 0x0b, 0x41, 0xd6, 0x7c, 0xc5, 0x74, 0xa4, 0x05,
-	0xf5, 0x69, 0x1a, 0xc7, 0xbc, 0x8f, 0xd5, 0x36, 0xd0, 0xa0, 0x1a, 0xde, 0xd5, 0x73, 0x0d, 0x6f,
-	0x9e, 0x5e, 0xa9, 0x7f, 0x1c, 0xce, 0xdc, 0x25, 0x6e, 0x8b, 0xb2, 0x93, 0x47, 0x91, 0x2f, 0xa1,
-	0x63, 0x86, 0xae, 0x64, 0x92, 0xfb, 0x63, 0x05, 0x2b, 0xec, 0x09, 0x23, 0x1a, 0x78, 0xc1, 0x5c,
-	0xad, 0x12, 0x0d, 0xa2, 0xa5, 0x7e, 0x98, 0x08, 0x4a, 0x53, 0x59, 0x2a, 0x41, 0x41, 0x39, 0xf2,
-	0xe6, 0x47, 0xdc, 0x1e, 0xb5, 0x39, 0x34, 0x28, 0x57, 0xdd, 0x27, 0x41, 0x68, 0xe9, 0x55, 0x27,
-	0xa0, 0xff, 0xbe, 0x34, 0xec, 0x1e, 0x74, 0x1c, 0x3a, 0x49, 0x3d, 0x7f, 0xa6, 0x6b, 0xf0, 0x36,
-	0x74, 0x0d, 0x46, 0x15, 0x1f, 0xff, 0x7c, 0x4c, 0x93, 0xd4, 0x67, 0x3a, 0x41, 0x12, 0xb2, 0xff,
-	0xaa, 0x00, 0x64, 0x1d, 0x7d, 0x9e, 0xe9, 0x2f, 0x2c, 0xf6, 0x12, 0x27, 0x0d, 0x30, 0x32, 0x15,
-	0x9c, 0x9b, 0x19, 0x82, 0xfc, 0xc8, 0xab, 0x5c, 0x76, 0xa9, 0xc8, 0x0b, 0x66, 0xf2, 0xf4, 0xa4,
-	0xe5, 0xd9, 0xa5, 0x74, 0x18, 0x45, 0x4a, 0xba, 0x7a, 0x1e, 0x69, 0xc3, 0x4e, 0xbe, 0x86, 0x8b,
-	0x69, 0x42, 0xf7, 0x3c, 0x8e, 0xe3, 0x55, 0xe9, 0xfa, 0xcf, 0x5c, 0xe6, 0x62, 0x05, 0x34, 0x9c,
-	0x75, 0x02, 0x79, 0x00, 0x8d, 0xc3, 0x38, 0x5c, 0xe0, 0x87, 0xea, 0x67, 0x7e, 0xc8, 0xf0, 0x92,
-	0x21, 0xd4, 0x58, 0x88, 0x52, 0x8d, 0x33, 0xa5, 0x14, 0x27, 0x1f, 0xdd, 0x9b, 0x7c, 0xbe, 0xb8,
-	0x3f, 0xc5, 0x22, 0x43, 0xc1, 0x74, 0x89, 0x75, 0x53, 0x75, 0x8a, 0x48, 0x72, 0x0b, 0xba, 0xdc,
-	0x4c, 0x87, 0xba, 0xbe, 0xe8, 0x5f, 0xb4, 0x1e, 0xd0, 0xfa, 0x55, 0x34, 0x1f, 0x05, 0xcd, 0x48,
-	0xb7, 0x31, 0x16, 0x54, 0x6b, 0xd8, 0xd3, 0xdb, 0xc0, 0x2c, 0xac, 0x8c, 0xc5, 0xfe, 0xbd, 0x04,
-	0x5b, 0x38, 0x3c, 0xcf, 0x5e, 0xf8, 0x8f, 0x60, 0xe3, 0xd3, 0x11, 0x0d, 0x30, 0xe5, 0x9d, 0xe1,
-	0x6d, 0x73, 0x48, 0x1c, 0x27, 0x3c, 0x10, 0x9c, 0xfb, 0x91, 0xdc, 0x1e, 0x28, 0x66, 0xbf, 0x87,
-	0x56, 0x0e, 0x49, 0x7a, 0xd0, 0x7e, 0xb3, 0xff, 0xee, 0x83, 0xb3, 0xfb, 0xe4, 0xd5, 0xf8, 0xc5,
-	0xeb, 0xdd, 0xde, 0x05, 0xd2, 0xe6, 0x13, 0xed, 0xc9, 0x68, 0xfc, 0xe1, 0xd9, 0x93, 0xf7, 0xbd,
-	0x12, 0xd9, 0x84, 0x26, 0x42, 0xef, 0x76, 0x77, 0x5f, 0xf6, 0xca, 0xdc, 0x18, 0x40, 0xf0, 0xf5,
-	0xfe, 0x9b, 0xf1, 0x5e, 0xaf, 0x42, 0x5a, 0x50, 0x1f, 0xef, 0xed, 0x7e, 0x78, 0xb5, 0x3f, 0xee,
-	0x6d, 0xd8, 0xff, 0x87, 0xed, 0x35, 0x33, 0x64, 0x79, 0x8b, 0xa5, 0x37, 0xe2, 0x85, 0x70, 0xa6,
-	0x77, 0xb6, 0x25, 0xe2, 0x50, 0x64, 0x54, 0x2a, 0x7e, 0x2b, 0x41, 0xc3, 0x5c, 0x55, 0xab, 0x41,
-	0xe1, 0x53, 0x65, 0x46, 0x93, 0x69, 0xec, 0xa1, 0x5b, 0xaa, 0x1d, 0xf2, 0x28, 0x9c, 0x3b, 0xa1,
-	0x17, 0x1c, 0xd0, 0x78, 0x4a, 0xd5, 0x5a, 0x11, 0x73, 0x27, 0x43, 0xe5, 0x66, 0xe4, 0x46, 0x61,
-	0x46, 0xca, 0x39, 0x58, 0x35, 0x73, 0xb0, 0xd0, 0x5f, 0xb5, 0x95, 0xfe, 0xb2, 0xef, 0xf1, 0x7e,
-	0x35, 0xfb, 0xe1, 0xd4, 0x5b, 0xa2, 0x22, 0xf5, 0x0d, 0xff, 0xae, 0x42, 0x93, 0x51, 0x9f, 0x72,
-	0xdf, 0x66, 0x2e, 0xf9, 0x0e, 0x6a, 0xf2, 0xb6, 0x22, 0x97, 0x54, 0x6a, 0x0b, 0xb7, 0x57, 0xff,
-	0xf2, 0x0a, 0x56, 0x45, 0xe7, 0x02, 0x79, 0x01, 0xed, 0xfc, 0x39, 0x44, 0xfa, 0x19, 0xe3, 0xea,
-	0xb1, 0xd5, 0xbf, 0x72, 0x2c, 0xcd, 0xa8, 0x7a, 0x0c, 0x4d, 0x73, 0xdd, 0x90, 0xed, 0x1c, 0x6f,
-	0xfe, 0x4e, 0xea, 0x5b, 0xeb, 0x04, 0xa3, 0x61, 0x1f, 0x2f, 0xc4, 0xdc, 0xed, 0x42, 0xae, 0x66,
-	0xdc, 0xeb, 0xb7, 0x4f, 0xff, 0xda, 0x09, 0xd4, 0x15, 0x93, 0xe4, 0xa1, 0x92, 0x37, 0xa9, 0x70,
-	0xcf, 0xe4, 0x4d, 0x2a, 0xde, 0x34, 0x99, 0x49, 0xd9, 0xee, 0x2e, 0x98, 0xb4, 0x76, 0x28, 0x14,
-	0x4c, 0x5a, 0xbf, 0x16, 0xb8, 0xc2, 0xb7, 0xd0, 0x5b, 0xbd, 0xb2, 0xc9, 0x75, 0x25, 0x74, 0xc2,
-	0xf5, 0xde, 0xbf, 0x71, 0x22, 0xdd, 0xa8, 0x75, 0xa0, 0xbb, 0xd2, 0x45, 0xe4, 0xda, 0xa9, 0x4d,
-	0xde, 0xbf, 0x7e, 0x12, 0x39, 0xef, 0x7b, 0xb1, 0xab, 0x8c, 0xef, 0xc7, 0x76, 0x65, 0xff, 0xda,
-	0x09, 0x54, 0xa3, 0xf0, 0x21, 0xd4, 0xd5, 0x06, 0x23, 0xba, 0x20, 0x8b, 0x3b, 0xae, 0xbf, 0xb5,
-	0x8a, 0xd6, 0xb2, 0x3b, 0xf7, 0xe0, 0x8a, 0x17, 0x0e, 0xe6, 0x71, 0x34, 0x1d, 0xd0, 0xcf, 0x7c,
-	0x08, 0xfb, 0x34, 0x19, 0x1c, 0xf1, 0x7f, 0x3f, 0xe1, 0xa7, 0x30, 0xf6, 0x67, 0x3b, 0xdd, 0x3d,
-	0xf1, 0x7e, 0x27, 0xde, 0x07, 0x42, 0xc3, 0x41, 0x69, 0x52, 0x43, 0x55, 0xf7, 0xff, 0x09, 0x00,
-	0x00, 0xff, 0xff, 0xcc, 0x6e, 0x59, 0x76, 0x16, 0x0f, 0x00, 0x00,
-}
@@ -99,6 +99,8 @@ func Close() {
 		simulation
 		strategy
 	list:
+		backfills
+		gaps
 		logs
 		portfolio
 		prices
@@ -111,9 +113,17 @@ func Close() {
 		simulation
 		strategy
 	start:
+		backfill
 		simulation
 	stop:
 		simulation
+	attach:
+		strategy
+	detach:
+		strategy
 	status:
+	killswitch:
+	repair:
+		gaps
 
 */
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/desertbit/grumble"
+)
+
+func init() {
+	attachCommand := &grumble.Command{
+		Name:    "attach",
+		Aliases: []string{"at"},
+		Help:    "attach operations",
+	}
+	App.AddCommand(attachCommand)
+
+	// attach strategy
+	attachCommand.AddCommand(&grumble.Command{
+		Name:      "strategy",
+		Aliases:   []string{"st"},
+		Help:      "attach strategy to a simulation or the LIVE portfolio",
+		Usage:     "attach strategy [simulation id|LIVE] [strategy id] [buy|sell]",
+		AllowArgs: true,
+		Completer: simIdCompleter,
+		Run:       attachStrategy,
+	})
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/desertbit/grumble"
+	"github.com/telecoda/teletrada/proto"
+	"golang.org/x/net/context"
+)
+
+// backfillPollFreq - how often a followed backfill is checked
+const backfillPollFreq = time.Duration(1 * time.Second)
+
+func startBackfill(c *grumble.Context) error {
+
+	printHeading("Start backfill")
+
+	if len(c.Args) == 0 {
+		return fmt.Errorf("you must provide at least one trading pair eg. ETH/BTC")
+	}
+
+	from := c.Flags.String("from")
+	to := c.Flags.String("to")
+	if from == "" {
+		return fmt.Errorf("you must provide a --from time")
+	}
+
+	req := &proto.StartBackfillRequest{
+		Interval: c.Flags.String("interval"),
+	}
+
+	for _, pair := range c.Args {
+		req.Pairs = append(req.Pairs, strings.ToUpper(pair))
+	}
+
+	var err error
+	if req.FromTime, err = parseSimulationTime(from); err != nil {
+		return err
+	}
+	if to == "" {
+		to = time.Now().UTC().Format(time.RFC3339)
+	}
+	if req.ToTime, err = parseSimulationTime(to); err != nil {
+		return err
+	}
+
+	r, err := getClient().StartBackfill(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("failed to start backfill: %v\n", err)
+	}
+
+	fmt.Printf("Backfill %s started\n", r.Backfill.Id)
+
+	if c.Flags.Bool("follow") {
+		return followBackfill(r.Backfill.Id)
+	}
+
+	return nil
+}
+
+// followBackfill - prints the progress of a backfill until it finishes
+func followBackfill(id string) error {
+	for {
+		r, err := getClient().GetBackfills(context.Background(), &proto.GetBackfillsRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("could not get backfill: %v\n", err)
+		}
+		if len(r.Backfills) == 0 {
+			return fmt.Errorf("backfill %s not found", id)
+		}
+
+		backfill := r.Backfills[0]
+		fmt.Printf("\r%s %6.2f%% %s prices added: %d    ", backfill.State, backfill.Progress, backfill.CurrentPair, backfill.PricesAdded)
+
+		if backfill.State != "running" {
+			fmt.Println()
+			if backfill.Error != "" {
+				return fmt.Errorf("backfill failed: %s", backfill.Error)
+			}
+			return nil
+		}
+
+		time.Sleep(backfillPollFreq)
+	}
+}
+
+func listBackfills(c *grumble.Context) error {
+
+	printHeading("List backfills")
+
+	req := &proto.GetBackfillsRequest{}
+	if len(c.Args) > 0 {
+		req.Id = c.Args[0]
+	}
+
+	r, err := getClient().GetBackfills(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("could not get backfills: %v\n", err)
+	}
+
+	buf := bytes.Buffer{}
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	// Header
+	header := []string{"id", "pairs", "from", "to", "interval", "state", "progress", "prices", "error"}
+	writeHeading(tw, header)
+
+	for _, backfill := range r.Backfills {
+		writeRow(tw, formatColRow(backfill.Id, strings.Join(backfill.Pairs, ","), formatProtoTimestamp(backfill.FromTime), formatProtoTimestamp(backfill.ToTime), backfill.Interval, backfill.State, fmt.Sprintf("%.2f%%", backfill.Progress), fmt.Sprintf("%d", backfill.PricesAdded), backfill.Error))
+	}
+
+	tw.Flush()
+	fmt.Printf("%s", buf.String())
+
+	return nil
+}
@@ -106,6 +106,19 @@ func simStartCompleter(prefix string, args []string) []string {
 	return []string{"invalid number of args"}
 }
 
+func simIdCompleter(prefix string, args []string) []string {
+
+	if len(simIds) == 0 {
+		initSimIds()
+	}
+
+	if len(args) == 0 {
+		return simIds
+	}
+
+	return []string{"invalid number of args"}
+}
+
 func simStopCompleter(prefix string, args []string) []string {
 
 	if len(simIds) == 0 {
@@ -21,4 +21,14 @@ func init() {
 		AllowArgs: true,
 		Run:       createSimulation,
 	})
+
+	// create strategy
+	createCommand.AddCommand(&grumble.Command{
+		Name:      "strategy",
+		Aliases:   []string{"st"},
+		Help:      "create strategy",
+		Usage:     "create strategy [id] [type] [symbol] [as] [coinPercent] [param=value ...]",
+		AllowArgs: true,
+		Run:       createStrategy,
+	})
 }
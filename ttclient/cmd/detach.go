@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/desertbit/grumble"
+)
+
+func init() {
+	detachCommand := &grumble.Command{
+		Name:    "detach",
+		Aliases: []string{"de"},
+		Help:    "detach operations",
+	}
+	App.AddCommand(detachCommand)
+
+	// detach strategy
+	detachCommand.AddCommand(&grumble.Command{
+		Name:      "strategy",
+		Aliases:   []string{"st"},
+		Help:      "detach strategy from a simulation or the LIVE portfolio",
+		Usage:     "detach strategy [simulation id|LIVE] [symbol] [buy|sell]",
+		AllowArgs: true,
+		Completer: simIdCompleter,
+		Run:       detachStrategy,
+	})
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/desertbit/grumble"
+	"github.com/telecoda/teletrada/proto"
+	"golang.org/x/net/context"
+)
+
+func init() {
+	repairCommand := &grumble.Command{
+		Name:    "repair",
+		Aliases: []string{"rp"},
+		Help:    "repair operations",
+	}
+	App.AddCommand(repairCommand)
+
+	// repair gaps
+	repairCommand.AddCommand(&grumble.Command{
+		Name:      "gaps",
+		Aliases:   []string{"ga"},
+		Help:      "backfill gaps in the price archive",
+		Usage:     "repair gaps [base] [as]",
+		AllowArgs: true,
+		Completer: symbolCompleter,
+		Run:       repairGaps,
+	})
+}
+
+// gapsRequest - builds a request for gaps filtered by the optional base and as args
+func gapsRequest(c *grumble.Context, repair bool) *proto.GetPriceGapsRequest {
+	req := &proto.GetPriceGapsRequest{Repair: repair}
+	if len(c.Args) >= 1 {
+		req.Base = strings.ToUpper(c.Args[0])
+	}
+	if len(c.Args) >= 2 {
+		req.As = strings.ToUpper(c.Args[1])
+	}
+	return req
+}
+
+func listGaps(c *grumble.Context) error {
+
+	printHeading("List price gaps")
+
+	r, err := getClient().GetPriceGaps(context.Background(), gapsRequest(c, false))
+	if err != nil {
+		return fmt.Errorf("could not get price gaps: %v\n", err)
+	}
+
+	printGaps(r)
+
+	return nil
+}
+
+func repairGaps(c *grumble.Context) error {
+
+	printHeading("Repair price gaps")
+
+	r, err := getClient().GetPriceGaps(context.Background(), gapsRequest(c, true))
+	if err != nil {
+		return fmt.Errorf("could not repair price gaps: %v\n", err)
+	}
+
+	printGaps(r)
+
+	if len(r.BackfillIds) == 0 {
+		fmt.Printf("No backfills started\n")
+		return nil
+	}
+
+	fmt.Printf("Backfills started: %s\n", strings.Join(r.BackfillIds, ", "))
+	fmt.Printf("Use \"list backfills\" to follow their progress\n")
+
+	return nil
+}
+
+func printGaps(r *proto.GetPriceGapsResponse) {
+
+	fmt.Print(formatAttrString("Max gap", (time.Duration(r.MaxGapSeconds)*time.Second).String()+"\n"))
+
+	if len(r.Gaps) == 0 {
+		fmt.Printf("No gaps found\n")
+		return
+	}
+
+	buf := bytes.Buffer{}
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	// Header
+	header := []string{"base", "as", "from", "to", "missing"}
+	writeHeading(tw, header)
+
+	for _, gap := range r.Gaps {
+		writeRow(tw, formatColRow(gap.Base, gap.As, formatProtoTimestamp(gap.FromTime), formatProtoTimestamp(gap.ToTime), fmt.Sprintf("%d", gap.MissingPrices)))
+	}
+
+	tw.Flush()
+	fmt.Printf("%s", buf.String())
+}
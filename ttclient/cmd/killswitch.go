@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/desertbit/grumble"
+	"github.com/telecoda/teletrada/proto"
+	"golang.org/x/net/context"
+)
+
+func init() {
+	App.AddCommand(&grumble.Command{
+		Name:      "killswitch",
+		Aliases:   []string{"ks"},
+		Help:      "halt or resume live trading",
+		Usage:     "killswitch [on|off] [reason]",
+		AllowArgs: true,
+		Run:       setKillSwitch,
+	})
+}
+
+func setKillSwitch(c *grumble.Context) error {
+
+	if len(c.Args) == 0 {
+		return fmt.Errorf("you must provide on or off")
+	}
+
+	req := &proto.SetKillSwitchRequest{
+		Reason: strings.Join(c.Args[1:], " "),
+	}
+
+	switch strings.ToLower(c.Args[0]) {
+	case "on":
+		req.Engaged = true
+	case "off":
+		req.Engaged = false
+	default:
+		return fmt.Errorf("%q is not valid, must be on or off", c.Args[0])
+	}
+
+	if _, err := getClient().SetKillSwitch(context.Background(), req); err != nil {
+		return fmt.Errorf("failed to set kill switch: %v\n", err)
+	}
+
+	if req.Engaged {
+		printWarningString("Kill switch engaged, live trading halted")
+	} else {
+		fmt.Printf("Kill switch released, live trading resumed\n")
+	}
+
+	return nil
+}
@@ -12,6 +12,27 @@ func init() {
 	}
 	App.AddCommand(listCommand)
 
+	// list backfills
+	listCommand.AddCommand(&grumble.Command{
+		Name:      "backfills",
+		Aliases:   []string{"bf"},
+		Help:      "list backfills",
+		Usage:     "list backfills [id]",
+		AllowArgs: true,
+		Run:       listBackfills,
+	})
+
+	// list gaps
+	listCommand.AddCommand(&grumble.Command{
+		Name:      "gaps",
+		Aliases:   []string{"ga"},
+		Help:      "list gaps in the price archive",
+		Usage:     "list gaps [base] [as]",
+		AllowArgs: true,
+		Completer: symbolCompleter,
+		Run:       listGaps,
+	})
+
 	// list logs
 	listCommand.AddCommand(&grumble.Command{
 		Name:    "logs",
@@ -35,7 +56,11 @@ func init() {
 		Help:      "list portfolio",
 		Usage:     "list portfolio [as]",
 		AllowArgs: true,
-		Run:       listPortfolio,
+		Flags: func(f *grumble.Flags) {
+			f.String("e", "exchange", "", "only balances on this exchange")
+			f.Bool("x", "byexchange", false, "list balances on each exchange instead of combining them")
+		},
+		Run: listPortfolio,
 	})
 
 	// list prices
@@ -57,12 +82,26 @@ func init() {
 		Run:     listSimulations,
 	})
 
+	// list simulation result
+	listCommand.AddCommand(&grumble.Command{
+		Name:      "result",
+		Aliases:   []string{"re"},
+		Help:      "show simulation result",
+		Usage:     "list result [id]",
+		AllowArgs: true,
+		Completer: simIdCompleter,
+		Run:       listSimulationResult,
+	})
+
 	// list strategies
 	listCommand.AddCommand(&grumble.Command{
-		Name:    "strategies",
-		Aliases: []string{"st"},
-		Help:    "list strategies",
-		Run:     listStrategies,
+		Name:      "strategies",
+		Aliases:   []string{"st"},
+		Help:      "list strategies",
+		Usage:     "list strategies [simulation id]",
+		AllowArgs: true,
+		Completer: simIdCompleter,
+		Run:       listStrategies,
 	})
 
 }
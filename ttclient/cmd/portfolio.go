@@ -23,9 +23,19 @@ func listPortfolio(c *grumble.Context) error {
 		as = defaultSymbol
 	}
 
-	printHeading(fmt.Sprintf("Listing portfolio as %q", as))
 	req := &proto.GetPortfolioRequest{
-		As: as,
+		As:         as,
+		Exchange:   strings.ToLower(c.Flags.String("exchange")),
+		ByExchange: c.Flags.Bool("byexchange"),
+	}
+
+	switch {
+	case req.Exchange != "":
+		printHeading(fmt.Sprintf("Listing %s portfolio as %q", req.Exchange, as))
+	case req.ByExchange:
+		printHeading(fmt.Sprintf("Listing portfolio by exchange as %q", as))
+	default:
+		printHeading(fmt.Sprintf("Listing portfolio as %q", as))
 	}
 
 	r, err := getClient().GetPortfolio(context.Background(), req)
@@ -46,7 +56,7 @@ func printBalances(balances []*proto.Balance) error {
 	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', tabwriter.AlignRight)
 
 	// Header
-	header := []string{"sym", "as", "total", "price", "price24", "value", "value24", "at", "change24", "changePct", "buystrat", "sellstrat", ""}
+	header := []string{"sym", "exchange", "as", "total", "price", "price24", "value", "value24", "at", "change24", "changePct", "buystrat", "sellstrat", ""}
 	writeHeading(tw, header)
 
 	total := proto.Balance{
@@ -67,7 +77,7 @@ func printBalances(balances []*proto.Balance) error {
 		if balance.SellStrategy != nil {
 			sellStrat = balance.SellStrategy.Id
 		}
-		writeRow(tw, formatColRow(balance.Symbol, balance.As, priceField(balance.Total), priceField(balance.Price), priceField(balance.Price24H), priceField(balance.Value), priceField(balance.Value24H), at.Format(DATE_FORMAT), priceField(balance.Change24H), percentField(balance.ChangePct24H), buyStrat, sellStrat, ""))
+		writeRow(tw, formatColRow(balance.Symbol, balance.Exchange, balance.As, priceField(balance.Total), priceField(balance.Price), priceField(balance.Price24H), priceField(balance.Value), priceField(balance.Value24H), at.Format(DATE_FORMAT), priceField(balance.Change24H), percentField(balance.ChangePct24H), buyStrat, sellStrat, ""))
 
 		// add to total
 		total.Exchange = balance.Exchange
@@ -82,7 +92,7 @@ func printBalances(balances []*proto.Balance) error {
 	}
 
 	// Print total
-	writeRow(tw, formatColRow(total.Symbol, total.As, "", "", "", priceField(total.Value), priceField(total.Value24H), "", priceField(total.Change24H), percentField(total.ChangePct24H), ""))
+	writeRow(tw, formatColRow(total.Symbol, "", total.As, "", "", "", priceField(total.Value), priceField(total.Value24H), "", priceField(total.Change24H), percentField(total.ChangePct24H), ""))
 
 	tw.Flush()
 	fmt.Printf("%s", buf.String())
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/desertbit/grumble"
+	tspb "github.com/golang/protobuf/ptypes"
+	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/telecoda/teletrada/proto"
 	"golang.org/x/net/context"
 )
@@ -56,6 +58,62 @@ func printSimulation(simulation *proto.Simulation) {
 	}
 }
 
+func listSimulationResult(c *grumble.Context) error {
+
+	printHeading("Simulation result")
+
+	if len(c.Args) != 1 {
+		return fmt.Errorf("you must provide a simulation id")
+	}
+
+	req := &proto.GetSimulationResultRequest{
+		Id: c.Args[0],
+	}
+
+	r, err := getClient().GetSimulationResult(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("could not get simulation result: %v\n", err)
+	}
+
+	printSimulationResult(r.Result)
+
+	return nil
+}
+
+func printSimulationResult(result *proto.SimulationResult) {
+	if result == nil {
+		return
+	}
+
+	printHeading(fmt.Sprintf("Id: %s", result.Id))
+	fmt.Print(formatAttrString("From", formatProtoTimestamp(result.FromTime)+"\n"))
+	fmt.Print(formatAttrString("To  ", formatProtoTimestamp(result.ToTime)+"\n"))
+	fmt.Print(formatAttrString("Start value", fmt.Sprintf("%f %s", result.StartValue, result.As)+"\n"))
+	fmt.Print(formatAttrString("End value", fmt.Sprintf("%f %s", result.EndValue, result.As)+"\n"))
+	fmt.Print(formatAttrString("Total return", fmt.Sprintf("%.2f%%", result.TotalReturn)+"\n"))
+	fmt.Print(formatAttrString("Annualised return", fmt.Sprintf("%.2f%%", result.AnnualisedReturn)+"\n"))
+	fmt.Print(formatAttrString("Max drawdown", fmt.Sprintf("%.2f%%", result.MaxDrawdown)+"\n"))
+	fmt.Print(formatAttrString("Volatility", fmt.Sprintf("%.2f%%", result.Volatility)+"\n"))
+	fmt.Print(formatAttrString("Sharpe ratio", fmt.Sprintf("%.2f", result.SharpeRatio)+"\n"))
+	fmt.Print(formatAttrString("Sortino ratio", fmt.Sprintf("%.2f", result.SortinoRatio)+"\n"))
+	fmt.Print(formatAttrInt("Trades", int(result.Trades)) + "\n")
+	fmt.Print(formatAttrString("Win rate", fmt.Sprintf("%.2f%%", result.WinRate)+"\n"))
+	fmt.Print(formatAttrString("Synthetic prices", fmt.Sprintf("%.2f%%", result.SyntheticPricePercent)+"\n"))
+
+	printHeading("Buy and hold benchmark")
+	fmt.Print(formatAttrString("Start value", fmt.Sprintf("%f %s", result.BenchmarkStartValue, result.As)+"\n"))
+	fmt.Print(formatAttrString("End value", fmt.Sprintf("%f %s", result.BenchmarkEndValue, result.As)+"\n"))
+	fmt.Print(formatAttrString("Return", fmt.Sprintf("%.2f%%", result.BenchmarkReturn)+"\n"))
+
+	// how much better (or worse) the simulation did than doing nothing
+	diff := result.TotalReturn - result.BenchmarkReturn
+	if diff < 0 {
+		printWarningString(fmt.Sprintf("simulation under performed benchmark by %.2f%%", -diff))
+	} else {
+		fmt.Print(formatAttrString("Out performed by", fmt.Sprintf("%.2f%%", diff)+"\n"))
+	}
+}
+
 func createSimulation(c *grumble.Context) error {
 	id := ""
 	name := ""
@@ -86,6 +144,14 @@ func startSimulation(c *grumble.Context) error {
 
 	printHeading("Start simulation")
 
+	from := c.Flags.String("from")
+	to := c.Flags.String("to")
+
+	// a timescale is optional when dates are provided
+	if len(c.Args) == 1 && (from != "" || to != "") {
+		c.Args = append(c.Args, proto.StartSimulationRequest_CUSTOM.String())
+	}
+
 	if len(c.Args) != 2 {
 		return fmt.Errorf("you must provide a simulation id and timescale")
 	}
@@ -100,8 +166,23 @@ func startSimulation(c *grumble.Context) error {
 	}
 
 	req := &proto.StartSimulationRequest{
-		Id:   c.Args[0],
-		When: proto.StartSimulationRequestWhenOptions(when),
+		Id:            c.Args[0],
+		When:          proto.StartSimulationRequestWhenOptions(when),
+		DataFrequency: int32(c.Flags.Duration("frequency").Seconds()),
+	}
+
+	if req.When == proto.StartSimulationRequest_CUSTOM {
+		if from == "" || to == "" {
+			return fmt.Errorf("you must provide --from and --to times for a %s timescale", proto.StartSimulationRequest_CUSTOM)
+		}
+
+		var err error
+		if req.FromTime, err = parseSimulationTime(from); err != nil {
+			return err
+		}
+		if req.ToTime, err = parseSimulationTime(to); err != nil {
+			return err
+		}
 	}
 
 	_, err := getClient().StartSimulation(context.Background(), req)
@@ -113,6 +194,22 @@ func startSimulation(c *grumble.Context) error {
 	return nil
 }
 
+var simulationTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseSimulationTime - parses a UTC time in one of the supported formats
+func parseSimulationTime(value string) (*google_protobuf.Timestamp, error) {
+	for _, format := range simulationTimeFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return tspb.TimestampProto(t)
+		}
+	}
+	return nil, fmt.Errorf("time %q is not valid, use a format like %s", value, simulationTimeFormats[len(simulationTimeFormats)-1])
+}
+
 func stopSimulation(c *grumble.Context) error {
 
 	printHeading("Stop simulation")
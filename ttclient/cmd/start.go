@@ -11,13 +11,34 @@ func init() {
 	}
 	App.AddCommand(startCommand)
 
+	// start backfill
+	startCommand.AddCommand(&grumble.Command{
+		Name:      "backfill",
+		Aliases:   []string{"bf"},
+		Help:      "download historical prices into the archive",
+		Usage:     "start backfill [base/as ...] --from time [--to time] [--interval 1m] [--follow]",
+		AllowArgs: true,
+		Flags: func(f *grumble.Flags) {
+			f.String("f", "from", "", "start of prices e.g. 2018-01-15 or 2018-01-15T12:00")
+			f.String("t", "to", "", "end of prices e.g. 2018-01-22 or 2018-01-22T12:00 (default now)")
+			f.String("i", "interval", "1m", "candle interval e.g. 1m, 5m, 15m, 1h, 4h, 1d")
+			f.Bool("w", "follow", false, "follow progress until the backfill finishes")
+		},
+		Run: startBackfill,
+	})
+
 	// start simulation
 	startCommand.AddCommand(&grumble.Command{
 		Name:      "simulation",
 		Aliases:   []string{"si"},
 		Help:      "start simulation",
-		Usage:     "start simulation [id] [timescale]",
+		Usage:     "start simulation [id] [timescale] [--from time --to time] [--frequency duration]",
 		AllowArgs: true,
+		Flags: func(f *grumble.Flags) {
+			f.String("f", "from", "", "start of CUSTOM timescale e.g. 2018-01-15 or 2018-01-15T12:00")
+			f.String("t", "to", "", "end of CUSTOM timescale e.g. 2018-01-22 or 2018-01-22T12:00")
+			f.Duration("q", "frequency", 0, "how often prices are sampled e.g. 1m, 1h (default 5m)")
+		},
 		Completer: simStartCompleter,
 		Run:       startSimulation,
 	})
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	tspb "github.com/golang/protobuf/ptypes"
 	"github.com/telecoda/teletrada/proto"
@@ -33,6 +35,82 @@ func getStatus(c *grumble.Context) error {
 	fmt.Print(formatAttrInt("Update count", int(s.UpdateCount)) + "\n")
 	fmt.Print(formatAttrInt("Total symbols", int(s.TotalSymbols)) + "\n")
 
+	if s.PriceStreamConnected {
+		fmt.Print(formatAttrString("Price stream", "connected") + "\n")
+	} else {
+		fmt.Print(formatAttrString("Price stream", "not connected, polling only") + "\n")
+	}
+	fmt.Print(formatAttrInt("Streamed prices", int(s.StreamedPrices)) + "\n")
+	if s.LastStreamedPrice != nil {
+		fmt.Print(formatAttrString("Last streamed", formatProtoTimestamp(s.LastStreamedPrice)) + "\n")
+	}
+
+	if s.Exchange != nil {
+		printExchangeStatus(s.Exchange)
+	}
+
+	if s.Risk != nil {
+		printRiskStatus(s.Risk)
+	}
+
 	return nil
 
 }
+
+func printExchangeStatus(e *proto.ExchangeStatus) {
+	printHeading("Exchange")
+	fmt.Print(formatAttrString("Exchange", e.Exchange) + "\n")
+	if e.CircuitBreaker == "closed" {
+		fmt.Print(formatAttrString("Circuit breaker", e.CircuitBreaker) + "\n")
+	} else {
+		printWarningString(fmt.Sprintf("Circuit breaker %s since %s", e.CircuitBreaker, formatProtoTimestamp(e.OpenedTime)))
+	}
+	fmt.Print(formatAttrInt("Failures in a row", int(e.ConsecutiveFailures)) + "\n")
+	if e.LastError != "" {
+		fmt.Print(formatAttrString("Last error", e.LastError) + "\n")
+	}
+	fmt.Print(formatAttrString("Weight used", fmt.Sprintf("%d of %s a minute", e.WeightUsed, limitField(float32(e.WeightLimit)))) + "\n")
+	fmt.Print(formatAttrInt("Requests", int(e.Requests)) + "\n")
+	fmt.Print(formatAttrInt("Retries", int(e.Retries)) + "\n")
+	fmt.Print(formatAttrInt("Failures", int(e.Failures)) + "\n")
+}
+
+func printRiskStatus(r *proto.RiskStatus) {
+	printHeading("Risk")
+	if r.KillSwitchEngaged {
+		printWarningString(fmt.Sprintf("Kill switch engaged: %s", r.KillSwitchReason))
+	} else {
+		fmt.Print(formatAttrString("Kill switch", "off") + "\n")
+	}
+	fmt.Print(formatAttrString("Max order size", formatLimits(r.MaxOrderSize)) + "\n")
+	fmt.Print(formatAttrString("Max trade pct", limitField(r.MaxTradePercent)) + "\n")
+	fmt.Print(formatAttrString("Max daily loss", limitField(r.MaxDailyLoss)) + "\n")
+	fmt.Print(formatAttrString("Max orders/hour", limitField(float32(r.MaxOrdersPerHour))) + "\n")
+	fmt.Print(formatAttrInt("Orders last hour", int(r.OrdersLastHour)) + "\n")
+	fmt.Print(formatAttrString("Realised today", fmt.Sprintf("%f", r.RealisedToday)) + "\n")
+}
+
+// limitField - limits of 0 are not enforced
+func limitField(limit float32) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%g", limit)
+}
+
+func formatLimits(limits map[string]float32) string {
+	if len(limits) == 0 {
+		return "unlimited"
+	}
+	symbols := make([]string, 0, len(limits))
+	for symbol := range limits {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	pairs := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		pairs[i] = fmt.Sprintf("%s=%g", symbol, limits[symbol])
+	}
+	return strings.Join(pairs, " ")
+}
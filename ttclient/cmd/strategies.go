@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/desertbit/grumble"
 	"github.com/telecoda/teletrada/proto"
@@ -12,34 +17,182 @@ func listStrategies(c *grumble.Context) error {
 
 	printHeading("List strategies")
 
-	// for now just fetch the strategies on the simulation
-	req := &proto.GetSimulationsRequest{}
+	req := &proto.GetStrategiesRequest{}
 
 	if len(c.Args) > 0 {
-		req.Id = c.Args[0]
+		req.SimulationId = c.Args[0]
 	}
 
-	r, err := getClient().GetSimulations(context.Background(), req)
+	r, err := getClient().GetStrategies(context.Background(), req)
 	if err != nil {
-		return fmt.Errorf("could not get simulations: %v\n", err)
-	}
-
-	for _, simulation := range r.Simulations {
-		if simulation.Portfolio != nil {
-			// Print simulation header details
-			printHeading(fmt.Sprintf("Name: %s PortName: %s", simulation.Name, simulation.Portfolio.Name))
-
-			for _, balance := range simulation.Portfolio.Balances {
-				if balance.BuyStrategy != nil {
-					fmt.Printf("Sim: %s BuyStrat: %s\n", simulation.Name, balance.BuyStrategy.Description)
-				}
-				if balance.BuyStrategy != nil {
-					fmt.Printf("Sim: %s SellStrat: %s\n", simulation.Name, balance.SellStrategy.Description)
-				}
-			}
+		return fmt.Errorf("could not get strategies: %v\n", err)
+	}
+
+	if len(r.Strategies) > 0 {
+		printHeading("Created")
+		printStrategies(r.Strategies)
+	}
+
+	if len(r.Attached) > 0 {
+		printHeading("Attached")
+		printAttachedStrategies(r.Attached)
+	}
+
+	return nil
+}
+
+func printStrategies(strategies []*proto.Strategy) {
+	buf := bytes.Buffer{}
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	// Header
+	header := []string{"id", "type", "sym", "as", "coinPct", "params", "description"}
+	writeHeading(tw, header)
+
+	for _, strategy := range strategies {
+		writeRow(tw, formatColRow(strategy.Id, strategy.Type, strategy.Symbol, strategy.As, percentField(strategy.CoinPercent), formatParams(strategy.Params), strategy.Description))
+	}
+
+	tw.Flush()
+	fmt.Printf("%s", buf.String())
+}
+
+func printAttachedStrategies(attached []*proto.AttachedStrategy) {
+	buf := bytes.Buffer{}
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	// Header
+	header := []string{"portfolio", "sym", "slot", "id", "type", "running", "description"}
+	writeHeading(tw, header)
+
+	for _, a := range attached {
+		if a.Strategy == nil {
+			continue
 		}
+		writeRow(tw, formatColRow(a.Portfolio, a.Symbol, strings.ToLower(a.Slot.String()), a.Strategy.Id, a.Strategy.Type, fmt.Sprintf("%t", a.Strategy.IsRunning), a.Strategy.Description))
 	}
 
-	// TODO: add code to fetch strategies from the live portfolio too
+	tw.Flush()
+	fmt.Printf("%s", buf.String())
+}
+
+func formatParams(params map[string]float64) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%g", name, params[name])
+	}
+	return strings.Join(pairs, " ")
+}
+
+func createStrategy(c *grumble.Context) error {
+
+	if len(c.Args) < 5 {
+		return fmt.Errorf("you must provide a strategy id, type, symbol, as and coin percent")
+	}
+
+	coinPercent, err := strconv.ParseFloat(c.Args[4], 32)
+	if err != nil {
+		return fmt.Errorf("coin percent %q is not a number", c.Args[4])
+	}
+
+	// remaining args are strategy params
+	params := make(map[string]float64)
+	for _, arg := range c.Args[5:] {
+		pair := strings.SplitN(arg, "=", 2)
+		if len(pair) != 2 {
+			return fmt.Errorf("param %q must be in the format name=value", arg)
+		}
+		value, err := strconv.ParseFloat(pair[1], 64)
+		if err != nil {
+			return fmt.Errorf("param %q value is not a number", pair[0])
+		}
+		params[pair[0]] = value
+	}
+
+	req := &proto.CreateStrategyRequest{
+		Id:          c.Args[0],
+		Type:        c.Args[1],
+		Symbol:      strings.ToUpper(c.Args[2]),
+		As:          strings.ToUpper(c.Args[3]),
+		CoinPercent: float32(coinPercent),
+		Params:      params,
+	}
+
+	r, err := getClient().CreateStrategy(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("failed to create strategy: %v\n", err)
+	}
+
+	printHeading("Created strategy")
+	printStrategies([]*proto.Strategy{r.Strategy})
+
+	return nil
+}
+
+func parseStrategySlot(slot string) (proto.StrategySlot, error) {
+	value, ok := proto.StrategySlot_value[strings.ToUpper(slot)]
+	if !ok {
+		return proto.StrategySlot_BUY, fmt.Errorf("slot %q is not valid, must be buy or sell", slot)
+	}
+	return proto.StrategySlot(value), nil
+}
+
+func attachStrategy(c *grumble.Context) error {
+
+	if len(c.Args) != 3 {
+		return fmt.Errorf("you must provide a simulation id or LIVE, strategy id and buy or sell slot")
+	}
+
+	slot, err := parseStrategySlot(c.Args[2])
+	if err != nil {
+		return err
+	}
+
+	req := &proto.AttachStrategyRequest{
+		SimulationId: c.Args[0],
+		StrategyId:   c.Args[1],
+		Slot:         slot,
+	}
+
+	if _, err := getClient().AttachStrategy(context.Background(), req); err != nil {
+		return fmt.Errorf("failed to attach strategy: %v\n", err)
+	}
+
+	fmt.Printf("Attached strategy: %s to: %s\n", req.StrategyId, req.SimulationId)
+
+	return nil
+}
+
+func detachStrategy(c *grumble.Context) error {
+
+	if len(c.Args) != 3 {
+		return fmt.Errorf("you must provide a simulation id or LIVE, symbol and buy or sell slot")
+	}
+
+	slot, err := parseStrategySlot(c.Args[2])
+	if err != nil {
+		return err
+	}
+
+	req := &proto.DetachStrategyRequest{
+		SimulationId: c.Args[0],
+		Symbol:       strings.ToUpper(c.Args[1]),
+		Slot:         slot,
+	}
+
+	if _, err := getClient().DetachStrategy(context.Background(), req); err != nil {
+		return fmt.Errorf("failed to detach strategy: %v\n", err)
+	}
+
+	fmt.Printf("Detached %s strategy on: %s from: %s\n", strings.ToLower(slot.String()), req.Symbol, req.SimulationId)
+
 	return nil
 }
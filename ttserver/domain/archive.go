@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/telecoda/teletrada/exchanges"
 	"github.com/telecoda/teletrada/ttserver/servertime"
 )
 
@@ -28,11 +29,15 @@ const (
 type SymbolsArchive interface {
 	AddSymbol(symbol Symbol) bool
 	AddPrice(price Price) error
+	AddPrices(base SymbolType, prices []Price) (int, error)
+	AddAllPrices(prices []Price) (int, error)
 	GetSymbol(symbol SymbolType) (Symbol, error)
 	GetSymbolTypes() map[SymbolType][]SymbolType
 	GetLatestPriceAs(base SymbolType, as SymbolType) (Price, error)
 	GetPriceAs(base SymbolType, as SymbolType, at time.Time) (Price, error)
 	GetDaySummaryAs(base SymbolType, as SymbolType) (DaySummary, error)
+	GetPriceRange() (time.Time, time.Time, error)
+	GetPriceGaps(maxGap time.Duration, until time.Time) []PriceGap
 
 	UpdatePrices() error
 	UpdateDaySummaries() error
@@ -48,8 +53,12 @@ type symbolsArchive struct {
 	updateStarted time.Time
 	lastUpdated   time.Time
 	updateCount   int
-	// price persistence
-	persist bool
+	// prices added are saved to the store, they are only held in memory if nil
+	store priceStore
+	// exchange prices are fetched from, DefaultClient if nil
+	client exchanges.ExchangeClient
+	// creates the symbols prices are added to, NewSymbol if nil
+	newSymbol func(SymbolType) Symbol
 }
 
 type ArchiveStatus struct {
@@ -65,6 +74,113 @@ func NewSymbolsArchive() SymbolsArchive {
 	return sa
 }
 
+// newExchangeArchive - returns an archive of the prices on an exchange other than the primary one
+func newExchangeArchive(client exchanges.ExchangeClient) SymbolsArchive {
+	return &symbolsArchive{
+		symbols: make(map[SymbolType]Symbol),
+		client:  client,
+	}
+}
+
+// newStoredArchive - returns an archive holding the prices in a store, prices added to it are
+// saved to the store
+func newStoredArchive(store priceStore) (SymbolsArchive, error) {
+	sa := &symbolsArchive{
+		symbols: make(map[SymbolType]Symbol),
+	}
+
+	prices, err := store.loadPrices()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load stored prices: %s", err)
+	}
+
+	byBase := make(map[SymbolType][]Price)
+	for _, price := range prices {
+		byBase[price.Base] = append(byBase[price.Base], price)
+	}
+	for base, basePrices := range byBase {
+		if _, err := sa.addPrices(base, basePrices); err != nil {
+			return nil, fmt.Errorf("Failed to load stored prices: %s", err)
+		}
+	}
+	log.Printf("Loaded %d stored prices for %d symbols\n", len(prices), len(byBase))
+
+	// only prices added from now on are saved
+	sa.store = store
+
+	return sa, nil
+}
+
+// newLiveArchive - returns the archive of the primary exchange's prices and the store they are kept in,
+// stored prices older than the retention are removed first
+func newLiveArchive(config Config) (priceStore, SymbolsArchive, error) {
+	now := servertime.Now()
+
+	if config.Archive == ARCHIVE_BOLT {
+		store, err := newBoltPriceStore(filepath.Join(config.DataDir, PRICES_DB), config.PriceRetention)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := store.compact(now); err != nil {
+			store.close()
+			return nil, nil, err
+		}
+		archive, err := newBoltArchive(store, config.ArchiveCache)
+		if err != nil {
+			store.close()
+			return nil, nil, err
+		}
+		return store, archive, nil
+	}
+
+	store, err := newPriceStore(config.DataDir, config.PriceRetention)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := store.compact(now); err != nil {
+		return nil, nil, err
+	}
+	archive, err := newStoredArchive(store)
+	if err != nil {
+		return nil, nil, err
+	}
+	// days partly older than the retention are still stored
+	archive.(*symbolsArchive).removePricesBefore(store.retainedFrom(now))
+	return store, archive, nil
+}
+
+// removePricesBefore - removes prices earlier than a time from every symbol held in memory
+func (sa *symbolsArchive) removePricesBefore(at time.Time) {
+	if at.IsZero() {
+		return
+	}
+
+	sa.RLock()
+	defer sa.RUnlock()
+
+	for _, held := range sa.symbols {
+		if s, ok := held.(*symbol); ok {
+			s.removePricesBefore(at)
+		}
+	}
+}
+
+// createSymbol - returns a new symbol for prices to be added to
+func (sa *symbolsArchive) createSymbol(base SymbolType) Symbol {
+	if sa.newSymbol != nil {
+		return sa.newSymbol(base)
+	}
+	return NewSymbol(base)
+}
+
+// exchangeClient - returns the client of the exchange prices are fetched from
+func (sa *symbolsArchive) exchangeClient() exchanges.ExchangeClient {
+	if sa.client != nil {
+		return sa.client
+	}
+	return DefaultClient
+}
+
 func (sa *symbolsArchive) GetSymbol(symbol SymbolType) (Symbol, error) {
 	sa.RLock()
 	defer sa.RUnlock()
@@ -111,6 +227,11 @@ func (sa *symbolsArchive) GetLatestPriceAs(base SymbolType, as SymbolType) (Pric
 		return price, nil
 	}
 
+	// the exchange may only quote the pair the other way round eg. BTC/USD but not USD/BTC
+	if inverse, err := sa.getLatestPriceAs(as, base); err == nil && inverse.Price != 0 {
+		return inverse.inverted(), nil
+	}
+
 	// no price found for trading pair of base/as
 	// so we'll have to convert via BTC
 
@@ -165,6 +286,10 @@ func (sa *symbolsArchive) GetPriceAs(base SymbolType, as SymbolType, at time.Tim
 		return price, nil
 	}
 
+	if inverse, err := sa.getPriceAs(as, base, at); err == nil && inverse.Price != 0 {
+		return inverse.inverted(), nil
+	}
+
 	// no price found for trading pair of base/as
 	// so we'll have to convert via BTC
 
@@ -185,11 +310,12 @@ func (sa *symbolsArchive) GetPriceAs(base SymbolType, as SymbolType, at time.Tim
 
 	// combine price conversions for overall exchange rate
 	combinedPrice := Price{
-		Base:     base,
-		As:       as,
-		Price:    baseToBtc.Price * btcToAs.Price,
-		At:       at,
-		Exchange: baseToBtc.Exchange,
+		Base:         base,
+		As:           as,
+		Price:        baseToBtc.Price * btcToAs.Price,
+		At:           at,
+		Exchange:     baseToBtc.Exchange,
+		Interpolated: baseToBtc.Interpolated || btcToAs.Interpolated,
 	}
 
 	return combinedPrice, nil
@@ -227,9 +353,38 @@ func (sa *symbolsArchive) GetDaySummaryAs(base SymbolType, as SymbolType) (DaySu
 
 }
 
+// GetPriceRange - returns the times of the earliest and latest prices held in the archive
+func (sa *symbolsArchive) GetPriceRange() (time.Time, time.Time, error) {
+	sa.RLock()
+	defer sa.RUnlock()
+
+	var from, to time.Time
+	found := false
+
+	for _, symbol := range sa.symbols {
+		symbolFrom, symbolTo, ok := symbol.GetPriceRange()
+		if !ok {
+			continue
+		}
+		if !found || symbolFrom.Before(from) {
+			from = symbolFrom
+		}
+		if !found || symbolTo.After(to) {
+			to = symbolTo
+		}
+		found = true
+	}
+
+	if !found {
+		return from, to, fmt.Errorf("Archive has no prices")
+	}
+
+	return from, to, nil
+}
+
 func (sa *symbolsArchive) UpdatePrices() error {
 
-	exPrices, err := DefaultClient.GetLatestPrices()
+	exPrices, err := sa.exchangeClient().GetLatestPrices()
 	if err != nil {
 		return fmt.Errorf("Failed to get latest prices: %s", err)
 	}
@@ -253,6 +408,10 @@ func (sa *symbolsArchive) UpdatePrices() error {
 		}
 	}
 
+	if err := sa.storePrices(prices); err != nil {
+		return err
+	}
+
 	// send to influxDB
 	if err := DefaultMetrics.SavePriceMetrics(prices); err != nil {
 		return err
@@ -266,11 +425,86 @@ func (sa *symbolsArchive) UpdatePrices() error {
 }
 
 func (sa *symbolsArchive) AddPrice(price Price) error {
-	return sa.savePrice(price)
+	if err := sa.savePrice(price); err != nil {
+		return err
+	}
+	return sa.storePrices([]Price{price})
+}
+
+// AddPrices - adds many prices for a base symbol, prices already held for a time are kept.
+// Returns the number of prices added
+func (sa *symbolsArchive) AddPrices(base SymbolType, prices []Price) (int, error) {
+	added, err := sa.addPrices(base, prices)
+	if err != nil || added == 0 {
+		return added, err
+	}
+
+	// prices already held are stored again, they are removed when the store is compacted
+	return added, sa.storePrices(prices)
+}
+
+// AddAllPrices - adds prices for any base symbols, prices already held for a time are kept.
+// The prices are stored in one go rather than for each base. Returns the number of prices added
+func (sa *symbolsArchive) AddAllPrices(prices []Price) (int, error) {
+	byBase := make(map[SymbolType][]Price)
+	for _, price := range prices {
+		byBase[price.Base] = append(byBase[price.Base], price)
+	}
+
+	added := 0
+	stored := make([]Price, 0, len(prices))
+	var addErr error
+	for base, basePrices := range byBase {
+		baseAdded, err := sa.addPrices(base, basePrices)
+		if err != nil {
+			// carry on adding the other bases
+			addErr = err
+			continue
+		}
+		if baseAdded > 0 {
+			added += baseAdded
+			stored = append(stored, basePrices...)
+		}
+	}
+
+	if len(stored) > 0 {
+		if err := sa.storePrices(stored); err != nil {
+			return added, err
+		}
+	}
+
+	return added, addErr
+}
+
+func (sa *symbolsArchive) addPrices(base SymbolType, prices []Price) (int, error) {
+	for _, price := range prices {
+		if price.Base != base {
+			return 0, fmt.Errorf("Price for %s cannot be added to symbol %s", price.Base, base)
+		}
+		if err := price.Validate(); err != nil {
+			return 0, fmt.Errorf("Price is not valid: %s - %#v", err, price)
+		}
+	}
+
+	pSymbol, err := sa.GetSymbol(base)
+	if err != nil {
+		// create new symbol
+		pSymbol = sa.createSymbol(base)
+		if sa.AddSymbol(pSymbol) {
+			log.Printf("New Symbol added: %s\n", pSymbol.GetType())
+		} else {
+			// added since we looked
+			if pSymbol, err = sa.GetSymbol(base); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return pSymbol.AddPrices(prices), nil
 }
 
 func (sa *symbolsArchive) UpdateDaySummaries() error {
-	summaries, err := DefaultClient.GetDaySummaries()
+	summaries, err := sa.exchangeClient().GetDaySummaries()
 	if err != nil {
 		return err
 	}
@@ -292,6 +526,13 @@ func (sa *symbolsArchive) UpdateDaySummaries() error {
 			LowestPrice:      exSummary.LowestPrice,
 			ChangePrice:      exSummary.ChangePrice,
 			ChangePercent:    exSummary.ChangePercent,
+			Volume:           exSummary.Volume,
+			QuoteVolume:      exSummary.QuoteVolume,
+			BidPrice:         exSummary.BidPrice,
+			BidQuantity:      exSummary.BidQuantity,
+			AskPrice:         exSummary.AskPrice,
+			AskQuantity:      exSummary.AskQuantity,
+			Trades:           exSummary.Trades,
 			At:               exSummary.At,
 			Exchange:         exSummary.Exchange,
 		}
@@ -327,7 +568,7 @@ func (sa *symbolsArchive) savePrice(price Price) error {
 
 	if err != nil {
 		// create new symbol
-		pSymbol = NewSymbol(price.Base)
+		pSymbol = sa.createSymbol(price.Base)
 		// add to map
 		sa.AddSymbol(pSymbol)
 		log.Printf("New Symbol added: %s\n", pSymbol.GetType())
@@ -337,6 +578,17 @@ func (sa *symbolsArchive) savePrice(price Price) error {
 	return nil
 }
 
+// storePrices - saves prices to the archive's store if it has one
+func (sa *symbolsArchive) storePrices(prices []Price) error {
+	if sa.store == nil {
+		return nil
+	}
+	if err := sa.store.savePrices(prices); err != nil {
+		return fmt.Errorf("Failed to store prices: %s", err)
+	}
+	return nil
+}
+
 /*
 
 Metrics we want to save:
@@ -361,7 +613,20 @@ Format:
 
 */
 
+// LoadPrices - loads the prices in the .json files of a dir, loaded prices are not stored
 func (sa *symbolsArchive) LoadPrices(dir string) error {
+	return loadPricesWith(dir, func(prices []Price) error {
+		for _, price := range prices {
+			if err := sa.savePrice(price); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// loadPricesWith - loads the prices in each .json file of a dir with add
+func loadPricesWith(dir string, add func(prices []Price) error) error {
 	// check dir exists
 	files, err := ioutil.ReadDir(dir)
 	if err != nil {
@@ -374,7 +639,7 @@ func (sa *symbolsArchive) LoadPrices(dir string) error {
 	for _, file := range files {
 		// only load from .json files
 		if strings.HasSuffix(file.Name(), ".json") {
-			if err := sa.loadPricesFrom(filepath.Join(dir, file.Name())); err != nil {
+			if err := loadPricesFrom(filepath.Join(dir, file.Name()), add); err != nil {
 				return err
 			}
 		}
@@ -384,7 +649,7 @@ func (sa *symbolsArchive) LoadPrices(dir string) error {
 	return nil
 }
 
-func (sa *symbolsArchive) loadPricesFrom(filePath string) error {
+func loadPricesFrom(filePath string, add func(prices []Price) error) error {
 
 	f, err := os.OpenFile(filePath, 0, 0)
 	if err != nil {
@@ -404,10 +669,8 @@ func (sa *symbolsArchive) loadPricesFrom(filePath string) error {
 		return err
 	}
 
-	for _, price := range prices {
-		if err := sa.savePrice(price); err != nil {
-			return fmt.Errorf("Failed to load price from file: %s - %s", filePath, err)
-		}
+	if err := add(prices); err != nil {
+		return fmt.Errorf("Failed to load price from file: %s - %s", filePath, err)
 	}
 
 	return nil
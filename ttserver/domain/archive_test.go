@@ -428,3 +428,69 @@ func TestMultiCurrencyPricesAt(t *testing.T) {
 	}
 
 }
+
+func TestGetPriceRange(t *testing.T) {
+	archive := setupArchive()
+
+	_, _, err := archive.GetPriceRange()
+	assert.Error(t, err, "Empty archive has no price range")
+
+	today := servertime.Now()
+	yesterday := today.AddDate(0, 0, -1)
+	lastWeek := today.AddDate(0, 0, -7)
+
+	prices := []Price{
+		{Base: ETH, As: BTC, Price: 0.1, At: yesterday, Exchange: "test_exchange"},
+		{Base: ETH, As: BTC, Price: 0.2, At: today, Exchange: "test_exchange"},
+		{Base: LTC, As: USDT, Price: 100.0, At: lastWeek, Exchange: "test_exchange"},
+	}
+
+	for _, price := range prices {
+		assert.NoError(t, archive.AddPrice(price))
+	}
+
+	from, to, err := archive.GetPriceRange()
+	assert.NoError(t, err)
+	assert.Equal(t, lastWeek, from)
+	assert.Equal(t, today, to)
+}
+
+func TestAddPrices(t *testing.T) {
+	archive := setupArchive()
+
+	today := servertime.Now()
+	yesterday := today.AddDate(0, 0, -1)
+	lastWeek := today.AddDate(0, 0, -7)
+
+	assert.NoError(t, archive.AddPrice(Price{Base: ETH, As: BTC, Price: 0.2, At: yesterday, Exchange: "test_exchange"}))
+
+	prices := []Price{
+		{Base: ETH, As: BTC, Price: 0.3, At: today, Exchange: "test_exchange"},
+		{Base: ETH, As: BTC, Price: 0.9, At: yesterday, Exchange: "test_exchange"},
+		{Base: ETH, As: BTC, Price: 0.1, At: lastWeek, Exchange: "test_exchange"},
+		{Base: ETH, As: USDT, Price: 100.0, At: lastWeek, Exchange: "test_exchange"},
+	}
+
+	added, err := archive.AddPrices(ETH, prices)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, added, "Existing price for yesterday should be kept")
+
+	price, err := archive.GetPriceAs(ETH, BTC, yesterday)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.2, price.Price)
+
+	price, err = archive.GetPriceAs(ETH, BTC, lastWeek)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, price.Price)
+
+	from, to, err := archive.GetPriceRange()
+	assert.NoError(t, err)
+	assert.Equal(t, lastWeek, from)
+	assert.Equal(t, today, to)
+
+	_, err = archive.AddPrices(ETH, []Price{{Base: LTC, As: BTC, Price: 0.1, At: today}})
+	assert.Error(t, err)
+
+	_, err = archive.AddPrices(ETH, []Price{{Base: ETH, As: BTC, Price: 0, At: today}})
+	assert.Error(t, err)
+}
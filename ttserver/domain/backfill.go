@@ -0,0 +1,292 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tspb "github.com/golang/protobuf/ptypes"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+
+A backfill downloads historical candles from the exchange and adds them to the
+archive so historical simulations have prices from before the server started.
+
+Each candle adds its open price at its open time. Prices the archive already
+holds for a time are kept.
+
+Candles are fetched BACKFILL_CHUNK_CANDLES at a time for each pair in turn so
+progress can be followed while the backfill runs in the background.
+
+*/
+
+// BACKFILL_CHUNK_CANDLES - number of candles fetched at a time
+const BACKFILL_CHUNK_CANDLES = 1000
+
+type backfillState string
+
+const (
+	BACKFILL_RUNNING   backfillState = "running"
+	BACKFILL_COMPLETED backfillState = "completed"
+	BACKFILL_FAILED    backfillState = "failed"
+)
+
+type tradingPair struct {
+	base SymbolType
+	as   SymbolType
+}
+
+func (t tradingPair) String() string {
+	return fmt.Sprintf("%s/%s", t.base, t.as)
+}
+
+// parseTradingPair - parses a pair in the format base/as eg. ETH/BTC
+func parseTradingPair(pair string) (tradingPair, error) {
+	parts := strings.Split(strings.ToUpper(pair), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return tradingPair{}, fmt.Errorf("Trading pair %q is not valid, expected base/as eg. ETH/BTC", pair)
+	}
+	return tradingPair{base: SymbolType(parts[0]), as: SymbolType(parts[1])}, nil
+}
+
+type backfill struct {
+	sync.RWMutex
+	id           string
+	pairs        []tradingPair
+	from         time.Time
+	to           time.Time
+	interval     exchanges.CandleInterval
+	state        backfillState
+	current      tradingPair
+	chunksDone   int
+	chunksTotal  int
+	pricesAdded  int
+	err          error
+	startedTime  time.Time
+	finishedTime time.Time
+	done         chan struct{} // closed when the backfill finishes
+}
+
+// newBackfill - validates the pairs and time range of a backfill
+func newBackfill(pairs []tradingPair, from, to time.Time, interval exchanges.CandleInterval) (*backfill, error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("At least one trading pair must be provided")
+	}
+
+	duration, err := interval.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	if !from.Before(to) {
+		return nil, fmt.Errorf("From time %s must be before to time %s", from.Format(DATE_FORMAT), to.Format(DATE_FORMAT))
+	}
+
+	now := servertime.Now()
+	if to.After(now) {
+		to = now
+	}
+
+	known, err := DefaultClient.GetTradingPairs()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get trading pairs - %s", err)
+	}
+	exists := make(map[tradingPair]bool, len(known))
+	for _, pair := range known {
+		exists[tradingPair{base: SymbolType(pair.Base), as: SymbolType(pair.As)}] = true
+	}
+	for _, pair := range pairs {
+		if !exists[pair] {
+			return nil, fmt.Errorf("Trading pair %s is not on the exchange", pair)
+		}
+	}
+
+	chunk := duration * BACKFILL_CHUNK_CANDLES
+	chunks := int(to.Sub(from) / chunk)
+	if to.Sub(from)%chunk != 0 {
+		chunks++
+	}
+
+	return &backfill{
+		id:          randSeq(10),
+		pairs:       pairs,
+		from:        from,
+		to:          to,
+		interval:    interval,
+		state:       BACKFILL_RUNNING,
+		chunksTotal: chunks * len(pairs),
+		startedTime: now,
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// run - fetches candles for every pair and adds them to the archive
+func (b *backfill) run() {
+	defer close(b.done)
+
+	DefaultLogger.log(fmt.Sprintf("Backfill %s started for %d pairs from %s to %s", b.id, len(b.pairs), b.from.Format(DATE_FORMAT), b.to.Format(DATE_FORMAT)))
+
+	if err := b.fetch(); err != nil {
+		b.finish(BACKFILL_FAILED, err)
+		DefaultLogger.log(fmt.Sprintf("ERROR: backfill %s failed - %s", b.id, err))
+		return
+	}
+
+	b.finish(BACKFILL_COMPLETED, nil)
+	DefaultLogger.log(fmt.Sprintf("Backfill %s completed, %d prices added", b.id, b.pricesAdded))
+}
+
+func (b *backfill) fetch() error {
+	// interval has been validated by newBackfill
+	duration, _ := b.interval.Duration()
+	chunk := duration * BACKFILL_CHUNK_CANDLES
+
+	for _, pair := range b.pairs {
+		b.Lock()
+		b.current = pair
+		b.Unlock()
+
+		for from := b.from; from.Before(b.to); from = from.Add(chunk) {
+			to := from.Add(chunk)
+			if to.After(b.to) {
+				to = b.to
+			}
+
+			candles, err := DefaultClient.GetCandles(string(pair.base), string(pair.as), b.interval, from, to)
+			if err != nil {
+				return err
+			}
+
+			prices := make([]Price, 0, len(candles))
+			for _, candle := range candles {
+				prices = append(prices, Price{
+					Base:     pair.base,
+					As:       pair.as,
+					Price:    candle.Open,
+					At:       candle.OpenTime,
+					Exchange: candle.Exchange,
+					Interval: duration,
+				})
+			}
+
+			added, err := DefaultArchive.AddPrices(pair.base, prices)
+			if err != nil {
+				return err
+			}
+
+			b.Lock()
+			b.chunksDone++
+			b.pricesAdded += added
+			b.Unlock()
+		}
+	}
+
+	return nil
+}
+
+func (b *backfill) finish(state backfillState, err error) {
+	b.Lock()
+	defer b.Unlock()
+	b.state = state
+	b.err = err
+	b.current = tradingPair{}
+	b.finishedTime = servertime.Now()
+}
+
+// progress - percentage of candle chunks fetched
+func (b *backfill) progress() float64 {
+	if b.chunksTotal == 0 {
+		return 100
+	}
+	return float64(b.chunksDone) / float64(b.chunksTotal) * 100
+}
+
+// StartBackfill - starts downloading historical prices into the archive
+func (s *server) StartBackfill(ctx context.Context, req *proto.StartBackfillRequest) (*proto.StartBackfillResponse, error) {
+
+	pairs := make([]tradingPair, len(req.Pairs))
+	for i, p := range req.Pairs {
+		pair, err := parseTradingPair(p)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+		pairs[i] = pair
+	}
+
+	if req.FromTime == nil || req.ToTime == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "From and to times must be provided")
+	}
+	from, err := tspb.Timestamp(req.FromTime)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "From time is not valid - %s", err)
+	}
+	to, err := tspb.Timestamp(req.ToTime)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "To time is not valid - %s", err)
+	}
+
+	interval := exchanges.CandleInterval(req.Interval)
+	if interval == "" {
+		interval = exchanges.CANDLE_1M
+	}
+
+	job, err := newBackfill(pairs, from, to, interval)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to start backfill - %s", err)
+	}
+
+	s.Lock()
+	s.backfills[job.id] = job
+	s.Unlock()
+
+	go job.run()
+
+	backfill, err := job.toProto()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to convert backfill - %s", err)
+	}
+
+	return &proto.StartBackfillResponse{Backfill: backfill}, nil
+}
+
+// GetBackfills - returns the progress of backfills, most recent first
+func (s *server) GetBackfills(ctx context.Context, req *proto.GetBackfillsRequest) (*proto.GetBackfillsResponse, error) {
+
+	s.RLock()
+	jobs := make([]*backfill, 0, len(s.backfills))
+	for id, job := range s.backfills {
+		if req.Id == "" || req.Id == id {
+			jobs = append(jobs, job)
+		}
+	}
+	s.RUnlock()
+
+	if req.Id != "" && len(jobs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Backfill %q not found", req.Id)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].startedTime.After(jobs[j].startedTime) })
+
+	resp := &proto.GetBackfillsResponse{
+		Backfills: make([]*proto.Backfill, len(jobs)),
+	}
+
+	for i, job := range jobs {
+		backfill, err := job.toProto()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to convert backfill - %s", err)
+		}
+		resp.Backfills[i] = backfill
+	}
+
+	return resp, nil
+}
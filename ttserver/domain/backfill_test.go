@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tspb "github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestParseTradingPair(t *testing.T) {
+
+	pair, err := parseTradingPair("eth/btc")
+	assert.NoError(t, err)
+	assert.Equal(t, tradingPair{base: ETH, as: BTC}, pair)
+	assert.Equal(t, "ETH/BTC", pair.String())
+
+	for _, invalid := range []string{"ETHBTC", "ETH/", "/BTC", "ETH/BTC/LTC"} {
+		_, err = parseTradingPair(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestBackfill(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	ctx := context.Background()
+
+	// before the mock price history
+	to := servertime.Now().AddDate(0, 0, -3).Truncate(time.Hour)
+	from := to.Add(-2 * time.Hour)
+
+	fromTime, err := tspb.TimestampProto(from)
+	assert.NoError(t, err)
+	toTime, err := tspb.TimestampProto(to)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		req  *proto.StartBackfillRequest
+	}{
+		{name: "No pairs", req: &proto.StartBackfillRequest{FromTime: fromTime, ToTime: toTime}},
+		{name: "Invalid pair", req: &proto.StartBackfillRequest{Pairs: []string{"ETHBTC"}, FromTime: fromTime, ToTime: toTime}},
+		{name: "Unknown pair", req: &proto.StartBackfillRequest{Pairs: []string{"XRP/BTC"}, FromTime: fromTime, ToTime: toTime}},
+		{name: "No times", req: &proto.StartBackfillRequest{Pairs: []string{"ETH/BTC"}}},
+		{name: "From after to", req: &proto.StartBackfillRequest{Pairs: []string{"ETH/BTC"}, FromTime: toTime, ToTime: fromTime}},
+		{name: "Invalid interval", req: &proto.StartBackfillRequest{Pairs: []string{"ETH/BTC"}, FromTime: fromTime, ToTime: toTime, Interval: "2m"}},
+	}
+
+	for _, test := range tests {
+		_, err := server.StartBackfill(ctx, test.req)
+		assert.Error(t, err, test.name)
+	}
+
+	resp, err := server.StartBackfill(ctx, &proto.StartBackfillRequest{
+		Pairs:    []string{"ETH/BTC", "ltc/btc"},
+		FromTime: fromTime,
+		ToTime:   toTime,
+	})
+	assert.NoError(t, err)
+	if !assert.NotNil(t, resp.Backfill) {
+		return
+	}
+	assert.Equal(t, []string{"ETH/BTC", "LTC/BTC"}, resp.Backfill.Pairs)
+	assert.Equal(t, "1m", resp.Backfill.Interval)
+
+	job := server.backfills[resp.Backfill.Id]
+	select {
+	case <-job.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Backfill did not finish")
+	}
+
+	listResp, err := server.GetBackfills(ctx, &proto.GetBackfillsRequest{Id: resp.Backfill.Id})
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(listResp.Backfills)) {
+		backfill := listResp.Backfills[0]
+		assert.Equal(t, string(BACKFILL_COMPLETED), backfill.State)
+		assert.Equal(t, float32(100), backfill.Progress)
+		assert.Equal(t, int32(240), backfill.PricesAdded, "2 hours of 1m candles for 2 pairs")
+		assert.Equal(t, "", backfill.Error)
+		assert.NotNil(t, backfill.FinishedTime)
+	}
+
+	// the archive now has prices from the start of the backfill
+	sym, err := DefaultArchive.GetSymbol(ETH)
+	assert.NoError(t, err)
+	earliest, _, found := sym.GetPriceRange()
+	assert.True(t, found)
+	assert.Equal(t, from, earliest)
+
+	// backfilling again adds nothing new
+	resp, err = server.StartBackfill(ctx, &proto.StartBackfillRequest{Pairs: []string{"ETH/BTC"}, FromTime: fromTime, ToTime: toTime, Interval: "1h"})
+	assert.NoError(t, err)
+	<-server.backfills[resp.Backfill.Id].done
+
+	listResp, err = server.GetBackfills(ctx, &proto.GetBackfillsRequest{Id: resp.Backfill.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), listResp.Backfills[0].PricesAdded)
+
+	listResp, err = server.GetBackfills(ctx, &proto.GetBackfillsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(listResp.Backfills))
+
+	_, err = server.GetBackfills(ctx, &proto.GetBackfillsRequest{Id: "unknown"})
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ type BalanceAs struct {
 	Value24H     float64
 	Change24H    float64
 	ChangePct24H float64
+	Interpolated bool // priced using an estimated price
 	//
 	BuyStrategy  Strategy
 	SellStrategy Strategy
@@ -0,0 +1,686 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/telecoda/teletrada/ttserver/servertime"
+	bolt "go.etcd.io/bbolt"
+)
+
+/*
+
+The bolt archive keeps prices in an embedded bolt database instead of holding
+every price in memory, so months of minute prices for every pair can be
+archived.
+
+Prices are kept in a bucket for each pair nested in a bucket for the base
+symbol, keyed by the time of the price in unix nanoseconds big endian so the
+keys of a pair sort in time order:-
+
+	prices / <base> / <as> / <time> = <price><exchange>
+
+The in-memory archive is kept as a cache of recent prices. Prices added are
+written to the database and cached if they are from the last cache period, the
+cache is trimmed back to the period after each update.
+
+Prices from before the cache are read from the database with range queries.
+Each query reads a page of prices from the one before the time requested, so a
+simulation replaying history reads a page of a pair at a time instead of a
+price at a time.
+
+Day summaries are only held in memory.
+
+*/
+
+const (
+	ARCHIVE_MEMORY = "memory"
+	ARCHIVE_BOLT   = "bolt"
+	PRICES_DB      = "prices.db"
+	// PRICE_PAGE_SIZE - prices of a pair read from the database at a time
+	PRICE_PAGE_SIZE = 1000
+)
+
+var pricesBucket = []byte("prices")
+
+type boltPriceStore struct {
+	db        *bolt.DB
+	retention time.Duration
+}
+
+// newBoltPriceStore - opens a price database, prices are kept for retention or forever if it is 0
+func newBoltPriceStore(filePath string, retention time.Duration) (*boltPriceStore, error) {
+	if retention < 0 {
+		return nil, fmt.Errorf("Price retention cannot be negative")
+	}
+
+	// another server using the database holds a lock on it
+	db, err := bolt.Open(filePath, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open price database: %s - %s", filePath, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(pricesBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Failed to create price database: %s - %s", filePath, err)
+	}
+
+	return &boltPriceStore{
+		db:        db,
+		retention: retention,
+	}, nil
+}
+
+func (b *boltPriceStore) close() error {
+	return b.db.Close()
+}
+
+func priceKey(at time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(at.UnixNano()))
+	return key
+}
+
+func keyTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key))).UTC()
+}
+
+func encodePrice(price Price) []byte {
+	value := make([]byte, 8, 8+len(price.Exchange)+9)
+	binary.BigEndian.PutUint64(value, math.Float64bits(price.Price))
+	value = append(value, price.Exchange...)
+	if price.Interval == 0 {
+		return value
+	}
+	// the interval follows the exchange name after a zero byte
+	interval := make([]byte, 9)
+	binary.BigEndian.PutUint64(interval[1:], uint64(price.Interval))
+	return append(value, interval...)
+}
+
+func decodePrice(base, as SymbolType, key, value []byte) (Price, error) {
+	if len(key) != 8 || len(value) < 8 {
+		return Price{}, fmt.Errorf("Stored price of %s/%s is corrupt", base, as)
+	}
+	exchange := value[8:]
+	var interval time.Duration
+	if i := bytes.IndexByte(exchange, 0); i >= 0 {
+		if len(exchange)-i != 9 {
+			return Price{}, fmt.Errorf("Stored price of %s/%s is corrupt", base, as)
+		}
+		interval = time.Duration(binary.BigEndian.Uint64(exchange[i+1:]))
+		exchange = exchange[:i]
+	}
+	return Price{
+		Base:     base,
+		As:       as,
+		Price:    math.Float64frombits(binary.BigEndian.Uint64(value)),
+		At:       keyTime(key),
+		Exchange: string(exchange),
+		Interval: interval,
+	}, nil
+}
+
+// pairBucket - returns the bucket of the prices of a pair, nil if there are none
+func pairBucket(tx *bolt.Tx, base, as SymbolType) *bolt.Bucket {
+	baseBucket := tx.Bucket(pricesBucket).Bucket([]byte(base))
+	if baseBucket == nil {
+		return nil
+	}
+	return baseBucket.Bucket([]byte(as))
+}
+
+// eachPair - calls fn with the bucket of each pair of a base symbol, every base if it is blank
+func eachPair(tx *bolt.Tx, base SymbolType, fn func(base, as SymbolType, bucket *bolt.Bucket) error) error {
+	prices := tx.Bucket(pricesBucket)
+	return prices.ForEach(func(baseName, value []byte) error {
+		if value != nil || (base != "" && string(baseName) != string(base)) {
+			return nil
+		}
+		baseBucket := prices.Bucket(baseName)
+		return baseBucket.ForEach(func(asName, value []byte) error {
+			if value != nil {
+				return nil
+			}
+			return fn(SymbolType(baseName), SymbolType(asName), baseBucket.Bucket(asName))
+		})
+	})
+}
+
+// savePrices - saves prices in one transaction, prices already held for a time are kept
+func (b *boltPriceStore) savePrices(prices []Price) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		for _, price := range prices {
+			baseBucket, err := tx.Bucket(pricesBucket).CreateBucketIfNotExists([]byte(price.Base))
+			if err != nil {
+				return err
+			}
+			asBucket, err := baseBucket.CreateBucketIfNotExists([]byte(price.As))
+			if err != nil {
+				return err
+			}
+			key := priceKey(price.At)
+			if asBucket.Get(key) != nil {
+				continue
+			}
+			if err := asBucket.Put(key, encodePrice(price)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// loadPrices - returns every stored price
+func (b *boltPriceStore) loadPrices() ([]Price, error) {
+	return b.pricesSince(time.Time{})
+}
+
+// pricesSince - returns the prices at or after a time
+func (b *boltPriceStore) pricesSince(at time.Time) ([]Price, error) {
+	prices := make([]Price, 0)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return eachPair(tx, "", func(base, as SymbolType, bucket *bolt.Bucket) error {
+			c := bucket.Cursor()
+			k, v := c.First()
+			if !at.IsZero() {
+				k, v = c.Seek(priceKey(at))
+			}
+			for ; k != nil; k, v = c.Next() {
+				price, err := decodePrice(base, as, k, v)
+				if err != nil {
+					return err
+				}
+				prices = append(prices, price)
+			}
+			return nil
+		})
+	})
+	return prices, err
+}
+
+// retainedFrom - returns the time prices are kept from, zero if they are kept forever
+func (b *boltPriceStore) retainedFrom(now time.Time) time.Time {
+	if b.retention == 0 {
+		return time.Time{}
+	}
+	return now.Add(-b.retention)
+}
+
+// compact - deletes prices older than the retention
+func (b *boltPriceStore) compact(now time.Time) error {
+	if b.retention == 0 {
+		return nil
+	}
+
+	expired := priceKey(now.Add(-b.retention))
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return eachPair(tx, "", func(base, as SymbolType, bucket *bolt.Bucket) error {
+			c := bucket.Cursor()
+			// deleting moves the cursor so start again from the first
+			for k, _ := c.First(); k != nil && bytes.Compare(k, expired) < 0; k, _ = c.First() {
+				if err := c.Delete(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// bases - returns the base symbols with stored prices
+func (b *boltPriceStore) bases() ([]SymbolType, error) {
+	bases := make([]SymbolType, 0)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(pricesBucket).ForEach(func(name, value []byte) error {
+			if value == nil {
+				bases = append(bases, SymbolType(name))
+			}
+			return nil
+		})
+	})
+	return bases, err
+}
+
+// asTypes - returns the symbols a base symbol has stored prices as
+func (b *boltPriceStore) asTypes(base SymbolType) ([]SymbolType, error) {
+	asTypes := make([]SymbolType, 0)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return eachPair(tx, base, func(base, as SymbolType, bucket *bolt.Bucket) error {
+			if k, _ := bucket.Cursor().First(); k != nil {
+				asTypes = append(asTypes, as)
+			}
+			return nil
+		})
+	})
+	return asTypes, err
+}
+
+// newPrices - returns how many prices are not already stored, counting a pair at a time once
+func (b *boltPriceStore) newPrices(prices []Price) (int, error) {
+	count := 0
+	seen := make(map[string]bool, len(prices))
+	err := b.db.View(func(tx *bolt.Tx) error {
+		for _, price := range prices {
+			key := priceKey(price.At)
+			pairKey := string(price.Base) + "/" + string(price.As) + "/" + string(key)
+			if seen[pairKey] {
+				continue
+			}
+			seen[pairKey] = true
+			bucket := pairBucket(tx, price.Base, price.As)
+			if bucket == nil || bucket.Get(key) == nil {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// latestPrice - returns the latest stored price of a pair, found is false if there are none
+func (b *boltPriceStore) latestPrice(base, as SymbolType) (price Price, found bool, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		bucket := pairBucket(tx, base, as)
+		if bucket == nil {
+			return nil
+		}
+		k, v := bucket.Cursor().Last()
+		if k == nil {
+			return nil
+		}
+		found = true
+		price, err = decodePrice(base, as, k, v)
+		return err
+	})
+	return price, found, err
+}
+
+// priceRange - returns the times of the earliest and latest stored prices of a base symbol
+func (b *boltPriceStore) priceRange(base SymbolType) (from time.Time, to time.Time, found bool, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		return eachPair(tx, base, func(base, as SymbolType, bucket *bolt.Bucket) error {
+			c := bucket.Cursor()
+			first, _ := c.First()
+			last, _ := c.Last()
+			if first == nil {
+				return nil
+			}
+			if !found || keyTime(first).Before(from) {
+				from = keyTime(first)
+			}
+			if !found || keyTime(last).After(to) {
+				to = keyTime(last)
+			}
+			found = true
+			return nil
+		})
+	})
+	return from, to, found, err
+}
+
+// priceGaps - returns gaps longer than maxGap, or the interval of the prices either side,
+// in the stored prices of a base symbol, the latest price is checked against until
+func (b *boltPriceStore) priceGaps(base SymbolType, maxGap time.Duration, until time.Time) ([]PriceGap, error) {
+	gaps := make([]PriceGap, 0)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return eachPair(tx, base, func(base, as SymbolType, bucket *bolt.Bucket) error {
+			if as == base {
+				return nil
+			}
+			var previous Price
+			c := bucket.Cursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				price, err := decodePrice(base, as, k, v)
+				if err != nil {
+					return err
+				}
+				if !previous.At.IsZero() && isPriceGap(previous, price, maxGap) {
+					gaps = append(gaps, PriceGap{Base: base, As: as, From: previous.At, To: price.At})
+				}
+				previous = price
+			}
+			if !previous.At.IsZero() && until.Sub(previous.At) > priceGapLimit(maxGap, previous.Interval) {
+				gaps = append(gaps, PriceGap{Base: base, As: as, From: previous.At, To: until})
+			}
+			return nil
+		})
+	})
+	return gaps, err
+}
+
+// pricePage - prices of a pair read from the database in time order
+type pricePage struct {
+	prices   []Price
+	first    bool // there are no earlier prices
+	complete bool // there are no later prices
+}
+
+// readPricePage - reads up to count prices of a pair starting from the last price before a time
+func (b *boltPriceStore) readPricePage(base, as SymbolType, at time.Time, count int) (pricePage, error) {
+	page := pricePage{prices: make([]Price, 0, count)}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := pairBucket(tx, base, as)
+		if bucket == nil {
+			return nil
+		}
+
+		c := bucket.Cursor()
+		key := priceKey(at)
+		k, v := c.Seek(key)
+		if k == nil {
+			// every price is before the time
+			k, v = c.Last()
+		} else if pk, pv := c.Prev(); pk != nil {
+			k, v = pk, pv
+		} else {
+			k, v = c.Seek(key)
+		}
+
+		start := k
+		for ; k != nil && len(page.prices) < count; k, v = c.Next() {
+			price, err := decodePrice(base, as, k, v)
+			if err != nil {
+				return err
+			}
+			page.prices = append(page.prices, price)
+		}
+		page.complete = k == nil
+
+		first, _ := c.First()
+		page.first = bytes.Equal(first, start)
+		return nil
+	})
+	return page, err
+}
+
+// search - returns the index of the first price at or after a time
+func (p pricePage) search(at time.Time) int {
+	return sort.Search(len(p.prices), func(i int) bool { return !p.prices[i].At.Before(at) })
+}
+
+// covers - returns true if the page holds the prices either side of a time
+func (p pricePage) covers(at time.Time) bool {
+	if len(p.prices) == 0 {
+		return false
+	}
+	i := p.search(at)
+	return (i > 0 || p.first) && (i < len(p.prices) || p.complete)
+}
+
+// priceAt - returns the price at a time from the prices either side of it
+func (p pricePage) priceAt(at time.Time) Price {
+	i := p.search(at)
+	if i == len(p.prices) {
+		return priceBetween(p.prices[i-1], Price{}, at)
+	}
+	if i == 0 {
+		return priceBetween(p.prices[0], p.prices[0], at)
+	}
+	return priceBetween(p.prices[i-1], p.prices[i], at)
+}
+
+// storedSymbol - a symbol with its prices in the database and its recent prices cached in memory
+type storedSymbol struct {
+	*symbol // recent prices and day summaries
+	store   *boltPriceStore
+
+	lock       sync.Mutex
+	cachedFrom time.Time                // prices from this time on are cached
+	pages      map[SymbolType]pricePage // last page read from the database by as symbol
+	additions  int                      // counts adds so pages read during an add aren't kept
+}
+
+func newStoredSymbol(base SymbolType, store *boltPriceStore, cachedFrom time.Time) *storedSymbol {
+	return &storedSymbol{
+		symbol:     NewSymbol(base),
+		store:      store,
+		cachedFrom: cachedFrom,
+		pages:      make(map[SymbolType]pricePage),
+	}
+}
+
+// recent - returns the prices that are cached and forgets pages the prices are added to
+func (s *storedSymbol) recent(prices []Price) []Price {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.additions++
+
+	recent := make([]Price, 0, len(prices))
+	for _, price := range prices {
+		delete(s.pages, price.As)
+		if !price.At.Before(s.cachedFrom) {
+			recent = append(recent, price)
+		}
+	}
+	return recent
+}
+
+// AddPrice - caches a price if it is recent, the archive saves it to the database
+func (s *storedSymbol) AddPrice(price Price) {
+	if recent := s.recent([]Price{price}); len(recent) > 0 {
+		s.symbol.AddPrice(price)
+	}
+}
+
+// AddPrices - caches recent prices, the archive saves them to the database.
+// Returns the number of prices not already in the database
+func (s *storedSymbol) AddPrices(prices []Price) int {
+	added, err := s.store.newPrices(prices)
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: checking stored prices of %s - %s", s.SymbolType, err))
+		added = len(prices)
+	}
+
+	s.symbol.AddPrices(s.recent(prices))
+
+	return added
+}
+
+func (s *storedSymbol) GetAsTypes() []SymbolType {
+	asTypes := s.symbol.GetAsTypes()
+
+	stored, err := s.store.asTypes(s.SymbolType)
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: reading stored prices of %s - %s", s.SymbolType, err))
+		return asTypes
+	}
+
+	for _, as := range asTypes {
+		found := false
+		for _, storedAs := range stored {
+			if as == storedAs {
+				found = true
+				break
+			}
+		}
+		if !found {
+			stored = append(stored, as)
+		}
+	}
+	return stored
+}
+
+// GetPriceAs - returns the price as another symbol at a time, from the cache if it holds prices
+// from before the time
+func (s *storedSymbol) GetPriceAs(as SymbolType, at time.Time) (Price, error) {
+	if s.SymbolType == as || s.symbol.hasPriceBefore(as, at) {
+		return s.symbol.GetPriceAs(as, at)
+	}
+
+	s.lock.Lock()
+	page, ok := s.pages[as]
+	additions := s.additions
+	s.lock.Unlock()
+
+	if ok && page.covers(at) {
+		return page.priceAt(at), nil
+	}
+
+	// the database is read without the lock so other prices can be looked up meanwhile
+	page, err := s.store.readPricePage(s.SymbolType, as, at, PRICE_PAGE_SIZE)
+	if err != nil {
+		return Price{}, fmt.Errorf("Failed to read prices of %s as %s - %s", s.SymbolType, as, err)
+	}
+	if len(page.prices) == 0 {
+		return Price{}, fmt.Errorf("Symbol: %s has no price information for: %s", s.SymbolType, as)
+	}
+
+	s.lock.Lock()
+	if s.additions == additions {
+		s.pages[as] = page
+	}
+	s.lock.Unlock()
+
+	return page.priceAt(at), nil
+}
+
+func (s *storedSymbol) GetLatestPriceAs(as SymbolType) (Price, error) {
+	if price, err := s.symbol.GetLatestPriceAs(as); err == nil {
+		return price, nil
+	}
+
+	price, found, err := s.store.latestPrice(s.SymbolType, as)
+	if err != nil {
+		return Price{}, fmt.Errorf("Failed to read latest price of %s as %s - %s", s.SymbolType, as, err)
+	}
+	if !found {
+		return Price{}, fmt.Errorf("Symbol: %s has no price information for: %s", s.SymbolType, as)
+	}
+	return price, nil
+}
+
+func (s *storedSymbol) GetPriceRange() (time.Time, time.Time, bool) {
+	from, to, found, err := s.store.priceRange(s.SymbolType)
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: reading stored prices of %s - %s", s.SymbolType, err))
+		return s.symbol.GetPriceRange()
+	}
+	return from, to, found
+}
+
+func (s *storedSymbol) GetPriceGaps(maxGap time.Duration, until time.Time) []PriceGap {
+	gaps, err := s.store.priceGaps(s.SymbolType, maxGap, until)
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: reading stored prices of %s - %s", s.SymbolType, err))
+		return s.symbol.GetPriceGaps(maxGap, until)
+	}
+	return gaps
+}
+
+// evict - removes cached prices from before a time
+func (s *storedSymbol) evict(before time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if before.After(s.cachedFrom) {
+		s.cachedFrom = before
+		s.symbol.removePricesBefore(before)
+	}
+}
+
+// boltArchive - an archive of prices in a bolt database with recent prices cached in memory
+type boltArchive struct {
+	*symbolsArchive // cache
+	db              *boltPriceStore
+	cacheFor        time.Duration
+}
+
+// newBoltArchive - returns an archive of the prices in a database caching the prices of the last cacheFor
+func newBoltArchive(store *boltPriceStore, cacheFor time.Duration) (*boltArchive, error) {
+	if cacheFor <= 0 {
+		return nil, fmt.Errorf("Archive cache period must be more than 0")
+	}
+
+	ba := &boltArchive{
+		db:       store,
+		cacheFor: cacheFor,
+	}
+	ba.symbolsArchive = &symbolsArchive{
+		symbols: make(map[SymbolType]Symbol),
+		newSymbol: func(base SymbolType) Symbol {
+			return newStoredSymbol(base, store, ba.cachedFrom())
+		},
+	}
+
+	cachedFrom := ba.cachedFrom()
+	bases, err := store.bases()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read stored prices: %s", err)
+	}
+	stored := make(map[SymbolType]*storedSymbol, len(bases))
+	for _, base := range bases {
+		stored[base] = newStoredSymbol(base, store, cachedFrom)
+		ba.symbols[base] = stored[base]
+	}
+
+	prices, err := store.pricesSince(cachedFrom)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read stored prices: %s", err)
+	}
+	byBase := make(map[SymbolType][]Price)
+	for _, price := range prices {
+		byBase[price.Base] = append(byBase[price.Base], price)
+	}
+	for base, basePrices := range byBase {
+		if symbol, ok := stored[base]; ok {
+			// cached without checking the database again
+			symbol.symbol.AddPrices(basePrices)
+		}
+	}
+	DefaultLogger.log(fmt.Sprintf("Archive has stored prices of %d symbols, %d prices cached", len(bases), len(prices)))
+
+	// prices added from now on are saved
+	ba.store = store
+
+	return ba, nil
+}
+
+// cachedFrom - returns the time prices are cached from
+func (ba *boltArchive) cachedFrom() time.Time {
+	return servertime.Now().Add(-ba.cacheFor)
+}
+
+// UpdatePrices - fetches the latest prices and removes prices older than the cache period from the cache
+func (ba *boltArchive) UpdatePrices() error {
+	if err := ba.symbolsArchive.UpdatePrices(); err != nil {
+		return err
+	}
+	ba.evict(ba.cachedFrom())
+	return nil
+}
+
+func (ba *boltArchive) evict(before time.Time) {
+	ba.RLock()
+	defer ba.RUnlock()
+
+	for base, symbol := range ba.symbols {
+		stored, ok := symbol.(*storedSymbol)
+		if !ok {
+			DefaultLogger.log(fmt.Sprintf("ERROR: symbol %s is not stored in the database, prices not evicted", base))
+			continue
+		}
+		stored.evict(before)
+	}
+}
+
+// LoadPrices - loads the prices in the .json files of a dir into the database
+func (ba *boltArchive) LoadPrices(dir string) error {
+	return loadPricesWith(dir, func(prices []Price) error {
+		_, err := ba.AddAllPrices(prices)
+		return err
+	})
+}
+
+func (ba *boltArchive) close() error {
+	return ba.db.close()
+}
@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+// testHistory - prices of ETH and LTC as BTC every minute for three days with an hour missing
+func testHistory(start time.Time) []Price {
+	prices := make([]Price, 0)
+	for i := 0; i < 3*24*60; i++ {
+		at := start.Add(time.Duration(i) * time.Minute)
+		if at.Sub(start) >= 30*time.Hour && at.Sub(start) < 31*time.Hour {
+			continue
+		}
+		prices = append(prices,
+			Price{Base: ETH, As: BTC, Price: 0.02 + 0.01*math.Sin(float64(i)/100), At: at, Exchange: "test"},
+			Price{Base: LTC, As: BTC, Price: 0.01 + float64(i)/1000000, At: at, Exchange: "test"},
+		)
+	}
+	return prices
+}
+
+func addTestHistory(t *testing.T, archive SymbolsArchive, prices []Price) {
+	byBase := make(map[SymbolType][]Price)
+	for _, price := range prices {
+		byBase[price.Base] = append(byBase[price.Base], price)
+	}
+	for base, basePrices := range byBase {
+		added, err := archive.AddPrices(base, basePrices)
+		assert.NoError(t, err)
+		assert.Equal(t, len(basePrices), added)
+	}
+}
+
+func newTestBoltArchive(t *testing.T, dataDir string, cacheFor time.Duration) *boltArchive {
+	store, err := newBoltPriceStore(filepath.Join(dataDir, PRICES_DB), 0)
+	assert.NoError(t, err)
+	archive, err := newBoltArchive(store, cacheFor)
+	assert.NoError(t, err)
+	return archive
+}
+
+func TestBoltPriceStore(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "teletrada-bolt")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	store, err := newBoltPriceStore(filepath.Join(dataDir, PRICES_DB), 48*time.Hour)
+	assert.NoError(t, err)
+
+	start := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	prices := testHistory(start)
+	assert.NoError(t, store.savePrices(prices))
+
+	// prices already held are kept
+	assert.NoError(t, store.savePrices([]Price{{Base: ETH, As: BTC, Price: 99, At: start}}))
+	latest, found, err := store.latestPrice(ETH, BTC)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, prices[len(prices)-2], latest)
+
+	added, err := store.newPrices([]Price{prices[0], {Base: ETH, As: USDT, Price: 200, At: start}, {Base: ETH, As: USDT, Price: 200, At: start}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	// pages start from the price before the time
+	page, err := store.readPricePage(ETH, BTC, start.Add(10*time.Minute+time.Second), 5)
+	assert.NoError(t, err)
+	if assert.Len(t, page.prices, 5) {
+		assert.Equal(t, start.Add(10*time.Minute), page.prices[0].At)
+	}
+	assert.False(t, page.first)
+	assert.False(t, page.complete)
+
+	page, err = store.readPricePage(ETH, BTC, start.Add(-time.Hour), 5)
+	assert.NoError(t, err)
+	assert.True(t, page.first)
+	assert.Equal(t, start, page.prices[0].At)
+
+	page, err = store.readPricePage(ETH, BTC, start.Add(100*time.Hour), 5)
+	assert.NoError(t, err)
+	assert.Len(t, page.prices, 1)
+	assert.True(t, page.complete)
+
+	gaps, err := store.priceGaps(ETH, 2*time.Minute, latest.At)
+	assert.NoError(t, err)
+	if assert.Len(t, gaps, 1) {
+		assert.Equal(t, start.Add(30*time.Hour-time.Minute), gaps[0].From)
+		assert.Equal(t, start.Add(31*time.Hour), gaps[0].To)
+	}
+
+	// backfilled candles keep their interval
+	xrp := SymbolType("XRP")
+	candle := Price{Base: xrp, As: BTC, Price: 0.01, At: start, Exchange: "binance", Interval: time.Hour}
+	assert.NoError(t, store.savePrices([]Price{candle, {Base: xrp, As: BTC, Price: 0.02, At: start.Add(time.Hour), Exchange: "binance", Interval: time.Hour}}))
+	hourly, found, err := store.latestPrice(xrp, BTC)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, time.Hour, hourly.Interval)
+	assert.Equal(t, "binance", hourly.Exchange)
+	gaps, err = store.priceGaps(xrp, 2*time.Minute, start.Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, gaps, 0)
+
+	// retention
+	assert.NoError(t, store.compact(start.Add(72*time.Hour)))
+	from, to, found, err := store.priceRange(ETH)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, start.Add(24*time.Hour), from)
+	assert.Equal(t, latest.At, to)
+
+	// prices are kept when reopened
+	assert.NoError(t, store.close())
+	store, err = newBoltPriceStore(filepath.Join(dataDir, PRICES_DB), 0)
+	assert.NoError(t, err)
+	defer store.close()
+	stored, err := store.loadPrices()
+	assert.NoError(t, err)
+	assert.Len(t, stored, 2*(2*24*60-60))
+
+	_, err = newBoltPriceStore(filepath.Join(dataDir, "missing", PRICES_DB), 0)
+	assert.Error(t, err)
+}
+
+func TestBoltArchive(t *testing.T) {
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	dataDir, err := ioutil.TempDir("", "teletrada-bolt")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	start := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3*24*time.Hour - time.Minute)
+	servertime.SetFakeTime(end)
+
+	prices := testHistory(start)
+	memory := NewSymbolsArchive()
+	addTestHistory(t, memory, prices)
+
+	archive := newTestBoltArchive(t, dataDir, 6*time.Hour)
+	addTestHistory(t, archive, prices)
+
+	// only recent prices are cached once the cache is trimmed
+	archive.evict(archive.cachedFrom())
+	symbol, err := archive.GetSymbol(ETH)
+	assert.NoError(t, err)
+	from, _, found := symbol.(*storedSymbol).symbol.GetPriceRange()
+	assert.True(t, found)
+	assert.Equal(t, end.Add(-6*time.Hour), from)
+
+	// prices match the in-memory archive through and before the cache
+	for at := start.Add(-time.Hour); at.Before(end.Add(time.Hour)); at = at.Add(7*time.Minute + 30*time.Second) {
+		for _, base := range []SymbolType{ETH, LTC} {
+			expected, err := memory.GetPriceAs(base, BTC, at)
+			assert.NoError(t, err)
+			price, err := archive.GetPriceAs(base, BTC, at)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, price, "%s at %s", base, at)
+		}
+	}
+
+	archiveFrom, archiveTo, err := archive.GetPriceRange()
+	assert.NoError(t, err)
+	assert.Equal(t, start, archiveFrom)
+	assert.Equal(t, end, archiveTo)
+	assert.Equal(t, memory.GetPriceGaps(2*time.Minute, end), archive.GetPriceGaps(2*time.Minute, end))
+
+	// prices older than the cache are saved but not cached
+	added, err := archive.AddPrices(ETH, []Price{{Base: ETH, As: USDT, Price: 200, At: start}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, map[SymbolType][]SymbolType{ETH: {BTC, USDT}, LTC: {BTC}}, archive.GetSymbolTypes())
+	latest, err := archive.GetLatestPriceAs(ETH, USDT)
+	assert.NoError(t, err)
+	assert.Equal(t, 200.0, latest.Price)
+
+	// a restart has the history
+	assert.NoError(t, archive.close())
+	archive = newTestBoltArchive(t, dataDir, 6*time.Hour)
+	defer archive.close()
+
+	latest, err = archive.GetLatestPriceAs(LTC, BTC)
+	assert.NoError(t, err)
+	expected, err := memory.GetLatestPriceAs(LTC, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, latest)
+
+	price, err := archive.GetPriceAs(ETH, BTC, start.Add(12*time.Hour+30*time.Second))
+	assert.NoError(t, err)
+	expected, err = memory.GetPriceAs(ETH, BTC, start.Add(12*time.Hour+30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, price)
+
+	_, err = archive.GetPriceAs(BNB, BTC, start)
+	assert.Error(t, err)
+
+	// the page read is kept for the next price
+	symbol, err = archive.GetSymbol(ETH)
+	assert.NoError(t, err)
+	page, ok := symbol.(*storedSymbol).pages[BTC]
+	assert.True(t, ok)
+	assert.True(t, page.covers(start.Add(12*time.Hour+30*time.Second)))
+
+	// symbols not stored in the database are skipped when evicting
+	archive.AddSymbol(NewSymbol(BNB))
+	archive.evict(archive.cachedFrom())
+}
+
+func TestBoltArchiveLoadPrices(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "teletrada-bolt")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	start := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	pricesJSON, err := json.Marshal(testHistory(start)[:10])
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, "prices.json"), pricesJSON, 0644))
+
+	archive := newTestBoltArchive(t, dataDir, time.Hour)
+	defer archive.close()
+
+	// loaded prices are saved in the database
+	assert.NoError(t, archive.LoadPrices(dataDir))
+	stored, err := archive.db.loadPrices()
+	assert.NoError(t, err)
+	assert.Len(t, stored, 10)
+
+	_, err = newBoltArchive(archive.db, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+
+Cost models are applied by the order engine to every simulated fill so
+backtests don't overstate returns by trading for free at the exact archive price.
+
+Costs are made up of:-
+
+	fees     - a maker or taker percentage of the fill, optionally paid in BNB at a discount
+	slippage - the fill price moves against the trade by a number of basis points,
+	           either fixed or from a curve based upon the value of the fill
+
+*/
+
+// MAX_SLIPPAGE_BPS - slippage of the whole price, a sell filled with it would be free
+const MAX_SLIPPAGE_BPS = 10000.0
+
+// CostModel - calculates the costs applied to a fill
+type CostModel interface {
+	// FillPrice - returns the price a fill executes at once slippage is applied
+	FillPrice(side tradeSide, price, quantity float64) float64
+	// FeePercent - returns the percentage of the fill value charged as a fee
+	FeePercent() float64
+	// PayFeesInBNB - returns true (and the discount percentage) if fees are paid in BNB
+	PayFeesInBNB() (bool, float64)
+}
+
+// CostConfig - configures the costs applied to simulated fills
+type CostConfig struct {
+	MakerFeePercent    float64         // fee charged on maker (limit) fills
+	TakerFeePercent    float64         // fee charged on taker (market) fills
+	FillAsMaker        bool            // charge maker fees on simulated fills rather than taker fees
+	PayFeesInBNB       bool            // pay fees in BNB when the portfolio holds enough
+	BNBDiscountPercent float64         // discount applied to fees paid in BNB
+	SlippageBps        float64         // fixed slippage in basis points
+	SlippageCurve      []SlippagePoint // additional slippage based upon fill value
+}
+
+// SlippagePoint - slippage in basis points for a fill of a particular value
+type SlippagePoint struct {
+	Value float64 // value of the fill in the "as" symbol
+	Bps   float64
+}
+
+// slippageModel - returns the slippage in basis points for a fill of a value
+type slippageModel interface {
+	bps(value float64) float64
+}
+
+type exchangeCosts struct {
+	feePercent         float64
+	payFeesInBNB       bool
+	bnbDiscountPercent float64
+	slippage           []slippageModel
+}
+
+// NewCostModel - creates a cost model from config
+func NewCostModel(config CostConfig) (CostModel, error) {
+
+	if config.MakerFeePercent < 0 || config.MakerFeePercent >= 100 {
+		return nil, fmt.Errorf("Maker fee must be between 0 and 100 percent")
+	}
+
+	if config.TakerFeePercent < 0 || config.TakerFeePercent >= 100 {
+		return nil, fmt.Errorf("Taker fee must be between 0 and 100 percent")
+	}
+
+	if config.BNBDiscountPercent < 0 || config.BNBDiscountPercent > 100 {
+		return nil, fmt.Errorf("BNB discount must be between 0 and 100 percent")
+	}
+
+	if config.SlippageBps < 0 {
+		return nil, fmt.Errorf("Slippage cannot be negative")
+	}
+
+	costs := &exchangeCosts{
+		feePercent:         config.TakerFeePercent,
+		payFeesInBNB:       config.PayFeesInBNB,
+		bnbDiscountPercent: config.BNBDiscountPercent,
+		slippage:           make([]slippageModel, 0),
+	}
+
+	if config.FillAsMaker {
+		costs.feePercent = config.MakerFeePercent
+	}
+
+	if config.SlippageBps > 0 {
+		costs.slippage = append(costs.slippage, fixedSlippage(config.SlippageBps))
+	}
+
+	maxCurveBps := 0.0
+	if len(config.SlippageCurve) > 0 {
+		curve, err := newVolumeSlippage(config.SlippageCurve)
+		if err != nil {
+			return nil, err
+		}
+		costs.slippage = append(costs.slippage, curve)
+		maxCurveBps = curve.maxBps()
+	}
+
+	if config.SlippageBps+maxCurveBps >= MAX_SLIPPAGE_BPS {
+		return nil, fmt.Errorf("Slippage must be less than %.0f bps including the slippage curve", MAX_SLIPPAGE_BPS)
+	}
+
+	return costs, nil
+}
+
+// FillPrice - moves the price against the trade by the slippage
+func (e *exchangeCosts) FillPrice(side tradeSide, price, quantity float64) float64 {
+	bps := 0.0
+	for _, model := range e.slippage {
+		bps += model.bps(price * quantity)
+	}
+
+	// never fill a sell at zero or less
+	if bps >= MAX_SLIPPAGE_BPS {
+		bps = MAX_SLIPPAGE_BPS - 1
+	}
+
+	if side == BUY_TRADE {
+		return price * (1 + bps/10000.0)
+	}
+	return price * (1 - bps/10000.0)
+}
+
+func (e *exchangeCosts) FeePercent() float64 {
+	return e.feePercent
+}
+
+func (e *exchangeCosts) PayFeesInBNB() (bool, float64) {
+	return e.payFeesInBNB, e.bnbDiscountPercent
+}
+
+// fixedSlippage - the same slippage regardless of fill size
+type fixedSlippage float64
+
+func (f fixedSlippage) bps(value float64) float64 {
+	return float64(f)
+}
+
+// volumeSlippage - slippage interpolated from a curve of fill values
+type volumeSlippage struct {
+	curve []SlippagePoint
+}
+
+func newVolumeSlippage(curve []SlippagePoint) (*volumeSlippage, error) {
+	points := make([]SlippagePoint, len(curve))
+	copy(points, curve)
+
+	for _, point := range points {
+		if point.Value <= 0 {
+			return nil, fmt.Errorf("Slippage curve values must be greater than 0")
+		}
+		if point.Bps < 0 {
+			return nil, fmt.Errorf("Slippage curve cannot be negative")
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].Value < points[j].Value })
+
+	return &volumeSlippage{
+		curve: points,
+	}, nil
+}
+
+// bps - interpolates between points on the curve, which starts at zero and
+// stays flat after the last point
+func (v *volumeSlippage) bps(value float64) float64 {
+	before := SlippagePoint{}
+	for _, point := range v.curve {
+		if value <= point.Value {
+			ratio := (value - before.Value) / (point.Value - before.Value)
+			return before.Bps + (point.Bps-before.Bps)*ratio
+		}
+		before = point
+	}
+	return before.Bps
+}
+
+// maxBps - returns the most slippage on the curve, interpolated slippage is never more than a point
+func (v *volumeSlippage) maxBps() float64 {
+	max := 0.0
+	for _, point := range v.curve {
+		if point.Bps > max {
+			max = point.Bps
+		}
+	}
+	return max
+}
+
+// noCosts - fills are free and execute exactly at the price
+type noCosts struct{}
+
+func (n noCosts) FillPrice(side tradeSide, price, quantity float64) float64 {
+	return price
+}
+
+func (n noCosts) FeePercent() float64 {
+	return 0
+}
+
+func (n noCosts) PayFeesInBNB() (bool, float64) {
+	return false, 0
+}
+
+// ParseSlippageCurve - parses a curve in the format "value:bps,value:bps"
+func ParseSlippageCurve(curve string) ([]SlippagePoint, error) {
+	points := make([]SlippagePoint, 0)
+	if curve == "" {
+		return points, nil
+	}
+
+	for _, pair := range strings.Split(curve, ",") {
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid slippage point %q, expected value:bps", pair)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid slippage value %q - %s", parts[0], err)
+		}
+		bps, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid slippage bps %q - %s", parts[1], err)
+		}
+		points = append(points, SlippagePoint{Value: value, Bps: bps})
+	}
+
+	return points, nil
+}
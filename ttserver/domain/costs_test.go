@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCostModel(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		config      CostConfig
+		errExpected bool
+		errText     string
+	}{
+		{
+			name:   "No costs",
+			config: CostConfig{},
+		},
+		{
+			name:   "Binance costs",
+			config: CostConfig{MakerFeePercent: 0.1, TakerFeePercent: 0.1, PayFeesInBNB: true, BNBDiscountPercent: 25.0, SlippageBps: 5},
+		},
+		{
+			name:        "Negative fee",
+			config:      CostConfig{TakerFeePercent: -0.1},
+			errExpected: true,
+			errText:     "Taker fee must be between 0 and 100 percent",
+		},
+		{
+			name:        "Invalid discount",
+			config:      CostConfig{BNBDiscountPercent: 101},
+			errExpected: true,
+			errText:     "BNB discount must be between 0 and 100 percent",
+		},
+		{
+			name:        "Negative slippage",
+			config:      CostConfig{SlippageBps: -1},
+			errExpected: true,
+			errText:     "Slippage cannot be negative",
+		},
+		{
+			name:        "Invalid curve",
+			config:      CostConfig{SlippageCurve: []SlippagePoint{{Value: 0, Bps: 10}}},
+			errExpected: true,
+			errText:     "Slippage curve values must be greater than 0",
+		},
+		{
+			name:        "Slippage of the whole price",
+			config:      CostConfig{SlippageBps: 10000},
+			errExpected: true,
+			errText:     "Slippage must be less than 10000 bps including the slippage curve",
+		},
+		{
+			name:        "Slippage with curve of the whole price",
+			config:      CostConfig{SlippageBps: 5000, SlippageCurve: []SlippagePoint{{Value: 1000, Bps: 100}, {Value: 10000, Bps: 5000}}},
+			errExpected: true,
+			errText:     "Slippage must be less than 10000 bps including the slippage curve",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			costs, err := NewCostModel(test.config)
+			if test.errExpected {
+				assert.Nil(t, costs)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.errText, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, costs)
+			}
+		})
+	}
+}
+
+func TestCostModelFees(t *testing.T) {
+
+	taker, err := NewCostModel(CostConfig{MakerFeePercent: 0.02, TakerFeePercent: 0.1})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, taker.FeePercent())
+
+	maker, err := NewCostModel(CostConfig{MakerFeePercent: 0.02, TakerFeePercent: 0.1, FillAsMaker: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.02, maker.FeePercent())
+
+	inBNB, discount := maker.PayFeesInBNB()
+	assert.False(t, inBNB)
+	assert.Equal(t, 0.0, discount)
+
+	bnb, err := NewCostModel(CostConfig{TakerFeePercent: 0.1, PayFeesInBNB: true, BNBDiscountPercent: 25})
+	assert.NoError(t, err)
+	inBNB, discount = bnb.PayFeesInBNB()
+	assert.True(t, inBNB)
+	assert.Equal(t, 25.0, discount)
+}
+
+func TestCostModelSlippage(t *testing.T) {
+
+	curve := []SlippagePoint{
+		{Value: 10000, Bps: 20},
+		{Value: 1000, Bps: 10},
+	}
+
+	fixed, err := NewCostModel(CostConfig{SlippageBps: 10})
+	assert.NoError(t, err)
+
+	volume, err := NewCostModel(CostConfig{SlippageCurve: curve})
+	assert.NoError(t, err)
+
+	both, err := NewCostModel(CostConfig{SlippageBps: 10, SlippageCurve: curve})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		costs    CostModel
+		side     tradeSide
+		price    float64
+		quantity float64
+		expPrice float64
+	}{
+		{name: "No costs", costs: noCosts{}, side: BUY_TRADE, price: 100, quantity: 1, expPrice: 100},
+		{name: "Fixed buy", costs: fixed, side: BUY_TRADE, price: 100, quantity: 1, expPrice: 100.1},
+		{name: "Fixed sell", costs: fixed, side: SELL_TRADE, price: 100, quantity: 1, expPrice: 99.9},
+		{name: "Curve start", costs: volume, side: BUY_TRADE, price: 100, quantity: 5, expPrice: 100.05},
+		{name: "Curve point", costs: volume, side: BUY_TRADE, price: 100, quantity: 10, expPrice: 100.1},
+		{name: "Curve between points", costs: volume, side: SELL_TRADE, price: 100, quantity: 55, expPrice: 99.85},
+		{name: "Curve after last point", costs: volume, side: SELL_TRADE, price: 100, quantity: 1000, expPrice: 99.8},
+		{name: "Fixed and curve", costs: both, side: BUY_TRADE, price: 100, quantity: 10, expPrice: 100.2},
+		{name: "Clamped sell", costs: &exchangeCosts{slippage: []slippageModel{fixedSlippage(20000)}}, side: SELL_TRADE, price: 100, quantity: 1, expPrice: 0.01},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.expPrice, test.costs.FillPrice(test.side, test.price, test.quantity), 0.0000001)
+		})
+	}
+}
+
+func TestParseSlippageCurve(t *testing.T) {
+
+	points, err := ParseSlippageCurve("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(points))
+
+	points, err = ParseSlippageCurve("1000:5, 10000:20")
+	assert.NoError(t, err)
+	assert.Equal(t, []SlippagePoint{{Value: 1000, Bps: 5}, {Value: 10000, Bps: 20}}, points)
+
+	_, err = ParseSlippageCurve("1000")
+	assert.Error(t, err)
+
+	_, err = ParseSlippageCurve("abc:5")
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/telecoda/teletrada/exchanges"
+)
+
+/*
+
+A server trades on its primary exchange, the one strategies, orders, backfills
+and simulations use. Other exchanges can be added so balances held on them are
+tracked as well.
+
+Each added exchange has an account with its own archive of the prices fetched
+from it and its own live portfolio, so a balance is kept by exchange and symbol
+and is always priced from the quotes of the exchange holding it. Prices on added
+exchanges are updated with the primary exchange's prices and their balances
+are refreshed every scheduled update.
+
+*/
+
+// ALL_EXCHANGES - the exchange of a balance aggregated over more than one exchange
+const ALL_EXCHANGES = "all"
+
+// exchangeAccount - balances held on an exchange other than the primary one
+type exchangeAccount struct {
+	client    exchanges.ExchangeClient
+	archive   SymbolsArchive
+	portfolio *portfolio
+}
+
+// newExchangeClient - returns a client of a named exchange to add to the primary one
+func newExchangeClient(name string, config exchanges.ResilienceConfig) (exchanges.ExchangeClient, error) {
+	var client exchanges.ExchangeClient
+	var err error
+
+	switch strings.ToLower(name) {
+	case exchanges.KRAKEN_EXCHANGE:
+		client, err = exchanges.NewKrakenClient()
+	default:
+		return nil, fmt.Errorf("Exchange %q is not supported", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return exchanges.NewResilientClient(client, config)
+}
+
+// addExchange - tracks the balances held on another exchange
+func (s *server) addExchange(client exchanges.ExchangeClient) error {
+	name := client.GetExchange()
+	if DefaultClient != nil && name == DefaultClient.GetExchange() {
+		return fmt.Errorf("Exchange %s is already the primary exchange", name)
+	}
+	if _, ok := s.accounts[name]; ok {
+		return fmt.Errorf("Exchange %s has already been added", name)
+	}
+
+	archive := newExchangeArchive(client)
+
+	s.accounts[name] = &exchangeAccount{
+		client:  client,
+		archive: archive,
+		portfolio: &portfolio{
+			name:     LIVE_PORTFOLIO + "-" + name,
+			isLive:   true,
+			balances: make(map[SymbolType]*BalanceAs, 0),
+			client:   client,
+			archive:  archive,
+		},
+	}
+
+	return nil
+}
+
+// exchangeNames - returns the primary exchange then the added exchanges in name order
+func (s *server) exchangeNames() []string {
+	names := make([]string, 0, len(s.accounts))
+	for name := range s.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultClient.GetExchange()}, names...)
+}
+
+// exchangePortfolio - returns the live portfolio of balances held on an exchange
+func (s *server) exchangePortfolio(exchange string) (*portfolio, error) {
+	if exchange == DefaultClient.GetExchange() {
+		if s.livePortfolio == nil {
+			return nil, fmt.Errorf("No live portfolio")
+		}
+		return s.livePortfolio, nil
+	}
+
+	account, ok := s.accounts[exchange]
+	if !ok {
+		return nil, fmt.Errorf("Exchange %s not found", exchange)
+	}
+
+	return account.portfolio, nil
+}
+
+// updateExchangePrices - fetches the latest prices of every added exchange, and their day
+// summaries when daily
+func (s *server) updateExchangePrices(daily bool) {
+	for name, account := range s.accounts {
+		if err := account.archive.UpdatePrices(); err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: updating %s prices - %s", name, err))
+			continue
+		}
+		if !daily {
+			continue
+		}
+		if err := account.archive.UpdateDaySummaries(); err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: updating %s closing prices - %s", name, err))
+		}
+	}
+}
+
+// updateExchangePortfolios - fetches the latest balances of every added exchange and reprices them,
+// an exchange that cannot be reached keeps its last balances
+func (s *server) updateExchangePortfolios() {
+	for name, account := range s.accounts {
+		if err := account.portfolio.refreshCoinBalances(); err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: updating %s portfolio - %s", name, err))
+			continue
+		}
+		if err := account.portfolio.reprice(); err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: repricing %s portfolio - %s", name, err))
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const TEST_OTHER_EXCHANGE = "otherexchange"
+
+// namedClient - a mock exchange with another name
+type namedClient struct {
+	exchanges.ExchangeClient
+	name string
+}
+
+func (n namedClient) GetExchange() string {
+	return n.name
+}
+
+func addTestExchange(t *testing.T, s *server) {
+	now := servertime.Now()
+	balances := []exchanges.CoinBalance{
+		{Symbol: BTC, Free: 2, Exchange: TEST_OTHER_EXCHANGE},
+		{Symbol: ETH, Free: 10, Exchange: TEST_OTHER_EXCHANGE},
+		{Symbol: USDT, Free: 5000, Exchange: TEST_OTHER_EXCHANGE},
+	}
+	// the other exchange only quotes USDT as BTC/USDT and has its own ETH price
+	prices := []exchanges.Price{
+		{Base: BTC, As: BTC, Price: 1, At: now, Exchange: TEST_OTHER_EXCHANGE},
+		{Base: BTC, As: USDT, Price: 10000, At: now, Exchange: TEST_OTHER_EXCHANGE},
+		{Base: ETH, As: BTC, Price: 0.1, At: now, Exchange: TEST_OTHER_EXCHANGE},
+	}
+	client, err := exchanges.NewMockClient(balances, prices)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.addExchange(namedClient{ExchangeClient: client, name: TEST_OTHER_EXCHANGE}))
+	s.updateExchangePrices(true)
+}
+
+func TestAddExchange(t *testing.T) {
+	srv, err := initMockServer()
+	assert.NoError(t, err)
+	s := srv.(*server)
+
+	addTestExchange(t, s)
+	assert.Equal(t, []string{exchanges.MOCK_EXCHANGE, TEST_OTHER_EXCHANGE}, s.exchangeNames())
+
+	// an exchange is only added once and the primary exchange is never added
+	client, err := exchanges.NewMockClient(nil, nil)
+	assert.NoError(t, err)
+	assert.Error(t, s.addExchange(namedClient{ExchangeClient: client, name: TEST_OTHER_EXCHANGE}))
+	assert.Error(t, s.addExchange(client))
+
+	// prices of the other exchange are kept apart
+	price, err := s.accounts[TEST_OTHER_EXCHANGE].archive.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, price.Price)
+	price, err = DefaultArchive.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0.1, price.Price)
+
+	_, err = newExchangeClient("unknown", exchanges.ResilienceConfig{})
+	assert.Error(t, err)
+}
+
+func TestMultiExchangePortfolio(t *testing.T) {
+	srv, err := initMockServer()
+	assert.NoError(t, err)
+	s := srv.(*server)
+
+	addTestExchange(t, s)
+
+	// one exchange
+	resp, err := s.GetPortfolio(context.Background(), &proto.GetPortfolioRequest{Exchange: TEST_OTHER_EXCHANGE})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Balances, 3) {
+		for _, balance := range resp.Balances {
+			assert.Equal(t, TEST_OTHER_EXCHANGE, balance.Exchange)
+			assert.Equal(t, BTC, balance.As)
+		}
+		assert.Equal(t, BTC, resp.Balances[0].Symbol)
+		assert.Equal(t, float32(2), resp.Balances[0].Value)
+		// priced with the other exchange's ETH price
+		assert.Equal(t, ETH, resp.Balances[1].Symbol)
+		assert.InDelta(t, 1.0, resp.Balances[1].Value, 0.0001)
+		// priced with the inverse of BTC/USDT
+		assert.Equal(t, USDT, resp.Balances[2].Symbol)
+		assert.InDelta(t, 0.5, resp.Balances[2].Value, 0.0001)
+	}
+
+	primary, err := s.GetPortfolio(context.Background(), &proto.GetPortfolioRequest{Exchange: exchanges.MOCK_EXCHANGE})
+	assert.NoError(t, err)
+	assert.Len(t, primary.Balances, 3)
+
+	// by exchange
+	resp, err = s.GetPortfolio(context.Background(), &proto.GetPortfolioRequest{ByExchange: true})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Balances, 6) {
+		for i, balance := range resp.Balances {
+			if i < 3 {
+				assert.Equal(t, exchanges.MOCK_EXCHANGE, balance.Exchange)
+			} else {
+				assert.Equal(t, TEST_OTHER_EXCHANGE, balance.Exchange)
+			}
+		}
+	}
+
+	// aggregated
+	resp, err = s.GetPortfolio(context.Background(), &proto.GetPortfolioRequest{})
+	assert.NoError(t, err)
+	bySymbol := make(map[string]*proto.Balance)
+	for _, balance := range resp.Balances {
+		bySymbol[balance.Symbol] = balance
+	}
+	assert.Len(t, bySymbol, 4)
+
+	btc := bySymbol[BTC]
+	if assert.NotNil(t, btc) {
+		assert.Equal(t, ALL_EXCHANGES, btc.Exchange)
+		assert.Equal(t, float32(27), btc.Total)
+		assert.Equal(t, float32(27), btc.Value)
+		assert.Equal(t, float32(1), btc.Price)
+		assert.Nil(t, btc.BuyStrategy)
+	}
+
+	eth := bySymbol[ETH]
+	primaryEth := primary.Balances[1]
+	if assert.NotNil(t, eth) {
+		assert.Equal(t, ALL_EXCHANGES, eth.Exchange)
+		assert.Equal(t, primaryEth.Total+10, eth.Total)
+		assert.InDelta(t, primaryEth.Value+1, eth.Value, 0.001)
+		assert.InDelta(t, eth.Value/eth.Total, eth.Price, 0.0001)
+	}
+
+	usdt := bySymbol[USDT]
+	if assert.NotNil(t, usdt) {
+		assert.Equal(t, TEST_OTHER_EXCHANGE, usdt.Exchange)
+		assert.Equal(t, float32(5000), usdt.Total)
+	}
+
+	// unknown exchange
+	_, err = s.GetPortfolio(context.Background(), &proto.GetPortfolioRequest{Exchange: "unknown"})
+	assert.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
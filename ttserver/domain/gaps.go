@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+
+Prices are added to the archive every update so there should never be much
+more than the update frequency between two prices for a trading pair. When the
+server has been stopped there will be a gap in the history.
+
+A gap is any period longer than PRICE_GAP_FACTOR updates between two prices for
+a trading pair, or between the latest price and now. Backfilled prices come from
+candles that can be coarser than the updates, so they record their interval and
+are only a gap when more than PRICE_GAP_FACTOR of their intervals apart.
+
+Prices calculated across a gap are marked as interpolated, simulation results
+report the percentage of prices that were interpolated.
+
+Gaps are repaired by starting a backfill for each one, optionally when the
+server starts.
+
+*/
+
+// PRICE_GAP_FACTOR - number of updates without a price before it is a gap
+const PRICE_GAP_FACTOR = 2
+
+// DEFAULT_MAX_PRICE_GAP - max gap until the server sets it from the update frequency
+const DEFAULT_MAX_PRICE_GAP = PRICE_GAP_FACTOR * time.Minute
+
+// maxPriceGap - longest time between prices that is not a gap, accessed atomically
+var maxPriceGap = int64(DEFAULT_MAX_PRICE_GAP)
+
+func setMaxPriceGap(gap time.Duration) {
+	atomic.StoreInt64(&maxPriceGap, int64(gap))
+}
+
+func getMaxPriceGap() time.Duration {
+	return time.Duration(atomic.LoadInt64(&maxPriceGap))
+}
+
+// priceGapLimit - returns the longest time after prices of the intervals that is not a gap
+func priceGapLimit(maxGap time.Duration, intervals ...time.Duration) time.Duration {
+	for _, interval := range intervals {
+		if limit := interval * PRICE_GAP_FACTOR; limit > maxGap {
+			maxGap = limit
+		}
+	}
+	return maxGap
+}
+
+// isPriceGap - returns true if the time between two prices is a gap
+func isPriceGap(before, after Price, maxGap time.Duration) bool {
+	return after.At.Sub(before.At) > priceGapLimit(maxGap, before.Interval, after.Interval)
+}
+
+// PriceGap - a period with no prices for a trading pair
+type PriceGap struct {
+	Base SymbolType
+	As   SymbolType
+	From time.Time // time of the last price before the gap
+	To   time.Time // time of the first price after the gap
+}
+
+func (g PriceGap) Duration() time.Duration {
+	return g.To.Sub(g.From)
+}
+
+// GetPriceGaps - returns gaps longer than maxGap, or the interval of the prices either
+// side, in the prices of the symbol, the latest price is checked against until
+func (s *symbol) GetPriceGaps(maxGap time.Duration, until time.Time) []PriceGap {
+	s.RLock()
+	defer s.RUnlock()
+
+	gaps := make([]PriceGap, 0)
+
+	for as, prices := range s.priceAs {
+		if as == s.SymbolType || len(prices) == 0 {
+			continue
+		}
+
+		// prices are sorted by time
+		for i := 1; i < len(prices); i++ {
+			if isPriceGap(prices[i-1], prices[i], maxGap) {
+				gaps = append(gaps, PriceGap{Base: s.SymbolType, As: as, From: prices[i-1].At, To: prices[i].At})
+			}
+		}
+
+		latest := prices[len(prices)-1]
+		if until.Sub(latest.At) > priceGapLimit(maxGap, latest.Interval) {
+			gaps = append(gaps, PriceGap{Base: s.SymbolType, As: as, From: latest.At, To: until})
+		}
+	}
+
+	return gaps
+}
+
+// GetPriceGaps - returns gaps in the prices of all symbols in the archive
+func (sa *symbolsArchive) GetPriceGaps(maxGap time.Duration, until time.Time) []PriceGap {
+	sa.RLock()
+	defer sa.RUnlock()
+
+	gaps := make([]PriceGap, 0)
+	for _, symbol := range sa.symbols {
+		gaps = append(gaps, symbol.GetPriceGaps(maxGap, until)...)
+	}
+
+	sort.Slice(gaps, func(i, j int) bool {
+		if gaps[i].Base != gaps[j].Base {
+			return gaps[i].Base < gaps[j].Base
+		}
+		if gaps[i].As != gaps[j].As {
+			return gaps[i].As < gaps[j].As
+		}
+		return gaps[i].From.Before(gaps[j].From)
+	})
+
+	return gaps
+}
+
+// candleIntervalFor - returns the longest candle interval no longer than the update frequency
+func candleIntervalFor(freq time.Duration) exchanges.CandleInterval {
+	best := exchanges.CANDLE_1M
+	bestDuration := time.Minute
+	for _, interval := range []exchanges.CandleInterval{exchanges.CANDLE_5M, exchanges.CANDLE_15M, exchanges.CANDLE_1H, exchanges.CANDLE_4H, exchanges.CANDLE_1D} {
+		duration, _ := interval.Duration()
+		if duration <= freq && duration > bestDuration {
+			best = interval
+			bestDuration = duration
+		}
+	}
+	return best
+}
+
+// findPriceGaps - returns the gaps in the archive, filtered by base and as if not blank
+func (s *server) findPriceGaps(base, as SymbolType) []PriceGap {
+	gaps := make([]PriceGap, 0)
+	for _, gap := range DefaultArchive.GetPriceGaps(getMaxPriceGap(), servertime.Now()) {
+		if base != "" && gap.Base != base {
+			continue
+		}
+		if as != "" && gap.As != as {
+			continue
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps
+}
+
+// repairPriceGaps - starts a backfill for each gap, the server must be locked
+func (s *server) repairPriceGaps(gaps []PriceGap) []string {
+
+	known, err := DefaultClient.GetTradingPairs()
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: cannot repair price gaps - failed to get trading pairs - %s", err))
+		return nil
+	}
+	exists := make(map[tradingPair]bool, len(known))
+	for _, pair := range known {
+		exists[tradingPair{base: SymbolType(pair.Base), as: SymbolType(pair.As)}] = true
+	}
+
+	interval := candleIntervalFor(s.config.UpdateFreq)
+
+	ids := make([]string, 0)
+	for _, gap := range gaps {
+		pair := tradingPair{base: gap.Base, as: gap.As}
+		if !exists[pair] {
+			DefaultLogger.log(fmt.Sprintf("Price gap for %s from %s to %s not repaired, pair is not on the exchange", pair, gap.From.Format(DATE_FORMAT), gap.To.Format(DATE_FORMAT)))
+			continue
+		}
+
+		job, err := newBackfill([]tradingPair{pair}, gap.From, gap.To, interval)
+		if err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: cannot repair price gap for %s - %s", pair, err))
+			continue
+		}
+
+		s.backfills[job.id] = job
+		go job.run()
+
+		ids = append(ids, job.id)
+	}
+
+	if len(ids) > 0 {
+		DefaultLogger.log(fmt.Sprintf("Repairing %d price gaps", len(ids)))
+	}
+
+	return ids
+}
+
+// GetPriceGaps - returns gaps in the price archive and optionally starts backfills to repair them
+func (s *server) GetPriceGaps(ctx context.Context, req *proto.GetPriceGapsRequest) (*proto.GetPriceGapsResponse, error) {
+
+	gaps := s.findPriceGaps(SymbolType(req.Base), SymbolType(req.As))
+
+	resp := &proto.GetPriceGapsResponse{
+		MaxGapSeconds: int64(getMaxPriceGap().Seconds()),
+		Gaps:          make([]*proto.PriceGap, len(gaps)),
+	}
+
+	for i, gap := range gaps {
+		pg, err := gap.toProto(s.config.UpdateFreq)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to convert price gap - %s", err)
+		}
+		resp.Gaps[i] = pg
+	}
+
+	if req.Repair {
+		s.Lock()
+		resp.BackfillIds = s.repairPriceGaps(gaps)
+		s.Unlock()
+	}
+
+	return resp, nil
+}
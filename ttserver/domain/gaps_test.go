@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestPriceGaps(t *testing.T) {
+
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	eth := NewSymbol(ETH)
+	eth.AddPrices([]Price{
+		{Base: ETH, As: BTC, Price: 0.1, At: start},
+		{Base: ETH, As: BTC, Price: 0.1, At: start.Add(time.Minute)},
+		{Base: ETH, As: BTC, Price: 0.2, At: start.Add(time.Hour)},
+		{Base: ETH, As: BTC, Price: 0.2, At: start.Add(61 * time.Minute)},
+		{Base: ETH, As: USDT, Price: 1000, At: start},
+		{Base: ETH, As: USDT, Price: 1000, At: start.Add(time.Minute)},
+	})
+
+	ltc := NewSymbol(LTC)
+	ltc.AddPrices([]Price{
+		{Base: LTC, As: BTC, Price: 0.01, At: start},
+		{Base: LTC, As: BTC, Price: 0.01, At: start.Add(30 * time.Minute)},
+	})
+
+	archive := NewSymbolsArchive()
+	archive.AddSymbol(eth)
+	archive.AddSymbol(ltc)
+
+	until := start.Add(62 * time.Minute)
+	gaps := archive.GetPriceGaps(2*time.Minute, until)
+
+	assert.Equal(t, []PriceGap{
+		{Base: ETH, As: BTC, From: start.Add(time.Minute), To: start.Add(time.Hour)},
+		{Base: ETH, As: USDT, From: start.Add(time.Minute), To: until},
+		{Base: LTC, As: BTC, From: start, To: start.Add(30 * time.Minute)},
+		{Base: LTC, As: BTC, From: start.Add(30 * time.Minute), To: until},
+	}, gaps)
+
+	assert.Equal(t, 59*time.Minute, gaps[0].Duration())
+
+	pg, err := gaps[0].toProto(time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(58), pg.MissingPrices)
+
+	// no gaps if they are allowed to be two hours
+	assert.Equal(t, 0, len(archive.GetPriceGaps(2*time.Hour, until)))
+}
+
+func TestBackfilledPriceGaps(t *testing.T) {
+
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// minute prices followed by hourly backfilled candles
+	eth := NewSymbol(ETH)
+	eth.AddPrices([]Price{
+		{Base: ETH, As: BTC, Price: 0.1, At: start},
+		{Base: ETH, As: BTC, Price: 0.1, At: start.Add(time.Minute)},
+		{Base: ETH, As: BTC, Price: 0.2, At: start.Add(time.Hour), Interval: time.Hour},
+		{Base: ETH, As: BTC, Price: 0.2, At: start.Add(2 * time.Hour), Interval: time.Hour},
+		{Base: ETH, As: BTC, Price: 0.3, At: start.Add(5 * time.Hour), Interval: time.Hour},
+	})
+
+	until := start.Add(6 * time.Hour)
+	assert.Equal(t, []PriceGap{
+		{Base: ETH, As: BTC, From: start.Add(2 * time.Hour), To: start.Add(5 * time.Hour)},
+	}, eth.GetPriceGaps(2*time.Minute, until))
+
+	// the latest candle is checked against its own interval
+	assert.Equal(t, 2, len(eth.GetPriceGaps(2*time.Minute, start.Add(8*time.Hour))))
+
+	// prices within a candle's interval aren't interpolated across a gap
+	price, err := eth.GetPriceAs(BTC, start.Add(90*time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, price.Interpolated)
+	price, err = eth.GetPriceAs(BTC, start.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, price.Interpolated)
+}
+
+func TestInterpolatedPrices(t *testing.T) {
+
+	defer setMaxPriceGap(getMaxPriceGap())
+	setMaxPriceGap(2 * time.Minute)
+
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	eth := NewSymbol(ETH)
+	eth.AddPrices([]Price{
+		{Base: ETH, As: BTC, Price: 0.1, At: start},
+		{Base: ETH, As: BTC, Price: 0.1, At: start.Add(time.Minute)},
+		{Base: ETH, As: BTC, Price: 0.2, At: start.Add(time.Hour)},
+	})
+
+	btc := NewSymbol(BTC)
+	btc.AddPrices([]Price{
+		{Base: BTC, As: USDT, Price: 10000, At: start},
+		{Base: BTC, As: USDT, Price: 10000, At: start.Add(time.Minute)},
+		{Base: BTC, As: USDT, Price: 10000, At: start.Add(2 * time.Minute)},
+	})
+
+	tests := []struct {
+		name         string
+		at           time.Time
+		interpolated bool
+	}{
+		{name: "Exact price", at: start},
+		{name: "Between close prices", at: start.Add(30 * time.Second)},
+		{name: "Across a gap", at: start.Add(30 * time.Minute), interpolated: true},
+		{name: "Just after latest", at: start.Add(61 * time.Minute)},
+		{name: "Long after latest", at: start.Add(2 * time.Hour), interpolated: true},
+		{name: "Long before earliest", at: start.Add(-time.Hour), interpolated: true},
+	}
+
+	for _, test := range tests {
+		price, err := eth.GetPriceAs(BTC, test.at)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.interpolated, price.Interpolated, test.name)
+	}
+
+	// conversions are interpolated if either price is
+	archive := NewSymbolsArchive()
+	archive.AddSymbol(eth)
+	archive.AddSymbol(btc)
+
+	price, err := archive.GetPriceAs(ETH, USDT, start.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.False(t, price.Interpolated)
+
+	price, err = archive.GetPriceAs(ETH, USDT, start.Add(30*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, price.Interpolated)
+}
+
+func TestCandleIntervalFor(t *testing.T) {
+	assert.Equal(t, exchanges.CANDLE_1M, candleIntervalFor(30*time.Second))
+	assert.Equal(t, exchanges.CANDLE_1M, candleIntervalFor(time.Minute))
+	assert.Equal(t, exchanges.CANDLE_5M, candleIntervalFor(10*time.Minute))
+	assert.Equal(t, exchanges.CANDLE_1H, candleIntervalFor(time.Hour))
+	assert.Equal(t, exchanges.CANDLE_1D, candleIntervalFor(7*24*time.Hour))
+}
+
+func TestRepairPriceGaps(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	ctx := context.Background()
+
+	// mock server updates hourly so a gap is longer than 2 hours
+	assert.Equal(t, 2*time.Hour, getMaxPriceGap())
+
+	now := servertime.Now()
+
+	defer func(archive SymbolsArchive) { DefaultArchive = archive }(DefaultArchive)
+	DefaultArchive = NewSymbolsArchive()
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: ETH, As: BTC, Price: 0.1, At: now.Add(-10 * time.Hour)}))
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: ETH, As: BTC, Price: 0.1, At: now.Add(-time.Hour)}))
+	// not traded on the mock exchange
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: ETH, As: SymbolType("XRP"), Price: 0.1, At: now.Add(-10 * time.Hour)}))
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: ETH, As: SymbolType("XRP"), Price: 0.1, At: now.Add(-time.Hour)}))
+
+	resp, err := server.GetPriceGaps(ctx, &proto.GetPriceGapsRequest{Base: string(ETH), As: string(BTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7200), resp.MaxGapSeconds)
+	if assert.Equal(t, 1, len(resp.Gaps)) {
+		assert.Equal(t, int32(8), resp.Gaps[0].MissingPrices)
+	}
+	assert.Equal(t, 0, len(resp.BackfillIds))
+
+	resp, err = server.GetPriceGaps(ctx, &proto.GetPriceGapsRequest{Repair: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(resp.Gaps))
+	if !assert.Equal(t, 1, len(resp.BackfillIds), "pair not on the exchange is not repaired") {
+		return
+	}
+
+	job := server.backfills[resp.BackfillIds[0]]
+	assert.Equal(t, exchanges.CANDLE_1H, job.interval)
+	select {
+	case <-job.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Backfill did not finish")
+	}
+
+	resp, err = server.GetPriceGaps(ctx, &proto.GetPriceGapsRequest{Base: string(ETH), As: string(BTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(resp.Gaps))
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+
+Indicators are calculated from a series of prices sampled from the archive at
+a fixed interval, so they behave the same when replaying history as they do
+in realtime:-
+
+	sma - simple moving average of the samples in a period
+	ema - exponential moving average, warmed up over EMA_WARMUP periods
+	rsi - relative strength index using simple average gains and losses
+
+Until the archive holds enough history to fill a series, strategies using
+indicators treat their condition as not met rather than failing.
+
+*/
+
+// MAX_INDICATOR_SAMPLES - limits the number of prices sampled for an indicator
+const MAX_INDICATOR_SAMPLES = 1000
+
+// EMA_WARMUP - number of periods of history used to settle an EMA
+const EMA_WARMUP = 3
+
+// indicatorSamples - returns the number of samples in a period and validates it
+func indicatorSamples(name string, period, interval time.Duration) (int, error) {
+	if interval <= 0 {
+		return 0, fmt.Errorf("interval must be greater than 0")
+	}
+	if period < interval {
+		return 0, fmt.Errorf("%s period must be at least the sample interval %s", name, interval)
+	}
+	samples := int(period / interval)
+	if samples > MAX_INDICATOR_SAMPLES {
+		return 0, fmt.Errorf("%s period is more than %d samples of %s", name, MAX_INDICATOR_SAMPLES, interval)
+	}
+	return samples, nil
+}
+
+// priceSeries - returns count prices sampled every interval up to and including at, oldest first.
+// ok is false when the archive does not hold prices back to the first sample
+func priceSeries(symbol, as SymbolType, at time.Time, interval time.Duration, count int) ([]float64, bool, error) {
+
+	from := at.Add(-time.Duration(count-1) * interval)
+
+	sym, err := DefaultArchive.GetSymbol(symbol)
+	if err != nil {
+		return nil, false, err
+	}
+
+	earliest, _, found := sym.GetPriceRange()
+	if !found || from.Before(earliest) {
+		return nil, false, nil
+	}
+
+	series := make([]float64, count)
+	for i := 0; i < count; i++ {
+		price, err := DefaultArchive.GetPriceAs(symbol, as, from.Add(time.Duration(i)*interval))
+		if err != nil {
+			return nil, false, err
+		}
+		series[i] = price.Price
+	}
+
+	return series, true, nil
+}
+
+// sma - simple moving average of the last period values
+func sma(values []float64, period int) float64 {
+	if period > len(values) {
+		period = len(values)
+	}
+	if period == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, value := range values[len(values)-period:] {
+		sum += value
+	}
+	return sum / float64(period)
+}
+
+// ema - exponential moving average over a period, seeded with the first value
+func ema(values []float64, period int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	alpha := 2.0 / float64(period+1)
+	average := values[0]
+	for _, value := range values[1:] {
+		average = alpha*value + (1-alpha)*average
+	}
+	return average
+}
+
+// rsi - relative strength index of the changes between the last period+1 values
+func rsi(values []float64, period int) float64 {
+	if period >= len(values) {
+		period = len(values) - 1
+	}
+	if period <= 0 {
+		return 50
+	}
+
+	gains := 0.0
+	losses := 0.0
+	values = values[len(values)-period-1:]
+	for i := 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			gains += change
+		} else {
+			losses -= change
+		}
+	}
+
+	if losses == 0 {
+		if gains == 0 {
+			// no movement
+			return 50
+		}
+		return 100
+	}
+
+	rs := gains / losses
+	return 100 - 100/(1+rs)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestIndicators(t *testing.T) {
+
+	assert.Equal(t, 3.5, sma([]float64{1, 2, 3, 4}, 2))
+	assert.Equal(t, 2.5, sma([]float64{1, 2, 3, 4}, 10))
+	assert.Equal(t, 0.0, sma([]float64{}, 2))
+
+	// alpha = 2/3, 1 -> 1.6667 -> 2.5556
+	assert.InDelta(t, 2.5555556, ema([]float64{1, 2, 3}, 2), 0.0000001)
+	assert.Equal(t, 0.0, ema([]float64{}, 2))
+
+	// gains of 3 and losses of 1
+	assert.Equal(t, 75.0, rsi([]float64{1, 2, 3, 2, 3}, 4))
+	assert.Equal(t, 100.0, rsi([]float64{1, 2, 3}, 2))
+	assert.Equal(t, 0.0, rsi([]float64{3, 2, 1}, 2))
+	assert.Equal(t, 50.0, rsi([]float64{1, 1, 1}, 2))
+}
+
+func TestIndicatorSamples(t *testing.T) {
+
+	samples, err := indicatorSamples("test", 4*time.Hour, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, samples)
+
+	_, err = indicatorSamples("test", time.Hour, 0)
+	assert.Error(t, err)
+
+	_, err = indicatorSamples("test", time.Minute, time.Hour)
+	assert.Error(t, err)
+
+	_, err = indicatorSamples("test", time.Duration(MAX_INDICATOR_SAMPLES+1)*time.Minute, time.Minute)
+	assert.Error(t, err)
+}
+
+func TestPriceSeries(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	defer func(archive SymbolsArchive) { DefaultArchive = archive }(DefaultArchive)
+	DefaultArchive = NewSymbolsArchive()
+
+	start := servertime.Now()
+	hour := time.Duration(1 * time.Hour)
+
+	symbol := SymbolType("test-symbol")
+	as := SymbolType("USDT")
+
+	_, _, err := priceSeries(symbol, as, start, hour, 2)
+	assert.Error(t, err)
+
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: symbol, As: as, Price: 100, At: start, Exchange: "exchange"}))
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: symbol, As: as, Price: 200, At: start.Add(2 * hour), Exchange: "exchange"}))
+
+	// prices are interpolated between archived prices
+	series, ok, err := priceSeries(symbol, as, start.Add(2*hour), hour, 3)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []float64{100, 150, 200}, series)
+
+	// not enough history
+	_, ok, err = priceSeries(symbol, as, start.Add(2*hour), hour, 4)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
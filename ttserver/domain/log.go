@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/telecoda/teletrada/proto"
@@ -19,6 +20,7 @@ type Logger interface {
 }
 
 type logger struct {
+	sync.RWMutex
 	isVerbose bool
 	// logging
 	statusLog []LogEntry
@@ -38,6 +40,9 @@ func NewLogger(isVerbose bool) Logger {
 }
 
 func (l *logger) log(msg string) {
+	l.Lock()
+	defer l.Unlock()
+
 	entry := LogEntry{
 		Timestamp: servertime.Now(),
 		Message:   msg,
@@ -55,7 +60,12 @@ func (l *logger) log(msg string) {
 }
 
 func (l *logger) GetEntries() []LogEntry {
-	return l.statusLog
+	l.RLock()
+	defer l.RUnlock()
+
+	entries := make([]LogEntry, len(l.statusLog))
+	copy(entries, l.statusLog)
+	return entries
 }
 
 // GetLog returns server log
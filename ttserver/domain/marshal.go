@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	tspb "github.com/golang/protobuf/ptypes"
 	"github.com/telecoda/teletrada/exchanges"
 	"github.com/telecoda/teletrada/proto"
@@ -152,6 +154,8 @@ func strategyToProto(s Strategy) (*proto.Strategy, error) {
 		Symbol:      string(s.Symbol()),
 		As:          string(s.As()),
 		IsRunning:   s.IsRunning(),
+		Type:        string(s.Type()),
+		Params:      s.Params(),
 	}
 	return ps, nil
 }
@@ -165,3 +169,146 @@ func (p Price) toExchangePrice() exchanges.Price {
 		At:       p.At,
 	}
 }
+
+func (g PriceGap) toProto(updateFreq time.Duration) (*proto.PriceGap, error) {
+	pg := &proto.PriceGap{
+		Base: string(g.Base),
+		As:   string(g.As),
+	}
+
+	if updateFreq > 0 {
+		pg.MissingPrices = int32(g.Duration()/updateFreq) - 1
+	}
+
+	var err error
+	if pg.FromTime, err = tspb.TimestampProto(g.From); err != nil {
+		return nil, err
+	}
+	if pg.ToTime, err = tspb.TimestampProto(g.To); err != nil {
+		return nil, err
+	}
+
+	return pg, nil
+}
+
+func (b *backfill) toProto() (*proto.Backfill, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	pairs := make([]string, len(b.pairs))
+	for i, pair := range b.pairs {
+		pairs[i] = pair.String()
+	}
+
+	backfill := &proto.Backfill{
+		Id:          b.id,
+		Pairs:       pairs,
+		Interval:    string(b.interval),
+		State:       string(b.state),
+		Progress:    float32(b.progress()),
+		PricesAdded: int32(b.pricesAdded),
+	}
+
+	if b.current.base != "" {
+		backfill.CurrentPair = b.current.String()
+	}
+
+	if b.err != nil {
+		backfill.Error = b.err.Error()
+	}
+
+	var err error
+	if backfill.FromTime, err = tspb.TimestampProto(b.from); err != nil {
+		return nil, err
+	}
+	if backfill.ToTime, err = tspb.TimestampProto(b.to); err != nil {
+		return nil, err
+	}
+	if backfill.StartedTime, err = tspb.TimestampProto(b.startedTime); err != nil {
+		return nil, err
+	}
+	if !b.finishedTime.IsZero() {
+		if backfill.FinishedTime, err = tspb.TimestampProto(b.finishedTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return backfill, nil
+}
+
+func clientStatusToProto(c exchanges.ClientStatus) (*proto.ExchangeStatus, error) {
+	pb := &proto.ExchangeStatus{
+		Exchange:            c.Exchange,
+		CircuitBreaker:      string(c.Breaker),
+		ConsecutiveFailures: int32(c.ConsecutiveFailures),
+		LastError:           c.LastError,
+		WeightUsed:          int32(c.WeightUsed),
+		WeightLimit:         int32(c.WeightLimit),
+		Requests:            int32(c.Requests),
+		Retries:             int32(c.Retries),
+		Failures:            int32(c.Failures),
+	}
+
+	if !c.OpenedAt.IsZero() {
+		ts, err := tspb.TimestampProto(c.OpenedAt)
+		if err != nil {
+			return nil, err
+		}
+		pb.OpenedTime = ts
+	}
+
+	return pb, nil
+}
+
+func (r riskStatus) toProto() *proto.RiskStatus {
+	maxOrderSize := make(map[string]float32, len(r.config.MaxOrderSize))
+	for symbol, size := range r.config.MaxOrderSize {
+		maxOrderSize[string(symbol)] = float32(size)
+	}
+
+	return &proto.RiskStatus{
+		MaxOrderSize:      maxOrderSize,
+		MaxTradePercent:   float32(r.config.MaxTradePercent),
+		MaxDailyLoss:      float32(r.config.MaxDailyLoss),
+		MaxOrdersPerHour:  int32(r.config.MaxOrdersPerHour),
+		KillSwitchEngaged: r.engaged,
+		KillSwitchReason:  r.reason,
+		OrdersLastHour:    int32(r.ordersLastHour),
+		RealisedToday:     float32(r.realised),
+	}
+}
+
+func (r *simulationResult) toProto() (*proto.SimulationResult, error) {
+	pr := &proto.SimulationResult{
+		Id:                    r.id,
+		As:                    string(r.as),
+		StartValue:            float32(r.startValue),
+		EndValue:              float32(r.endValue),
+		TotalReturn:           float32(r.totalReturn),
+		AnnualisedReturn:      float32(r.annualisedReturn),
+		MaxDrawdown:           float32(r.maxDrawdown),
+		Volatility:            float32(r.volatility),
+		SharpeRatio:           float32(r.sharpeRatio),
+		SortinoRatio:          float32(r.sortinoRatio),
+		Trades:                int32(r.trades),
+		WinRate:               float32(r.winRate),
+		BenchmarkStartValue:   float32(r.benchmarkStartValue),
+		BenchmarkEndValue:     float32(r.benchmarkEndValue),
+		BenchmarkReturn:       float32(r.benchmarkReturn),
+		SyntheticPricePercent: float32(r.syntheticPercent),
+	}
+
+	fromTime, err := tspb.TimestampProto(r.from)
+	if err != nil {
+		return nil, err
+	}
+	pr.FromTime = fromTime
+
+	toTime, err := tspb.TimestampProto(r.to)
+	if err != nil {
+		return nil, err
+	}
+	pr.ToTime = toTime
+
+	return pr, nil
+}
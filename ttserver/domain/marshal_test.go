@@ -296,6 +296,8 @@ func TestMarshalStrategy(t *testing.T) {
 				CoinPercent: 12.34,
 				IsRunning:   true,
 				Description: "Base strategy for building other strategies upon",
+				Type:        "base",
+				Params:      map[string]float64{},
 			},
 		},
 	}
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/telecoda/teletrada/exchanges"
+)
+
+/*
+
+The order engine executes simulated trades against a portfolio.
+
+When a strategy triggers the engine moves CoinPercent() of the balance between
+the strategy's Symbol() and As() at the price supplied:-
+
+	sell - CoinPercent() of the free Symbol() balance is sold into As()
+	buy  - CoinPercent() of the free As() balance is spent buying Symbol()
+
+Each fill has the engine's cost model applied, slippage moves the fill price
+against the trade and fees are deducted from the coins received (or paid in BNB).
+
+*/
+
+type orderEngine struct {
+	costs CostModel
+}
+
+func newOrderEngine(costs CostModel) *orderEngine {
+	if costs == nil {
+		costs = noCosts{}
+	}
+	return &orderEngine{
+		costs: costs,
+	}
+}
+
+// executeSell - sells a percentage of the strategy symbol into the as symbol
+func (o *orderEngine) executeSell(p *portfolio, strategy Strategy, price Price) (trade, error) {
+	if err := o.validate(strategy, price); err != nil {
+		return trade{}, err
+	}
+
+	from, ok := p.balances[strategy.Symbol()]
+	if !ok {
+		return trade{}, fmt.Errorf("Cannot sell %s, not in portfolio %q", strategy.Symbol(), p.name)
+	}
+
+	quantity := from.Free * strategy.CoinPercent() / 100.0
+	if quantity <= 0 {
+		return trade{}, fmt.Errorf("Cannot sell %s, no free balance in portfolio %q", strategy.Symbol(), p.name)
+	}
+
+	to, err := p.balanceFor(strategy.As(), from.Exchange, price)
+	if err != nil {
+		return trade{}, err
+	}
+
+	fillPrice := o.costs.FillPrice(SELL_TRADE, price.Price, quantity)
+	proceeds := quantity * fillPrice
+
+	from.adjustFree(-quantity)
+
+	// fees are charged in the coins received
+	fee, feeSymbol := o.payFee(p, proceeds, strategy.As(), price)
+	if feeSymbol == strategy.As() {
+		proceeds -= fee
+	}
+
+	to.adjustFree(proceeds)
+
+	return trade{
+		side:       SELL_TRADE,
+		symbol:     strategy.Symbol(),
+		as:         strategy.As(),
+		quantity:   quantity,
+		exchange:   from.Exchange,
+		price:      fillPrice,
+		fee:        fee,
+		feeSymbol:  feeSymbol,
+		totalCost:  proceeds,
+		date:       price.At,
+		strategyID: strategy.ID(),
+	}, nil
+}
+
+// executeBuy - spends a percentage of the as symbol buying the strategy symbol
+func (o *orderEngine) executeBuy(p *portfolio, strategy Strategy, price Price) (trade, error) {
+	if err := o.validate(strategy, price); err != nil {
+		return trade{}, err
+	}
+
+	from, ok := p.balances[strategy.As()]
+	if !ok {
+		return trade{}, fmt.Errorf("Cannot buy %s with %s, not in portfolio %q", strategy.Symbol(), strategy.As(), p.name)
+	}
+
+	spend := from.Free * strategy.CoinPercent() / 100.0
+	if spend <= 0 {
+		return trade{}, fmt.Errorf("Cannot buy %s, no free %s balance in portfolio %q", strategy.Symbol(), strategy.As(), p.name)
+	}
+
+	to, err := p.balanceFor(strategy.Symbol(), from.Exchange, price)
+	if err != nil {
+		return trade{}, err
+	}
+
+	fillPrice := o.costs.FillPrice(BUY_TRADE, price.Price, spend/price.Price)
+	quantity := spend / fillPrice
+
+	from.adjustFree(-spend)
+
+	// fees are charged in the coins received
+	fee, feeSymbol := o.payFee(p, spend, strategy.As(), price)
+	if feeSymbol == strategy.As() {
+		// convert fee into the symbol bought
+		fee = fee / fillPrice
+		feeSymbol = strategy.Symbol()
+		quantity -= fee
+	}
+
+	to.adjustFree(quantity)
+
+	return trade{
+		side:       BUY_TRADE,
+		symbol:     strategy.Symbol(),
+		as:         strategy.As(),
+		quantity:   quantity,
+		exchange:   from.Exchange,
+		price:      fillPrice,
+		fee:        fee,
+		feeSymbol:  feeSymbol,
+		totalCost:  spend,
+		date:       price.At,
+		strategyID: strategy.ID(),
+	}, nil
+}
+
+// payFee - calculates the fee on a fill valued in the as symbol
+// if fees are paid in BNB and there is enough BNB in the portfolio the discounted fee is
+// deducted from the BNB balance, otherwise the fee is returned in the as symbol for the caller to deduct
+func (o *orderEngine) payFee(p *portfolio, value float64, as SymbolType, price Price) (float64, SymbolType) {
+	fee := value * o.costs.FeePercent() / 100.0
+	if fee == 0 {
+		return 0, as
+	}
+
+	if inBNB, discount := o.costs.PayFeesInBNB(); inBNB {
+		if bnb, ok := p.balances[BNB]; ok {
+			bnbAs, err := DefaultArchive.GetPriceAs(BNB, as, price.At)
+			if err == nil && bnbAs.Price > 0 {
+				bnbFee := fee * (1 - discount/100.0) / bnbAs.Price
+				if bnb.Free >= bnbFee {
+					bnb.adjustFree(-bnbFee)
+					return bnbFee, BNB
+				}
+			}
+		}
+	}
+
+	return fee, as
+}
+
+func (o *orderEngine) validate(strategy Strategy, price Price) error {
+	if strategy == nil {
+		return fmt.Errorf("Cannot execute trade, strategy cannot be nil")
+	}
+	if price.Base != strategy.Symbol() || price.As != strategy.As() {
+		return fmt.Errorf("Cannot execute trade for %s/%s with a %s/%s price", strategy.Symbol(), strategy.As(), price.Base, price.As)
+	}
+	if price.Price <= 0 {
+		return fmt.Errorf("Cannot execute trade for %s/%s, price must be greater than 0", strategy.Symbol(), strategy.As())
+	}
+	return nil
+}
+
+// balanceFor - returns the balance for a symbol, adding an empty one if it is not already held
+func (p *portfolio) balanceFor(symbol SymbolType, exchange string, price Price) (*BalanceAs, error) {
+	if balance, ok := p.balances[symbol]; ok {
+		return balance, nil
+	}
+
+	balance := &BalanceAs{
+		CoinBalance: exchanges.CoinBalance{
+			Symbol:   string(symbol),
+			Exchange: exchange,
+		},
+		As: DEFAULT_SYMBOL,
+		At: price.At,
+	}
+
+	// a balance that can't be priced would break repricing the portfolio
+	if err := balance.repriceAt(price.At); err != nil {
+		return nil, fmt.Errorf("Cannot add %s to portfolio %q - %s", symbol, p.name, err)
+	}
+
+	p.balances[symbol] = balance
+
+	return balance, nil
+}
+
+// adjustFree - adds (or removes) free coins from a balance
+func (b *BalanceAs) adjustFree(quantity float64) {
+	b.Lock()
+	defer b.Unlock()
+	b.Free += quantity
+	b.Total = b.Free + b.Locked
+	b.Value = b.Price * b.Total
+}
+
+func (h *tradeHistory) add(t trade) {
+	h.trades = append(h.trades, t)
+}
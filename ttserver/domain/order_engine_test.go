@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func orderEngineSetup(t *testing.T) *portfolio {
+	servertime.UseFakeTime()
+
+	_, err := initMockServer()
+	assert.NoError(t, err)
+
+	now := servertime.Now()
+
+	p := &portfolio{
+		name:     "engine-test",
+		balances: make(map[SymbolType]*BalanceAs),
+	}
+
+	p.balances[BTC] = &BalanceAs{
+		CoinBalance: exchanges.CoinBalance{Symbol: BTC, Exchange: exchanges.MOCK_EXCHANGE, Free: 1.0},
+		Total:       1.0,
+		As:          BTC,
+		At:          now,
+	}
+	p.balances[ETH] = &BalanceAs{
+		CoinBalance: exchanges.CoinBalance{Symbol: ETH, Exchange: exchanges.MOCK_EXCHANGE, Free: 10.0, Locked: 5.0},
+		Total:       15.0,
+		As:          BTC,
+		At:          now,
+	}
+
+	return p
+}
+
+func TestOrderEngineSell(t *testing.T) {
+	defer servertime.UseRealTime()
+
+	p := orderEngineSetup(t)
+	engine := newOrderEngine(nil)
+	now := servertime.Now()
+
+	strat, err := NewDoNothingStrategy("sell-eth", ETH, BTC, 50.0)
+	assert.NoError(t, err)
+
+	price := Price{Base: ETH, As: BTC, Price: 0.1, At: now}
+
+	tr, err := engine.executeSell(p, strat, price)
+	assert.NoError(t, err)
+
+	assert.Equal(t, SELL_TRADE, tr.side)
+	assert.Equal(t, SymbolType(ETH), tr.symbol)
+	assert.Equal(t, SymbolType(BTC), tr.as)
+	assert.Equal(t, 5.0, tr.quantity)
+	assert.Equal(t, 0.1, tr.price)
+	assert.Equal(t, 0.5, tr.totalCost)
+	assert.Equal(t, "sell-eth", tr.strategyID)
+	assert.Equal(t, now, tr.date)
+
+	// locked coins are not traded
+	assert.Equal(t, 5.0, p.balances[ETH].Free)
+	assert.Equal(t, 5.0, p.balances[ETH].Locked)
+	assert.Equal(t, 10.0, p.balances[ETH].Total)
+	assert.Equal(t, 1.5, p.balances[BTC].Free)
+	assert.Equal(t, 1.5, p.balances[BTC].Total)
+}
+
+func TestOrderEngineBuy(t *testing.T) {
+	defer servertime.UseRealTime()
+
+	p := orderEngineSetup(t)
+	engine := newOrderEngine(nil)
+	now := servertime.Now()
+
+	// LTC is not in the portfolio so will be added
+	strat, err := NewDoNothingStrategy("buy-ltc", LTC, BTC, 100.0)
+	assert.NoError(t, err)
+
+	price := Price{Base: LTC, As: BTC, Price: 0.02, At: now}
+
+	tr, err := engine.executeBuy(p, strat, price)
+	assert.NoError(t, err)
+
+	assert.Equal(t, BUY_TRADE, tr.side)
+	assert.Equal(t, 50.0, tr.quantity)
+	assert.Equal(t, 1.0, tr.totalCost)
+
+	assert.Equal(t, 0.0, p.balances[BTC].Free)
+	if assert.Contains(t, p.balances, SymbolType(LTC)) {
+		assert.Equal(t, 50.0, p.balances[LTC].Free)
+		assert.Equal(t, 50.0, p.balances[LTC].Total)
+		assert.Equal(t, exchanges.MOCK_EXCHANGE, p.balances[LTC].Exchange)
+	}
+
+	// nothing left to spend
+	_, err = engine.executeBuy(p, strat, price)
+	assert.Error(t, err)
+}
+
+func TestOrderEngineInvalidTrades(t *testing.T) {
+	defer servertime.UseRealTime()
+
+	p := orderEngineSetup(t)
+	engine := newOrderEngine(nil)
+	now := servertime.Now()
+
+	sellEth, err := NewDoNothingStrategy("sell-eth", ETH, BTC, 50.0)
+	assert.NoError(t, err)
+
+	sellLtc, err := NewDoNothingStrategy("sell-ltc", LTC, BTC, 50.0)
+	assert.NoError(t, err)
+
+	sellUnknown, err := NewDoNothingStrategy("sell-eth-unknown", ETH, SymbolType("unknown"), 50.0)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		strategy Strategy
+		price    Price
+		errText  string
+	}{
+		{
+			name:     "Price for wrong pair",
+			strategy: sellEth,
+			price:    Price{Base: LTC, As: BTC, Price: 0.1, At: now},
+			errText:  "Cannot execute trade for ETH/BTC with a LTC/BTC price",
+		},
+		{
+			name:     "Zero price",
+			strategy: sellEth,
+			price:    Price{Base: ETH, As: BTC, Price: 0, At: now},
+			errText:  "Cannot execute trade for ETH/BTC, price must be greater than 0",
+		},
+		{
+			name:     "Symbol not in portfolio",
+			strategy: sellLtc,
+			price:    Price{Base: LTC, As: BTC, Price: 0.1, At: now},
+			errText:  `Cannot sell LTC, not in portfolio "engine-test"`,
+		},
+		{
+			name:     "As symbol cannot be priced",
+			strategy: sellUnknown,
+			price:    Price{Base: ETH, As: SymbolType("unknown"), Price: 0.1, At: now},
+			errText:  `Cannot add unknown to portfolio "engine-test"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := engine.executeSell(p, test.strategy, test.price)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.errText)
+			}
+		})
+	}
+
+	// failed trades must not change balances
+	assert.Equal(t, 10.0, p.balances[ETH].Free)
+	assert.Equal(t, 1.0, p.balances[BTC].Free)
+	assert.NotContains(t, p.balances, SymbolType("unknown"))
+}
+
+func TestOrderEngineFees(t *testing.T) {
+	defer servertime.UseRealTime()
+
+	p := orderEngineSetup(t)
+	now := servertime.Now()
+
+	costs, err := NewCostModel(CostConfig{TakerFeePercent: 1.0, SlippageBps: 100})
+	assert.NoError(t, err)
+	engine := newOrderEngine(costs)
+
+	sellEth, err := NewDoNothingStrategy("sell-eth", ETH, BTC, 100.0)
+	assert.NoError(t, err)
+
+	// sell 10 ETH @ 0.1 with 1% slippage = 0.099
+	// proceeds 0.99 BTC less 1% fee
+	tr, err := engine.executeSell(p, sellEth, Price{Base: ETH, As: BTC, Price: 0.1, At: now})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.099, tr.price, 0.0000001)
+	assert.InDelta(t, 0.0099, tr.fee, 0.0000001)
+	assert.Equal(t, SymbolType(BTC), tr.feeSymbol)
+	assert.InDelta(t, 0.9801, tr.totalCost, 0.0000001)
+	assert.InDelta(t, 1.9801, p.balances[BTC].Free, 0.0000001)
+
+	buyEth, err := NewDoNothingStrategy("buy-eth", ETH, BTC, 50.0)
+	assert.NoError(t, err)
+
+	// spend 0.99005 BTC @ 0.1 with 1% slippage = 0.101
+	// fee is charged in ETH
+	tr, err = engine.executeBuy(p, buyEth, Price{Base: ETH, As: BTC, Price: 0.1, At: now})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.101, tr.price, 0.0000001)
+	assert.Equal(t, SymbolType(ETH), tr.feeSymbol)
+	assert.InDelta(t, 0.99005, tr.totalCost, 0.0000001)
+	assert.InDelta(t, 0.0099005/0.101, tr.fee, 0.0000001)
+	assert.InDelta(t, 0.99005/0.101*0.99, tr.quantity, 0.0000001)
+	assert.InDelta(t, tr.quantity, p.balances[ETH].Free, 0.0000001)
+}
+
+func TestOrderEngineBNBFees(t *testing.T) {
+	defer servertime.UseRealTime()
+
+	p := orderEngineSetup(t)
+	now := servertime.Now()
+
+	// BNB is worth 0.001 BTC
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: BNB, As: BTC, Price: 0.001, At: now, Exchange: "test-exchange"}))
+
+	costs, err := NewCostModel(CostConfig{TakerFeePercent: 1.0, PayFeesInBNB: true, BNBDiscountPercent: 50})
+	assert.NoError(t, err)
+	engine := newOrderEngine(costs)
+
+	sellEth, err := NewDoNothingStrategy("sell-eth", ETH, BTC, 50.0)
+	assert.NoError(t, err)
+
+	// no BNB held so fee is paid in BTC
+	tr, err := engine.executeSell(p, sellEth, Price{Base: ETH, As: BTC, Price: 0.1, At: now})
+	assert.NoError(t, err)
+	assert.Equal(t, SymbolType(BTC), tr.feeSymbol)
+	assert.InDelta(t, 0.005, tr.fee, 0.0000001)
+
+	p.balances[BNB] = &BalanceAs{
+		CoinBalance: exchanges.CoinBalance{Symbol: BNB, Exchange: exchanges.MOCK_EXCHANGE, Free: 10.0},
+		Total:       10.0,
+		As:          BTC,
+		At:          now,
+	}
+
+	// sell 2.5 ETH for 0.25 BTC, fee of 0.0025 BTC halved and paid as 1.25 BNB
+	tr, err = engine.executeSell(p, sellEth, Price{Base: ETH, As: BTC, Price: 0.1, At: now})
+	assert.NoError(t, err)
+	assert.Equal(t, SymbolType(BNB), tr.feeSymbol)
+	assert.InDelta(t, 1.25, tr.fee, 0.0000001)
+	assert.InDelta(t, 0.25, tr.totalCost, 0.0000001)
+	assert.InDelta(t, 8.75, p.balances[BNB].Free, 0.0000001)
+}
@@ -0,0 +1,670 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+/*
+
+The order manager is the only way strategies on the live portfolio trade real funds.
+
+Every order is tracked through these states:-
+
+	pending          - created but not yet acknowledged by the exchange
+	open             - resting on the exchange
+	partiallyFilled  - some of the quantity has been filled
+	filled           - all of the quantity has been filled
+	cancelled        - cancelled, expired or never sent because of dry run
+	rejected         - the exchange refused the order
+
+When dry run is enabled orders are logged and recorded but never sent.
+
+Every order is checked against the risk limits before it is sent, orders that
+breach a limit are recorded as rejected.
+
+Orders are saved under <data dir>/orders whenever their state changes. When the
+server restarts saved orders are reconciled with the exchange's open orders and
+any open orders the manager didn't know about are tracked too. The state of the
+kill switch is saved alongside them.
+
+*/
+
+const (
+	ORDERS_DIR = "orders"
+	// KILL_SWITCH_ID - name of the kill switch record in the orders dir
+	KILL_SWITCH_ID = "kill-switch"
+)
+
+type orderState string
+
+const (
+	ORDER_PENDING          orderState = "pending"
+	ORDER_OPEN             orderState = "open"
+	ORDER_PARTIALLY_FILLED orderState = "partiallyFilled"
+	ORDER_FILLED           orderState = "filled"
+	ORDER_CANCELLED        orderState = "cancelled"
+	ORDER_REJECTED         orderState = "rejected"
+)
+
+// orderTransitions - the states an order can move to from each state
+var orderTransitions = map[orderState][]orderState{
+	ORDER_PENDING:          []orderState{ORDER_OPEN, ORDER_PARTIALLY_FILLED, ORDER_FILLED, ORDER_CANCELLED, ORDER_REJECTED},
+	ORDER_OPEN:             []orderState{ORDER_PARTIALLY_FILLED, ORDER_FILLED, ORDER_CANCELLED},
+	ORDER_PARTIALLY_FILLED: []orderState{ORDER_PARTIALLY_FILLED, ORDER_FILLED, ORDER_CANCELLED},
+	ORDER_FILLED:           []orderState{},
+	ORDER_CANCELLED:        []orderState{},
+	ORDER_REJECTED:         []orderState{},
+}
+
+// isFinal - returns true when an order can't change state again
+func (o orderState) isFinal() bool {
+	return len(orderTransitions[o]) == 0
+}
+
+func (o orderState) canMoveTo(to orderState) bool {
+	for _, state := range orderTransitions[o] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// stateFromExchange - converts an exchange order status to an order state
+func stateFromExchange(status exchanges.OrderStatus) (orderState, error) {
+	switch status {
+	case exchanges.ORDER_NEW:
+		return ORDER_OPEN, nil
+	case exchanges.ORDER_PARTIALLY_FILLED:
+		return ORDER_PARTIALLY_FILLED, nil
+	case exchanges.ORDER_FILLED:
+		return ORDER_FILLED, nil
+	case exchanges.ORDER_CANCELED, exchanges.ORDER_EXPIRED:
+		return ORDER_CANCELLED, nil
+	case exchanges.ORDER_REJECTED:
+		return ORDER_REJECTED, nil
+	default:
+		return "", fmt.Errorf("Order status %q is not recognised", status)
+	}
+}
+
+// liveOrder - an order raised on the exchange for the live portfolio
+type liveOrder struct {
+	ID               string
+	ExchangeID       string
+	Exchange         string
+	StrategyID       string
+	Side             tradeSide
+	Symbol           SymbolType
+	As               SymbolType
+	Quantity         float64
+	ExecutedQuantity float64
+	QuoteQuantity    float64
+	State            orderState
+	DryRun           bool
+	Reason           string
+	CreatedTime      time.Time
+	UpdatedTime      time.Time
+}
+
+// killSwitchState - the saved state of the kill switch
+type killSwitchState struct {
+	Engaged     bool
+	Reason      string
+	UpdatedTime time.Time
+}
+
+type orderStore interface {
+	saveOrder(order *liveOrder) error
+	loadOrders() ([]*liveOrder, error)
+	saveKillSwitch(state killSwitchState) error
+	loadKillSwitch() (killSwitchState, error)
+}
+
+// newOrderStore - returns a file store when a data dir is configured
+func newOrderStore(dataDir string) (orderStore, error) {
+	if dataDir == "" {
+		return noOrderStore{}, nil
+	}
+
+	dir := filepath.Join(dataDir, ORDERS_DIR)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("Failed to create order store dir: %s - %s", dir, err)
+	}
+
+	return &fileOrderStore{
+		dir: dir,
+	}, nil
+}
+
+type fileOrderStore struct {
+	dir string
+}
+
+func (f *fileOrderStore) saveOrder(order *liveOrder) error {
+	return writeRecordFile(f.dir, order.ID, order)
+}
+
+func (f *fileOrderStore) loadOrders() ([]*liveOrder, error) {
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("Can't load orders from dir: %s - %s", f.dir, err)
+	}
+
+	orders := make([]*liveOrder, 0, len(files))
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") || file.Name() == KILL_SWITCH_ID+".json" {
+			continue
+		}
+
+		filePath := filepath.Join(f.dir, file.Name())
+		orderJSON, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		order := &liveOrder{}
+		if err := json.Unmarshal(orderJSON, order); err != nil {
+			DefaultLogger.log(fmt.Sprintf("Failed to load order from file: %s - %s", filePath, err))
+			continue
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+func (f *fileOrderStore) saveKillSwitch(state killSwitchState) error {
+	return writeRecordFile(f.dir, KILL_SWITCH_ID, state)
+}
+
+// loadKillSwitch - returns the saved kill switch, it is released if it has never been saved
+func (f *fileOrderStore) loadKillSwitch() (killSwitchState, error) {
+	state := killSwitchState{}
+
+	filePath := filepath.Join(f.dir, KILL_SWITCH_ID+".json")
+	stateJSON, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	if err := json.Unmarshal(stateJSON, &state); err != nil {
+		return state, fmt.Errorf("Failed to load kill switch from file: %s - %s", filePath, err)
+	}
+
+	return state, nil
+}
+
+// noOrderStore - orders are only held in memory
+type noOrderStore struct{}
+
+func (n noOrderStore) saveOrder(order *liveOrder) error {
+	return nil
+}
+
+func (n noOrderStore) loadOrders() ([]*liveOrder, error) {
+	return []*liveOrder{}, nil
+}
+
+func (n noOrderStore) saveKillSwitch(state killSwitchState) error {
+	return nil
+}
+
+func (n noOrderStore) loadKillSwitch() (killSwitchState, error) {
+	return killSwitchState{}, nil
+}
+
+type orderManager struct {
+	sync.RWMutex
+	dryRun bool
+	store  orderStore
+	risk   *riskManager
+	orders map[string]*liveOrder
+}
+
+func newOrderManager(dryRun bool, store orderStore, risk *riskManager) *orderManager {
+	return &orderManager{
+		dryRun: dryRun,
+		store:  store,
+		risk:   risk,
+		orders: make(map[string]*liveOrder),
+	}
+}
+
+// save - saves the order, failures are logged as the order has already been placed
+func (m *orderManager) save(order *liveOrder) {
+	if err := m.store.saveOrder(order); err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: saving order %s - %s", order.ID, err))
+	}
+}
+
+// transition - moves an order to a new state
+func (m *orderManager) transition(order *liveOrder, to orderState, reason string) error {
+	if order.State != to && !order.State.canMoveTo(to) {
+		return fmt.Errorf("Order %s cannot move from %s to %s", order.ID, order.State, to)
+	}
+
+	if order.State != to {
+		DefaultLogger.log(fmt.Sprintf("Order %s %s %f %s as %s: %s -> %s %s", order.ID, order.Side, order.Quantity, order.Symbol, order.As, order.State, to, reason))
+	}
+
+	order.State = to
+	order.Reason = reason
+	order.UpdatedTime = servertime.Now()
+	m.save(order)
+
+	return nil
+}
+
+// apply - updates an order from the exchange's view of it
+func (m *orderManager) apply(order *liveOrder, exOrder exchanges.Order) error {
+	state, err := stateFromExchange(exOrder.Status)
+	if err != nil {
+		return err
+	}
+
+	// only new fills count towards realised profit and loss
+	if filled := exOrder.ExecutedQuantity - order.ExecutedQuantity; filled > 0 {
+		quote := exOrder.QuoteQuantity - order.QuoteQuantity
+		if err := m.risk.recordFill(order.Side, order.Symbol, order.As, filled, quote, servertime.Now()); err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: recording fill for order %s - %s", order.ID, err))
+		}
+	}
+
+	order.ExchangeID = exOrder.ID
+	order.Exchange = exOrder.Exchange
+	order.ExecutedQuantity = exOrder.ExecutedQuantity
+	order.QuoteQuantity = exOrder.QuoteQuantity
+
+	return m.transition(order, state, "")
+}
+
+// placeMarketOrder - raises a market order on the exchange unless running dry.
+// portfolioValue is the live portfolio's value in DEFAULT_SYMBOL used to check risk limits
+func (m *orderManager) placeMarketOrder(strategyID string, side tradeSide, symbol, as SymbolType, quantity, portfolioValue float64) (liveOrder, error) {
+	if quantity <= 0 {
+		return liveOrder{}, fmt.Errorf("Cannot %s %s, quantity must be greater than 0", side, symbol)
+	}
+
+	pair, err := findTradingPair(symbol, as)
+	if err != nil {
+		return liveOrder{}, fmt.Errorf("Cannot %s %s as %s - %s", side, symbol, as, err)
+	}
+
+	prepared, err := m.prepareOrder(strategyID, side, pair, quantity, portfolioValue)
+	if err != nil || prepared.State.isFinal() {
+		return prepared, err
+	}
+
+	// the lock isn't held while waiting for the exchange, the order isn't
+	// refreshed or cancelled until it has an exchange id
+	exOrder, err := DefaultClient.PlaceMarketOrder(exchanges.OrderSide(side), string(symbol), string(as), prepared.Quantity)
+
+	m.Lock()
+	defer m.Unlock()
+
+	order := m.orders[prepared.ID]
+
+	if err != nil {
+		if terr := m.transition(order, ORDER_REJECTED, err.Error()); terr != nil {
+			return liveOrder{}, terr
+		}
+		return *order, fmt.Errorf("Order %s rejected - %s", order.ID, err)
+	}
+
+	if err := m.apply(order, exOrder); err != nil {
+		return liveOrder{}, err
+	}
+
+	return *order, nil
+}
+
+// findTradingPair - returns the exchange's trading pair of a symbol as another
+func findTradingPair(symbol, as SymbolType) (exchanges.TradingPair, error) {
+	pairs, err := DefaultClient.GetTradingPairs()
+	if err != nil {
+		return exchanges.TradingPair{}, err
+	}
+
+	for _, pair := range pairs {
+		if pair.Base == string(symbol) && pair.As == string(as) {
+			return pair, nil
+		}
+	}
+
+	return exchanges.TradingPair{}, fmt.Errorf("No trading pair for %s as %s", symbol, as)
+}
+
+// prepareOrder - records a new order with its quantity rounded to the pair's lot size and
+// checks it against the risk limits. The order returned is final when it must not be sent
+// to the exchange
+func (m *orderManager) prepareOrder(strategyID string, side tradeSide, pair exchanges.TradingPair, quantity, portfolioValue float64) (liveOrder, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	symbol, as := SymbolType(pair.Base), SymbolType(pair.As)
+	rounded, roundErr := pair.RoundQuantity(quantity)
+	if roundErr == nil {
+		quantity = rounded
+	}
+
+	now := servertime.Now()
+	order := &liveOrder{
+		ID:          randSeq(10),
+		StrategyID:  strategyID,
+		Side:        side,
+		Symbol:      symbol,
+		As:          as,
+		Quantity:    quantity,
+		State:       ORDER_PENDING,
+		DryRun:      m.dryRun,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+	m.orders[order.ID] = order
+	m.save(order)
+
+	// the exchange rejects quantities below its lot size
+	if roundErr != nil {
+		if terr := m.transition(order, ORDER_REJECTED, roundErr.Error()); terr != nil {
+			return liveOrder{}, terr
+		}
+		return *order, roundErr
+	}
+
+	if err := m.risk.checkOrder(side, symbol, quantity, portfolioValue, now); err != nil {
+		if terr := m.transition(order, ORDER_REJECTED, err.Error()); terr != nil {
+			return liveOrder{}, terr
+		}
+		return *order, err
+	}
+
+	if m.dryRun {
+		DefaultLogger.log(fmt.Sprintf("DRY RUN: would %s %f %s as %s for strategy %s", side, quantity, symbol, as, strategyID))
+		if err := m.transition(order, ORDER_CANCELLED, "dry run - order not sent"); err != nil {
+			return liveOrder{}, err
+		}
+	}
+
+	return *order, nil
+}
+
+// refreshOrders - fetches the latest state of orders still open on the exchange
+func (m *orderManager) refreshOrders() error {
+	for _, order := range m.openOrders() {
+		exOrder, err := DefaultClient.GetOrder(string(order.Symbol), string(order.As), order.ExchangeID)
+		if err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: refreshing order %s - %s", order.ID, err))
+			continue
+		}
+
+		if err := m.refreshOrder(order.ID, exOrder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openOrders - returns copies of the orders still open on the exchange
+func (m *orderManager) openOrders() []liveOrder {
+	m.RLock()
+	defer m.RUnlock()
+
+	open := make([]liveOrder, 0)
+	for _, order := range m.orders {
+		if order.State.isFinal() || order.ExchangeID == "" {
+			continue
+		}
+		open = append(open, *order)
+	}
+	return open
+}
+
+// refreshOrder - updates an order from the exchange unless it has moved on since it was fetched
+func (m *orderManager) refreshOrder(id string, exOrder exchanges.Order) error {
+	m.Lock()
+	defer m.Unlock()
+
+	order, ok := m.orders[id]
+	if !ok || order.State.isFinal() || exOrder.ExecutedQuantity < order.ExecutedQuantity {
+		return nil
+	}
+
+	return m.apply(order, exOrder)
+}
+
+// cancelOpenOrders - cancels all orders still open on the exchange
+func (m *orderManager) cancelOpenOrders(reason string) error {
+	var cancelErr error
+	for _, order := range m.openOrders() {
+		exOrder, err := DefaultClient.CancelOrder(string(order.Symbol), string(order.As), order.ExchangeID)
+		if err == nil {
+			err = m.cancelledOrder(order.ID, exOrder, reason)
+		}
+		if err != nil {
+			// carry on cancelling the rest
+			cancelErr = fmt.Errorf("Failed to cancel order %s - %s", order.ID, err)
+			DefaultLogger.log(fmt.Sprintf("ERROR: %s", cancelErr))
+		}
+	}
+
+	return cancelErr
+}
+
+// cancelledOrder - updates an order cancelled on the exchange unless it has already finished
+func (m *orderManager) cancelledOrder(id string, exOrder exchanges.Order, reason string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	order, ok := m.orders[id]
+	if !ok || order.State.isFinal() {
+		return nil
+	}
+
+	if err := m.apply(order, exOrder); err != nil {
+		return err
+	}
+	order.Reason = reason
+	m.save(order)
+
+	return nil
+}
+
+// reconcile - loads saved orders and brings them up to date with the exchange
+func (m *orderManager) reconcile() error {
+	m.Lock()
+	defer m.Unlock()
+
+	saved, err := m.store.loadOrders()
+	if err != nil {
+		return err
+	}
+
+	for _, order := range saved {
+		m.orders[order.ID] = order
+	}
+
+	openOrders, err := DefaultClient.GetOpenOrders("", "")
+	if err != nil {
+		return fmt.Errorf("Failed to get open orders from exchange - %s", err)
+	}
+
+	unknown := make(map[string]exchanges.Order, len(openOrders))
+	for _, exOrder := range openOrders {
+		unknown[exOrder.ID] = exOrder
+	}
+
+	for _, order := range m.orders {
+		if order.State.isFinal() {
+			continue
+		}
+
+		if order.ExchangeID == "" {
+			// the server stopped before the exchange acknowledged the order, if it
+			// was placed it will be found in the exchange's open orders below
+			if err := m.transition(order, ORDER_REJECTED, "not acknowledged by exchange before restart"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		exOrder, ok := unknown[order.ExchangeID]
+		if ok {
+			delete(unknown, order.ExchangeID)
+		} else {
+			// no longer open so find out how it finished
+			if exOrder, err = DefaultClient.GetOrder(string(order.Symbol), string(order.As), order.ExchangeID); err != nil {
+				DefaultLogger.log(fmt.Sprintf("ERROR: reconciling order %s - %s", order.ID, err))
+				continue
+			}
+		}
+
+		if err := m.apply(order, exOrder); err != nil {
+			return err
+		}
+	}
+
+	// track open orders raised outside of the manager
+	for _, exOrder := range unknown {
+		now := servertime.Now()
+		order := &liveOrder{
+			ID:          randSeq(10),
+			Side:        tradeSide(exOrder.Side),
+			Symbol:      SymbolType(exOrder.Base),
+			As:          SymbolType(exOrder.As),
+			Quantity:    exOrder.Quantity,
+			State:       ORDER_PENDING,
+			CreatedTime: now,
+			UpdatedTime: now,
+		}
+		m.orders[order.ID] = order
+		DefaultLogger.log(fmt.Sprintf("Tracking open order %s found on exchange", exOrder.ID))
+		if err := m.apply(order, exOrder); err != nil {
+			return err
+		}
+	}
+
+	DefaultLogger.log(fmt.Sprintf("Reconciled %d orders", len(m.orders)))
+
+	return nil
+}
+
+// getOrders - returns copies of all orders, oldest first
+func (m *orderManager) getOrders() []liveOrder {
+	m.RLock()
+	defer m.RUnlock()
+
+	orders := make([]liveOrder, 0, len(m.orders))
+	for _, order := range m.orders {
+		orders = append(orders, *order)
+	}
+
+	sort.Slice(orders, func(i, j int) bool { return orders[i].CreatedTime.Before(orders[j].CreatedTime) })
+
+	return orders
+}
+
+// liveOrderRequest - an order a live strategy has triggered
+type liveOrderRequest struct {
+	strategy Strategy
+	side     tradeSide
+	quantity float64
+}
+
+// executeLiveStrategies - evaluates the live portfolio's strategies and raises
+// orders for those triggered through the order manager
+func (s *server) executeLiveStrategies(at time.Time) {
+	if s.livePortfolio == nil {
+		return
+	}
+
+	if s.risk.isEngaged() {
+		// also catches orders raised while the kill switch was being engaged
+		s.haltLiveTrading()
+		return
+	}
+
+	requests, err := s.livePortfolio.triggeredOrders(at)
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: evaluating live strategies - %s", err))
+	}
+
+	s.livePortfolio.RLock()
+	portfolioValue := s.livePortfolio.value()
+	s.livePortfolio.RUnlock()
+
+	for _, request := range requests {
+		strategy := request.strategy
+		if _, err := s.orders.placeMarketOrder(strategy.ID(), request.side, strategy.Symbol(), strategy.As(), request.quantity, portfolioValue); err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: executing live %s strategy %s - %s", request.side, strategy.ID(), err))
+		}
+	}
+}
+
+// triggeredOrders - returns the orders for strategies whose conditions are met at a time
+func (p *portfolio) triggeredOrders(at time.Time) ([]liveOrderRequest, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	requests := make([]liveOrderRequest, 0)
+
+	for _, balance := range p.balances {
+		if balance.SellStrategy != nil {
+			sell, err := balance.SellStrategy.ConditionMet(at)
+			if err != nil {
+				return requests, fmt.Errorf("Error executing sell strategy for symbol: %s - %s", balance.Symbol, err)
+			}
+			if sell {
+				requests = append(requests, liveOrderRequest{
+					strategy: balance.SellStrategy,
+					side:     SELL_TRADE,
+					quantity: balance.Free * balance.SellStrategy.CoinPercent() / 100.0,
+				})
+			}
+		}
+		if balance.BuyStrategy != nil {
+			buy, err := balance.BuyStrategy.ConditionMet(at)
+			if err != nil {
+				return requests, fmt.Errorf("Error executing buy strategy for symbol: %s - %s", balance.Symbol, err)
+			}
+			if buy {
+				strategy := balance.BuyStrategy
+				from, ok := p.balances[strategy.As()]
+				if !ok {
+					return requests, fmt.Errorf("Cannot buy %s, no %s in portfolio %q", strategy.Symbol(), strategy.As(), p.name)
+				}
+				price, err := DefaultArchive.GetPriceAs(strategy.Symbol(), strategy.As(), at)
+				if err != nil {
+					return requests, err
+				}
+				if price.Price <= 0 {
+					return requests, fmt.Errorf("Cannot buy %s, price as %s must be greater than 0", strategy.Symbol(), strategy.As())
+				}
+				requests = append(requests, liveOrderRequest{
+					strategy: strategy,
+					side:     BUY_TRADE,
+					quantity: from.Free * strategy.CoinPercent() / 100.0 / price.Price,
+				})
+			}
+		}
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func mockBalance(t *testing.T, symbol SymbolType) exchanges.CoinBalance {
+	balances, err := DefaultClient.GetCoinBalances()
+	assert.NoError(t, err)
+	for _, balance := range balances {
+		if balance.Symbol == string(symbol) {
+			return balance
+		}
+	}
+	return exchanges.CoinBalance{Symbol: string(symbol)}
+}
+
+func TestOrderStateTransitions(t *testing.T) {
+
+	tests := []struct {
+		from    orderState
+		to      orderState
+		allowed bool
+	}{
+		{from: ORDER_PENDING, to: ORDER_OPEN, allowed: true},
+		{from: ORDER_PENDING, to: ORDER_REJECTED, allowed: true},
+		{from: ORDER_OPEN, to: ORDER_PARTIALLY_FILLED, allowed: true},
+		{from: ORDER_OPEN, to: ORDER_FILLED, allowed: true},
+		{from: ORDER_OPEN, to: ORDER_REJECTED, allowed: false},
+		{from: ORDER_OPEN, to: ORDER_PENDING, allowed: false},
+		{from: ORDER_PARTIALLY_FILLED, to: ORDER_PARTIALLY_FILLED, allowed: true},
+		{from: ORDER_PARTIALLY_FILLED, to: ORDER_CANCELLED, allowed: true},
+		{from: ORDER_FILLED, to: ORDER_CANCELLED, allowed: false},
+		{from: ORDER_CANCELLED, to: ORDER_OPEN, allowed: false},
+		{from: ORDER_REJECTED, to: ORDER_OPEN, allowed: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.allowed, test.from.canMoveTo(test.to), "%s -> %s", test.from, test.to)
+	}
+
+	assert.False(t, ORDER_OPEN.isFinal())
+	assert.True(t, ORDER_FILLED.isFinal())
+	assert.True(t, ORDER_CANCELLED.isFinal())
+	assert.True(t, ORDER_REJECTED.isFinal())
+}
+
+func TestPlaceMarketOrder(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	ethBefore := mockBalance(t, ETH)
+
+	// dry run never reaches the exchange
+	server.orders.dryRun = true
+	order, err := server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, 1.0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_CANCELLED, order.State)
+	assert.True(t, order.DryRun)
+	assert.Equal(t, "", order.ExchangeID)
+	assert.Equal(t, ethBefore.Free, mockBalance(t, ETH).Free)
+
+	// real order is filled by the mock exchange
+	server.orders.dryRun = false
+	order, err = server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, 1.0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, ORDER_FILLED, order.State)
+	assert.False(t, order.DryRun)
+	assert.NotEqual(t, "", order.ExchangeID)
+	assert.Equal(t, 1.0, order.ExecutedQuantity)
+	assert.InDelta(t, ethBefore.Free-1.0, mockBalance(t, ETH).Free, 0.0000001)
+
+	// insufficient funds
+	order, err = server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, ethBefore.Free*10, 0)
+	assert.Error(t, err)
+	assert.Equal(t, ORDER_REJECTED, order.State)
+	assert.NotEqual(t, "", order.Reason)
+
+	// invalid quantity
+	_, err = server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, 0, 0)
+	assert.Error(t, err)
+
+	assert.Equal(t, 3, len(server.orders.getOrders()))
+
+	// quantities are rounded down to the pair's lot size
+	order, err = server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, 1.000000009, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, order.Quantity)
+	assert.Equal(t, 1.0, order.ExecutedQuantity)
+
+	order, err = server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, 0.000000009, 0)
+	assert.Error(t, err)
+	assert.Equal(t, ORDER_REJECTED, order.State)
+	assert.Equal(t, "", order.ExchangeID)
+
+	// pairs the exchange doesn't trade aren't ordered
+	_, err = server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, SymbolType("NOPE"), 1.0, 0)
+	assert.Error(t, err)
+}
+
+func TestReconcileOrders(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	dataDir, err := ioutil.TempDir("", "teletrada-orders")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	_, err = initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+
+	store, err := newOrderStore(dataDir)
+	assert.NoError(t, err)
+
+	now := servertime.Now()
+
+	// server stopped before the exchange acknowledged the order
+	unacknowledged := &liveOrder{ID: "unacknowledged", Side: SELL_TRADE, Symbol: ETH, As: BTC, Quantity: 1.0, State: ORDER_PENDING, CreatedTime: now}
+	assert.NoError(t, store.saveOrder(unacknowledged))
+
+	// order filled on the exchange while the server was stopped
+	filled, err := DefaultClient.PlaceMarketOrder(exchanges.SELL_ORDER, string(ETH), string(BTC), 1.0)
+	assert.NoError(t, err)
+	wasOpen := &liveOrder{ID: "was-open", ExchangeID: filled.ID, Side: SELL_TRADE, Symbol: ETH, As: BTC, Quantity: 1.0, State: ORDER_OPEN, CreatedTime: now}
+	assert.NoError(t, store.saveOrder(wasOpen))
+
+	// order raised outside of the server
+	resting, err := DefaultClient.PlaceLimitOrder(exchanges.BUY_ORDER, string(ETH), string(BTC), 1.0, 0.00000001)
+	assert.NoError(t, err)
+	assert.Equal(t, exchanges.ORDER_NEW, resting.Status)
+
+	risk, err := newRiskManager(RiskConfig{}, noOrderStore{})
+	assert.NoError(t, err)
+	manager := newOrderManager(false, store, risk)
+	assert.NoError(t, manager.reconcile())
+
+	orders := make(map[string]liveOrder)
+	for _, order := range manager.getOrders() {
+		orders[order.ID] = order
+	}
+
+	if assert.Equal(t, 3, len(orders)) {
+		assert.Equal(t, ORDER_REJECTED, orders["unacknowledged"].State)
+		assert.Equal(t, ORDER_FILLED, orders["was-open"].State)
+		assert.Equal(t, 1.0, orders["was-open"].ExecutedQuantity)
+	}
+
+	adopted := 0
+	for _, order := range orders {
+		if order.ExchangeID == resting.ID {
+			adopted++
+			assert.Equal(t, ORDER_OPEN, order.State)
+			assert.Equal(t, BUY_TRADE, order.Side)
+		}
+	}
+	assert.Equal(t, 1, adopted)
+
+	// reconciled states are saved
+	saved, err := store.loadOrders()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(saved))
+
+	// an order that can't be fetched doesn't stop the others being refreshed
+	manager.orders["missing"] = &liveOrder{ID: "missing", ExchangeID: "missing", Side: SELL_TRADE, Symbol: ETH, As: BTC, Quantity: 1.0, State: ORDER_OPEN, CreatedTime: now}
+	manager.orders["refreshed"] = &liveOrder{ID: "refreshed", ExchangeID: filled.ID, Side: SELL_TRADE, Symbol: ETH, As: BTC, Quantity: 1.0, State: ORDER_OPEN, CreatedTime: now}
+	assert.NoError(t, manager.refreshOrders())
+	assert.Equal(t, ORDER_OPEN, manager.orders["missing"].State)
+	assert.Equal(t, ORDER_FILLED, manager.orders["refreshed"].State)
+
+	// an order that can't be cancelled doesn't leave the others open
+	assert.Error(t, manager.cancelOpenOrders("testing"))
+	assert.Equal(t, ORDER_OPEN, manager.orders["missing"].State)
+	for _, order := range manager.getOrders() {
+		if order.ExchangeID == resting.ID {
+			assert.Equal(t, ORDER_CANCELLED, order.State)
+			assert.Equal(t, "testing", order.Reason)
+		}
+	}
+}
+
+// newServerWithoutPortfolio - returns a server started without a live portfolio
+func newServerWithoutPortfolio(strategies map[string]Strategy) *server {
+	return &server{strategies: strategies}
+}
+
+func TestExecuteLiveStrategies(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	ctx := context.Background()
+
+	_, err = server.CreateStrategy(ctx, &proto.CreateStrategyRequest{
+		Id:          "sell-eth",
+		Type:        string(PRICE_ABOVE_STRATEGY),
+		Symbol:      string(ETH),
+		As:          string(BTC),
+		CoinPercent: 10.0,
+		Params:      map[string]float64{"abovePrice": 0.00000001},
+	})
+	assert.NoError(t, err)
+
+	_, err = server.AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: LIVE_PORTFOLIO, StrategyId: "sell-eth", Slot: proto.StrategySlot_SELL})
+	assert.NoError(t, err)
+
+	server.orders.dryRun = true
+	server.executeLiveStrategies(servertime.Now())
+
+	orders := server.orders.getOrders()
+	if assert.Equal(t, 1, len(orders)) {
+		assert.Equal(t, "sell-eth", orders[0].StrategyID)
+		assert.Equal(t, SELL_TRADE, orders[0].Side)
+		assert.InDelta(t, mockBalance(t, ETH).Free*0.1, orders[0].Quantity, 0.0000001)
+		assert.Equal(t, ORDER_CANCELLED, orders[0].State)
+	}
+
+	_, err = server.DetachStrategy(ctx, &proto.DetachStrategyRequest{SimulationId: LIVE_PORTFOLIO, Symbol: string(ETH), Slot: proto.StrategySlot_SELL})
+	assert.NoError(t, err)
+
+	server.executeLiveStrategies(servertime.Now())
+	assert.Equal(t, 1, len(server.orders.getOrders()))
+
+	// a server without a live portfolio refuses live strategies
+	notLive := newServerWithoutPortfolio(server.strategies)
+	_, err = notLive.AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: LIVE_PORTFOLIO, StrategyId: "sell-eth", Slot: proto.StrategySlot_SELL})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = notLive.DetachStrategy(ctx, &proto.DetachStrategyRequest{SimulationId: LIVE_PORTFOLIO, Symbol: string(ETH), Slot: proto.StrategySlot_SELL})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
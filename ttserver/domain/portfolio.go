@@ -3,12 +3,16 @@ package domain
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/telecoda/teletrada/exchanges"
 	"github.com/telecoda/teletrada/proto"
 	"github.com/telecoda/teletrada/ttserver/servertime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type portfolio struct {
@@ -16,11 +20,17 @@ type portfolio struct {
 	name     string
 	isLive   bool
 	balances map[SymbolType]*BalanceAs
+	client   exchanges.ExchangeClient // exchange holding the balances, DefaultClient if nil
+	archive  SymbolsArchive           // prices the balances, DefaultArchive if nil
 }
 
 const DEFAULT_SYMBOL = SymbolType("BTC")
 
-// GetPortfolio returns current portfolio
+// LIVE_PORTFOLIO - name of the real portfolio on the exchange
+const LIVE_PORTFOLIO = "LIVE"
+
+// GetPortfolio returns current portfolio, balances of a symbol on different exchanges are combined
+// unless requested by exchange
 func (s *server) GetPortfolio(ctx context.Context, req *proto.GetPortfolioRequest) (*proto.GetPortfolioResponse, error) {
 
 	resp := &proto.GetPortfolioResponse{}
@@ -29,18 +39,39 @@ func (s *server) GetPortfolio(ctx context.Context, req *proto.GetPortfolioReques
 		return nil, fmt.Errorf("failed to update portfolios - %s", err)
 	}
 
-	balances := s.livePortfolio.balances
+	exchangeNames := s.exchangeNames()
+	if req.Exchange != "" {
+		exchangeNames = []string{strings.ToLower(req.Exchange)}
+	}
+
+	bySymbol := make(map[string]*proto.Balance)
 
-	resp.Balances = make([]*proto.Balance, len(balances))
+	for _, exchange := range exchangeNames {
+		p, err := s.exchangePortfolio(exchange)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "%s", err)
+		}
 
-	var err error
-	i := 0
-	for _, balance := range balances {
-		resp.Balances[i], err = balance.toProto()
+		p.RLock()
+		pp, err := p.toProto()
+		p.RUnlock()
 		if err != nil {
 			return nil, err
 		}
-		i++
+		sort.Slice(pp.Balances, func(i, j int) bool { return pp.Balances[i].Symbol < pp.Balances[j].Symbol })
+
+		for _, balance := range pp.Balances {
+			if req.ByExchange {
+				resp.Balances = append(resp.Balances, balance)
+				continue
+			}
+			if combined, ok := bySymbol[balance.Symbol]; ok {
+				combineBalances(combined, balance)
+				continue
+			}
+			bySymbol[balance.Symbol] = balance
+			resp.Balances = append(resp.Balances, balance)
+		}
 	}
 
 	// All balances returned in BTC
@@ -58,12 +89,43 @@ func (s *server) GetPortfolio(ctx context.Context, req *proto.GetPortfolioReques
 	return resp, nil
 }
 
+// combineBalances - adds a balance of the same symbol on another exchange to a combined balance,
+// prices of the combined balance are averaged by quantity
+func combineBalances(combined, balance *proto.Balance) {
+	if combined.Exchange != balance.Exchange {
+		combined.Exchange = ALL_EXCHANGES
+	}
+
+	total := combined.Total + balance.Total
+	if total != 0 {
+		combined.Price = (combined.Value + balance.Value) / total
+		combined.Price24H = (combined.Value24H + balance.Value24H) / total
+		combined.Change24H = (combined.Change24H*combined.Total + balance.Change24H*balance.Total) / total
+		combined.ChangePct24H = (combined.ChangePct24H*combined.Total + balance.ChangePct24H*balance.Total) / total
+	}
+
+	combined.Free += balance.Free
+	combined.Locked += balance.Locked
+	combined.Total = total
+	combined.Value += balance.Value
+	combined.Value24H += balance.Value24H
+	if balance.At.GetSeconds() > combined.At.GetSeconds() {
+		combined.At = balance.At
+	}
+
+	// strategies only trade on the primary exchange
+	if combined.Exchange == ALL_EXCHANGES {
+		combined.BuyStrategy = nil
+		combined.SellStrategy = nil
+	}
+}
+
 // initPortfolios - fetches latest balances from exchange
 func (s *server) initPortfolios() error {
 
 	DefaultLogger.log("Initialising portfolios")
 	s.livePortfolio = &portfolio{
-		name:     "LIVE",
+		name:     LIVE_PORTFOLIO,
 		isLive:   true,
 		balances: make(map[SymbolType]*BalanceAs, 0),
 	}
@@ -89,7 +151,6 @@ func (s *server) initPortfolios() error {
 		return err
 	}
 
-	s.simulations = make(map[string]*simulation, 0)
 	DefaultLogger.log("Initialised portfolios")
 	return nil
 }
@@ -100,13 +161,27 @@ func (s *server) updatePortfolios() error {
 		return err
 	}
 
+	s.updateExchangePortfolios()
+
+	return s.repricePortfolios()
+}
+
+// repricePortfolios - reprices the live portfolio and realtime simulations at the latest prices
+func (s *server) repricePortfolios() error {
+	if s.livePortfolio == nil {
+		return fmt.Errorf("No live portfolio to reprice")
+	}
+
 	if err := s.livePortfolio.reprice(); err != nil {
 		return err
 	}
 
 	for _, simulation := range s.simulations {
-		if simulation.useRealtimeData {
-			if err := simulation.reprice(); err != nil {
+		if simulation.isRealtime() {
+			simulation.Lock()
+			err := simulation.reprice()
+			simulation.Unlock()
+			if err != nil {
 				return err
 			}
 		}
@@ -115,6 +190,16 @@ func (s *server) updatePortfolios() error {
 	return nil
 }
 
+// updateRealtimeSimulations - notifies running realtime simulations that prices have been updated
+func (s *server) updateRealtimeSimulations(at time.Time) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, simulation := range s.simulations {
+		simulation.priceUpdated(at)
+	}
+}
+
 // updateMetrics - sends metrics about portfolios to Influx
 func (s *server) saveMetrics() error {
 
@@ -124,6 +209,13 @@ func (s *server) saveMetrics() error {
 		return err
 	}
 
+	// portfolios on other exchanges
+	for _, account := range s.accounts {
+		if err := DefaultMetrics.SavePortfolioMetrics(account.portfolio); err != nil {
+			return err
+		}
+	}
+
 	// simulated portfolio metrics
 	for _, simulation := range s.simulations {
 		if err := DefaultMetrics.SavePortfolioMetrics(simulation.portfolio); err != nil {
@@ -146,7 +238,7 @@ func (p *portfolio) refreshCoinBalances() error {
 		return fmt.Errorf("Simulated portfolio: %s cannot have balances refreshed from exchange", p.name)
 	}
 
-	coinBalances, err := DefaultClient.GetCoinBalances()
+	coinBalances, err := p.exchangeClient().GetCoinBalances()
 	if err != nil {
 		return fmt.Errorf("failed to get balances from exchange: %s", err)
 	}
@@ -172,19 +264,37 @@ func (p *portfolio) refreshCoinBalances() error {
 	return nil
 }
 
+// exchangeClient - returns the client of the exchange holding the balances
+func (p *portfolio) exchangeClient() exchanges.ExchangeClient {
+	if p.client != nil {
+		return p.client
+	}
+	return DefaultClient
+}
+
+// priceArchive - returns the archive of prices on the exchange holding the balances
+func (p *portfolio) priceArchive() SymbolsArchive {
+	if p.archive != nil {
+		return p.archive
+	}
+	return DefaultArchive
+}
+
 // reprice - will reprice all balances based upon latest prices
 func (p *portfolio) reprice() error {
+	archive := p.priceArchive()
 	// convert exchange balances to trada balances
 	for _, balance := range p.balances {
 
-		if err := balance.reprice(); err != nil {
+		if err := balance.repriceFrom(archive); err != nil {
 			return fmt.Errorf("failed reprice balance: %#v - %s", balance, err)
 		}
 	}
 	return nil
 }
 
-// repriceAt - will reprice all balances based upon prices at a specific time
+// repriceAt - will reprice all balances based upon prices at a specific time,
+// the caller holds the portfolio's lock
 func (p *portfolio) repriceAt(at time.Time) error {
 	// convert exchange balances to trada balances
 	for _, balance := range p.balances {
@@ -204,21 +314,21 @@ func (b *BalanceAs) repriceAt(at time.Time) error {
 	if err != nil {
 		return fmt.Errorf("failed to get latest price for: %s as %s - %s", b.Symbol, b.As, err)
 	}
-	return b.repriceUsing(priceAs)
+	return b.repriceUsing(DefaultArchive, priceAs)
 }
 
-// reprice will reprice balances based upon latest prices
-func (b *BalanceAs) reprice() error {
+// repriceFrom will reprice balances based upon the latest prices in an archive
+func (b *BalanceAs) repriceFrom(archive SymbolsArchive) error {
 	// find latest price for trading pair
-	priceAs, err := DefaultArchive.GetLatestPriceAs(SymbolType(b.Symbol), b.As)
+	priceAs, err := archive.GetLatestPriceAs(SymbolType(b.Symbol), b.As)
 	if err != nil {
 		return fmt.Errorf("failed to get latest price for: %s as %s - %s", b.Symbol, b.As, err)
 	}
 
-	return b.repriceUsing(priceAs)
+	return b.repriceUsing(archive, priceAs)
 }
 
-func (b *BalanceAs) repriceUsing(priceAs Price) error {
+func (b *BalanceAs) repriceUsing(archive SymbolsArchive, priceAs Price) error {
 
 	if b.Symbol != string(priceAs.Base) {
 		return fmt.Errorf("Cannot reprice symbol: %s with price: %s", b.Symbol, priceAs.Base)
@@ -228,9 +338,10 @@ func (b *BalanceAs) repriceUsing(priceAs Price) error {
 	b.Value = priceAs.Price * b.Total
 	b.At = priceAs.At
 	b.As = priceAs.As
+	b.Interpolated = priceAs.Interpolated
 	// get 24h price
 
-	daySummary, err := DefaultArchive.GetDaySummaryAs(SymbolType(b.Symbol), b.As)
+	daySummary, err := archive.GetDaySummaryAs(SymbolType(b.Symbol), b.As)
 	if err != nil {
 		// no daily price info, but lets carry on
 		//return fmt.Errorf("failed to get day summary for: %s as %s - %s", b.Symbol, b.As, err)
@@ -244,6 +355,15 @@ func (b *BalanceAs) repriceUsing(priceAs Price) error {
 	return nil
 }
 
+// value - total value of all balances
+func (p *portfolio) value() float64 {
+	total := 0.0
+	for _, balance := range p.balances {
+		total += balance.Value
+	}
+	return total
+}
+
 // clone - creates a clone of portfolio for simulations
 func (p *portfolio) clone() (*portfolio, error) {
 
@@ -276,34 +396,82 @@ func (p *portfolio) diff(before *portfolio) (*portfolio, error) {
 	for symbol, balanceNow := range p.balances {
 
 		// fetch before balance
-		if balanceBefore, ok := before.balances[symbol]; ok {
-			// calc diff
-			d.balances[symbol] = &BalanceAs{
-				CoinBalance: exchanges.CoinBalance{
-					Symbol:   balanceNow.Symbol,
-					Exchange: balanceNow.Exchange,
-					Free:     balanceNow.Free - balanceBefore.Free,
-					Locked:   balanceNow.Locked - balanceBefore.Locked,
-				},
-				Total:        balanceNow.Total - balanceBefore.Total,
-				At:           balanceNow.At,
-				As:           balanceNow.As,
-				Price:        balanceNow.Price - balanceBefore.Price,
-				Value:        balanceNow.Value - balanceBefore.Value,
-				Price24H:     balanceNow.Price24H - balanceBefore.Price24H,
-				Value24H:     balanceNow.Value24H - balanceBefore.Value24H,
-				Change24H:    balanceNow.Change24H - balanceBefore.Change24H,
-				ChangePct24H: balanceNow.ChangePct24H - balanceBefore.ChangePct24H,
-			}
+		balanceBefore, ok := before.balances[symbol]
+		if !ok {
+			// balance was acquired since, so compare against nothing
+			balanceBefore = &BalanceAs{}
+		}
 
-		} else {
-			return nil, fmt.Errorf("Before portfolio did not contain a balance for %s", symbol)
+		// calc diff
+		d.balances[symbol] = &BalanceAs{
+			CoinBalance: exchanges.CoinBalance{
+				Symbol:   balanceNow.Symbol,
+				Exchange: balanceNow.Exchange,
+				Free:     balanceNow.Free - balanceBefore.Free,
+				Locked:   balanceNow.Locked - balanceBefore.Locked,
+			},
+			Total:        balanceNow.Total - balanceBefore.Total,
+			At:           balanceNow.At,
+			As:           balanceNow.As,
+			Price:        balanceNow.Price - balanceBefore.Price,
+			Value:        balanceNow.Value - balanceBefore.Value,
+			Price24H:     balanceNow.Price24H - balanceBefore.Price24H,
+			Value24H:     balanceNow.Value24H - balanceBefore.Value24H,
+			Change24H:    balanceNow.Change24H - balanceBefore.Change24H,
+			ChangePct24H: balanceNow.ChangePct24H - balanceBefore.ChangePct24H,
 		}
 	}
 
 	return d, nil
 }
 
+// startStrategies - starts all buy/sell strategies attached to balances
+func (p *portfolio) startStrategies() {
+	for _, balance := range p.balances {
+		if balance.BuyStrategy != nil {
+			balance.BuyStrategy.Start()
+		}
+		if balance.SellStrategy != nil {
+			balance.SellStrategy.Start()
+		}
+	}
+}
+
+// resumeStrategies - starts all buy/sell strategies attached to balances keeping the
+// prices they were tracking when they were saved
+func (p *portfolio) resumeStrategies() {
+	for _, balance := range p.balances {
+		resumeStrategy(balance.BuyStrategy)
+		resumeStrategy(balance.SellStrategy)
+	}
+}
+
+func resumeStrategy(strategy Strategy) {
+	if strategy == nil {
+		return
+	}
+	stateful, ok := strategy.(statefulStrategy)
+	if !ok {
+		strategy.Start()
+		return
+	}
+	state := stateful.state()
+	strategy.Start()
+	stateful.restoreState(state)
+}
+
+// stopStrategies - stops all buy/sell strategies attached to balances
+func (p *portfolio) stopStrategies() {
+	for _, balance := range p.balances {
+		if balance.BuyStrategy != nil {
+			balance.BuyStrategy.Stop()
+		}
+		if balance.SellStrategy != nil {
+			balance.SellStrategy.Stop()
+		}
+	}
+}
+
 func (p *portfolio) print() {
 	fmt.Printf("Portfolio: %s\n", p.name)
 
@@ -0,0 +1,410 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+
+The price store keeps the prices added to the archive on disk so price history
+builds up across restarts instead of being thrown away.
+
+Prices are split into segments by the UTC day of their time, each day has up
+to two files under <data dir>/prices:-
+
+	<yyyymmdd>.log - prices appended as they are added to the archive
+	<yyyymmdd>.seg - compacted prices of the day in time order without duplicates
+
+Each line of a file is one price as JSON. Files are only ever appended to or
+replaced whole, so a crash can at worst leave a partly written last line. Logs
+are truncated back to their last complete line when the store is opened so the
+next price saved doesn't join onto it.
+
+Compaction merges the log of every day before today into its segment and
+deletes days that ended longer ago than the retention. It runs when the server
+starts, before the store is reloaded into the archive, and after each daily
+update, when prices older than the retention are also removed from the archive.
+
+*/
+
+const (
+	PRICES_DIR = "prices"
+	// PRICE_DAY_FORMAT - names of segments sort in day order
+	PRICE_DAY_FORMAT  = "20060102"
+	PRICE_LOG_EXT     = ".log"
+	PRICE_SEGMENT_EXT = ".seg"
+)
+
+type priceStore interface {
+	savePrices(prices []Price) error
+	loadPrices() ([]Price, error)
+	compact(now time.Time) error
+	retainedFrom(now time.Time) time.Time
+}
+
+// newPriceStore - returns a segmented store when a data dir is configured, prices are kept
+// for retention or forever if it is 0
+func newPriceStore(dataDir string, retention time.Duration) (priceStore, error) {
+	if dataDir == "" {
+		return noPriceStore{}, nil
+	}
+
+	if retention < 0 {
+		return nil, fmt.Errorf("Price retention cannot be negative")
+	}
+
+	dir := filepath.Join(dataDir, PRICES_DIR)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("Failed to create price store dir: %s - %s", dir, err)
+	}
+
+	store := &segmentPriceStore{
+		dir:       dir,
+		retention: retention,
+	}
+
+	days, err := store.days()
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		if err := truncateTornLine(filepath.Join(dir, day+PRICE_LOG_EXT)); err != nil {
+			return nil, fmt.Errorf("Failed to repair price log for %s - %s", day, err)
+		}
+	}
+
+	return store, nil
+}
+
+type segmentPriceStore struct {
+	sync.Mutex
+	dir       string
+	retention time.Duration
+}
+
+// savePrices - appends prices to the log of their day
+func (f *segmentPriceStore) savePrices(prices []Price) error {
+	byDay := make(map[string][]byte)
+	for _, price := range prices {
+		priceJSON, err := json.Marshal(price)
+		if err != nil {
+			return err
+		}
+		day := price.At.UTC().Format(PRICE_DAY_FORMAT)
+		byDay[day] = append(append(byDay[day], priceJSON...), '\n')
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	for day, lines := range byDay {
+		if err := appendFile(filepath.Join(f.dir, day+PRICE_LOG_EXT), lines); err != nil {
+			return fmt.Errorf("Failed to save prices for %s - %s", day, err)
+		}
+	}
+
+	return nil
+}
+
+// truncateTornLine - removes a partly written last line from a file, there is nothing to do
+// if it does not exist
+func truncateTornLine(filePath string) error {
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	// search back from the end for the last newline
+	complete := int64(0)
+	buf := make([]byte, 4096)
+	for end := info.Size(); end > 0; {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		n, err := file.ReadAt(buf[:end-start], start)
+		if err != nil && err != io.EOF {
+			file.Close()
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			complete = start + int64(i) + 1
+			break
+		}
+		end = start
+	}
+
+	if complete == info.Size() {
+		return file.Close()
+	}
+
+	DefaultLogger.log(fmt.Sprintf("Truncating partly written stored price %s at %d bytes", filePath, complete))
+	if err := file.Truncate(complete); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func appendFile(filePath string, data []byte) error {
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// loadPrices - returns every stored price, oldest day first
+func (f *segmentPriceStore) loadPrices() ([]Price, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	days, err := f.days()
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]Price, 0)
+	for _, day := range days {
+		dayPrices, err := f.readDay(day)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, dayPrices...)
+	}
+
+	return prices, nil
+}
+
+// compact - merges the logs of days before now into their segments and deletes days
+// older than the retention
+func (f *segmentPriceStore) compact(now time.Time) error {
+	f.Lock()
+	defer f.Unlock()
+
+	days, err := f.days()
+	if err != nil {
+		return err
+	}
+
+	today := now.UTC().Format(PRICE_DAY_FORMAT)
+
+	for _, day := range days {
+		segmentPath := filepath.Join(f.dir, day+PRICE_SEGMENT_EXT)
+		logPath := filepath.Join(f.dir, day+PRICE_LOG_EXT)
+
+		if f.expired(day, now) {
+			for _, filePath := range []string{segmentPath, logPath} {
+				if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("Failed to delete expired prices: %s - %s", filePath, err)
+				}
+			}
+			continue
+		}
+
+		// today's log is still being appended to
+		if day >= today {
+			continue
+		}
+
+		if _, err := os.Stat(logPath); os.IsNotExist(err) {
+			// already compacted
+			continue
+		}
+
+		prices, err := f.readDay(day)
+		if err != nil {
+			return err
+		}
+
+		if err := writeSegment(segmentPath, uniquePrices(prices)); err != nil {
+			return fmt.Errorf("Failed to compact prices for %s - %s", day, err)
+		}
+
+		// if this fails the log is merged again next time, duplicates are removed then
+		if err := os.Remove(logPath); err != nil {
+			return fmt.Errorf("Failed to remove compacted log: %s - %s", logPath, err)
+		}
+	}
+
+	return nil
+}
+
+// retainedFrom - returns the time prices are kept from, zero if they are kept forever
+func (f *segmentPriceStore) retainedFrom(now time.Time) time.Time {
+	if f.retention == 0 {
+		return time.Time{}
+	}
+	return now.Add(-f.retention)
+}
+
+// expired - returns true if all of day is older than the retention
+func (f *segmentPriceStore) expired(day string, now time.Time) bool {
+	if f.retention == 0 {
+		return false
+	}
+	start, err := time.Parse(PRICE_DAY_FORMAT, day)
+	if err != nil {
+		return false
+	}
+	return !start.Add(24 * time.Hour).After(now.Add(-f.retention))
+}
+
+// days - returns the days with stored prices in order
+func (f *segmentPriceStore) days() ([]string, error) {
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("Can't read price store dir: %s - %s", f.dir, err)
+	}
+
+	found := make(map[string]bool)
+	days := make([]string, 0, len(files))
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+		if ext != PRICE_LOG_EXT && ext != PRICE_SEGMENT_EXT {
+			continue
+		}
+		day := strings.TrimSuffix(file.Name(), ext)
+		if _, err := time.Parse(PRICE_DAY_FORMAT, day); err != nil {
+			continue
+		}
+		if !found[day] {
+			found[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Strings(days)
+
+	return days, nil
+}
+
+// readDay - returns the prices in the segment and log of a day
+func (f *segmentPriceStore) readDay(day string) ([]Price, error) {
+	prices, err := readPriceFile(filepath.Join(f.dir, day+PRICE_SEGMENT_EXT))
+	if err != nil {
+		return nil, err
+	}
+
+	logged, err := readPriceFile(filepath.Join(f.dir, day+PRICE_LOG_EXT))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(prices, logged...), nil
+}
+
+// readPriceFile - returns the prices in a file, there are none if it does not exist
+func readPriceFile(filePath string) ([]Price, error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	prices := make([]Price, 0)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		price := Price{}
+		if err := json.Unmarshal(scanner.Bytes(), &price); err != nil {
+			// one bad line shouldn't lose the rest of the history
+			DefaultLogger.log(fmt.Sprintf("Skipping stored price %s:%d - %s", filePath, line, err))
+			continue
+		}
+		prices = append(prices, price)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read stored prices: %s - %s", filePath, err)
+	}
+
+	return prices, nil
+}
+
+// uniquePrices - returns prices in time order keeping the first price of a pair at a time
+func uniquePrices(prices []Price) []Price {
+	type key struct {
+		base, as SymbolType
+		at       int64
+	}
+
+	seen := make(map[key]bool, len(prices))
+	unique := make([]Price, 0, len(prices))
+	for _, price := range prices {
+		k := key{base: price.Base, as: price.As, at: price.At.UnixNano()}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, price)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool { return unique[i].At.Before(unique[j].At) })
+
+	return unique
+}
+
+// writeSegment - replaces a segment, writing to a temp file first so a crash never leaves
+// a partially written segment
+func writeSegment(filePath string, prices []Price) error {
+	buf := bytes.Buffer{}
+	for _, price := range prices {
+		priceJSON, err := json.Marshal(price)
+		if err != nil {
+			return err
+		}
+		buf.Write(priceJSON)
+		buf.WriteByte('\n')
+	}
+
+	tempPath := filePath + ".tmp"
+	if err := ioutil.WriteFile(tempPath, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, filePath)
+}
+
+// noPriceStore - prices are only held in memory
+type noPriceStore struct{}
+
+func (n noPriceStore) savePrices(prices []Price) error {
+	return nil
+}
+
+func (n noPriceStore) loadPrices() ([]Price, error) {
+	return []Price{}, nil
+}
+
+func (n noPriceStore) compact(now time.Time) error {
+	return nil
+}
+
+func (n noPriceStore) retainedFrom(now time.Time) time.Time {
+	return time.Time{}
+}
@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPriceStore(t *testing.T, retention time.Duration) (*segmentPriceStore, string) {
+	dataDir, err := ioutil.TempDir("", "teletrada-prices")
+	assert.NoError(t, err)
+
+	store, err := newPriceStore(dataDir, retention)
+	assert.NoError(t, err)
+
+	return store.(*segmentPriceStore), dataDir
+}
+
+func storedFiles(t *testing.T, store *segmentPriceStore) []string {
+	files, err := ioutil.ReadDir(store.dir)
+	assert.NoError(t, err)
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Name()
+	}
+	return names
+}
+
+func TestPriceStore(t *testing.T) {
+	store, dataDir := newTestPriceStore(t, 0)
+	defer os.RemoveAll(dataDir)
+
+	day1 := time.Date(2020, 3, 1, 23, 59, 0, 0, time.UTC)
+	day2 := day1.Add(2 * time.Minute)
+	prices := []Price{
+		{Base: ETH, As: BTC, Price: 0.02, At: day1, Exchange: "test"},
+		{Base: LTC, As: BTC, Price: 0.01, At: day1, Exchange: "test"},
+		{Base: ETH, As: BTC, Price: 0.03, At: day2, Exchange: "test"},
+	}
+
+	assert.NoError(t, store.savePrices(prices[:2]))
+	assert.NoError(t, store.savePrices(prices[2:]))
+	assert.Equal(t, []string{"20200301.log", "20200302.log"}, storedFiles(t, store))
+
+	loaded, err := store.loadPrices()
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 3)
+	for i := range prices {
+		assert.True(t, prices[i].At.Equal(loaded[i].At))
+		assert.Equal(t, prices[i].Base, loaded[i].Base)
+		assert.Equal(t, prices[i].Price, loaded[i].Price)
+	}
+
+	// a partly written line is skipped
+	file, err := os.OpenFile(filepath.Join(store.dir, "20200302.log"), os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"Base":"ETH","As":"BT`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	loaded, err = store.loadPrices()
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 3)
+
+	// reopening truncates it so the next price isn't joined onto it
+	reopened, err := newPriceStore(dataDir, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, reopened.savePrices([]Price{{Base: ETH, As: BTC, Price: 0.04, At: day2.Add(time.Minute), Exchange: "test"}}))
+	loaded, err = reopened.loadPrices()
+	assert.NoError(t, err)
+	if assert.Len(t, loaded, 4) {
+		assert.Equal(t, 0.04, loaded[3].Price)
+	}
+
+	// no store without a data dir
+	none, err := newPriceStore("", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, noPriceStore{}, none)
+
+	_, err = newPriceStore(dataDir, -time.Hour)
+	assert.Error(t, err)
+}
+
+func TestPriceStoreCompaction(t *testing.T) {
+	store, dataDir := newTestPriceStore(t, 0)
+	defer os.RemoveAll(dataDir)
+
+	day1 := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	assert.NoError(t, store.savePrices([]Price{
+		{Base: ETH, As: BTC, Price: 0.03, At: day1.Add(time.Minute)},
+		{Base: ETH, As: BTC, Price: 0.02, At: day1},
+		// stored twice
+		{Base: ETH, As: BTC, Price: 0.02, At: day1},
+		{Base: LTC, As: BTC, Price: 0.01, At: day1},
+		{Base: ETH, As: BTC, Price: 0.04, At: day2},
+	}))
+
+	// today is still being appended to
+	assert.NoError(t, store.compact(day2))
+	assert.Equal(t, []string{"20200301.seg", "20200302.log"}, storedFiles(t, store))
+
+	loaded, err := store.loadPrices()
+	assert.NoError(t, err)
+	if assert.Len(t, loaded, 4) {
+		assert.Equal(t, 0.02, loaded[0].Price)
+		assert.Equal(t, 0.01, loaded[1].Price)
+		assert.Equal(t, 0.03, loaded[2].Price)
+		assert.Equal(t, 0.04, loaded[3].Price)
+	}
+
+	// prices saved late for a compacted day are merged into its segment
+	assert.NoError(t, store.savePrices([]Price{{Base: LTC, As: BTC, Price: 0.02, At: day1.Add(time.Hour)}}))
+	assert.NoError(t, store.compact(day2.Add(24*time.Hour)))
+	assert.Equal(t, []string{"20200301.seg", "20200302.seg"}, storedFiles(t, store))
+
+	loaded, err = store.loadPrices()
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 5)
+
+	// compacting again changes nothing
+	assert.NoError(t, store.compact(day2.Add(24*time.Hour)))
+	again, err := store.loadPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, loaded, again)
+}
+
+func TestPriceStoreRetention(t *testing.T) {
+	store, dataDir := newTestPriceStore(t, 48*time.Hour)
+	defer os.RemoveAll(dataDir)
+
+	day1 := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		at := day1.Add(time.Duration(i) * 24 * time.Hour)
+		assert.NoError(t, store.savePrices([]Price{{Base: ETH, As: BTC, Price: 0.02, At: at}}))
+	}
+
+	// the 1st of March ended more than 48 hours before, the 2nd did not
+	assert.NoError(t, store.compact(time.Date(2020, 3, 4, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"20200302.seg", "20200303.seg", "20200304.log"}, storedFiles(t, store))
+}
+
+func TestCompactPricesPrunesArchive(t *testing.T) {
+	store, dataDir := newTestPriceStore(t, 48*time.Hour)
+	defer os.RemoveAll(dataDir)
+
+	day1 := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		at := day1.Add(time.Duration(i) * 24 * time.Hour)
+		assert.NoError(t, store.savePrices([]Price{{Base: ETH, As: BTC, Price: 0.02, At: at}}))
+	}
+
+	defer func(archive SymbolsArchive) { DefaultArchive = archive }(DefaultArchive)
+	archive, err := newStoredArchive(store)
+	assert.NoError(t, err)
+	DefaultArchive = archive
+
+	// prices older than the retention are removed from memory as well as disk
+	now := day1.Add(3 * 24 * time.Hour)
+	s := &server{prices: store}
+	s.compactPrices(now)
+
+	from, to, err := DefaultArchive.GetPriceRange()
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-48*time.Hour), from)
+	assert.Equal(t, now, to)
+}
+
+// countingPriceStore - counts the times prices are saved
+type countingPriceStore struct {
+	priceStore
+	saves int
+}
+
+func (c *countingPriceStore) savePrices(prices []Price) error {
+	c.saves++
+	return c.priceStore.savePrices(prices)
+}
+
+func TestStoredArchive(t *testing.T) {
+	store, dataDir := newTestPriceStore(t, 0)
+	defer os.RemoveAll(dataDir)
+
+	now := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.savePrices([]Price{
+		{Base: ETH, As: BTC, Price: 0.02, At: now},
+		{Base: LTC, As: BTC, Price: 0.01, At: now},
+	}))
+
+	archive, err := newStoredArchive(store)
+	assert.NoError(t, err)
+
+	price, err := archive.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.02, price.Price)
+
+	// loading doesn't store prices again
+	loaded, err := store.loadPrices()
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 2)
+
+	// prices added are stored
+	assert.NoError(t, archive.AddPrice(Price{Base: ETH, As: BTC, Price: 0.03, At: now.Add(time.Minute)}))
+	added, err := archive.AddPrices(LTC, []Price{
+		{Base: LTC, As: BTC, Price: 0.01, At: now},
+		{Base: LTC, As: BTC, Price: 0.02, At: now.Add(time.Minute)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	// a restart has the prices added
+	restarted, err := newStoredArchive(store)
+	assert.NoError(t, err)
+	price, err = restarted.GetLatestPriceAs(LTC, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.02, price.Price)
+	price, err = restarted.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.03, price.Price)
+
+	// prices of many bases are stored together, an invalid price doesn't stop the others
+	counting := &countingPriceStore{priceStore: store}
+	archive, err = newStoredArchive(counting)
+	assert.NoError(t, err)
+	added, err = archive.AddAllPrices([]Price{
+		{Base: ETH, As: BTC, Price: 0.04, At: now.Add(2 * time.Minute)},
+		{Base: LTC, As: BTC, Price: 0.03, At: now.Add(2 * time.Minute)},
+		{Base: LTC, As: BTC, Price: 0.02, At: now.Add(time.Minute)},
+		{Base: BNB, As: BTC, Price: 0, At: now.Add(2 * time.Minute)},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 1, counting.saves)
+	price, err = archive.GetLatestPriceAs(LTC, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.03, price.Price)
+}
@@ -10,11 +10,13 @@ import (
 )
 
 type Price struct {
-	Base     SymbolType
-	As       SymbolType
-	Price    float64
-	At       time.Time
-	Exchange string
+	Base         SymbolType
+	As           SymbolType
+	Price        float64
+	At           time.Time
+	Exchange     string
+	Interpolated bool          // estimated across a gap in the price history
+	Interval     time.Duration // time the price stands for when coarser than the updates, eg. a backfilled candle
 }
 
 type DaySummary struct {
@@ -27,6 +29,13 @@ type DaySummary struct {
 	LowestPrice      float64
 	ChangePrice      float64
 	ChangePercent    float64
+	Volume           float64 // traded in the base symbol
+	QuoteVolume      float64 // traded in the as symbol
+	BidPrice         float64
+	BidQuantity      float64
+	AskPrice         float64
+	AskQuantity      float64
+	Trades           int64
 	At               time.Time
 	Exchange         string
 }
@@ -55,6 +64,15 @@ func (p Price) Validate() error {
 
 }
 
+// inverted - returns the price of the as symbol in the base symbol
+func (p Price) inverted() Price {
+	inverse := p
+	inverse.Base = p.As
+	inverse.As = p.Base
+	inverse.Price = 1.0 / p.Price
+	return inverse
+}
+
 // GetPrices returns current prices
 func (s *server) GetPrices(ctx context.Context, req *proto.GetPricesRequest) (*proto.GetPricesResponse, error) {
 	resp := &proto.GetPricesResponse{}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+/*
+
+A server can record the responses of its exchange then replay them later
+instead of using the exchange, so a day captured once can be run through the
+scheduler, portfolios and simulations again offline with the same results.
+
+When replaying, server time is fake and starts at the first recorded response.
+Every update moves server time on by the update frequency, updates run
+ReplaySpeed times faster than real time and a daily update runs whenever server
+time passes midnight. Updates stop once the end of the recording is reached.
+
+*/
+
+// serverNow - servertime.Now is switched between real and fake time so it is called
+// through this when a clock is passed to an exchange client
+func serverNow() time.Time {
+	return servertime.Now()
+}
+
+// initReplayClients - replays the recording in dir from its first response, returns the time
+// of the last response
+func initReplayClients(dir string) (time.Time, error) {
+	client, err := exchanges.NewReplayClient(dir, serverNow)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	from, to := client.(exchanges.Replayer).RecordedBetween()
+
+	servertime.SetFakeTime(from)
+	servertime.UseFakeTime()
+
+	DefaultClient = client
+
+	// replayed prices are not saved to influx
+	DefaultMetrics, err = newMockMetricsClient(TEST_INFLUX_DATABASE)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	DefaultLogger.log(fmt.Sprintf("Replaying %s recorded from %s to %s", client.GetExchange(), from.Format(DATE_FORMAT), to.Format(DATE_FORMAT)))
+
+	return to, nil
+}
+
+// replayUpdates - runs an update every tick until the recording has been replayed
+func (s *server) replayUpdates() {
+	tick := time.Duration(float64(s.config.UpdateFreq) / s.config.ReplaySpeed)
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopUpdate:
+			DefaultLogger.log("Replay stopping")
+			return
+		case <-ticker.C:
+			if !s.replayUpdate() {
+				DefaultLogger.log("Replay finished")
+				return
+			}
+		}
+	}
+}
+
+// replayUpdate - moves server time on by the update frequency then updates, returns false
+// when the end of the recording has already been reached
+func (s *server) replayUpdate() bool {
+	before := servertime.Now()
+	if !before.Before(s.replayEnd) {
+		return false
+	}
+
+	servertime.TickFakeTime(s.config.UpdateFreq)
+
+	if now := servertime.Now(); !now.Truncate(24 * time.Hour).Equal(before.Truncate(24 * time.Hour)) {
+		s.dailyUpdate()
+	}
+
+	s.scheduledUpdate()
+
+	return true
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestReplayRecording(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "teletrada-recording")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// record an hour of prices from the mock exchange
+	start := time.Date(2018, 1, 18, 23, 0, 0, 0, time.UTC)
+	now := start
+	clock := func() time.Time { return now }
+
+	pricesAt := func(at time.Time, ethAsBtc float64) []exchanges.Price {
+		return []exchanges.Price{
+			{Base: "ETH", As: "BTC", Price: ethAsBtc, At: at, Exchange: exchanges.MOCK_EXCHANGE},
+			{Base: "BTC", As: "USDT", Price: 11000.0, At: at, Exchange: exchanges.MOCK_EXCHANGE},
+		}
+	}
+
+	for i, ethAsBtc := range []float64{0.1, 0.2} {
+		now = start.Add(time.Duration(i) * time.Hour)
+		mock, err := exchanges.NewMockClient(mockCoinBalances(), pricesAt(now, ethAsBtc))
+		assert.NoError(t, err)
+		// each recorder adds to the recording
+		recorder, err := exchanges.NewRecordingClient(mock, dir, clock)
+		assert.NoError(t, err)
+
+		_, err = recorder.GetCoinBalances()
+		assert.NoError(t, err)
+		_, err = recorder.GetLatestPrices()
+		assert.NoError(t, err)
+		_, err = recorder.GetTradingPairs()
+		assert.NoError(t, err)
+	}
+
+	config := Config{
+		InfluxDBName: "test-db",
+		UpdateFreq:   time.Duration(1 * time.Hour),
+		Verbose:      true,
+		Port:         TEST_GRPC_PORT,
+		ReplayDir:    dir,
+		ReplaySpeed:  1,
+	}
+
+	s, err := NewTradaServer(config)
+	assert.NoError(t, err)
+	assert.Equal(t, start, servertime.Now(), "Replay starts at the first recorded response")
+	assert.Equal(t, exchanges.MOCK_EXCHANGE, DefaultClient.GetExchange())
+
+	assert.NoError(t, s.Init())
+	defer s.stopScheduler()
+
+	price, err := DefaultArchive.GetLatestPriceAs("ETH", "BTC")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, price.Price)
+	assert.Equal(t, start, price.At)
+
+	// moves on to the next recorded prices, passing midnight
+	assert.True(t, s.(*server).replayUpdate())
+	assert.Equal(t, start.Add(time.Hour), servertime.Now())
+
+	price, err = DefaultArchive.GetLatestPriceAs("ETH", "BTC")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.2, price.Price)
+
+	assert.False(t, s.(*server).replayUpdate(), "Recording has been replayed")
+	assert.Equal(t, start.Add(time.Hour), servertime.Now())
+
+	// replay speed must be set
+	config.ReplaySpeed = 0
+	_, err = NewTradaServer(config)
+	assert.Error(t, err)
+}
@@ -0,0 +1,309 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/telecoda/teletrada/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+
+Simulation results measure how well a simulation's strategies performed.
+
+Every step of a simulation records the value of the simulated portfolio and of
+a benchmark portfolio in an equity curve. The benchmark is the real portfolio at
+the start of the simulation held unchanged (buy-and-hold).
+
+From the equity curve and the trades executed the following are calculated:-
+
+	total return      - percentage change in value from start to end
+	annualised return - total return compounded over a year
+	max drawdown      - largest percentage fall from a peak value
+	volatility        - annualised standard deviation of step returns
+	sharpe ratio      - annualised mean step return / standard deviation (risk free rate of 0)
+	sortino ratio     - as sharpe but only penalising downside deviation
+	win rate          - percentage of sells filled above the cost of the coins sold
+	synthetic prices  - percentage of balance prices interpolated across gaps in the price history
+
+Long running realtime simulations would grow the equity curve without limit, so
+once it has more than MAX_EQUITY_POINTS every other point is dropped. The
+older part of the curve is then sampled less often, so results are an
+approximation of the full curve after this.
+
+*/
+
+const year = time.Duration(365 * 24 * time.Hour)
+
+// MAX_EQUITY_POINTS - the equity curve is downsampled when it grows beyond this
+const MAX_EQUITY_POINTS = 10000
+
+// equityPoint - value of the simulated and benchmark portfolios at a point in time
+type equityPoint struct {
+	at        time.Time
+	value     float64
+	benchmark float64
+	prices    int // balances priced
+	synthetic int // balances priced with an interpolated price
+}
+
+type simulationResult struct {
+	id               string
+	as               SymbolType
+	from             time.Time
+	to               time.Time
+	startValue       float64
+	endValue         float64
+	totalReturn      float64
+	annualisedReturn float64
+	maxDrawdown      float64
+	volatility       float64
+	sharpeRatio      float64
+	sortinoRatio     float64
+	trades           int
+	winRate          float64
+	// buy-and-hold benchmark
+	benchmarkStartValue float64
+	benchmarkEndValue   float64
+	benchmarkReturn     float64
+	// percentage of prices interpolated across gaps
+	syntheticPercent float64
+}
+
+// recordEquity - saves the current value of the simulated and benchmark portfolios
+func (s *simulation) recordEquity(at time.Time) {
+	point := equityPoint{
+		at:    at,
+		value: s.portfolio.value(),
+	}
+	for _, balance := range s.portfolio.balances {
+		point.prices++
+		if balance.Interpolated {
+			point.synthetic++
+		}
+	}
+	if s.benchmark != nil {
+		point.benchmark = s.benchmark.value()
+	}
+	s.equity = append(s.equity, point)
+	if len(s.equity) > MAX_EQUITY_POINTS {
+		s.equity = downsampleEquity(s.equity)
+	}
+}
+
+// downsampleEquity - drops every other point of the equity curve, keeping the first and latest
+func downsampleEquity(equity []equityPoint) []equityPoint {
+	kept := make([]equityPoint, 0, len(equity)/2+1)
+	for i, point := range equity {
+		if i%2 == 0 || i == len(equity)-1 {
+			kept = append(kept, point)
+			continue
+		}
+		// prices of dropped points are still counted so the synthetic percent is unchanged
+		kept[len(kept)-1].prices += point.prices
+		kept[len(kept)-1].synthetic += point.synthetic
+	}
+	return kept
+}
+
+// result - calculates the performance of the simulation so far
+func (s *simulation) result() (*simulationResult, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return newSimulationResult(s.id, s.equity, s.trades.trades)
+}
+
+func newSimulationResult(id string, equity []equityPoint, trades []trade) (*simulationResult, error) {
+	if len(equity) == 0 {
+		return nil, fmt.Errorf("Simulation: %s has no results yet", id)
+	}
+
+	first := equity[0]
+	last := equity[len(equity)-1]
+
+	r := &simulationResult{
+		id:                  id,
+		as:                  DEFAULT_SYMBOL,
+		from:                first.at,
+		to:                  last.at,
+		startValue:          first.value,
+		endValue:            last.value,
+		totalReturn:         percentChange(first.value, last.value),
+		benchmarkStartValue: first.benchmark,
+		benchmarkEndValue:   last.benchmark,
+		benchmarkReturn:     percentChange(first.benchmark, last.benchmark),
+		trades:              len(trades),
+		winRate:             winRate(first.at, trades),
+	}
+
+	period := last.at.Sub(first.at)
+	if period > 0 && first.value > 0 && last.value > 0 {
+		r.annualisedReturn = (math.Pow(last.value/first.value, float64(year)/float64(period)) - 1) * 100.0
+	}
+
+	r.maxDrawdown = maxDrawdown(equity)
+	r.syntheticPercent = syntheticPercent(equity)
+
+	returns := stepReturns(equity)
+	if len(returns) > 0 && period > 0 {
+		stepsPerYear := float64(year) / (float64(period) / float64(len(returns)))
+
+		mean, stdDev, downsideDev := returnStats(returns)
+
+		r.volatility = stdDev * math.Sqrt(stepsPerYear) * 100.0
+		if stdDev > 0 {
+			r.sharpeRatio = mean / stdDev * math.Sqrt(stepsPerYear)
+		}
+		if downsideDev > 0 {
+			r.sortinoRatio = mean / downsideDev * math.Sqrt(stepsPerYear)
+		}
+	}
+
+	return r, nil
+}
+
+func percentChange(from, to float64) float64 {
+	if from == 0 {
+		return 0
+	}
+	return (to - from) / from * 100.0
+}
+
+// maxDrawdown - largest percentage fall from a peak in the equity curve
+func maxDrawdown(equity []equityPoint) float64 {
+	peak := 0.0
+	drawdown := 0.0
+	for _, point := range equity {
+		if point.value > peak {
+			peak = point.value
+		}
+		if peak > 0 {
+			drawdown = math.Max(drawdown, (peak-point.value)/peak*100.0)
+		}
+	}
+	return drawdown
+}
+
+// syntheticPercent - percentage of balance prices in the equity curve that were interpolated
+func syntheticPercent(equity []equityPoint) float64 {
+	prices := 0
+	synthetic := 0
+	for _, point := range equity {
+		prices += point.prices
+		synthetic += point.synthetic
+	}
+	if prices == 0 {
+		return 0
+	}
+	return float64(synthetic) / float64(prices) * 100.0
+}
+
+// stepReturns - fractional return between each point in the equity curve
+func stepReturns(equity []equityPoint) []float64 {
+	returns := make([]float64, 0, len(equity))
+	for i := 1; i < len(equity); i++ {
+		if equity[i-1].value == 0 {
+			continue
+		}
+		returns = append(returns, equity[i].value/equity[i-1].value-1)
+	}
+	return returns
+}
+
+// returnStats - returns the mean, standard deviation and downside deviation of returns
+func returnStats(returns []float64) (float64, float64, float64) {
+	n := float64(len(returns))
+
+	sum := 0.0
+	for _, r := range returns {
+		sum += r
+	}
+	mean := sum / n
+
+	variance := 0.0
+	downside := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+		if r < 0 {
+			downside += r * r
+		}
+	}
+
+	return mean, math.Sqrt(variance / n), math.Sqrt(downside / n)
+}
+
+// winRate - percentage of sells filled above the average cost of the coins sold
+// coins held at the start of the simulation cost their price at the start
+func winRate(start time.Time, trades []trade) float64 {
+	type position struct {
+		quantity float64
+		cost     float64 // average price paid
+	}
+
+	positions := make(map[string]*position)
+	sells := 0
+	wins := 0
+
+	for _, t := range trades {
+		pair := string(t.symbol) + string(t.as)
+		pos, ok := positions[pair]
+		if !ok {
+			pos = &position{}
+			if price, err := DefaultArchive.GetPriceAs(t.symbol, t.as, start); err == nil {
+				pos.cost = price.Price
+			}
+			positions[pair] = pos
+		}
+
+		switch t.side {
+		case BUY_TRADE:
+			if pos.quantity+t.quantity > 0 {
+				pos.cost = (pos.cost*pos.quantity + t.totalCost) / (pos.quantity + t.quantity)
+			}
+			pos.quantity += t.quantity
+		case SELL_TRADE:
+			sells++
+			if t.price > pos.cost {
+				wins++
+			}
+			pos.quantity = math.Max(0, pos.quantity-t.quantity)
+		}
+	}
+
+	if sells == 0 {
+		return 0
+	}
+	return float64(wins) / float64(sells) * 100.0
+}
+
+// GetSimulationResult returns the performance of a simulation
+func (s *server) GetSimulationResult(ctx context.Context, req *proto.GetSimulationResultRequest) (*proto.GetSimulationResultResponse, error) {
+
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "You must provide a simulation Id")
+	}
+
+	sim, err := s.getSimulation(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to get simulation - %s", err)
+	}
+
+	result, err := sim.result()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Failed to get simulation result - %s", err)
+	}
+
+	resp := &proto.GetSimulationResultResponse{}
+
+	resp.Result, err = result.toProto()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to convert simulation result - %s", err)
+	}
+
+	return resp, nil
+}
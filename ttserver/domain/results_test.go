@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestSimulationResult(t *testing.T) {
+
+	_, err := newSimulationResult("no-equity", nil, nil)
+	assert.Error(t, err)
+
+	start := servertime.Now()
+	day := time.Duration(24 * time.Hour)
+
+	equity := []equityPoint{
+		{at: start, value: 100, benchmark: 100, prices: 3},
+		{at: start.Add(day), value: 110, benchmark: 100, prices: 3, synthetic: 3},
+		{at: start.Add(2 * day), value: 99, benchmark: 100, prices: 3},
+		{at: start.Add(3 * day), value: 121, benchmark: 105, prices: 3},
+	}
+
+	result, err := newSimulationResult("test-sim-id", equity, []trade{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "test-sim-id", result.id)
+	assert.Equal(t, start, result.from)
+	assert.Equal(t, start.Add(3*day), result.to)
+	assert.Equal(t, 100.0, result.startValue)
+	assert.Equal(t, 121.0, result.endValue)
+	assert.InDelta(t, 21.0, result.totalReturn, 0.0000001)
+	assert.InDelta(t, math.Pow(1.21, 365.0/3.0)*100.0-100.0, result.annualisedReturn, 0.0001)
+
+	// peak of 110 fell to 99
+	assert.InDelta(t, 10.0, result.maxDrawdown, 0.0000001)
+
+	// daily returns
+	returns := []float64{0.1, 99.0/110.0 - 1, 121.0/99.0 - 1}
+	mean := (returns[0] + returns[1] + returns[2]) / 3
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	stdDev := math.Sqrt(variance / 3)
+	downsideDev := math.Sqrt(returns[1] * returns[1] / 3)
+
+	assert.InDelta(t, stdDev*math.Sqrt(365)*100.0, result.volatility, 0.0000001)
+	assert.InDelta(t, mean/stdDev*math.Sqrt(365), result.sharpeRatio, 0.0000001)
+	assert.InDelta(t, mean/downsideDev*math.Sqrt(365), result.sortinoRatio, 0.0000001)
+
+	assert.Equal(t, 100.0, result.benchmarkStartValue)
+	assert.Equal(t, 105.0, result.benchmarkEndValue)
+	assert.InDelta(t, 5.0, result.benchmarkReturn, 0.0000001)
+
+	assert.Equal(t, 0, result.trades)
+	assert.Equal(t, 0.0, result.winRate)
+
+	// every price on the second day was interpolated
+	assert.InDelta(t, 25.0, result.syntheticPercent, 0.0000001)
+}
+
+func TestSimulationResultWinRate(t *testing.T) {
+	defer func(archive SymbolsArchive) { DefaultArchive = archive }(DefaultArchive)
+	DefaultArchive = NewSymbolsArchive()
+
+	start := servertime.Now()
+
+	// ETH held at start cost 0.1 BTC
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: ETH, As: BTC, Price: 0.1, At: start, Exchange: "test-exchange"}))
+
+	trades := []trade{
+		// sold above start price
+		{side: SELL_TRADE, symbol: ETH, as: BTC, quantity: 5, price: 0.12, totalCost: 0.6},
+		// bought back above start price
+		{side: BUY_TRADE, symbol: ETH, as: BTC, quantity: 10, price: 0.15, totalCost: 1.5},
+		// sold below cost of buy
+		{side: SELL_TRADE, symbol: ETH, as: BTC, quantity: 5, price: 0.14, totalCost: 0.7},
+	}
+
+	equity := []equityPoint{
+		{at: start, value: 100, benchmark: 100},
+	}
+
+	result, err := newSimulationResult("test-sim-id", equity, trades)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.trades)
+	assert.InDelta(t, 50.0, result.winRate, 0.0000001)
+
+	// a single point has no returns
+	assert.Equal(t, 0.0, result.annualisedReturn)
+	assert.Equal(t, 0.0, result.volatility)
+	assert.Equal(t, 0.0, result.sharpeRatio)
+}
+
+func TestDownsampleEquity(t *testing.T) {
+	start := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	sim := &simulation{portfolio: &portfolio{balances: map[SymbolType]*BalanceAs{}}}
+	for i := 0; i < MAX_EQUITY_POINTS; i++ {
+		sim.equity = append(sim.equity, equityPoint{at: start.Add(time.Duration(i) * time.Minute), value: float64(i), prices: 2, synthetic: i % 2})
+	}
+	before := syntheticPercent(sim.equity)
+
+	// one more point halves the curve
+	sim.recordEquity(start.Add(time.Duration(MAX_EQUITY_POINTS) * time.Minute))
+	assert.Len(t, sim.equity, MAX_EQUITY_POINTS/2+1)
+	assert.Equal(t, start, sim.equity[0].at)
+	assert.Equal(t, start.Add(time.Duration(MAX_EQUITY_POINTS)*time.Minute), sim.equity[len(sim.equity)-1].at)
+
+	prices := 0
+	for _, point := range sim.equity {
+		prices += point.prices
+	}
+	assert.Equal(t, 2*MAX_EQUITY_POINTS, prices)
+	assert.InDelta(t, before, syntheticPercent(sim.equity), 0.01)
+}
+
+func TestGetSimulationResult(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	sim, err := createTestSimulation(server)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = server.GetSimulationResult(ctx, &proto.GetSimulationResultRequest{})
+	assert.Error(t, err)
+
+	_, err = server.GetSimulationResult(ctx, &proto.GetSimulationResultRequest{Id: "unknown"})
+	assert.Error(t, err)
+
+	// not run yet
+	_, err = server.GetSimulationResult(ctx, &proto.GetSimulationResultRequest{Id: sim.id})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "has no results yet")
+	}
+
+	// ETH as BTC is always above this price so will sell every time
+	ethSell, err := NewPriceAboveStrategy("sell-eth", ETH, BTC, 0.00000001, 50.0)
+	assert.NoError(t, err)
+	assert.NoError(t, sim.SetSellStrategy(ethSell))
+
+	now := servertime.Now()
+	from := now.Add(-2 * time.Hour)
+	to := now.Add(-1 * time.Hour)
+	sim.simFromTime = &from
+	sim.simToTime = &to
+
+	assert.NoError(t, sim.runOverHistory(30*time.Minute))
+
+	resp, err := server.GetSimulationResult(ctx, &proto.GetSimulationResultRequest{Id: sim.id})
+	assert.NoError(t, err)
+	if assert.NotNil(t, resp.Result) {
+		assert.Equal(t, sim.id, resp.Result.Id)
+		assert.Equal(t, string(DEFAULT_SYMBOL), resp.Result.As)
+		assert.Equal(t, int32(3), resp.Result.Trades)
+		assert.True(t, resp.Result.StartValue > 0)
+		assert.True(t, resp.Result.BenchmarkStartValue > 0)
+		// ETH falls against BTC in the mock so selling beats holding
+		assert.True(t, resp.Result.TotalReturn > resp.Result.BenchmarkReturn)
+	}
+
+	assert.Equal(t, 3, len(sim.equity))
+}
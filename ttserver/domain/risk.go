@@ -0,0 +1,403 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+/*
+
+Risk limits are checked by the order manager before every order for the live
+portfolio is sent, including dry run orders:-
+
+	max order size     - the largest quantity of a symbol in a single order
+	max trade percent  - the largest share of the live portfolio value in a single order
+	max orders an hour - the number of orders accepted in the last hour
+	max daily loss     - realised losses since midnight (UTC) valued in DEFAULT_SYMBOL
+
+A limit of 0 is not enforced.
+
+Breaching the daily loss limit engages the kill switch, it can also be engaged
+manually. While it is engaged no orders are accepted, live strategies are
+stopped and open orders are cancelled. Releasing it restarts live strategies.
+The kill switch is saved with the orders so it stays engaged across restarts,
+if it can't be restored it is engaged to be safe.
+
+Realised losses are calculated from the average price paid for a symbol by
+orders seen since the server started, sells of coins bought before then use
+the price at the start of the day.
+
+*/
+
+// RiskConfig - limits applied to orders for the live portfolio
+type RiskConfig struct {
+	MaxOrderSize     map[SymbolType]float64 // max quantity of a symbol in one order
+	MaxTradePercent  float64                // max percentage of the live portfolio value in one order
+	MaxDailyLoss     float64                // max realised loss in a day valued in DEFAULT_SYMBOL
+	MaxOrdersPerHour int                    // max orders accepted in an hour
+}
+
+// ParseOrderSizeLimits - parses max order sizes in the format symbol=quantity,symbol=quantity
+func ParseOrderSizeLimits(limits string) (map[SymbolType]float64, error) {
+	sizes := make(map[SymbolType]float64)
+	if limits == "" {
+		return sizes, nil
+	}
+
+	for _, pair := range strings.Split(limits, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid order size limit %q, expected symbol=quantity", pair)
+		}
+		size, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid order size %q - %s", parts[1], err)
+		}
+		sizes[SymbolType(strings.ToUpper(strings.TrimSpace(parts[0])))] = size
+	}
+
+	return sizes, nil
+}
+
+func (c RiskConfig) validate() error {
+	for symbol, size := range c.MaxOrderSize {
+		if size < 0 {
+			return fmt.Errorf("Max order size for %s cannot be negative", symbol)
+		}
+	}
+	if c.MaxTradePercent < 0 || c.MaxTradePercent > 100 {
+		return fmt.Errorf("Max trade percent must be between 0 and 100 percent")
+	}
+	if c.MaxDailyLoss < 0 {
+		return fmt.Errorf("Max daily loss cannot be negative")
+	}
+	if c.MaxOrdersPerHour < 0 {
+		return fmt.Errorf("Max orders per hour cannot be negative")
+	}
+	return nil
+}
+
+// position - quantity of a symbol bought and its cost in DEFAULT_SYMBOL
+type position struct {
+	quantity float64
+	cost     float64
+}
+
+type riskManager struct {
+	sync.Mutex
+	config     RiskConfig
+	store      orderStore // saves the kill switch
+	engaged    bool
+	reason     string
+	orderTimes []time.Time // when orders were accepted in the last hour
+	lossDay    time.Time   // day realised is totalled for
+	realised   float64     // realised profit (or loss if negative) for lossDay
+	positions  map[SymbolType]*position
+}
+
+func newRiskManager(config RiskConfig, store orderStore) (*riskManager, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &riskManager{
+		config:     config,
+		store:      store,
+		orderTimes: make([]time.Time, 0),
+		positions:  make(map[SymbolType]*position),
+	}, nil
+}
+
+// valueAt - returns the value of a quantity of a symbol in DEFAULT_SYMBOL
+func valueAt(symbol SymbolType, quantity float64, at time.Time) (float64, error) {
+	if symbol == DEFAULT_SYMBOL {
+		return quantity, nil
+	}
+	price, err := DefaultArchive.GetPriceAs(symbol, DEFAULT_SYMBOL, at)
+	if err != nil {
+		return 0, err
+	}
+	return price.Price * quantity, nil
+}
+
+// breach - logs the breach of a limit and returns it as an error
+func breach(reason string) error {
+	DefaultLogger.log(fmt.Sprintf("RISK BREACH: %s", reason))
+	return fmt.Errorf("Risk limit breached - %s", reason)
+}
+
+// checkOrder - returns an error if an order would breach a limit, otherwise
+// the order is counted towards the hourly limit
+func (r *riskManager) checkOrder(side tradeSide, symbol SymbolType, quantity float64, portfolioValue float64, at time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.engaged {
+		return breach(fmt.Sprintf("kill switch engaged: %s", r.reason))
+	}
+
+	if maxSize, ok := r.config.MaxOrderSize[symbol]; ok && maxSize > 0 && quantity > maxSize {
+		return breach(fmt.Sprintf("%s %f %s is more than max order size %f", side, quantity, symbol, maxSize))
+	}
+
+	if r.config.MaxTradePercent > 0 {
+		value, err := valueAt(symbol, quantity, at)
+		if err != nil {
+			return breach(fmt.Sprintf("cannot value %s %f %s - %s", side, quantity, symbol, err))
+		}
+		maxValue := portfolioValue * r.config.MaxTradePercent / 100.0
+		if value > maxValue {
+			return breach(fmt.Sprintf("%s %f %s worth %f %s is more than %.2f%% of portfolio value %f", side, quantity, symbol, value, DEFAULT_SYMBOL, r.config.MaxTradePercent, portfolioValue))
+		}
+	}
+
+	r.pruneOrderTimes(at)
+	if r.config.MaxOrdersPerHour > 0 && len(r.orderTimes) >= r.config.MaxOrdersPerHour {
+		return breach(fmt.Sprintf("%d orders in the last hour, max is %d", len(r.orderTimes), r.config.MaxOrdersPerHour))
+	}
+
+	r.orderTimes = append(r.orderTimes, at)
+
+	return nil
+}
+
+// pruneOrderTimes - forgets orders accepted more than an hour ago
+func (r *riskManager) pruneOrderTimes(at time.Time) {
+	from := at.Add(-time.Hour)
+	recent := r.orderTimes[:0]
+	for _, orderTime := range r.orderTimes {
+		if orderTime.After(from) {
+			recent = append(recent, orderTime)
+		}
+	}
+	r.orderTimes = recent
+}
+
+// recordFill - updates realised profit and loss for a fill and engages the kill switch
+// if the daily loss limit is breached
+func (r *riskManager) recordFill(side tradeSide, symbol, as SymbolType, quantity, quoteQuantity float64, at time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+
+	day := at.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(r.lossDay) {
+		r.lossDay = day
+		r.realised = 0
+	}
+
+	value, err := valueAt(as, quoteQuantity, at)
+	if err != nil {
+		return fmt.Errorf("Cannot value %s fill of %f %s - %s", side, quantity, symbol, err)
+	}
+
+	pos, ok := r.positions[symbol]
+	if !ok {
+		pos = &position{}
+		r.positions[symbol] = pos
+	}
+
+	if side == BUY_TRADE {
+		pos.quantity += quantity
+		pos.cost += value
+		return nil
+	}
+
+	// cost of the coins sold
+	var cost float64
+	sellQuantity := quantity
+	if pos.quantity > 0 {
+		sold := quantity
+		if sold > pos.quantity {
+			sold = pos.quantity
+		}
+		avgCost := pos.cost / pos.quantity
+		cost = avgCost * sold
+		pos.quantity -= sold
+		pos.cost -= cost
+		quantity -= sold
+	}
+	if quantity > 0 {
+		// bought before the server started
+		dayCost, err := valueAt(symbol, quantity, day)
+		if err != nil {
+			// no price history for today so treat them as sold at cost
+			DefaultLogger.log(fmt.Sprintf("Cannot value cost of %f %s at %s, no loss recorded - %s", quantity, symbol, day.Format(DATE_FORMAT), err))
+			dayCost = value * quantity / sellQuantity
+		}
+		cost += dayCost
+	}
+
+	r.realised += value - cost
+
+	if r.config.MaxDailyLoss > 0 && -r.realised > r.config.MaxDailyLoss && !r.engaged {
+		reason := fmt.Sprintf("realised loss %f %s today is more than max daily loss %f", -r.realised, DEFAULT_SYMBOL, r.config.MaxDailyLoss)
+		breach(reason)
+		r.engage(reason)
+	}
+
+	return nil
+}
+
+// engage - engages the kill switch, returns false if already engaged
+func (r *riskManager) engage(reason string) bool {
+	if r.engaged {
+		return false
+	}
+	r.engaged = true
+	r.reason = reason
+	DefaultLogger.log(fmt.Sprintf("KILL SWITCH engaged: %s", reason))
+	r.saveKillSwitch()
+	return true
+}
+
+// release - releases the kill switch, returns false if not engaged
+func (r *riskManager) release() bool {
+	if !r.engaged {
+		return false
+	}
+	r.engaged = false
+	r.reason = ""
+	DefaultLogger.log("KILL SWITCH released")
+	r.saveKillSwitch()
+	return true
+}
+
+// saveKillSwitch - saves the kill switch, failures are logged as it has already changed
+func (r *riskManager) saveKillSwitch() {
+	state := killSwitchState{
+		Engaged:     r.engaged,
+		Reason:      r.reason,
+		UpdatedTime: servertime.Now(),
+	}
+	if err := r.store.saveKillSwitch(state); err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: saving kill switch - %s", err))
+	}
+}
+
+// restoreKillSwitch - restores the kill switch saved before a restart
+func (r *riskManager) restoreKillSwitch() {
+	r.Lock()
+	defer r.Unlock()
+
+	state, err := r.store.loadKillSwitch()
+	if err != nil {
+		r.engage(fmt.Sprintf("failed to restore kill switch - %s", err))
+		return
+	}
+
+	if state.Engaged {
+		r.engaged = true
+		r.reason = state.Reason
+		DefaultLogger.log(fmt.Sprintf("KILL SWITCH still engaged since %s: %s", state.UpdatedTime.Format(DATE_FORMAT), state.Reason))
+	}
+}
+
+// isEngaged - returns true if the kill switch is engaged
+func (r *riskManager) isEngaged() bool {
+	r.Lock()
+	defer r.Unlock()
+	return r.engaged
+}
+
+// riskStatus - current limits and usage
+type riskStatus struct {
+	config         RiskConfig
+	engaged        bool
+	reason         string
+	ordersLastHour int
+	realised       float64
+}
+
+func (r *riskManager) status(at time.Time) riskStatus {
+	r.Lock()
+	defer r.Unlock()
+
+	r.pruneOrderTimes(at)
+
+	realised := r.realised
+	if !at.UTC().Truncate(24 * time.Hour).Equal(r.lossDay) {
+		realised = 0
+	}
+
+	return riskStatus{
+		config:         r.config,
+		engaged:        r.engaged,
+		reason:         r.reason,
+		ordersLastHour: len(r.orderTimes),
+		realised:       realised,
+	}
+}
+
+// SetKillSwitch - manually engages or releases the kill switch for live trading
+func (s *server) SetKillSwitch(ctx context.Context, req *proto.SetKillSwitchRequest) (*proto.SetKillSwitchResponse, error) {
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "engaged manually"
+	}
+
+	s.setKillSwitch(req.Engaged, reason)
+
+	return &proto.SetKillSwitchResponse{}, nil
+}
+
+// setKillSwitch - engages the kill switch halting live trading or releases it
+func (s *server) setKillSwitch(engaged bool, reason string) {
+	s.risk.Lock()
+	var changed bool
+	if engaged {
+		changed = s.risk.engage(reason)
+	} else {
+		changed = s.risk.release()
+	}
+	s.risk.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if engaged {
+		s.haltLiveTrading()
+	} else {
+		s.setLiveStrategiesRunning(true)
+	}
+}
+
+// haltLiveTrading - stops live strategies and cancels open orders
+func (s *server) haltLiveTrading() {
+	s.setLiveStrategiesRunning(false)
+
+	if err := s.orders.cancelOpenOrders("kill switch engaged"); err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: cancelling open orders - %s", err))
+	}
+}
+
+// setLiveStrategiesRunning - starts or stops all strategies attached to the live portfolio
+func (s *server) setLiveStrategiesRunning(running bool) {
+	if s.livePortfolio == nil {
+		return
+	}
+
+	s.livePortfolio.RLock()
+	defer s.livePortfolio.RUnlock()
+
+	for _, balance := range s.livePortfolio.balances {
+		for _, strategy := range []Strategy{balance.BuyStrategy, balance.SellStrategy} {
+			if strategy == nil {
+				continue
+			}
+			if running {
+				strategy.Start()
+			} else {
+				strategy.Stop()
+			}
+		}
+	}
+}
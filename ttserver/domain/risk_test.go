@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestParseOrderSizeLimits(t *testing.T) {
+
+	sizes, err := ParseOrderSizeLimits("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(sizes))
+
+	sizes, err = ParseOrderSizeLimits("btc=0.5, ETH=10")
+	assert.NoError(t, err)
+	assert.Equal(t, map[SymbolType]float64{BTC: 0.5, ETH: 10}, sizes)
+
+	_, err = ParseOrderSizeLimits("BTC")
+	assert.Error(t, err)
+
+	_, err = ParseOrderSizeLimits("BTC=lots")
+	assert.Error(t, err)
+
+	_, err = newRiskManager(RiskConfig{MaxTradePercent: 101}, noOrderStore{})
+	assert.Error(t, err)
+
+	_, err = newRiskManager(RiskConfig{MaxOrderSize: map[SymbolType]float64{BTC: -1}}, noOrderStore{})
+	assert.Error(t, err)
+}
+
+func TestRiskLimits(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	_, err := initMockServer()
+	assert.NoError(t, err)
+
+	risk, err := newRiskManager(RiskConfig{
+		MaxOrderSize:     map[SymbolType]float64{ETH: 10},
+		MaxTradePercent:  50,
+		MaxOrdersPerHour: 2,
+	}, noOrderStore{})
+	assert.NoError(t, err)
+
+	now := servertime.Now()
+
+	// order size
+	assert.Error(t, risk.checkOrder(SELL_TRADE, ETH, 11, 1000, now))
+
+	// share of portfolio value
+	ethValue, err := valueAt(ETH, 1, now)
+	assert.NoError(t, err)
+	assert.Error(t, risk.checkOrder(SELL_TRADE, ETH, 2, ethValue*3, now))
+	assert.NoError(t, risk.checkOrder(SELL_TRADE, ETH, 1, ethValue*3, now))
+
+	// orders in an hour
+	assert.NoError(t, risk.checkOrder(BUY_TRADE, BTC, 1, 1000, now.Add(time.Minute)))
+	assert.Error(t, risk.checkOrder(BUY_TRADE, BTC, 1, 1000, now.Add(2*time.Minute)))
+	assert.NoError(t, risk.checkOrder(BUY_TRADE, BTC, 1, 1000, now.Add(time.Hour)))
+
+	status := risk.status(now.Add(time.Hour))
+	assert.Equal(t, 2, status.ordersLastHour)
+	assert.False(t, status.engaged)
+}
+
+func TestDailyLossKillSwitch(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	risk, err := newRiskManager(RiskConfig{MaxDailyLoss: 0.1}, noOrderStore{})
+	assert.NoError(t, err)
+	server.risk = risk
+	server.orders.risk = risk
+
+	now := servertime.Now()
+
+	// bought at 0.1 and sold at 0.09 is a profit of -0.1
+	assert.NoError(t, risk.recordFill(BUY_TRADE, ETH, BTC, 10, 1.0, now))
+	assert.NoError(t, risk.recordFill(SELL_TRADE, ETH, BTC, 10, 0.9, now))
+	assert.InDelta(t, -0.1, risk.status(now).realised, 0.0000001)
+	assert.False(t, risk.isEngaged())
+
+	// an open order to be cancelled
+	resting, err := DefaultClient.PlaceLimitOrder(exchanges.BUY_ORDER, string(ETH), string(BTC), 1.0, 0.00000001)
+	assert.NoError(t, err)
+	assert.NoError(t, server.orders.reconcile())
+
+	assert.NoError(t, risk.recordFill(SELL_TRADE, ETH, BTC, 1, 0.0, now))
+	assert.True(t, risk.isEngaged())
+
+	_, err = server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, 1.0, 0)
+	assert.Error(t, err)
+
+	// the next update halts live trading
+	server.executeLiveStrategies(now)
+
+	cancelled, err := DefaultClient.GetOrder(string(ETH), string(BTC), resting.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, exchanges.ORDER_CANCELED, cancelled.Status)
+
+	for _, balance := range server.livePortfolio.balances {
+		if balance.SellStrategy != nil {
+			assert.False(t, balance.SellStrategy.IsRunning())
+		}
+	}
+
+	// losses are only totalled for a day
+	assert.Equal(t, 0.0, risk.status(now.Add(24*time.Hour)).realised)
+}
+
+func TestKillSwitchRPC(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	ctx := context.Background()
+
+	_, err = server.SetKillSwitch(ctx, &proto.SetKillSwitchRequest{Engaged: true, Reason: "testing"})
+	assert.NoError(t, err)
+
+	rsp, err := server.GetStatus(ctx, &proto.GetStatusRequest{})
+	assert.NoError(t, err)
+	if assert.NotNil(t, rsp.Risk) {
+		assert.True(t, rsp.Risk.KillSwitchEngaged)
+		assert.Equal(t, "testing", rsp.Risk.KillSwitchReason)
+	}
+
+	order, err := server.orders.placeMarketOrder("test-strategy", SELL_TRADE, ETH, BTC, 1.0, 0)
+	assert.Error(t, err)
+	assert.Equal(t, ORDER_REJECTED, order.State)
+
+	_, err = server.SetKillSwitch(ctx, &proto.SetKillSwitchRequest{Engaged: false})
+	assert.NoError(t, err)
+
+	rsp, err = server.GetStatus(ctx, &proto.GetStatusRequest{})
+	assert.NoError(t, err)
+	assert.False(t, rsp.Risk.KillSwitchEngaged)
+
+	for _, balance := range server.livePortfolio.balances {
+		if balance.SellStrategy != nil {
+			assert.True(t, balance.SellStrategy.IsRunning())
+		}
+	}
+}
+
+func TestKillSwitchRestored(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	dataDir, err := ioutil.TempDir("", "teletrada-risk")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	s, err := initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+	first := s.(*server)
+
+	first.setKillSwitch(true, "testing")
+
+	// still engaged after a restart and not mistaken for an order
+	s, err = initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+	restarted := s.(*server)
+
+	status := restarted.risk.status(servertime.Now())
+	assert.True(t, status.engaged)
+	assert.Equal(t, "testing", status.reason)
+	for _, order := range restarted.orders.getOrders() {
+		assert.NotEqual(t, KILL_SWITCH_ID, order.ID)
+	}
+
+	restarted.setKillSwitch(false, "")
+
+	s, err = initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+	assert.False(t, s.(*server).risk.isEngaged())
+
+	// engaged when it can't be restored
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, ORDERS_DIR, KILL_SWITCH_ID+".json"), []byte("{"), 0644))
+	s, err = initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+	assert.True(t, s.(*server).risk.isEngaged())
+}
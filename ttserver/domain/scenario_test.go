@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestMockScenario(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "teletrada-scenario")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "scenario.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{
+		"name": "pump",
+		"seed": 7,
+		"history": "2h",
+		"balances": [{"symbol": "ETH", "free": 10}],
+		"pairs": [
+			{"base": "ETH", "as": "BTC", "price": 0.1, "shocks": [{"at": "1h", "changePercent": 100}]},
+			{"base": "BTC", "as": "USDT", "price": 10000, "process": {"type": "randomwalk", "volatility": 10}}
+		]
+	}`), 0644))
+
+	servertime.InitFakeTime()
+	servertime.UseFakeTime()
+	now := servertime.Now()
+
+	s, err := NewTradaServer(Config{
+		UseMock:      true,
+		InfluxDBName: "test-db",
+		UpdateFreq:   time.Duration(1 * time.Hour),
+		Port:         TEST_GRPC_PORT,
+		Scenario:     path,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Init())
+	defer s.stopScheduler()
+
+	// history before the pump
+	price, err := DefaultArchive.GetPriceAs(ETH, BTC, now.Add(-90*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, price.Price)
+
+	price, err = DefaultArchive.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.2, price.Price)
+
+	balances, err := DefaultClient.GetCoinBalances()
+	assert.NoError(t, err)
+	assert.Equal(t, []exchanges.CoinBalance{{Symbol: "ETH", Free: 10, Exchange: exchanges.MOCK_EXCHANGE}}, balances)
+
+	// the market moves with server time
+	servertime.TickFakeTime(10 * time.Minute)
+	prices, err := DefaultClient.GetLatestPrices()
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(9*time.Minute), prices[0].At)
+
+	// the scenario file must exist
+	_, err = NewTradaServer(Config{UseMock: true, UpdateFreq: time.Hour, Scenario: filepath.Join(dir, "missing.json")})
+	assert.Error(t, err)
+}
@@ -15,6 +15,12 @@ func (s *server) startScheduler() {
 	s.dailyUpdate()
 	log.Printf("Initialisation complete")
 
+	if s.config.ReplayDir != "" {
+		// updates are driven by the recording instead of the clock
+		go s.replayUpdates()
+		return
+	}
+
 	// this update runs every x seconds
 	go func(frequency time.Duration) {
 		updateTicker := time.NewTicker(frequency)
@@ -66,18 +72,24 @@ func (s *server) startScheduler() {
 
 func (s *server) stopScheduler() {
 	s.Lock()
+	if s.stopStream != nil {
+		s.stopStream()
+	}
 	s.stopUpdate <- true
 	s.Unlock()
 }
 
 // scheduledUpdate - runs every x seconds
 func (s *server) scheduledUpdate() {
+	s.updating.Lock()
+	defer s.updating.Unlock()
 
 	// Update latest prices
 	if err := DefaultArchive.UpdatePrices(); err != nil {
 		// log error
 		DefaultLogger.log(fmt.Sprintf("ERROR: updating prices - %s", err))
 	}
+	s.updateExchangePrices(false)
 
 	// update portfolios
 	if err := s.updatePortfolios(); err != nil {
@@ -85,6 +97,15 @@ func (s *server) scheduledUpdate() {
 		DefaultLogger.log(fmt.Sprintf("ERROR: updating portfolios - %s", err))
 	}
 
+	// evaluate realtime simulations at latest prices
+	s.updateRealtimeSimulations(servertime.Now())
+
+	// bring live orders up to date then trade any live strategies triggered
+	if err := s.orders.refreshOrders(); err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: refreshing orders - %s", err))
+	}
+	s.executeLiveStrategies(servertime.Now())
+
 	if err := s.saveMetrics(); err != nil {
 		// log error
 		DefaultLogger.log(fmt.Sprintf("ERROR: saving portfolios - %s", err))
@@ -103,6 +124,21 @@ func (s *server) dailyUpdate() {
 	if err := DefaultArchive.UpdateDaySummaries(); err != nil {
 		DefaultLogger.log(fmt.Sprintf("ERROR: updating closing prices - %s", err))
 	}
+	s.updateExchangePrices(true)
+	s.compactPrices(servertime.Now())
 	DefaultLogger.log("ended Daily update")
 
 }
+
+// compactPrices - compacts the stored prices and removes those older than the retention
+// from the archive, the database archive only caches recent prices so it is left alone
+func (s *server) compactPrices(now time.Time) {
+	if err := s.prices.compact(now); err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: compacting stored prices - %s", err))
+		return
+	}
+
+	if archive, ok := DefaultArchive.(*symbolsArchive); ok {
+		archive.removePricesBefore(s.prices.retainedFrom(now))
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -28,13 +29,27 @@ type server struct {
 	sync.RWMutex
 	livePortfolio *portfolio             // This represents the real live portfolio on the exchange
 	simulations   map[string]*simulation // These represent alternate simulated portfolios and their total values
+	strategies    map[string]Strategy    // Strategies created to be attached to simulations
 	config        Config
+	costs         CostModel            // costs applied to simulated trades
+	store         simulationStore      // saves simulations between restarts
+	prices        priceStore           // saves archived prices between restarts
+	orders        *orderManager        // raises orders for the live portfolio
+	risk          *riskManager         // limits orders for the live portfolio
+	backfills     map[string]*backfill // downloads of historical prices
+	stream        *priceStream         // prices pushed by the exchange
+	updating      sync.Mutex           // one scheduled or streamed update at a time
+
+	// other exchanges
+	accounts map[string]*exchangeAccount // balances held on exchanges other than the primary one
 
 	// status
 	startTime time.Time
+	replayEnd time.Time // time of the last recorded response when replaying
 
 	// scheduling
 	stopUpdate chan bool
+	stopStream context.CancelFunc
 }
 
 type Config struct {
@@ -45,6 +60,22 @@ type Config struct {
 	UpdateFreq     time.Duration
 	Verbose        bool
 	Port           int
+	Costs          CostConfig
+	DataDir        string  // where simulations and orders are saved, not saved if empty
+	DryRun         bool    // log live orders instead of sending them to the exchange
+	RepairGaps     bool    // backfill gaps in the price archive at startup
+	StreamPrices   bool    // update as prices are pushed by the exchange as well as polling
+	RecordDir      string  // where exchange responses are recorded, not recorded if empty
+	ReplayDir      string  // replay a recording instead of using the exchange if not empty
+	ReplaySpeed    float64 // how many times faster than real time a recording is replayed
+	Scenario       string  // file of the market the mock exchange runs, the default market if empty
+	Risk           RiskConfig
+	Resilience     exchanges.ResilienceConfig // limits and retries of requests to the exchange
+	Exchanges      []string                   // other exchanges whose balances are tracked
+	PriceRetention time.Duration              // how long stored prices are kept, forever if 0
+	LoadPricesDir  string                     // dir of .json price files loaded at startup
+	Archive        string                     // where prices are archived, ARCHIVE_MEMORY if empty
+	ArchiveCache   time.Duration              // how long recent prices are held in memory by ARCHIVE_BOLT
 }
 
 func NewTradaServer(config Config) (Server, error) {
@@ -53,8 +84,42 @@ func NewTradaServer(config Config) (Server, error) {
 
 	DefaultArchive = NewSymbolsArchive()
 
+	if config.UpdateFreq > 0 {
+		setMaxPriceGap(config.UpdateFreq * PRICE_GAP_FACTOR)
+	}
+
+	switch config.Archive {
+	case "", ARCHIVE_MEMORY:
+	case ARCHIVE_BOLT:
+		if config.UseMock || config.ReplayDir != "" {
+			return nil, fmt.Errorf("Prices can only be archived in a database for the live exchange")
+		}
+		if config.DataDir == "" {
+			return nil, fmt.Errorf("A data dir is needed to archive prices in a database")
+		}
+	default:
+		return nil, fmt.Errorf("Archive %q is not supported", config.Archive)
+	}
+
 	var err error
-	if config.UseMock {
+	var replayEnd time.Time
+	var prices priceStore = noPriceStore{}
+	switch {
+	case config.ReplayDir != "":
+		if config.ReplaySpeed <= 0 {
+			return nil, fmt.Errorf("Replay speed must be more than 0")
+		}
+		replayEnd, err = initReplayClients(config.ReplayDir)
+		if err != nil {
+			return nil, err
+		}
+
+	case config.UseMock && config.Scenario != "":
+		if err := initScenarioClients(config.Scenario); err != nil {
+			return nil, err
+		}
+
+	case config.UseMock:
 		latestPrices, err := initMockPriceHistory(proto.StartSimulationRequest_LAST_DAY)
 		if err != nil {
 			return nil, err
@@ -65,8 +130,12 @@ func NewTradaServer(config Config) (Server, error) {
 			return nil, err
 		}
 
-	} else {
-		DefaultClient, err = exchanges.NewBinanceClient()
+	default:
+		binanceClient, err := exchanges.NewBinanceClient()
+		if err != nil {
+			return nil, err
+		}
+		DefaultClient, err = exchanges.NewResilientClient(binanceClient, config.Resilience)
 		if err != nil {
 			return nil, err
 		}
@@ -74,12 +143,75 @@ func NewTradaServer(config Config) (Server, error) {
 		if err != nil {
 			return nil, err
 		}
+		// only prices from the real exchange are kept
+		prices, DefaultArchive, err = newLiveArchive(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config.LoadPricesDir != "" {
+		if err := DefaultArchive.LoadPrices(config.LoadPricesDir); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.RecordDir != "" {
+		DefaultClient, err = exchanges.NewRecordingClient(DefaultClient, config.RecordDir, serverNow)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.Exchanges) > 0 && config.ReplayDir != "" {
+		return nil, fmt.Errorf("Other exchanges cannot be tracked while replaying")
+	}
+
+	costs, err := NewCostModel(config.Costs)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := newSimulationStore(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := newOrderStore(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	risk, err := newRiskManager(config.Risk, orders)
+	if err != nil {
+		return nil, err
 	}
 
 	server := &server{
-		config:     config,
-		startTime:  servertime.Now(),
-		stopUpdate: make(chan bool),
+		config:      config,
+		costs:       costs,
+		store:       store,
+		prices:      prices,
+		orders:      newOrderManager(config.DryRun, orders, risk),
+		risk:        risk,
+		simulations: make(map[string]*simulation),
+		strategies:  make(map[string]Strategy),
+		backfills:   make(map[string]*backfill),
+		stream:      newPriceStream(),
+		startTime:   servertime.Now(),
+		replayEnd:   replayEnd,
+		stopUpdate:  make(chan bool),
+		accounts:    make(map[string]*exchangeAccount),
+	}
+
+	for _, name := range config.Exchanges {
+		client, err := newExchangeClient(name, config.Resilience)
+		if err != nil {
+			return nil, err
+		}
+		if err := server.addExchange(client); err != nil {
+			return nil, err
+		}
 	}
 
 	return server, nil
@@ -91,12 +223,36 @@ func (s *server) Init() error {
 
 	s.startTime = servertime.Now()
 
+	// the kill switch must be restored before the scheduler runs live strategies
+	s.risk.restoreKillSwitch()
+
 	// scheduler will do a price update immediately
 	s.startScheduler()
 
 	if err := s.initPortfolios(); err != nil {
 		DefaultLogger.log(fmt.Sprintf("Failed to initialise portfolio: %s", err))
 	}
+	s.updateExchangePortfolios()
+
+	if err := s.orders.reconcile(); err != nil {
+		DefaultLogger.log(fmt.Sprintf("Failed to reconcile orders: %s", err))
+	}
+
+	if err := s.loadStrategies(); err != nil {
+		DefaultLogger.log(fmt.Sprintf("Failed to load strategies: %s", err))
+	}
+
+	if err := s.loadSimulations(); err != nil {
+		DefaultLogger.log(fmt.Sprintf("Failed to load simulations: %s", err))
+	}
+
+	if s.config.StreamPrices {
+		s.startPriceStream()
+	}
+
+	if s.config.RepairGaps {
+		s.repairPriceGaps(s.findPriceGaps("", ""))
+	}
 
 	// // TEMP code create simulation
 
@@ -31,6 +31,11 @@ var _ltcAsLtc = 1.0     // 1:1
 var _ltcAsUsdt = 180.00 // $180
 
 func initMockServer() (Server, error) {
+	return initMockServerWithDataDir("")
+}
+
+// initMockServerWithDataDir - creates a mock server that saves simulations in dataDir
+func initMockServerWithDataDir(dataDir string) (Server, error) {
 
 	servertime.InitFakeTime()
 	// override server time func
@@ -52,6 +57,7 @@ func initMockServer() (Server, error) {
 		UpdateFreq:     time.Duration(1 * time.Hour),
 		Verbose:        true,
 		Port:           TEST_GRPC_PORT,
+		DataDir:        dataDir,
 	}
 
 	server, err := NewTradaServer(config)
@@ -77,44 +83,31 @@ func mockCoinBalances() []exchanges.CoinBalance {
 	}
 }
 
-func goingUp(currentPrice, upwardsInc float64) func() float64 {
-	// returns a funtion that starts at current price and will increase with each call
-	return func() float64 {
-		currentPrice += upwardsInc
-		return currentPrice
-	}
-}
-
-func goingDown(currentPrice, downwardsDec float64) func() float64 {
-	// returns a funtion that starts at current price and will decrease with each call
-	return func() float64 {
-		currentPrice -= downwardsDec
-		if currentPrice <= 0 {
-			// default to min price
-			currentPrice = 0.0000001
-		}
-		return currentPrice
-	}
-}
-
-func goingUpDown(currentPrice, upDownInc, upDownMin, upDownMax float64, goingUp bool) func() float64 {
-	return func() float64 {
-		if goingUp {
-			// going up
-			currentPrice += upDownInc
-			if currentPrice > upDownMax {
-				currentPrice = upDownMax
-				goingUp = false
-			}
-		} else {
-			// going down
-			currentPrice -= upDownInc
-			if currentPrice < upDownMin {
-				currentPrice = upDownMin
-				goingUp = true
-			}
-		}
-		return currentPrice
+// defaultMockScenario - the mock market used in all the simulation & portfolio tests. Be careful when you change it
+func defaultMockScenario() exchanges.Scenario {
+	flat := exchanges.ScenarioProcess{Type: exchanges.PROCESS_FLAT}
+
+	return exchanges.Scenario{
+		Name:     "default",
+		Interval: exchanges.ScenarioDuration(1 * time.Minute),
+		Balances: mockCoinBalances(),
+		Pairs: []exchanges.ScenarioPair{
+			// BTC
+			{Base: string(BTC), As: string(BTC), Price: _btcAsBtc, Process: flat},
+			{Base: string(BTC), As: string(ETH), Price: _btcAsEth, Process: exchanges.ScenarioProcess{Type: exchanges.PROCESS_TREND, Step: 0.00001}},
+			{Base: string(BTC), As: string(LTC), Price: _btcAsLtc, Process: flat},
+			{Base: string(BTC), As: string(USDT), Price: _btcAsUsdt, Process: flat},
+			// ETH
+			{Base: string(ETH), As: string(BTC), Price: _ethAsBtc, Process: exchanges.ScenarioProcess{Type: exchanges.PROCESS_TREND, Step: -0.00001}},
+			{Base: string(ETH), As: string(ETH), Price: _ethAsEth, Process: flat},
+			{Base: string(ETH), As: string(LTC), Price: _ethAsLtc, Process: flat},
+			{Base: string(ETH), As: string(USDT), Price: _ethAsUsdt, Process: flat},
+			// LTC
+			{Base: string(LTC), As: string(BTC), Price: _ltcAsBtc, Process: flat},
+			{Base: string(LTC), As: string(ETH), Price: _ltcAsEth, Process: exchanges.ScenarioProcess{Type: exchanges.PROCESS_BOUNCE, Step: 0.01, Min: _ltcAsEth / 2.0, Max: _ltcAsEth * 2.0}},
+			{Base: string(LTC), As: string(LTC), Price: _ltcAsLtc, Process: flat},
+			{Base: string(LTC), As: string(USDT), Price: _ltcAsUsdt, Process: flat},
+		},
 	}
 }
 
@@ -137,110 +130,75 @@ func initMockPriceHistory(when proto.StartSimulationRequestWhenOptions) ([]excha
 		return nil, fmt.Errorf("When value %d is not valid", when)
 	}
 
-	type baseAs struct {
-		base  SymbolType
-		as    SymbolType
-		price func() float64
+	scenario := defaultMockScenario()
+	scenario.History = exchanges.ScenarioDuration(toTime.Sub(fromTime))
+
+	market, err := exchanges.NewScenarioMarket(scenario, toTime)
+	if err != nil {
+		return nil, err
 	}
 
-	priceTypes := []baseAs{
-		// BTC
-		baseAs{base: BTC, as: BTC, price: func() float64 { return _btcAsBtc }},
-		//baseAs{base: BTC, as: ETH, price: func() float64 { return _btcAsEth }},
-		baseAs{base: BTC, as: ETH, price: goingUp(_btcAsEth, 0.00001)},
-		baseAs{base: BTC, as: LTC, price: func() float64 { return _btcAsLtc }},
-		baseAs{base: BTC, as: USDT, price: func() float64 { return _btcAsUsdt }},
-		// ETH
-		//baseAs{base: ETH, as: BTC, price: func() float64 { return _ethAsBtc }},
-		baseAs{base: ETH, as: BTC, price: goingDown(_ethAsBtc, 0.00001)},
-		baseAs{base: ETH, as: ETH, price: func() float64 { return _ethAsEth }},
-		baseAs{base: ETH, as: LTC, price: func() float64 { return _ethAsLtc }},
-		baseAs{base: ETH, as: USDT, price: func() float64 { return _ethAsUsdt }},
-		// LTC
-		baseAs{base: LTC, as: BTC, price: func() float64 { return _ltcAsBtc }},
-		//baseAs{base: LTC, as: ETH, price: func() float64 { return _ltcAsEth }},
-		baseAs{base: LTC, as: ETH, price: goingUpDown(_ltcAsEth, 0.01, _ltcAsEth/2.0, _ltcAsEth*2.0, true)},
-		baseAs{base: LTC, as: LTC, price: func() float64 { return _ltcAsLtc }},
-		baseAs{base: LTC, as: USDT, price: func() float64 { return _ltcAsUsdt }},
+	if err := addScenarioPrices(market.Generate(toTime)); err != nil {
+		return nil, err
 	}
 
-	dataFrequency := time.Duration(1 * time.Minute)
-
-	for priceTime := fromTime; priceTime.Before(toTime); priceTime = priceTime.Add(dataFrequency) {
-		// update all symbols in portfolio
-
-		for _, priceType := range priceTypes {
-			price := Price{
-				Base:     priceType.base,
-				As:       priceType.as,
-				At:       priceTime,
-				Price:    priceType.price(),
-				Exchange: "test-exchange",
-			}
-			err := DefaultArchive.AddPrice(price)
-			if err != nil {
-				return nil, err
-			}
-		}
+	return market.Latest(), nil
+}
+
+// initScenarioClients - creates a mock exchange that moves with the scenario in a file, with
+// the history of the scenario in the archive
+func initScenarioClients(path string) error {
+	scenario, err := exchanges.LoadScenario(path)
+	if err != nil {
+		return err
 	}
 
-	// type priceFunc func() float64
-
-	// 	upwardsPrice := 1.0
-	// 	upwardsInc := 0.0001
-	// 	downwardsPrice := 100.0
-	// 	downwardsDec := 0.0001
-	// 	upDownPrice := 50.0
-	// 	upDownGoingUp := true
-	// 	upDownMax := 75.00
-	// 	upDownMin := 25.00
-	// 	upDownInc := 0.02
-
-	// 	goingUpAndDown := func() float64 {
-	// 		if upDownGoingUp {
-	// 			// going up
-	// 			upDownPrice += upDownInc
-	// 			if upDownPrice > upDownMax {
-	// 				upDownPrice = upDownMax
-	// 				upDownGoingUp = false
-	// 			}
-	// 		} else {
-	// 			// going down
-	// 			upDownPrice -= upDownInc
-	// 			if upDownPrice < upDownMin {
-	// 				upDownPrice = upDownMin
-	// 				upDownGoingUp = true
-	// 			}
-	// 		}
-	// 		return upDownPrice
-	// 	}
-
-	// 	pricers := map[SymbolType]priceFunc{
-	// 		BTC: goingUp,
-	// 		ETH: goingDown,
-	// 		LTC: goingUpAndDown,
-	// 	}
-
-	// symbols := make([]SymbolType, len(s.livePortfolio.balances))
-	// i := 0
-	// for key, _ := range s.livePortfolio.balances {
-	// 	symbols[i] = key
-	// 	i++
-	// }
-
-	// accumulate latest prices from archive
-	latestPrices := make([]exchanges.Price, 0)
-
-	for _, priceType := range priceTypes {
-		price, err := DefaultArchive.GetLatestPriceAs(priceType.base, priceType.as)
-		if err != nil {
-			return nil, err
-		}
+	now := servertime.Now()
+
+	market, err := exchanges.NewScenarioMarket(scenario, now)
+	if err != nil {
+		return err
+	}
+
+	if err := addScenarioPrices(market.Generate(now)); err != nil {
+		return err
+	}
 
-		latestPrices = append(latestPrices, price.toExchangePrice())
+	DefaultClient, err = exchanges.NewScenarioClient(scenario, market, serverNow)
+	if err != nil {
+		return err
+	}
+	DefaultMetrics, err = newMockMetricsClient(TEST_INFLUX_DATABASE)
+	if err != nil {
+		return err
 	}
 
-	return latestPrices, nil
+	DefaultLogger.log(fmt.Sprintf("Mock exchange running scenario %q with seed %d", scenario.Name, scenario.Seed))
+
+	return nil
+}
+
+// addScenarioPrices - adds generated prices to the archive
+func addScenarioPrices(exPrices []exchanges.Price) error {
+	byBase := make(map[SymbolType][]Price)
+	for _, exPrice := range exPrices {
+		base := SymbolType(exPrice.Base)
+		byBase[base] = append(byBase[base], Price{
+			Base:     base,
+			As:       SymbolType(exPrice.As),
+			Price:    exPrice.Price,
+			At:       exPrice.At,
+			Exchange: exPrice.Exchange,
+		})
+	}
+
+	for base, prices := range byBase {
+		if _, err := DefaultArchive.AddPrices(base, prices); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func initMockClients(prices []exchanges.Price) error {
@@ -3,8 +3,10 @@ package domain
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	tspb "github.com/golang/protobuf/ptypes"
 	"github.com/telecoda/teletrada/proto"
 	"github.com/telecoda/teletrada/ttserver/servertime"
 	"google.golang.org/grpc/codes"
@@ -27,6 +29,12 @@ apply Strategy to all coin balances
 
 */
 
+// DEFAULT_DATA_FREQUENCY - how often historical prices are sampled when no frequency is requested
+const DEFAULT_DATA_FREQUENCY = time.Duration(5 * time.Minute)
+
+// SIMULATION_SAVE_INTERVAL - how often a running realtime simulation is saved between trades
+const SIMULATION_SAVE_INTERVAL = time.Duration(5 * time.Minute)
+
 type simulation struct {
 	id          string
 	name        string
@@ -52,7 +60,23 @@ type simulation struct {
 	simToTime         *time.Time    // when does data end
 	dataFrequency     time.Duration // what frequency do we sample the data (normally captured once per minute)
 
+	// realtime simulation attributes
+	// the scheduler reads these under realtime as the simulation's lock is
+	// held through a historical run, they are written under both
+	realtime        sync.RWMutex
 	useRealtimeData bool
+	priceUpdates    chan time.Time // notified after each scheduled price update
+	stopRealtime    chan bool      // closed when the simulation is stopped
+
+	// simulated trading
+	engine *orderEngine // executes trades when strategies trigger
+	trades tradeHistory // trades executed during the simulation
+
+	// results
+	benchmark *portfolio    // portfolio at start held unchanged
+	equity    []equityPoint // value of the portfolio and benchmark at each step
+
+	store simulationStore // saves the simulation between restarts
 }
 
 func (s *server) getSimulation(id string) (*simulation, error) {
@@ -93,6 +117,8 @@ func (s *server) CreateSimulation(ctx context.Context, req *proto.CreateSimulati
 		return nil, err
 	}
 
+	sim.save()
+
 	pSim, err := sim.toProto()
 	if err != nil {
 		return nil, err
@@ -131,43 +157,58 @@ func (s *server) StartSimulation(ctx context.Context, req *proto.StartSimulation
 
 	now := servertime.Now()
 
+	if req.DataFrequency < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Data frequency cannot be negative")
+	}
+
+	// the simulation is only changed once the whole request is valid
+	frequency := DEFAULT_DATA_FREQUENCY
+	if req.DataFrequency > 0 {
+		frequency = time.Duration(req.DataFrequency) * time.Second
+	}
+
+	// mode of this run
+	historical := false
+	realtime := false
+	var from, to time.Time
+
 	switch req.When {
+	case proto.StartSimulationRequest_CUSTOM:
+		from, to, err = validateSimulationDates(req)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+		if frequency > to.Sub(from) {
+			return nil, status.Errorf(codes.InvalidArgument, "Data frequency %s is longer than the simulation period", frequency)
+		}
+		historical = true
 	case proto.StartSimulationRequest_LAST_DAY:
-		sim.simToTime = &now
-		from := now.AddDate(0, 0, -1)
-		sim.simFromTime = &from
-		sim.useHistoricalData = true
+		from, to = now.AddDate(0, 0, -1), now
+		historical = true
 	case proto.StartSimulationRequest_LAST_WEEK:
-		sim.simToTime = &now
-		from := now.AddDate(0, 0, -7)
-		sim.simFromTime = &from
-		sim.useHistoricalData = true
+		from, to = now.AddDate(0, 0, -7), now
+		historical = true
 	case proto.StartSimulationRequest_LAST_MONTH:
-		sim.simToTime = &now
-		from := now.AddDate(0, 0, -30)
-		sim.simFromTime = &from
-		sim.useHistoricalData = true
+		from, to = now.AddDate(0, 0, -30), now
+		historical = true
 	case proto.StartSimulationRequest_THE_LOT:
-		sim.simToTime = &now
-		from := now.AddDate(-10, 0, 0) // 10 year should be long enough..
-		sim.simFromTime = &from
-		sim.useHistoricalData = true
+		from, to = now.AddDate(-10, 0, 0), now // 10 year should be long enough..
+		historical = true
 	case proto.StartSimulationRequest_NOW_REALTIME:
-		sim.useRealtimeData = true
+		realtime = true
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "When value %d is not valid", req.When)
 	}
 
-	if req.When == proto.StartSimulationRequest_NOW_REALTIME {
-		sim.useRealtimeData = true
-		sim.useHistoricalData = false
+	if req.When != proto.StartSimulationRequest_CUSTOM && (req.FromTime != nil || req.ToTime != nil) {
+		return nil, status.Errorf(codes.InvalidArgument, "From and to times can only be used with %s", proto.StartSimulationRequest_CUSTOM)
 	}
 
-	if !sim.useHistoricalData && !sim.useRealtimeData {
+	if !historical && !realtime {
 		return nil, status.Errorf(codes.Unavailable, "Must enable either historical or realtime data")
 	}
 
-	if sim.useHistoricalData && sim.useRealtimeData {
+	if historical && realtime {
 		return nil, status.Errorf(codes.Unavailable, "Simulation cannot be run in historical and realtime mode simultaneously")
 	}
 
@@ -180,6 +221,21 @@ func (s *server) StartSimulation(ctx context.Context, req *proto.StartSimulation
 	}
 
 	sim.realAtStart = realAtStart
+	sim.dataFrequency = frequency
+	if historical {
+		sim.simFromTime = &from
+		sim.simToTime = &to
+	}
+	// a previous run may have used the other mode
+	sim.useHistoricalData = historical
+	sim.setRealtime(realtime)
+
+	if realtime {
+		if err := sim.startResults(); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to start simulation results - %s", err)
+		}
+		sim.stopRealtime = make(chan bool)
+	}
 
 	go sim.run()
 
@@ -188,6 +244,39 @@ func (s *server) StartSimulation(ctx context.Context, req *proto.StartSimulation
 	return resp, nil
 }
 
+// validateSimulationDates - checks requested dates are within the range of prices held in the archive
+func validateSimulationDates(req *proto.StartSimulationRequest) (time.Time, time.Time, error) {
+	if req.FromTime == nil || req.ToTime == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("You must provide a from and to time")
+	}
+
+	from, err := tspb.Timestamp(req.FromTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("From time is not valid - %s", err)
+	}
+
+	to, err := tspb.Timestamp(req.ToTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("To time is not valid - %s", err)
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("From time must be before to time")
+	}
+
+	archiveFrom, archiveTo, err := DefaultArchive.GetPriceRange()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if from.Before(archiveFrom) || to.After(archiveTo) {
+		return time.Time{}, time.Time{}, fmt.Errorf("Simulation from %s to %s is outside of archived prices from %s to %s",
+			from.Format(time.RFC3339), to.Format(time.RFC3339), archiveFrom.Format(time.RFC3339), archiveTo.Format(time.RFC3339))
+	}
+
+	return from, to, nil
+}
+
 // StopSimulation stops a simulation running
 func (s *server) StopSimulation(ctx context.Context, req *proto.StopSimulationRequest) (*proto.StopSimulationResponse, error) {
 	resp := &proto.StopSimulationResponse{}
@@ -212,6 +301,11 @@ func (s *server) StopSimulation(ctx context.Context, req *proto.StopSimulationRe
 	now := servertime.Now()
 	sim.stoppedTime = &now
 
+	if sim.stopRealtime != nil {
+		close(sim.stopRealtime)
+		sim.stopRealtime = nil
+	}
+
 	s.setSimulation(sim)
 
 	DefaultLogger.log(fmt.Sprintf("Simulation: %s stop requested", sim.id))
@@ -245,6 +339,8 @@ func (s *server) newSimulation(id, simName string) (*simulation, error) {
 		name:      simName,
 		portfolio: clonedPort,
 		realNow:   real,
+		engine:    newOrderEngine(s.costs),
+		store:     s.store,
 	}
 
 	s.simulations[id] = sim
@@ -263,8 +359,10 @@ func (s *simulation) SetBuyStrategy(strategy Strategy) error {
 		return fmt.Errorf("Cannot set buy strategy for simulation %q on symbol %q, not in portfolio", s.name, strategy.Symbol())
 	} else {
 		symbol.Lock()
+		replaced := symbol.BuyStrategy
 		symbol.BuyStrategy = strategy
 		symbol.Unlock()
+		stopReplaced(replaced, strategy)
 	}
 	return nil
 }
@@ -280,21 +378,38 @@ func (s *simulation) SetSellStrategy(strategy Strategy) error {
 		return fmt.Errorf("Cannot set sell strategy for simulation %q on symbol %q, not in portfolio", s.name, strategy.Symbol())
 	} else {
 		symbol.Lock()
+		replaced := symbol.SellStrategy
 		symbol.SellStrategy = strategy
 		symbol.Unlock()
+		stopReplaced(replaced, strategy)
 	}
 	return nil
 }
 
 func (s *simulation) run() {
-
 	s.Lock()
-	s.isRunning = true
 	now := servertime.Now()
 	s.startedTime = &now
+	s.Unlock()
+
+	s.runSimulation(false)
+}
+
+// resume - carries on running a saved simulation after a restart, keeping the time
+// it was started and the state of its strategies
+func (s *simulation) resume() {
+	s.runSimulation(true)
+}
+
+func (s *simulation) runSimulation(resumed bool) {
+
+	s.Lock()
+	s.isRunning = true
 	// take a copy of portfolio at start
 	s.Unlock()
 
+	s.save()
+
 	// sleep a little at the start
 	// just to help the tests do little check...
 	time.Sleep(500 * time.Millisecond)
@@ -305,11 +420,15 @@ func (s *simulation) run() {
 		now := servertime.Now()
 		s.stoppedTime = &now
 		s.Unlock()
+
+		s.save()
 	}()
 
 	if s.useHistoricalData {
 
-		frequency := time.Duration(5 * time.Minute)
+		s.RLock()
+		frequency := s.dataFrequency
+		s.RUnlock()
 
 		err := s.runOverHistory(frequency)
 		if err != nil {
@@ -319,8 +438,8 @@ func (s *simulation) run() {
 
 	}
 
-	if s.useRealtimeData {
-		err := s.runRealtime()
+	if s.isRealtime() {
+		err := s.runRealtime(resumed)
 		if err != nil {
 			DefaultLogger.log(fmt.Sprintf("Error running realtime simulation: %s - %s", s.id, err))
 			return
@@ -360,12 +479,13 @@ func (s *simulation) runOverHistory(frequency time.Duration) error {
 
 	DefaultLogger.log(fmt.Sprintf("Historical simulation: %s started", s.id))
 
-	// Save a before version of the portfolio
-	before, err := s.portfolio.clone()
-	if err != nil {
-		return fmt.Errorf("Error cloning portfolio during historical simulation: %s - %s", s.id, err)
-
+	if err := s.startResults(); err != nil {
+		return fmt.Errorf("Error starting results for historical simulation: %s - %s", s.id, err)
 	}
+
+	s.portfolio.startStrategies()
+	defer s.portfolio.stopStrategies()
+
 	// Replay all prices between dates
 	toTime := *s.simToTime
 
@@ -377,50 +497,223 @@ func (s *simulation) runOverHistory(frequency time.Duration) error {
 
 		// now coins have correct price for time
 		// execute strategies
+		if err := s.executeStrategies(priceTime, DefaultArchive.GetPriceAs); err != nil {
+			return err
+		}
 
-		for symbol, balance := range s.portfolio.balances {
-			if balance.SellStrategy != nil {
-				// exec Sell strat
-				sell, err := balance.SellStrategy.ConditionMet(priceTime)
-				if err != nil {
-					return fmt.Errorf("Error executing sell strategy for symbol: %s - %s", symbol, err)
-				}
-				if sell {
-					// sell, Sell, SELL!
-				}
-			}
-			if balance.BuyStrategy != nil {
-				// exec Buy strat
-			}
+		if err := s.benchmark.repriceAt(priceTime); err != nil {
+			return fmt.Errorf("Error repriced benchmark portfolio at: %s - %s", priceTime.String(), err)
 		}
 
-		// DefaultLogger.log(fmt.Sprintf("Reading prices for %s", priceTime))
-		// process all symbols in portfolio
-		//for s.portfolio
+		s.recordEquity(priceTime)
+	}
+
+	result, err := newSimulationResult(s.id, s.equity, s.trades.trades)
+	if err != nil {
+		return err
+	}
+
+	DefaultLogger.log(fmt.Sprintf("Historical simulation: %s ended - return: %.2f%% benchmark: %.2f%% trades: %d",
+		s.id, result.totalReturn, result.benchmarkReturn, result.trades))
+	return nil
+}
 
+// startResults - clears results of any previous run and takes a copy of the
+// portfolio to benchmark against
+func (s *simulation) startResults() error {
+	base := s.realAtStart
+	if base == nil {
+		base = s.portfolio
 	}
 
-	// Compare portfolio afterwards
-	diff, err := s.portfolio.diff(before)
+	benchmark, err := base.clone()
 	if err != nil {
-		return fmt.Errorf("Error comparing portfolio differences - %s", err)
+		return err
 	}
 
-	// print portfolio diffs
-	if true == false {
-		// TEMP: skip for now
-		diff.print()
+	s.benchmark = benchmark
+	s.equity = make([]equityPoint, 0)
+	s.trades = tradeHistory{}
+
+	return nil
+}
+
+// priceFunc - returns the price trades are executed at
+type priceFunc func(base SymbolType, as SymbolType, at time.Time) (Price, error)
+
+// latestPrice - trades realtime simulations at the latest prices
+func latestPrice(base SymbolType, as SymbolType, at time.Time) (Price, error) {
+	return DefaultArchive.GetLatestPriceAs(base, as)
+}
+
+// executeStrategies - evaluates buy/sell strategies and trades when their conditions are met
+func (s *simulation) executeStrategies(at time.Time, priceAt priceFunc) error {
+
+	// take a copy of the balances as trading may add new ones
+	balances := make([]*BalanceAs, 0, len(s.portfolio.balances))
+	for _, balance := range s.portfolio.balances {
+		balances = append(balances, balance)
+	}
+
+	for _, balance := range balances {
+		if balance.SellStrategy != nil {
+			sell, err := balance.SellStrategy.ConditionMet(at)
+			if err != nil {
+				return fmt.Errorf("Error executing sell strategy for symbol: %s - %s", balance.Symbol, err)
+			}
+			if sell {
+				// sell, Sell, SELL!
+				s.executeTrade(balance.SellStrategy, at, priceAt, s.engine.executeSell)
+			}
+		}
+		if balance.BuyStrategy != nil {
+			buy, err := balance.BuyStrategy.ConditionMet(at)
+			if err != nil {
+				return fmt.Errorf("Error executing buy strategy for symbol: %s - %s", balance.Symbol, err)
+			}
+			if buy {
+				s.executeTrade(balance.BuyStrategy, at, priceAt, s.engine.executeBuy)
+			}
+		}
 	}
 
-	DefaultLogger.log(fmt.Sprintf("Historical simulation: %s ended", s.id))
 	return nil
 }
 
-func (s *simulation) runRealtime() error {
+type executeFunc func(p *portfolio, strategy Strategy, price Price) (trade, error)
+
+// executeTrade - executes a trade at the price for the strategy's trading pair
+// trades that cannot be executed are logged and skipped
+func (s *simulation) executeTrade(strategy Strategy, at time.Time, priceAt priceFunc, execute executeFunc) {
+	price, err := priceAt(strategy.Symbol(), strategy.As(), at)
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("Simulation: %s strategy: %s trade skipped - %s", s.id, strategy.ID(), err))
+		return
+	}
+
+	t, err := execute(s.portfolio, strategy, price)
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("Simulation: %s strategy: %s trade skipped - %s", s.id, strategy.ID(), err))
+		return
+	}
+
+	s.trades.add(t)
+	DefaultLogger.log(fmt.Sprintf("Simulation: %s %s %f %s @ %f %s", s.id, t.side, t.quantity, t.symbol, t.price, t.as))
+}
+
+// runRealtime - paper trades against the latest prices until the simulation is stopped,
+// a resumed simulation's strategies carry on with the state they were saved with
+func (s *simulation) runRealtime(resumed bool) error {
+	s.Lock()
+	priceUpdates := s.priceUpdates
+	stopRealtime := s.stopRealtime
+	started := s.benchmark != nil
+	if started && resumed {
+		s.portfolio.resumeStrategies()
+	} else if started {
+		s.portfolio.startStrategies()
+	}
+	s.Unlock()
+
+	if priceUpdates == nil || stopRealtime == nil || !started {
+		return fmt.Errorf("Realtime simulation: %s has not been started", s.id)
+	}
+
+	defer func() {
+		s.Lock()
+		s.portfolio.stopStrategies()
+		s.Unlock()
+	}()
+
 	DefaultLogger.log(fmt.Sprintf("Realtime simulation: %s started", s.id))
 
-	DefaultLogger.log(fmt.Sprintf("Realtime simulation: %s ended", s.id))
-	return nil
+	// saving every price update would rewrite the whole simulation each time
+	saveTicker := time.NewTicker(SIMULATION_SAVE_INTERVAL)
+	defer saveTicker.Stop()
+
+	for {
+		select {
+		case <-stopRealtime:
+			DefaultLogger.log(fmt.Sprintf("Realtime simulation: %s ended", s.id))
+			return nil
+		case <-saveTicker.C:
+			s.save()
+		case at := <-priceUpdates:
+			traded, err := s.tradeRealtime(at)
+			if err != nil {
+				DefaultLogger.log(fmt.Sprintf("Error updating realtime simulation: %s - %s", s.id, err))
+			}
+			if traded {
+				s.save()
+			}
+		}
+	}
+}
+
+// tradeRealtime - reprices the simulated portfolio and executes strategies against the latest prices,
+// returns true if any trades were executed
+func (s *simulation) tradeRealtime(at time.Time) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	trades := len(s.trades.trades)
+
+	if err := s.portfolio.reprice(); err != nil {
+		return false, fmt.Errorf("Error repricing simulated portfolio - %s", err)
+	}
+
+	err := s.executeStrategies(at, latestPrice)
+	traded := len(s.trades.trades) > trades
+	if err != nil {
+		return traded, err
+	}
+
+	if err := s.benchmark.reprice(); err != nil {
+		return traded, fmt.Errorf("Error repricing benchmark portfolio - %s", err)
+	}
+
+	s.recordEquity(at)
+
+	return traded, nil
+}
+
+// setRealtime - sets whether the simulation runs on realtime prices, the caller holds the
+// simulation's lock or it hasn't started running
+func (s *simulation) setRealtime(realtime bool) {
+	s.realtime.Lock()
+	defer s.realtime.Unlock()
+
+	s.useRealtimeData = realtime
+	s.priceUpdates = nil
+	if realtime {
+		s.priceUpdates = make(chan time.Time, 1)
+	}
+}
+
+// isRealtime - returns true if the simulation runs on realtime prices without waiting
+// for a historical run to finish
+func (s *simulation) isRealtime() bool {
+	s.realtime.RLock()
+	defer s.realtime.RUnlock()
+
+	return s.useRealtimeData
+}
+
+// priceUpdated - notifies a running realtime simulation that prices have been updated
+func (s *simulation) priceUpdated(at time.Time) {
+	s.realtime.RLock()
+	priceUpdates := s.priceUpdates
+	s.realtime.RUnlock()
+
+	if priceUpdates == nil {
+		return
+	}
+
+	select {
+	case priceUpdates <- at:
+	default:
+		// simulation is still busy with the previous update
+	}
 }
 
 // GetSimulations returns current simulations
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	tspb "github.com/golang/protobuf/ptypes"
+	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/assert"
 	"github.com/telecoda/teletrada/proto"
 	"github.com/telecoda/teletrada/ttserver/servertime"
@@ -195,6 +197,178 @@ func TestStartSimulationDates(t *testing.T) {
 	}
 }
 
+func TestStartSimulationCustomDates(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	// times are offsets from now, zero offsets are not sent
+	timestamp := func(t *testing.T, now time.Time, offset time.Duration) *google_protobuf.Timestamp {
+		if offset == 0 {
+			return nil
+		}
+		ts, err := tspb.TimestampProto(now.Add(offset))
+		assert.NoError(t, err)
+		return ts
+	}
+
+	tests := []struct {
+		name          string
+		when          proto.StartSimulationRequestWhenOptions
+		fromTime      time.Duration
+		toTime        time.Duration
+		dataFrequency int32
+		simFromTime   time.Duration
+		simToTime     time.Duration
+		expFrequency  time.Duration
+		errExpected   bool
+		errText       string
+	}{
+		{
+			name:          "Valid custom request",
+			when:          proto.StartSimulationRequest_CUSTOM,
+			fromTime:      -12 * time.Hour,
+			toTime:        -6 * time.Hour,
+			dataFrequency: 60,
+			simFromTime:   -12 * time.Hour,
+			simToTime:     -6 * time.Hour,
+			expFrequency:  time.Duration(1 * time.Minute),
+		},
+		{
+			name:         "Valid custom request with default frequency",
+			when:         proto.StartSimulationRequest_CUSTOM,
+			fromTime:     -12 * time.Hour,
+			toTime:       -6 * time.Hour,
+			simFromTime:  -12 * time.Hour,
+			simToTime:    -6 * time.Hour,
+			expFrequency: DEFAULT_DATA_FREQUENCY,
+		},
+		{
+			name:          "Valid last day request with frequency",
+			when:          proto.StartSimulationRequest_LAST_DAY,
+			dataFrequency: 3600,
+			simFromTime:   -24 * time.Hour,
+			simToTime:     0,
+			expFrequency:  time.Duration(1 * time.Hour),
+		},
+		{
+			name:        "Missing to time",
+			when:        proto.StartSimulationRequest_CUSTOM,
+			fromTime:    -12 * time.Hour,
+			errExpected: true,
+			errText:     "You must provide a from and to time",
+		},
+		{
+			name:        "From after to",
+			when:        proto.StartSimulationRequest_CUSTOM,
+			fromTime:    -6 * time.Hour,
+			toTime:      -12 * time.Hour,
+			errExpected: true,
+			errText:     "From time must be before to time",
+		},
+		{
+			name:        "Before archived prices",
+			when:        proto.StartSimulationRequest_CUSTOM,
+			fromTime:    -48 * time.Hour,
+			toTime:      -6 * time.Hour,
+			errExpected: true,
+			errText:     "is outside of archived prices",
+		},
+		{
+			name:        "After archived prices",
+			when:        proto.StartSimulationRequest_CUSTOM,
+			fromTime:    -6 * time.Hour,
+			toTime:      1 * time.Hour,
+			errExpected: true,
+			errText:     "is outside of archived prices",
+		},
+		{
+			name:          "Frequency longer than period",
+			when:          proto.StartSimulationRequest_CUSTOM,
+			fromTime:      -2 * time.Hour,
+			toTime:        -1 * time.Hour,
+			dataFrequency: 7200,
+			errExpected:   true,
+			errText:       "is longer than the simulation period",
+		},
+		{
+			name:          "Negative frequency",
+			when:          proto.StartSimulationRequest_LAST_DAY,
+			dataFrequency: -60,
+			errExpected:   true,
+			errText:       "Data frequency cannot be negative",
+		},
+		{
+			name:        "Dates without custom timescale",
+			when:        proto.StartSimulationRequest_LAST_DAY,
+			fromTime:    -12 * time.Hour,
+			toTime:      -6 * time.Hour,
+			errExpected: true,
+			errText:     "From and to times can only be used with CUSTOM",
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			s, err := initMockServer()
+			assert.NoError(t, err)
+			assert.NotNil(t, s)
+
+			// cast to internal type
+			server := s.(*server)
+
+			// mock server resets fake time
+			now := servertime.Now()
+
+			sim, err := createTestSimulation(server)
+			if assert.NoError(t, err) {
+
+				req := &proto.StartSimulationRequest{
+					Id:            sim.id,
+					When:          test.when,
+					FromTime:      timestamp(t, now, test.fromTime),
+					ToTime:        timestamp(t, now, test.toTime),
+					DataFrequency: test.dataFrequency,
+				}
+
+				sim.RLock()
+				frequencyBefore, fromBefore, toBefore := sim.dataFrequency, sim.simFromTime, sim.simToTime
+				sim.RUnlock()
+
+				resp, err := server.StartSimulation(ctx, req)
+
+				if test.errExpected {
+					if assert.Error(t, err) {
+						assert.Contains(t, err.Error(), test.errText)
+					}
+					assert.Nil(t, resp)
+
+					// a rejected request leaves the simulation unchanged
+					sim.RLock()
+					assert.Equal(t, frequencyBefore, sim.dataFrequency)
+					assert.Equal(t, fromBefore, sim.simFromTime)
+					assert.Equal(t, toBefore, sim.simToTime)
+					assert.False(t, sim.useHistoricalData)
+					sim.RUnlock()
+				} else {
+					assert.NoError(t, err)
+					assert.NotNil(t, resp)
+
+					sim.RLock()
+					assert.True(t, sim.useHistoricalData)
+					assert.Equal(t, now.Add(test.simFromTime), *sim.simFromTime, "FromTime")
+					assert.Equal(t, now.Add(test.simToTime), *sim.simToTime, "ToTime")
+					assert.Equal(t, test.expFrequency, sim.dataFrequency)
+					sim.RUnlock()
+				}
+			}
+		})
+	}
+}
+
 func TestSimulationStartStop(t *testing.T) {
 	servertime.UseFakeTime()
 	defer servertime.UseRealTime()
@@ -372,7 +546,9 @@ func TestSimulationWithSimpleStrategy(t *testing.T) {
 		assert.Equal(t, time.Duration(1*time.Hour), execDur)
 
 		// reprice "start" portfolio
+		sim.realAtStart.Lock()
 		err := sim.realAtStart.repriceAt(*sim.simFromTime)
+		sim.realAtStart.Unlock()
 		assert.NoError(t, err)
 
 		// reprice "now" portfolio
@@ -468,3 +644,153 @@ func TestSimulationWithSimpleStrategy(t *testing.T) {
 	}
 
 }
+
+func TestSimulationExecutesTrades(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+
+	// cast to internal type
+	server := s.(*server)
+
+	sim, err := createTestSimulation(server)
+	assert.NoError(t, err)
+
+	ethBefore := sim.balances[ETH].Free
+	btcBefore := sim.balances[BTC].Free
+
+	// ETH as BTC is always above this price so will sell every time
+	ethSell, err := NewPriceAboveStrategy("sell-eth", ETH, BTC, 0.00000001, 50.0)
+	assert.NoError(t, err)
+	assert.NoError(t, sim.SetSellStrategy(ethSell))
+
+	// replay 3 prices
+	now := servertime.Now()
+	from := now.Add(-2 * time.Hour)
+	to := now.Add(-1 * time.Hour)
+	sim.simFromTime = &from
+	sim.simToTime = &to
+
+	err = sim.runOverHistory(30 * time.Minute)
+	assert.NoError(t, err)
+
+	if assert.Equal(t, 3, len(sim.trades.trades)) {
+		for _, tr := range sim.trades.trades {
+			assert.Equal(t, SELL_TRADE, tr.side)
+			assert.Equal(t, "sell-eth", tr.strategyID)
+		}
+		assert.Equal(t, from, sim.trades.trades[0].date)
+		assert.Equal(t, to, sim.trades.trades[2].date)
+	}
+
+	// half sold each time
+	assert.InDelta(t, ethBefore/8.0, sim.balances[ETH].Free, 0.0000001)
+	assert.True(t, sim.balances[BTC].Free > btcBefore, "BTC balance should have increased")
+
+	// live portfolio is untouched
+	assert.Equal(t, ethBefore, server.livePortfolio.balances[ETH].Free)
+
+	// strategies are stopped after the simulation
+	assert.False(t, ethSell.IsRunning())
+	assert.Equal(t, 3, ethSell.TriggerCount())
+}
+
+func TestRealtimeSimulation(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+
+	// cast to internal type
+	server := s.(*server)
+
+	sim, err := createTestSimulation(server)
+	assert.NoError(t, err)
+	server.setSimulation(sim)
+
+	ethBefore := sim.balances[ETH].Free
+
+	// ETH as BTC is always above this price so will sell on every update
+	ethSell, err := NewPriceAboveStrategy("sell-eth", ETH, BTC, 0.00000001, 50.0)
+	assert.NoError(t, err)
+	assert.NoError(t, sim.SetSellStrategy(ethSell))
+
+	ctx := context.Background()
+
+	startReq := &proto.StartSimulationRequest{
+		Id:   sim.id,
+		When: proto.StartSimulationRequest_NOW_REALTIME,
+	}
+
+	_, err = server.StartSimulation(ctx, startReq)
+	assert.NoError(t, err)
+
+	// wait for simulation to start
+	time.Sleep(1 * time.Second)
+
+	sim.RLock()
+	assert.True(t, sim.isRunning)
+	assert.Equal(t, 0, len(sim.trades.trades), "No trades until prices are updated")
+	sim.RUnlock()
+
+	// each scheduled update should trade
+	server.scheduledUpdate()
+	time.Sleep(200 * time.Millisecond)
+	server.scheduledUpdate()
+	time.Sleep(200 * time.Millisecond)
+
+	sim.RLock()
+	if assert.Equal(t, 2, len(sim.trades.trades)) {
+		for _, tr := range sim.trades.trades {
+			assert.Equal(t, SELL_TRADE, tr.side)
+			assert.Equal(t, "sell-eth", tr.strategyID)
+		}
+	}
+	assert.InDelta(t, ethBefore/4.0, sim.balances[ETH].Free, 0.0000001)
+	sim.RUnlock()
+
+	// live portfolio is untouched
+	assert.Equal(t, ethBefore, server.livePortfolio.balances[ETH].Free)
+
+	// simulation runs until stopped
+	stopReq := &proto.StopSimulationRequest{
+		Id: sim.id,
+	}
+	_, err = server.StopSimulation(ctx, stopReq)
+	assert.NoError(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+
+	sim.RLock()
+	assert.False(t, sim.isRunning)
+	assert.NotNil(t, sim.stoppedTime)
+	sim.RUnlock()
+	assert.False(t, ethSell.IsRunning())
+
+	// no more trades after stopping
+	server.scheduledUpdate()
+	time.Sleep(200 * time.Millisecond)
+
+	sim.RLock()
+	assert.Equal(t, 2, len(sim.trades.trades))
+	sim.RUnlock()
+
+	// a stopped realtime simulation can be run over history
+	_, err = server.StartSimulation(ctx, &proto.StartSimulationRequest{Id: sim.id, When: proto.StartSimulationRequest_LAST_DAY})
+	assert.NoError(t, err)
+
+	sim.RLock()
+	assert.True(t, sim.useHistoricalData)
+	assert.False(t, sim.useRealtimeData)
+	sim.RUnlock()
+
+	// wait for the historical run to finish
+	time.Sleep(500 * time.Millisecond)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	tspb "github.com/golang/protobuf/ptypes"
+	"github.com/telecoda/teletrada/exchanges"
 	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
 )
 
 // GetStatus returns status of server
@@ -30,6 +32,22 @@ func (s *server) GetStatus(ctx context.Context, req *proto.GetStatusRequest) (*p
 		LastUpdate:    lastUpdated,
 		UpdateCount:   int32(archiveStatus.UpdateCount),
 		TotalSymbols:  int32(archiveStatus.TotalSymbols),
+		Risk:          s.risk.status(servertime.Now()).toProto(),
+	}
+
+	stream := s.stream.status()
+	resp.PriceStreamConnected = stream.connected
+	resp.StreamedPrices = int32(stream.received)
+	if !stream.lastPrice.IsZero() {
+		if resp.LastStreamedPrice, err = tspb.TimestampProto(stream.lastPrice); err != nil {
+			return nil, fmt.Errorf("failed to convert lastStreamedPrice: %s", err)
+		}
+	}
+
+	if reporter, ok := DefaultClient.(exchanges.ClientStatusReporter); ok {
+		if resp.Exchange, err = clientStatusToProto(reporter.GetClientStatus()); err != nil {
+			return nil, fmt.Errorf("failed to convert exchange status: %s", err)
+		}
 	}
 
 	return resp, nil
@@ -3,8 +3,10 @@ package domain
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
 	"github.com/telecoda/teletrada/proto"
 )
 
@@ -23,5 +25,33 @@ func TestStatusEndpoint(t *testing.T) {
 	assert.NotZero(t, rsp.LastUpdate, "Should have updated on init")
 	assert.NotZero(t, rsp.ServerStarted, "Should have a start time")
 	assert.Equal(t, int32(1), rsp.UpdateCount, "Should have updated prices once at startup")
+	assert.Nil(t, rsp.Exchange, "Mock client does not report its status")
 
 }
+
+func TestExchangeStatus(t *testing.T) {
+
+	server, err := initMockServer()
+	assert.NoError(t, err)
+
+	DefaultClient, err = exchanges.NewResilientClient(DefaultClient, exchanges.ResilienceConfig{
+		WeightLimit:     1200,
+		BreakerFailures: 5,
+		BreakerReset:    time.Minute,
+	})
+	assert.NoError(t, err)
+
+	_, err = DefaultClient.GetLatestPrices()
+	assert.NoError(t, err)
+
+	rsp, err := server.GetStatus(context.Background(), &proto.GetStatusRequest{})
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, rsp.Exchange) {
+		assert.Equal(t, exchanges.MOCK_EXCHANGE, rsp.Exchange.Exchange)
+		assert.Equal(t, string(exchanges.BREAKER_CLOSED), rsp.Exchange.CircuitBreaker)
+		assert.Equal(t, int32(1200), rsp.Exchange.WeightLimit)
+		assert.Equal(t, int32(1), rsp.Exchange.Requests)
+		assert.Nil(t, rsp.Exchange.OpenedTime)
+	}
+}
@@ -0,0 +1,594 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+/*
+
+The simulation store saves simulations so they survive server restarts.
+
+Each simulation is saved as a JSON file under <data dir>/simulations including:-
+
+	- the simulation definition (dates, frequency, realtime or historical)
+	- the simulated portfolio and its buy/sell strategies, with their trigger
+	  counts and any prices they are tracking such as trailing peaks
+	- the real portfolio at the start and the benchmark portfolio
+	- trades executed and the equity curve used for results
+
+Strategies created to be attached to simulations are saved as JSON files under
+<data dir>/strategies and reloaded before the simulations.
+
+Simulations are saved when they are created, started, updated and stopped.
+Running realtime simulations are also saved after each trade and every
+SIMULATION_SAVE_INTERVAL. When the server is initialised saved simulations are
+reloaded and realtime simulations that were running are resumed, keeping the
+time they were started.
+
+*/
+
+const SIMULATIONS_DIR = "simulations"
+const STRATEGIES_DIR = "strategies"
+
+type simulationStore interface {
+	saveSimulation(record *simulationRecord) error
+	loadSimulations() ([]*simulationRecord, error)
+	saveStrategy(record *strategyRecord) error
+	loadStrategies() ([]*strategyRecord, error)
+}
+
+// newSimulationStore - returns a file store when a data dir is configured
+func newSimulationStore(dataDir string) (simulationStore, error) {
+	if dataDir == "" {
+		return noSimulationStore{}, nil
+	}
+
+	dir := filepath.Join(dataDir, SIMULATIONS_DIR)
+	strategiesDir := filepath.Join(dataDir, STRATEGIES_DIR)
+	for _, storeDir := range []string{dir, strategiesDir} {
+		if err := os.MkdirAll(storeDir, 0755); err != nil {
+			return nil, fmt.Errorf("Failed to create simulation store dir: %s - %s", storeDir, err)
+		}
+	}
+
+	return &fileSimulationStore{
+		dir:           dir,
+		strategiesDir: strategiesDir,
+	}, nil
+}
+
+type fileSimulationStore struct {
+	dir           string
+	strategiesDir string
+}
+
+func (f *fileSimulationStore) saveSimulation(record *simulationRecord) error {
+	return writeRecordFile(f.dir, record.ID, record)
+}
+
+// writeRecordFile - saves a record as JSON in dir using its escaped id as the file name
+func writeRecordFile(dir, id string, record interface{}) error {
+	recordJSON, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temp file first so a crash never leaves a partially written record
+	filePath := filepath.Join(dir, url.PathEscape(id)+".json")
+	tempPath := filePath + ".tmp"
+
+	if err := ioutil.WriteFile(tempPath, recordJSON, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, filePath)
+}
+
+// readRecordFiles - calls fn with the contents of each JSON record file in dir
+func readRecordFiles(dir string, fn func(filePath string, recordJSON []byte)) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		filePath := filepath.Join(dir, file.Name())
+		recordJSON, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		fn(filePath, recordJSON)
+	}
+
+	return nil
+}
+
+func (f *fileSimulationStore) loadSimulations() ([]*simulationRecord, error) {
+	records := make([]*simulationRecord, 0)
+
+	err := readRecordFiles(f.dir, func(filePath string, recordJSON []byte) {
+		record := &simulationRecord{}
+		if err := json.Unmarshal(recordJSON, record); err != nil {
+			// one bad file shouldn't lose all the other simulations
+			DefaultLogger.log(fmt.Sprintf("Failed to load simulation from file: %s - %s", filePath, err))
+			return
+		}
+		records = append(records, record)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Can't load simulations from dir: %s - %s", f.dir, err)
+	}
+
+	return records, nil
+}
+
+func (f *fileSimulationStore) saveStrategy(record *strategyRecord) error {
+	return writeRecordFile(f.strategiesDir, record.ID, record)
+}
+
+func (f *fileSimulationStore) loadStrategies() ([]*strategyRecord, error) {
+	records := make([]*strategyRecord, 0)
+
+	err := readRecordFiles(f.strategiesDir, func(filePath string, recordJSON []byte) {
+		record := &strategyRecord{}
+		if err := json.Unmarshal(recordJSON, record); err != nil {
+			DefaultLogger.log(fmt.Sprintf("Failed to load strategy from file: %s - %s", filePath, err))
+			return
+		}
+		records = append(records, record)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Can't load strategies from dir: %s - %s", f.strategiesDir, err)
+	}
+
+	return records, nil
+}
+
+// noSimulationStore - simulations are only held in memory
+type noSimulationStore struct{}
+
+func (n noSimulationStore) saveSimulation(record *simulationRecord) error {
+	return nil
+}
+
+func (n noSimulationStore) loadSimulations() ([]*simulationRecord, error) {
+	return []*simulationRecord{}, nil
+}
+
+func (n noSimulationStore) saveStrategy(record *strategyRecord) error {
+	return nil
+}
+
+func (n noSimulationStore) loadStrategies() ([]*strategyRecord, error) {
+	return []*strategyRecord{}, nil
+}
+
+// records are the saved versions of the domain types
+
+type simulationRecord struct {
+	ID                string
+	Name              string
+	IsRunning         bool
+	StartedTime       *time.Time
+	StoppedTime       *time.Time
+	UseHistoricalData bool
+	SimFromTime       *time.Time
+	SimToTime         *time.Time
+	DataFrequency     time.Duration
+	UseRealtimeData   bool
+	Portfolio         *portfolioRecord
+	RealAtStart       *portfolioRecord
+	Benchmark         *portfolioRecord
+	Trades            []tradeRecord
+	Equity            []equityRecord
+}
+
+type portfolioRecord struct {
+	Name     string
+	Balances []balanceRecord
+}
+
+type balanceRecord struct {
+	exchanges.CoinBalance
+	Total        float64
+	At           time.Time
+	As           SymbolType
+	Price        float64
+	Value        float64
+	BuyStrategy  *strategyRecord
+	SellStrategy *strategyRecord
+}
+
+type strategyRecord struct {
+	Type         StrategyType
+	ID           string
+	Symbol       SymbolType
+	As           SymbolType
+	CoinPercent  float64
+	Params       map[string]float64 // strategy specific parameters
+	TriggerCount int
+	State        map[string]float64 // prices tracked by stateful strategies, eg. trailing peaks
+}
+
+type tradeRecord struct {
+	Side       tradeSide
+	Symbol     SymbolType
+	As         SymbolType
+	Quantity   float64
+	Exchange   string
+	Price      float64
+	Fee        float64
+	FeeSymbol  SymbolType
+	TotalCost  float64
+	Date       time.Time
+	StrategyID string
+}
+
+type equityRecord struct {
+	At        time.Time
+	Value     float64
+	Benchmark float64
+	Prices    int
+	Synthetic int
+}
+
+// save - persists the simulation so it survives restarts
+func (s *simulation) save() {
+	if s.store == nil {
+		return
+	}
+
+	s.RLock()
+	record, err := s.toRecord()
+	s.RUnlock()
+
+	if err == nil {
+		err = s.store.saveSimulation(record)
+	}
+
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("Failed to save simulation: %s - %s", s.id, err))
+	}
+}
+
+func (s *simulation) toRecord() (*simulationRecord, error) {
+	r := &simulationRecord{
+		ID:                s.id,
+		Name:              s.name,
+		IsRunning:         s.isRunning,
+		StartedTime:       s.startedTime,
+		StoppedTime:       s.stoppedTime,
+		UseHistoricalData: s.useHistoricalData,
+		SimFromTime:       s.simFromTime,
+		SimToTime:         s.simToTime,
+		DataFrequency:     s.dataFrequency,
+		UseRealtimeData:   s.useRealtimeData,
+		Trades:            make([]tradeRecord, len(s.trades.trades)),
+		Equity:            make([]equityRecord, len(s.equity)),
+	}
+
+	var err error
+	if r.Portfolio, err = newPortfolioRecord(s.portfolio); err != nil {
+		return nil, err
+	}
+	// the simulated portfolio shares the simulation's lock, the others are locked while read
+	if r.RealAtStart, err = newLockedPortfolioRecord(s.realAtStart); err != nil {
+		return nil, err
+	}
+	if r.Benchmark, err = newLockedPortfolioRecord(s.benchmark); err != nil {
+		return nil, err
+	}
+
+	for i, t := range s.trades.trades {
+		r.Trades[i] = tradeRecord{
+			Side:       t.side,
+			Symbol:     t.symbol,
+			As:         t.as,
+			Quantity:   t.quantity,
+			Exchange:   t.exchange,
+			Price:      t.price,
+			Fee:        t.fee,
+			FeeSymbol:  t.feeSymbol,
+			TotalCost:  t.totalCost,
+			Date:       t.date,
+			StrategyID: t.strategyID,
+		}
+	}
+
+	for i, point := range s.equity {
+		r.Equity[i] = equityRecord{
+			At:        point.at,
+			Value:     point.value,
+			Benchmark: point.benchmark,
+			Prices:    point.prices,
+			Synthetic: point.synthetic,
+		}
+	}
+
+	return r, nil
+}
+
+// simulationFromRecord - recreates a saved simulation
+func (s *server) simulationFromRecord(r *simulationRecord) (*simulation, error) {
+	if r.ID == "" {
+		return nil, fmt.Errorf("Simulation ID must be provided")
+	}
+
+	if r.Portfolio == nil {
+		return nil, fmt.Errorf("Simulation: %s has no portfolio", r.ID)
+	}
+
+	sim := &simulation{
+		id:                r.ID,
+		name:              r.Name,
+		isRunning:         r.IsRunning,
+		startedTime:       r.StartedTime,
+		stoppedTime:       r.StoppedTime,
+		realNow:           s.livePortfolio,
+		useHistoricalData: r.UseHistoricalData,
+		simFromTime:       r.SimFromTime,
+		simToTime:         r.SimToTime,
+		dataFrequency:     r.DataFrequency,
+		useRealtimeData:   r.UseRealtimeData,
+		engine:            newOrderEngine(s.costs),
+		store:             s.store,
+		equity:            make([]equityPoint, len(r.Equity)),
+	}
+
+	var err error
+	if sim.portfolio, err = r.Portfolio.toPortfolio(); err != nil {
+		return nil, fmt.Errorf("Failed to load portfolio for simulation: %s - %s", r.ID, err)
+	}
+	if sim.realAtStart, err = r.RealAtStart.toPortfolio(); err != nil {
+		return nil, fmt.Errorf("Failed to load real portfolio for simulation: %s - %s", r.ID, err)
+	}
+	if sim.benchmark, err = r.Benchmark.toPortfolio(); err != nil {
+		return nil, fmt.Errorf("Failed to load benchmark portfolio for simulation: %s - %s", r.ID, err)
+	}
+
+	for _, t := range r.Trades {
+		sim.trades.add(trade{
+			side:       t.Side,
+			symbol:     t.Symbol,
+			as:         t.As,
+			quantity:   t.Quantity,
+			exchange:   t.Exchange,
+			price:      t.Price,
+			fee:        t.Fee,
+			feeSymbol:  t.FeeSymbol,
+			totalCost:  t.TotalCost,
+			date:       t.Date,
+			strategyID: t.StrategyID,
+		})
+	}
+
+	for i, point := range r.Equity {
+		sim.equity[i] = equityPoint{
+			at:        point.At,
+			value:     point.Value,
+			benchmark: point.Benchmark,
+			prices:    point.Prices,
+			synthetic: point.Synthetic,
+		}
+	}
+
+	return sim, nil
+}
+
+// saveStrategy - persists a created strategy so it survives restarts
+func (s *server) saveStrategy(strategy Strategy) {
+	if s.store == nil {
+		return
+	}
+
+	record, err := newStrategyRecord(strategy)
+	if err == nil {
+		err = s.store.saveStrategy(record)
+	}
+
+	if err != nil {
+		DefaultLogger.log(fmt.Sprintf("Failed to save strategy: %s - %s", strategy.ID(), err))
+	}
+}
+
+// loadStrategies - restores saved strategies that can be attached to simulations
+func (s *server) loadStrategies() error {
+	records, err := s.store.loadStrategies()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		strategy, err := record.toStrategy()
+		if err != nil {
+			DefaultLogger.log(fmt.Sprintf("Failed to load strategy: %s - %s", record.ID, err))
+			continue
+		}
+
+		s.strategies[strategy.ID()] = strategy
+	}
+
+	DefaultLogger.log(fmt.Sprintf("Loaded %d strategies", len(records)))
+
+	return nil
+}
+
+// loadSimulations - restores saved simulations, resuming realtime simulations that were running
+func (s *server) loadSimulations() error {
+	records, err := s.store.loadSimulations()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		sim, err := s.simulationFromRecord(record)
+		if err != nil {
+			DefaultLogger.log(fmt.Sprintf("Failed to load simulation: %s - %s", record.ID, err))
+			continue
+		}
+
+		s.simulations[sim.id] = sim
+
+		if !sim.isRunning {
+			continue
+		}
+
+		if sim.useRealtimeData {
+			DefaultLogger.log(fmt.Sprintf("Resuming realtime simulation: %s", sim.id))
+			sim.setRealtime(true)
+			sim.stopRealtime = make(chan bool)
+			go sim.resume()
+			continue
+		}
+
+		// historical simulations can't be resumed part way through
+		DefaultLogger.log(fmt.Sprintf("Historical simulation: %s was interrupted", sim.id))
+		sim.isRunning = false
+		now := servertime.Now()
+		sim.stoppedTime = &now
+		sim.save()
+	}
+
+	DefaultLogger.log(fmt.Sprintf("Loaded %d simulations", len(records)))
+
+	return nil
+}
+
+// newLockedPortfolioRecord - returns the record of a portfolio read under its own lock
+func newLockedPortfolioRecord(p *portfolio) (*portfolioRecord, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	p.RLock()
+	defer p.RUnlock()
+
+	return newPortfolioRecord(p)
+}
+
+func newPortfolioRecord(p *portfolio) (*portfolioRecord, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	r := &portfolioRecord{
+		Name:     p.name,
+		Balances: make([]balanceRecord, 0, len(p.balances)),
+	}
+
+	for _, balance := range p.balances {
+		br := balanceRecord{
+			CoinBalance: balance.CoinBalance,
+			Total:       balance.Total,
+			At:          balance.At,
+			As:          balance.As,
+			Price:       balance.Price,
+			Value:       balance.Value,
+		}
+
+		var err error
+		if br.BuyStrategy, err = newStrategyRecord(balance.BuyStrategy); err != nil {
+			return nil, err
+		}
+		if br.SellStrategy, err = newStrategyRecord(balance.SellStrategy); err != nil {
+			return nil, err
+		}
+
+		r.Balances = append(r.Balances, br)
+	}
+
+	return r, nil
+}
+
+func (r *portfolioRecord) toPortfolio() (*portfolio, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	p := &portfolio{
+		name:     r.Name,
+		isLive:   false, // only simulated portfolios are saved
+		balances: make(map[SymbolType]*BalanceAs, len(r.Balances)),
+	}
+
+	for _, br := range r.Balances {
+		balance := &BalanceAs{
+			CoinBalance: br.CoinBalance,
+			Total:       br.Total,
+			At:          br.At,
+			As:          br.As,
+			Price:       br.Price,
+			Value:       br.Value,
+		}
+
+		var err error
+		if balance.BuyStrategy, err = br.BuyStrategy.toStrategy(); err != nil {
+			return nil, err
+		}
+		if balance.SellStrategy, err = br.SellStrategy.toStrategy(); err != nil {
+			return nil, err
+		}
+
+		p.balances[SymbolType(br.Symbol)] = balance
+	}
+
+	return p, nil
+}
+
+func newStrategyRecord(strategy Strategy) (*strategyRecord, error) {
+	if strategy == nil {
+		return nil, nil
+	}
+
+	r := &strategyRecord{
+		Type:         strategy.Type(),
+		ID:           strategy.ID(),
+		Symbol:       strategy.Symbol(),
+		As:           strategy.As(),
+		CoinPercent:  strategy.CoinPercent(),
+		Params:       strategy.Params(),
+		TriggerCount: strategy.TriggerCount(),
+	}
+
+	if stateful, ok := strategy.(statefulStrategy); ok {
+		r.State = stateful.state()
+	}
+
+	return r, nil
+}
+
+func (r *strategyRecord) toStrategy() (Strategy, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	strategy, err := NewStrategy(r.Type, r.ID, r.Symbol, r.As, r.CoinPercent, r.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if counted, ok := strategy.(interface{ restoreCount(int) }); ok {
+		counted.restoreCount(r.TriggerCount)
+	}
+
+	if stateful, ok := strategy.(statefulStrategy); ok && r.State != nil {
+		stateful.restoreState(r.State)
+	}
+
+	return strategy, nil
+}
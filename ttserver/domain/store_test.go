@@ -0,0 +1,244 @@
+package domain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestStrategyRecords(t *testing.T) {
+
+	base, err := NewBaseStrategy("base", ETH, BTC, 10.0)
+	assert.NoError(t, err)
+	doNothing, err := NewDoNothingStrategy("do-nothing", ETH, BTC, 20.0)
+	assert.NoError(t, err)
+	above, err := NewPriceAboveStrategy("above", ETH, BTC, 0.5, 30.0)
+	assert.NoError(t, err)
+	below, err := NewPriceBelowStrategy("below", LTC, USDT, 25.0, 40.0)
+	assert.NoError(t, err)
+
+	for _, strategy := range []Strategy{base, doNothing, above, below} {
+		record, err := newStrategyRecord(strategy)
+		assert.NoError(t, err)
+
+		loaded, err := record.toStrategy()
+		assert.NoError(t, err)
+		assert.Equal(t, strategy, loaded)
+	}
+
+	// trailing strategies carry on from the peak they were tracking
+	defer func(archive SymbolsArchive) { DefaultArchive = archive }(DefaultArchive)
+	DefaultArchive = NewSymbolsArchive()
+	now := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: ETH, As: BTC, Price: 0.5, At: now, Exchange: "test"}))
+	trailingStop, err := NewTrailingStopStrategy("trailing", ETH, BTC, 10.0, 0, 50.0)
+	assert.NoError(t, err)
+	trailingStop.Start()
+	_, err = trailingStop.ConditionMet(now)
+	assert.NoError(t, err)
+	trailingStop.IncCount()
+
+	record, err := newStrategyRecord(trailingStop)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, record.TriggerCount)
+	assert.Equal(t, map[string]float64{"extreme": 0.5, "tracking": 1, "triggered": 0}, record.State)
+
+	loaded, err := record.toStrategy()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, loaded.TriggerCount())
+	resumeStrategy(loaded)
+	assert.Equal(t, record.State, loaded.(statefulStrategy).state())
+
+	// nil strategies are saved as nil
+	record, err = newStrategyRecord(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	_, err = (&strategyRecord{ID: "unknown", Type: StrategyType("unknown")}).toStrategy()
+	assert.Error(t, err)
+}
+
+func TestSimulationStore(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	dataDir, err := ioutil.TempDir("", "teletrada-store")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	s, err := initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+
+	// cast to internal type
+	first := s.(*server)
+
+	ctx := context.Background()
+
+	// historical simulation with results
+	createResp, err := first.CreateSimulation(ctx, &proto.CreateSimulationRequest{Id: "hist-sim", Name: "Historical"})
+	assert.NoError(t, err)
+	assert.NotNil(t, createResp)
+
+	histSim, err := first.getSimulation("hist-sim")
+	assert.NoError(t, err)
+
+	histSell, err := NewPriceAboveStrategy("sell-eth", ETH, BTC, 0.00000001, 50.0)
+	assert.NoError(t, err)
+	assert.NoError(t, histSim.SetSellStrategy(histSell))
+
+	now := servertime.Now()
+	from := now.Add(-2 * time.Hour)
+	to := now.Add(-1 * time.Hour)
+	histSim.simFromTime = &from
+	histSim.simToTime = &to
+
+	assert.NoError(t, histSim.runOverHistory(30*time.Minute))
+	histSim.save()
+
+	assert.FileExists(t, filepath.Join(dataDir, SIMULATIONS_DIR, "hist-sim.json"))
+
+	// running realtime simulation
+	_, err = first.CreateSimulation(ctx, &proto.CreateSimulationRequest{Id: "live-sim", Name: "Realtime"})
+	assert.NoError(t, err)
+
+	liveSim, err := first.getSimulation("live-sim")
+	assert.NoError(t, err)
+
+	liveSell, err := NewPriceAboveStrategy("sell-eth", ETH, BTC, 0.00000001, 50.0)
+	assert.NoError(t, err)
+	assert.NoError(t, liveSim.SetSellStrategy(liveSell))
+
+	_, err = first.StartSimulation(ctx, &proto.StartSimulationRequest{Id: liveSim.id, When: proto.StartSimulationRequest_NOW_REALTIME})
+	assert.NoError(t, err)
+
+	// wait for simulation to start and trade once
+	time.Sleep(1 * time.Second)
+	first.scheduledUpdate()
+	time.Sleep(200 * time.Millisecond)
+
+	liveSim.RLock()
+	assert.Equal(t, 1, len(liveSim.trades.trades))
+	liveEth := liveSim.balances[ETH].Free
+	liveStarted := *liveSim.startedTime
+	liveSim.RUnlock()
+
+	// simulate a restart without stopping the realtime simulation
+	liveSim.Lock()
+	close(liveSim.stopRealtime)
+	liveSim.stopRealtime = nil
+	liveSim.Unlock()
+	time.Sleep(200 * time.Millisecond)
+
+	// the stopped simulation has been saved, so save running state again
+	liveSim.Lock()
+	liveSim.isRunning = true
+	liveSim.Unlock()
+	liveSim.save()
+
+	s, err = initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+	restarted := s.(*server)
+
+	assert.Equal(t, 2, len(restarted.simulations))
+
+	// historical simulation is restored with its results
+	loadedHist, err := restarted.getSimulation("hist-sim")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Historical", loadedHist.name)
+		assert.False(t, loadedHist.isRunning)
+		assert.True(t, loadedHist.useHistoricalData)
+		assert.Equal(t, from.UTC(), loadedHist.simFromTime.UTC())
+		assert.Equal(t, to.UTC(), loadedHist.simToTime.UTC())
+		assert.Equal(t, 30*time.Minute, loadedHist.dataFrequency)
+		assert.Equal(t, 3, len(loadedHist.trades.trades))
+		assert.Equal(t, 3, len(loadedHist.equity))
+		// counts of balances priced are kept for the synthetic percent
+		for i, point := range histSim.equity {
+			assert.NotZero(t, point.prices)
+			assert.Equal(t, point.prices, loadedHist.equity[i].prices)
+			assert.Equal(t, point.synthetic, loadedHist.equity[i].synthetic)
+		}
+		assert.InDelta(t, histSim.balances[ETH].Free, loadedHist.balances[ETH].Free, 0.0000001)
+		assert.Equal(t, restarted.livePortfolio, loadedHist.realNow)
+
+		if assert.NotNil(t, loadedHist.balances[ETH].SellStrategy) {
+			assert.Equal(t, histSell.Description(), loadedHist.balances[ETH].SellStrategy.Description())
+		}
+
+		resp, err := restarted.GetSimulationResult(ctx, &proto.GetSimulationResultRequest{Id: "hist-sim"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), resp.Result.Trades)
+	}
+
+	// realtime simulation resumes and carries on trading
+	loadedLive, err := restarted.getSimulation("live-sim")
+	if assert.NoError(t, err) {
+		time.Sleep(1 * time.Second)
+
+		loadedLive.RLock()
+		assert.True(t, loadedLive.isRunning)
+		assert.Equal(t, liveStarted.UTC(), loadedLive.startedTime.UTC())
+		assert.Equal(t, 1, len(loadedLive.trades.trades))
+		assert.InDelta(t, liveEth, loadedLive.balances[ETH].Free, 0.0000001)
+		loadedLive.RUnlock()
+
+		restarted.scheduledUpdate()
+		time.Sleep(200 * time.Millisecond)
+
+		loadedLive.RLock()
+		assert.Equal(t, 2, len(loadedLive.trades.trades))
+		loadedLive.RUnlock()
+
+		_, err = restarted.StopSimulation(ctx, &proto.StopSimulationRequest{Id: "live-sim"})
+		assert.NoError(t, err)
+	}
+}
+
+func TestSimulationStoreInterrupted(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	dataDir, err := ioutil.TempDir("", "teletrada-store")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	s, err := initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+	first := s.(*server)
+
+	sim, err := createTestSimulation(first)
+	assert.NoError(t, err)
+
+	// historical simulation saved part way through
+	now := servertime.Now()
+	from := now.Add(-2 * time.Hour)
+	sim.simFromTime = &from
+	sim.simToTime = &now
+	sim.useHistoricalData = true
+	sim.isRunning = true
+	sim.save()
+
+	// unreadable files are skipped
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, SIMULATIONS_DIR, "bad.json"), []byte("not json"), 0644))
+
+	s, err = initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+	restarted := s.(*server)
+
+	assert.Equal(t, 1, len(restarted.simulations))
+
+	loaded, err := restarted.getSimulation(sim.id)
+	if assert.NoError(t, err) {
+		assert.False(t, loaded.isRunning)
+		assert.NotNil(t, loaded.stoppedTime)
+	}
+}
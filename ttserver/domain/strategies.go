@@ -0,0 +1,395 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/telecoda/teletrada/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+
+Strategies are managed in two steps:-
+
+	create - a strategy is defined with its type and parameters
+	attach - a copy of the strategy is attached to the buy or sell slot of
+	         its symbol in a simulation's portfolio
+
+Each simulation gets its own copy so strategies attached to many simulations
+don't share running state or trigger counts.
+
+Strategies attached to the LIVE portfolio only trade through the order manager.
+
+Attaching a strategy to a slot that already has one stops the strategy it replaces.
+
+*/
+
+// addStrategy - adds a strategy that can be attached to simulations
+func (s *server) addStrategy(strategy Strategy) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.strategies[strategy.ID()]; ok {
+		return fmt.Errorf("Cannot create strategy %s as it already exists", strategy.ID())
+	}
+
+	s.strategies[strategy.ID()] = strategy
+	return nil
+}
+
+func (s *server) getStrategy(id string) (Strategy, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if strategy, ok := s.strategies[id]; ok {
+		return strategy, nil
+	}
+	return nil, fmt.Errorf("Strategy Id: %s not found", id)
+}
+
+// copyStrategy - creates a new instance of a strategy with the same settings
+func copyStrategy(strategy Strategy) (Strategy, error) {
+	return NewStrategy(strategy.Type(), strategy.ID(), strategy.Symbol(), strategy.As(), strategy.CoinPercent(), strategy.Params())
+}
+
+// stopReplaced - stops a strategy replaced in a slot so it doesn't keep running
+func stopReplaced(replaced, strategy Strategy) {
+	if replaced != nil && replaced != strategy {
+		replaced.Stop()
+	}
+}
+
+// attachStrategy - sets the strategy in the buy or sell slot of its symbol
+func (s *simulation) attachStrategy(slot tradeSide, strategy Strategy) error {
+	var err error
+	switch slot {
+	case BUY_TRADE:
+		err = s.SetBuyStrategy(strategy)
+	case SELL_TRADE:
+		err = s.SetSellStrategy(strategy)
+	default:
+		err = fmt.Errorf("Strategy slot %q is not valid", slot)
+	}
+	if err != nil {
+		return err
+	}
+
+	// strategies attached to a running simulation start straight away
+	s.RLock()
+	if s.isRunning {
+		strategy.Start()
+	}
+	s.RUnlock()
+
+	return nil
+}
+
+// setStrategy - sets the strategy in the buy or sell slot of its symbol
+func (p *portfolio) setStrategy(slot tradeSide, strategy Strategy) error {
+	p.Lock()
+	defer p.Unlock()
+
+	balance, ok := p.balances[strategy.Symbol()]
+	if !ok {
+		return fmt.Errorf("Cannot attach strategy for portfolio %q on symbol %q, not in portfolio", p.name, strategy.Symbol())
+	}
+
+	balance.Lock()
+	defer balance.Unlock()
+
+	var replaced Strategy
+	switch slot {
+	case BUY_TRADE:
+		replaced = balance.BuyStrategy
+		balance.BuyStrategy = strategy
+	case SELL_TRADE:
+		replaced = balance.SellStrategy
+		balance.SellStrategy = strategy
+	default:
+		return fmt.Errorf("Strategy slot %q is not valid", slot)
+	}
+
+	stopReplaced(replaced, strategy)
+
+	return nil
+}
+
+// detachStrategy - removes the strategy from the buy or sell slot of a symbol
+func (p *portfolio) detachStrategy(symbol SymbolType, slot tradeSide) error {
+	p.Lock()
+	defer p.Unlock()
+
+	balance, ok := p.balances[symbol]
+	if !ok {
+		return fmt.Errorf("Cannot detach strategy for portfolio %q on symbol %q, not in portfolio", p.name, symbol)
+	}
+
+	balance.Lock()
+	defer balance.Unlock()
+
+	var strategy Strategy
+	switch slot {
+	case BUY_TRADE:
+		strategy = balance.BuyStrategy
+		balance.BuyStrategy = nil
+	case SELL_TRADE:
+		strategy = balance.SellStrategy
+		balance.SellStrategy = nil
+	default:
+		return fmt.Errorf("Strategy slot %q is not valid", slot)
+	}
+
+	if strategy == nil {
+		return fmt.Errorf("Portfolio %q has no %s strategy on symbol %q", p.name, slot, symbol)
+	}
+
+	strategy.Stop()
+
+	return nil
+}
+
+// attachedStrategies - returns the strategies attached to every balance in a portfolio
+func (p *portfolio) attachedStrategies(name string) ([]*proto.AttachedStrategy, error) {
+	attached := make([]*proto.AttachedStrategy, 0)
+
+	for symbol, balance := range p.balances {
+		slots := []struct {
+			slot     proto.StrategySlot
+			strategy Strategy
+		}{
+			{slot: proto.StrategySlot_BUY, strategy: balance.BuyStrategy},
+			{slot: proto.StrategySlot_SELL, strategy: balance.SellStrategy},
+		}
+
+		for _, slot := range slots {
+			if slot.strategy == nil {
+				continue
+			}
+			ps, err := strategyToProto(slot.strategy)
+			if err != nil {
+				return nil, err
+			}
+			attached = append(attached, &proto.AttachedStrategy{
+				Portfolio: name,
+				Symbol:    string(symbol),
+				Slot:      slot.slot,
+				Strategy:  ps,
+			})
+		}
+	}
+
+	return attached, nil
+}
+
+func slotToSide(slot proto.StrategySlot) (tradeSide, error) {
+	switch slot {
+	case proto.StrategySlot_BUY:
+		return BUY_TRADE, nil
+	case proto.StrategySlot_SELL:
+		return SELL_TRADE, nil
+	default:
+		return "", fmt.Errorf("Strategy slot %d is not valid", slot)
+	}
+}
+
+// CreateStrategy creates a strategy that can be attached to simulations
+func (s *server) CreateStrategy(ctx context.Context, req *proto.CreateStrategyRequest) (*proto.CreateStrategyResponse, error) {
+
+	if req.Id == "" {
+		// if no id is provided generate one
+		req.Id = randSeq(10)
+	}
+
+	strategy, err := NewStrategy(StrategyType(req.Type), req.Id, SymbolType(req.Symbol), SymbolType(req.As), float64(req.CoinPercent), req.Params)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to create strategy - %s", err)
+	}
+
+	if err := s.addStrategy(strategy); err != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+	}
+
+	s.saveStrategy(strategy)
+
+	ps, err := strategyToProto(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	DefaultLogger.log(fmt.Sprintf("Created %s strategy: %s", strategy.Type(), strategy.ID()))
+
+	return &proto.CreateStrategyResponse{
+		Strategy: ps,
+	}, nil
+}
+
+// AttachStrategy attaches a copy of a strategy to a simulation or the LIVE portfolio
+func (s *server) AttachStrategy(ctx context.Context, req *proto.AttachStrategyRequest) (*proto.AttachStrategyResponse, error) {
+
+	if req.SimulationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "You must provide a simulation Id")
+	}
+
+	if req.StrategyId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "You must provide a strategy Id")
+	}
+
+	side, err := slotToSide(req.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	strategy, err := s.getStrategy(req.StrategyId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to get strategy - %s", err)
+	}
+
+	attached, err := copyStrategy(strategy)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to copy strategy - %s", err)
+	}
+
+	if req.SimulationId == LIVE_PORTFOLIO {
+		if s.livePortfolio == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "No live portfolio to attach strategy to")
+		}
+		// live strategies trade through the order manager
+		if err := s.livePortfolio.setStrategy(side, attached); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "Failed to attach strategy - %s", err)
+		}
+		attached.Start()
+
+		DefaultLogger.log(fmt.Sprintf("Attached %s strategy: %s to live portfolio", side, strategy.ID()))
+
+		return &proto.AttachStrategyResponse{}, nil
+	}
+
+	sim, err := s.getSimulation(req.SimulationId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to get simulation - %s", err)
+	}
+
+	if err := sim.attachStrategy(side, attached); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to attach strategy - %s", err)
+	}
+
+	sim.save()
+
+	DefaultLogger.log(fmt.Sprintf("Attached %s strategy: %s to simulation: %s", side, strategy.ID(), sim.id))
+
+	return &proto.AttachStrategyResponse{}, nil
+}
+
+// DetachStrategy removes a strategy from a simulation or the LIVE portfolio
+func (s *server) DetachStrategy(ctx context.Context, req *proto.DetachStrategyRequest) (*proto.DetachStrategyResponse, error) {
+
+	if req.SimulationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "You must provide a simulation Id")
+	}
+
+	if req.Symbol == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "You must provide a symbol")
+	}
+
+	side, err := slotToSide(req.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	if req.SimulationId == LIVE_PORTFOLIO {
+		if s.livePortfolio == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "No live portfolio to detach strategy from")
+		}
+		if err := s.livePortfolio.detachStrategy(SymbolType(req.Symbol), side); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "Failed to detach strategy - %s", err)
+		}
+
+		DefaultLogger.log(fmt.Sprintf("Detached %s strategy on: %s from live portfolio", side, req.Symbol))
+
+		return &proto.DetachStrategyResponse{}, nil
+	}
+
+	sim, err := s.getSimulation(req.SimulationId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to get simulation - %s", err)
+	}
+
+	if err := sim.detachStrategy(SymbolType(req.Symbol), side); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to detach strategy - %s", err)
+	}
+
+	sim.save()
+
+	DefaultLogger.log(fmt.Sprintf("Detached %s strategy on: %s from simulation: %s", side, req.Symbol, sim.id))
+
+	return &proto.DetachStrategyResponse{}, nil
+}
+
+// GetStrategies returns created strategies and those attached to portfolios
+func (s *server) GetStrategies(ctx context.Context, req *proto.GetStrategiesRequest) (*proto.GetStrategiesResponse, error) {
+
+	resp := &proto.GetStrategiesResponse{
+		Strategies: make([]*proto.Strategy, 0),
+		Attached:   make([]*proto.AttachedStrategy, 0),
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, strategy := range s.strategies {
+		ps, err := strategyToProto(strategy)
+		if err != nil {
+			return nil, err
+		}
+		resp.Strategies = append(resp.Strategies, ps)
+	}
+	sort.Slice(resp.Strategies, func(i, j int) bool { return resp.Strategies[i].Id < resp.Strategies[j].Id })
+
+	// attached to a single simulation or all portfolios
+	portfolios := make(map[string]*simulation)
+	if req.SimulationId != "" {
+		sim, ok := s.simulations[req.SimulationId]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "Simulation Id: %s not found", req.SimulationId)
+		}
+		portfolios[sim.id] = sim
+	} else {
+		for id, sim := range s.simulations {
+			portfolios[id] = sim
+		}
+
+		if s.livePortfolio != nil {
+			attached, err := s.livePortfolio.attachedStrategies(s.livePortfolio.name)
+			if err != nil {
+				return nil, err
+			}
+			resp.Attached = append(resp.Attached, attached...)
+		}
+	}
+
+	for id, sim := range portfolios {
+		sim.RLock()
+		attached, err := sim.portfolio.attachedStrategies(id)
+		sim.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		resp.Attached = append(resp.Attached, attached...)
+	}
+
+	sort.Slice(resp.Attached, func(i, j int) bool {
+		a, b := resp.Attached[i], resp.Attached[j]
+		if a.Portfolio != b.Portfolio {
+			return a.Portfolio < b.Portfolio
+		}
+		if a.Symbol != b.Symbol {
+			return a.Symbol < b.Symbol
+		}
+		return a.Slot < b.Slot
+	})
+
+	return resp, nil
+}
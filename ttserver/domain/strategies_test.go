@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestNewStrategy(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		strategyType StrategyType
+		params       map[string]float64
+		errExpected  bool
+		errText      string
+	}{
+		{
+			name:         "Do nothing",
+			strategyType: DO_NOTHING_STRATEGY,
+		},
+		{
+			name:         "Price above",
+			strategyType: PRICE_ABOVE_STRATEGY,
+			params:       map[string]float64{"abovePrice": 0.5},
+		},
+		{
+			name:         "Price below missing param",
+			strategyType: PRICE_BELOW_STRATEGY,
+			errExpected:  true,
+			errText:      `Parameter "belowPrice" must be provided`,
+		},
+		{
+			name:         "Unknown type",
+			strategyType: StrategyType("unknown"),
+			errExpected:  true,
+			errText:      `Strategy type "unknown" is not valid`,
+		},
+	}
+
+	for _, test := range tests {
+		strategy, err := NewStrategy(test.strategyType, "test-strategy", ETH, BTC, 50.0, test.params)
+		if test.errExpected {
+			if assert.Error(t, err, test.name) {
+				assert.Contains(t, err.Error(), test.errText, test.name)
+			}
+			continue
+		}
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.strategyType, strategy.Type(), test.name)
+			if test.params != nil {
+				assert.Equal(t, test.params, strategy.Params(), test.name)
+			}
+		}
+	}
+}
+
+func TestStrategyRPCs(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	sim, err := createTestSimulation(server)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	// create
+	createReq := &proto.CreateStrategyRequest{
+		Id:          "sell-eth",
+		Type:        string(PRICE_ABOVE_STRATEGY),
+		Symbol:      string(ETH),
+		As:          string(BTC),
+		CoinPercent: 50.0,
+		Params:      map[string]float64{"abovePrice": 0.5},
+	}
+	createResp, err := server.CreateStrategy(ctx, createReq)
+	assert.NoError(t, err)
+	if assert.NotNil(t, createResp.Strategy) {
+		assert.Equal(t, "sell-eth", createResp.Strategy.Id)
+		assert.Equal(t, string(PRICE_ABOVE_STRATEGY), createResp.Strategy.Type)
+		assert.Equal(t, 0.5, createResp.Strategy.Params["abovePrice"])
+	}
+
+	// duplicate id
+	_, err = server.CreateStrategy(ctx, createReq)
+	assert.Error(t, err)
+
+	// bad params
+	_, err = server.CreateStrategy(ctx, &proto.CreateStrategyRequest{Type: string(PRICE_BELOW_STRATEGY), Symbol: string(ETH), As: string(BTC)})
+	assert.Error(t, err)
+
+	// attach
+	_, err = server.AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: sim.id, StrategyId: "unknown", Slot: proto.StrategySlot_SELL})
+	assert.Error(t, err)
+
+	_, err = server.AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: "unknown", StrategyId: "sell-eth", Slot: proto.StrategySlot_SELL})
+	assert.Error(t, err)
+
+	_, err = server.AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: sim.id, StrategyId: "sell-eth", Slot: proto.StrategySlot_SELL})
+	assert.NoError(t, err)
+
+	created, err := server.getStrategy("sell-eth")
+	assert.NoError(t, err)
+
+	attached := sim.balances[ETH].SellStrategy
+	if assert.NotNil(t, attached) {
+		// simulations get their own copy of the strategy
+		assert.Equal(t, created.Description(), attached.Description())
+		assert.False(t, created == attached)
+	}
+
+	// attaching over a strategy stops the one it replaces
+	attached.Start()
+	_, err = server.AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: sim.id, StrategyId: "sell-eth", Slot: proto.StrategySlot_SELL})
+	assert.NoError(t, err)
+	assert.False(t, attached.IsRunning())
+	assert.False(t, attached == sim.balances[ETH].SellStrategy)
+
+	// list
+	listResp, err := server.GetStrategies(ctx, &proto.GetStrategiesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(listResp.Strategies))
+
+	// only strategies attached to the simulation
+	listResp, err = server.GetStrategies(ctx, &proto.GetStrategiesRequest{SimulationId: sim.id})
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(listResp.Attached)) {
+		assert.Equal(t, sim.id, listResp.Attached[0].Portfolio)
+		assert.Equal(t, string(ETH), listResp.Attached[0].Symbol)
+		assert.Equal(t, proto.StrategySlot_SELL, listResp.Attached[0].Slot)
+	}
+
+	_, err = server.GetStrategies(ctx, &proto.GetStrategiesRequest{SimulationId: "unknown"})
+	assert.Error(t, err)
+
+	// detach
+	_, err = server.DetachStrategy(ctx, &proto.DetachStrategyRequest{SimulationId: sim.id, Symbol: string(ETH), Slot: proto.StrategySlot_BUY})
+	assert.Error(t, err)
+
+	_, err = server.DetachStrategy(ctx, &proto.DetachStrategyRequest{SimulationId: sim.id, Symbol: string(ETH), Slot: proto.StrategySlot_SELL})
+	assert.NoError(t, err)
+	assert.Nil(t, sim.balances[ETH].SellStrategy)
+
+	listResp, err = server.GetStrategies(ctx, &proto.GetStrategiesRequest{SimulationId: sim.id})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(listResp.Attached))
+}
+
+func TestCreatedStrategiesAreSaved(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	dataDir, err := ioutil.TempDir("", "teletrada-strategies")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	s, err := initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = s.(*server).CreateStrategy(ctx, &proto.CreateStrategyRequest{
+		Id:          "sell-eth",
+		Type:        string(PRICE_ABOVE_STRATEGY),
+		Symbol:      string(ETH),
+		As:          string(BTC),
+		CoinPercent: 50.0,
+		Params:      map[string]float64{"abovePrice": 0.5},
+	})
+	assert.NoError(t, err)
+
+	created, err := s.(*server).getStrategy("sell-eth")
+	assert.NoError(t, err)
+
+	// strategies are reloaded when the server restarts
+	s, err = initMockServerWithDataDir(dataDir)
+	assert.NoError(t, err)
+
+	loaded, err := s.(*server).getStrategy("sell-eth")
+	if assert.NoError(t, err) {
+		assert.Equal(t, created.Description(), loaded.Description())
+		assert.Equal(t, created.Params(), loaded.Params())
+	}
+
+	// and can still be attached
+	sim, err := createTestSimulation(s.(*server))
+	assert.NoError(t, err)
+	_, err = s.(*server).AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: sim.id, StrategyId: "sell-eth", Slot: proto.StrategySlot_SELL})
+	assert.NoError(t, err)
+}
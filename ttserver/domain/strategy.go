@@ -2,12 +2,16 @@ package domain
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"time"
 )
 
 type Strategy interface {
 	ID() string
+	Type() StrategyType
+	Params() map[string]float64
 	Description() string
 	ConditionMet(at time.Time) (bool, error)
 	CoinPercent() float64
@@ -27,12 +31,134 @@ Buy/Sell Strategies
 --------------
 - (PriceBelowStrategy) price drops below value (v)
 - (PriceAboveStrategy) price rises above value (v) (Unlikely use for buying?)
-- price drops by (p) percent within (d) duration
-- price rises by (p) percent within (d) duration
-- price stops rising and starts dropping by (p) percent within (d) duration
+- (PriceDropStrategy) price drops by (p) percent within (d) duration
+- (PriceRiseStrategy) price rises by (p) percent within (d) duration
+- (TrailingStopStrategy) price stops rising and starts dropping by (p) percent or amount (a) from its peak
+- (TrailingBuyStrategy) price stops dropping and starts rising by (p) percent or amount (a) from its trough
+
+Indicator Strategies
+--------------
+- (CrossoverStrategy) fast SMA/EMA crosses above or below slow SMA/EMA
+- (RSIStrategy) RSI is above or below a threshold (t)
 
 */
 
+// StrategyType - identifies the kind of strategy so it can be recreated
+type StrategyType string
+
+const (
+	BASE_STRATEGY            StrategyType = "base"
+	DO_NOTHING_STRATEGY      StrategyType = "doNothing"
+	PRICE_ABOVE_STRATEGY     StrategyType = "priceAbove"
+	PRICE_BELOW_STRATEGY     StrategyType = "priceBelow"
+	PRICE_DROP_STRATEGY      StrategyType = "priceDrop"
+	PRICE_RISE_STRATEGY      StrategyType = "priceRise"
+	TRAILING_STOP_STRATEGY   StrategyType = "trailingStop"
+	TRAILING_BUY_STRATEGY    StrategyType = "trailingBuy"
+	SMA_CROSS_ABOVE_STRATEGY StrategyType = "smaCrossAbove"
+	SMA_CROSS_BELOW_STRATEGY StrategyType = "smaCrossBelow"
+	EMA_CROSS_ABOVE_STRATEGY StrategyType = "emaCrossAbove"
+	EMA_CROSS_BELOW_STRATEGY StrategyType = "emaCrossBelow"
+	RSI_ABOVE_STRATEGY       StrategyType = "rsiAbove"
+	RSI_BELOW_STRATEGY       StrategyType = "rsiBelow"
+)
+
+// strategyFactory - creates a strategy from its type specific parameters
+type strategyFactory func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error)
+
+var strategyFactories = map[StrategyType]strategyFactory{
+	BASE_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		return NewBaseStrategy(id, symbol, as, coinPercent)
+	},
+	DO_NOTHING_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		return NewDoNothingStrategy(id, symbol, as, coinPercent)
+	},
+	PRICE_ABOVE_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		abovePrice, err := requiredParam(params, "abovePrice")
+		if err != nil {
+			return nil, err
+		}
+		return NewPriceAboveStrategy(id, symbol, as, abovePrice, coinPercent)
+	},
+	PRICE_BELOW_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		belowPrice, err := requiredParam(params, "belowPrice")
+		if err != nil {
+			return nil, err
+		}
+		return NewPriceBelowStrategy(id, symbol, as, belowPrice, coinPercent)
+	},
+	PRICE_DROP_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		dropPercent, err := requiredParam(params, "dropPercent")
+		if err != nil {
+			return nil, err
+		}
+		withinSeconds, err := requiredParam(params, "withinSeconds")
+		if err != nil {
+			return nil, err
+		}
+		return NewPriceDropStrategy(id, symbol, as, dropPercent, time.Duration(withinSeconds*float64(time.Second)), coinPercent)
+	},
+	PRICE_RISE_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		risePercent, err := requiredParam(params, "risePercent")
+		if err != nil {
+			return nil, err
+		}
+		withinSeconds, err := requiredParam(params, "withinSeconds")
+		if err != nil {
+			return nil, err
+		}
+		return NewPriceRiseStrategy(id, symbol, as, risePercent, time.Duration(withinSeconds*float64(time.Second)), coinPercent)
+	},
+	TRAILING_STOP_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		return NewTrailingStopStrategy(id, symbol, as, params["retracePercent"], params["retraceAmount"], coinPercent)
+	},
+	TRAILING_BUY_STRATEGY: func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		return NewTrailingBuyStrategy(id, symbol, as, params["retracePercent"], params["retraceAmount"], coinPercent)
+	},
+	SMA_CROSS_ABOVE_STRATEGY: crossoverFactory(SMA_CROSS_ABOVE_STRATEGY),
+	SMA_CROSS_BELOW_STRATEGY: crossoverFactory(SMA_CROSS_BELOW_STRATEGY),
+	EMA_CROSS_ABOVE_STRATEGY: crossoverFactory(EMA_CROSS_ABOVE_STRATEGY),
+	EMA_CROSS_BELOW_STRATEGY: crossoverFactory(EMA_CROSS_BELOW_STRATEGY),
+	RSI_ABOVE_STRATEGY:       rsiFactory(RSI_ABOVE_STRATEGY),
+	RSI_BELOW_STRATEGY:       rsiFactory(RSI_BELOW_STRATEGY),
+}
+
+// NewStrategy - creates a strategy of any type
+func NewStrategy(strategyType StrategyType, id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+	factory, ok := strategyFactories[strategyType]
+	if !ok {
+		return nil, fmt.Errorf("Strategy type %q is not valid, must be one of %v", strategyType, StrategyTypes())
+	}
+	return factory(id, symbol, as, coinPercent, params)
+}
+
+// StrategyTypes - returns the types of strategy that can be created
+func StrategyTypes() []StrategyType {
+	types := make([]StrategyType, 0, len(strategyFactories))
+	for strategyType := range strategyFactories {
+		types = append(types, strategyType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// requiredDurationParam - returns a duration parameter provided in seconds
+func requiredDurationParam(params map[string]float64, name string) (time.Duration, error) {
+	seconds, err := requiredParam(params, name)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func requiredParam(params map[string]float64, name string) (float64, error) {
+	value, ok := params[name]
+	if !ok {
+		return 0, fmt.Errorf("Parameter %q must be provided", name)
+	}
+	return value, nil
+}
+
 type baseStrategy struct {
 	sync.RWMutex
 	id           string
@@ -95,6 +221,15 @@ func (b *baseStrategy) CoinPercent() float64 {
 	return b.coinPercent
 }
 
+func (b *baseStrategy) Type() StrategyType {
+	return BASE_STRATEGY
+}
+
+// Params - returns the type specific parameters of the strategy
+func (b *baseStrategy) Params() map[string]float64 {
+	return map[string]float64{}
+}
+
 // Description - returns a description of the strategy
 func (b *baseStrategy) Description() string {
 	return "Base strategy for building other strategies upon"
@@ -120,6 +255,11 @@ func (b *baseStrategy) IncCount() {
 	b.triggerCount++
 }
 
+// restoreCount - sets the trigger count of a saved strategy
+func (b *baseStrategy) restoreCount(count int) {
+	b.triggerCount = count
+}
+
 // Start - start the strategy running
 func (b *baseStrategy) Start() {
 	b.Lock()
@@ -150,6 +290,10 @@ func NewDoNothingStrategy(id string, symbol, as SymbolType, coinPercentage float
 	}
 }
 
+func (d *doNothingStrategy) Type() StrategyType {
+	return DO_NOTHING_STRATEGY
+}
+
 // Description - returns a description of the strategy
 func (d *doNothingStrategy) Description() string {
 	return "You say it best... when you say nothing at all..."
@@ -181,6 +325,14 @@ func NewPriceAboveStrategy(id string, symbol, as SymbolType, abovePrice, coinPer
 	}
 }
 
+func (p *priceAboveStrategy) Type() StrategyType {
+	return PRICE_ABOVE_STRATEGY
+}
+
+func (p *priceAboveStrategy) Params() map[string]float64 {
+	return map[string]float64{"abovePrice": p.abovePrice}
+}
+
 // Description - returns a description of the strategy
 func (p *priceAboveStrategy) Description() string {
 	return fmt.Sprintf("Price Above Strategy\nTriggered when %s price above %f %s - %3.2f%% of coins committed\n", p.symbol, p.abovePrice, p.as, p.coinPercent)
@@ -231,6 +383,14 @@ func NewPriceBelowStrategy(id string, symbol, as SymbolType, belowPrice, coinPer
 	}
 }
 
+func (p *priceBelowStrategy) Type() StrategyType {
+	return PRICE_BELOW_STRATEGY
+}
+
+func (p *priceBelowStrategy) Params() map[string]float64 {
+	return map[string]float64{"belowPrice": p.belowPrice}
+}
+
 // Description - returns a description of the strategy
 func (p *priceBelowStrategy) Description() string {
 	return fmt.Sprintf("Price Below Strategy\nTriggered when %s price below %f %s - %3.2f%% of coins committed\n", p.symbol, p.belowPrice, p.as, p.coinPercent)
@@ -259,3 +419,665 @@ func (p *priceBelowStrategy) ConditionMet(at time.Time) (bool, error) {
 
 	return false, nil
 }
+
+// priceChangePercent - returns the percentage change in price between (at - within) and at
+func priceChangePercent(symbol, as SymbolType, at time.Time, within time.Duration) (float64, error) {
+
+	price, err := DefaultArchive.GetPriceAs(symbol, as, at)
+	if err != nil {
+		return 0, err
+	}
+
+	previous, err := DefaultArchive.GetPriceAs(symbol, as, at.Add(-within))
+	if err != nil {
+		return 0, err
+	}
+
+	if previous.Price <= 0 {
+		return 0, fmt.Errorf("Price of %s as %s at %s must be greater than 0", symbol, as, previous.At)
+	}
+
+	return (price.Price - previous.Price) / previous.Price * 100.0, nil
+}
+
+type priceDropStrategy struct {
+	baseStrategy
+	dropPercent float64
+	within      time.Duration
+}
+
+// NewPriceDropStrategy - creates a PriceDrop Strategy
+func NewPriceDropStrategy(id string, symbol, as SymbolType, dropPercent float64, within time.Duration, coinPercentage float64) (Strategy, error) {
+
+	if bs, err := newBaseStrategy(id, symbol, as, coinPercentage); err != nil {
+		return nil, err
+	} else {
+		if dropPercent <= 0 {
+			return nil, fmt.Errorf("drop percent must be greater than 0")
+		}
+		if dropPercent >= 100 {
+			return nil, fmt.Errorf("drop percent must be less than 100")
+		}
+		if within <= 0 {
+			return nil, fmt.Errorf("within duration must be greater than 0")
+		}
+		return &priceDropStrategy{
+			baseStrategy: *bs,
+			dropPercent:  dropPercent,
+			within:       within,
+		}, nil
+	}
+}
+
+func (p *priceDropStrategy) Type() StrategyType {
+	return PRICE_DROP_STRATEGY
+}
+
+func (p *priceDropStrategy) Params() map[string]float64 {
+	return map[string]float64{"dropPercent": p.dropPercent, "withinSeconds": p.within.Seconds()}
+}
+
+// Description - returns a description of the strategy
+func (p *priceDropStrategy) Description() string {
+	return fmt.Sprintf("Price Drop Strategy\nTriggered when %s price as %s drops by %3.2f%% within %s - %3.2f%% of coins committed\n", p.symbol, p.as, p.dropPercent, p.within, p.coinPercent)
+}
+
+// ConditionMet - triggers when price has dropped by percentage within duration
+func (p *priceDropStrategy) ConditionMet(at time.Time) (bool, error) {
+
+	p.RLock()
+	defer p.RUnlock()
+
+	if !p.isRunning {
+		return false, nil
+	}
+
+	change, err := priceChangePercent(p.symbol, p.as, at, p.within)
+	if err != nil {
+		return false, fmt.Errorf("Failed to evaluate strategy %s - %s", p.id, err)
+	}
+
+	if change <= -p.dropPercent {
+		p.IncCount()
+		return true, nil
+	}
+
+	return false, nil
+}
+
+type priceRiseStrategy struct {
+	baseStrategy
+	risePercent float64
+	within      time.Duration
+}
+
+// NewPriceRiseStrategy - creates a PriceRise Strategy
+func NewPriceRiseStrategy(id string, symbol, as SymbolType, risePercent float64, within time.Duration, coinPercentage float64) (Strategy, error) {
+
+	if bs, err := newBaseStrategy(id, symbol, as, coinPercentage); err != nil {
+		return nil, err
+	} else {
+		if risePercent <= 0 {
+			return nil, fmt.Errorf("rise percent must be greater than 0")
+		}
+		if within <= 0 {
+			return nil, fmt.Errorf("within duration must be greater than 0")
+		}
+		return &priceRiseStrategy{
+			baseStrategy: *bs,
+			risePercent:  risePercent,
+			within:       within,
+		}, nil
+	}
+}
+
+func (p *priceRiseStrategy) Type() StrategyType {
+	return PRICE_RISE_STRATEGY
+}
+
+func (p *priceRiseStrategy) Params() map[string]float64 {
+	return map[string]float64{"risePercent": p.risePercent, "withinSeconds": p.within.Seconds()}
+}
+
+// Description - returns a description of the strategy
+func (p *priceRiseStrategy) Description() string {
+	return fmt.Sprintf("Price Rise Strategy\nTriggered when %s price as %s rises by %3.2f%% within %s - %3.2f%% of coins committed\n", p.symbol, p.as, p.risePercent, p.within, p.coinPercent)
+}
+
+// ConditionMet - triggers when price has risen by percentage within duration
+func (p *priceRiseStrategy) ConditionMet(at time.Time) (bool, error) {
+
+	p.RLock()
+	defer p.RUnlock()
+
+	if !p.isRunning {
+		return false, nil
+	}
+
+	change, err := priceChangePercent(p.symbol, p.as, at, p.within)
+	if err != nil {
+		return false, fmt.Errorf("Failed to evaluate strategy %s - %s", p.id, err)
+	}
+
+	if change >= p.risePercent {
+		p.IncCount()
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// trailing - tracks the extreme price seen since a strategy started and
+// how far the price has retraced from it
+type trailing struct {
+	retracePercent float64
+	retraceAmount  float64
+	extreme        float64
+	tracking       bool
+	triggered      bool // latched once the price has retraced until the strategy is started again
+}
+
+func newTrailing(retracePercent, retraceAmount float64) (trailing, error) {
+	if retracePercent < 0 || retraceAmount < 0 {
+		return trailing{}, fmt.Errorf("retrace percent and amount cannot be negative")
+	}
+	if retracePercent == 0 && retraceAmount == 0 {
+		return trailing{}, fmt.Errorf("retrace percent or amount must be provided")
+	}
+	if retracePercent != 0 && retraceAmount != 0 {
+		return trailing{}, fmt.Errorf("only one of retrace percent or amount can be provided")
+	}
+	return trailing{
+		retracePercent: retracePercent,
+		retraceAmount:  retraceAmount,
+	}, nil
+}
+
+func (t *trailing) params() map[string]float64 {
+	return map[string]float64{"retracePercent": t.retracePercent, "retraceAmount": t.retraceAmount}
+}
+
+// statefulStrategy - strategies that track prices between evaluations, their state is
+// saved with simulations so a resumed simulation carries on from where it was
+type statefulStrategy interface {
+	state() map[string]float64
+	restoreState(state map[string]float64)
+}
+
+func (t *trailing) trackingState() map[string]float64 {
+	tracking, triggered := 0.0, 0.0
+	if t.tracking {
+		tracking = 1.0
+	}
+	if t.triggered {
+		triggered = 1.0
+	}
+	return map[string]float64{"extreme": t.extreme, "tracking": tracking, "triggered": triggered}
+}
+
+func (t *trailing) restoreTracking(state map[string]float64) {
+	t.extreme = state["extreme"]
+	t.tracking = state["tracking"] != 0
+	t.triggered = state["triggered"] != 0
+}
+
+// resetTracking - forgets the extreme and any trigger when a strategy starts
+func (t *trailing) resetTracking() {
+	t.tracking = false
+	t.triggered = false
+}
+
+func (t *trailing) retraceDescription() string {
+	if t.retracePercent != 0 {
+		return fmt.Sprintf("%3.2f%%", t.retracePercent)
+	}
+	return fmt.Sprintf("%f", t.retraceAmount)
+}
+
+// retraced - updates the extreme with the latest price and returns true when
+// the price has moved back far enough from it. isMore is true when a price is
+// a new extreme, eg. higher for a peak or lower for a trough. It only returns
+// true once until the strategy is started again
+func (t *trailing) retraced(price float64, isMore func(price, extreme float64) bool) bool {
+
+	if t.triggered {
+		return false
+	}
+
+	if !t.tracking || isMore(price, t.extreme) {
+		t.extreme = price
+		t.tracking = true
+		return false
+	}
+
+	distance := math.Abs(t.extreme - price)
+
+	limit := t.retraceAmount
+	if t.retracePercent != 0 {
+		limit = t.extreme * t.retracePercent / 100.0
+	}
+
+	if distance < limit {
+		return false
+	}
+
+	// latched so a market that keeps moving doesn't trigger again every price
+	t.triggered = true
+	return true
+}
+
+type trailingStopStrategy struct {
+	baseStrategy
+	trailing
+}
+
+// NewTrailingStopStrategy - creates a TrailingStop Strategy, either retracePercent or retraceAmount must be provided
+func NewTrailingStopStrategy(id string, symbol, as SymbolType, retracePercent, retraceAmount, coinPercentage float64) (Strategy, error) {
+
+	if bs, err := newBaseStrategy(id, symbol, as, coinPercentage); err != nil {
+		return nil, err
+	} else {
+		if retracePercent >= 100 {
+			return nil, fmt.Errorf("retrace percent must be less than 100")
+		}
+		t, err := newTrailing(retracePercent, retraceAmount)
+		if err != nil {
+			return nil, err
+		}
+		return &trailingStopStrategy{
+			baseStrategy: *bs,
+			trailing:     t,
+		}, nil
+	}
+}
+
+func (t *trailingStopStrategy) Type() StrategyType {
+	return TRAILING_STOP_STRATEGY
+}
+
+func (t *trailingStopStrategy) Params() map[string]float64 {
+	return t.params()
+}
+
+// state - returns the peak being tracked
+func (t *trailingStopStrategy) state() map[string]float64 {
+	t.RLock()
+	defer t.RUnlock()
+	return t.trackingState()
+}
+
+// restoreState - carries on tracking a saved peak
+func (t *trailingStopStrategy) restoreState(state map[string]float64) {
+	t.Lock()
+	t.restoreTracking(state)
+	t.Unlock()
+}
+
+// Description - returns a description of the strategy
+func (t *trailingStopStrategy) Description() string {
+	return fmt.Sprintf("Trailing Stop Strategy\nTriggered when %s price as %s drops by %s from its peak - %3.2f%% of coins committed\n", t.symbol, t.as, t.retraceDescription(), t.coinPercent)
+}
+
+// Start - start the strategy running, tracking a new peak
+func (t *trailingStopStrategy) Start() {
+	t.Lock()
+	t.isRunning = true
+	t.resetTracking()
+	t.Unlock()
+}
+
+// ConditionMet - triggers when price drops back from the highest price seen since starting
+func (t *trailingStopStrategy) ConditionMet(at time.Time) (bool, error) {
+
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.isRunning {
+		return false, nil
+	}
+
+	price, err := DefaultArchive.GetPriceAs(t.symbol, t.as, at)
+	if err != nil {
+		return false, fmt.Errorf("Failed to evaluate strategy %s - %s", t.id, err)
+	}
+
+	higher := func(price, peak float64) bool { return price > peak }
+
+	if t.retraced(price.Price, higher) {
+		t.IncCount()
+		return true, nil
+	}
+
+	return false, nil
+}
+
+type trailingBuyStrategy struct {
+	baseStrategy
+	trailing
+}
+
+// NewTrailingBuyStrategy - creates a TrailingBuy Strategy, either retracePercent or retraceAmount must be provided
+func NewTrailingBuyStrategy(id string, symbol, as SymbolType, retracePercent, retraceAmount, coinPercentage float64) (Strategy, error) {
+
+	if bs, err := newBaseStrategy(id, symbol, as, coinPercentage); err != nil {
+		return nil, err
+	} else {
+		t, err := newTrailing(retracePercent, retraceAmount)
+		if err != nil {
+			return nil, err
+		}
+		return &trailingBuyStrategy{
+			baseStrategy: *bs,
+			trailing:     t,
+		}, nil
+	}
+}
+
+func (t *trailingBuyStrategy) Type() StrategyType {
+	return TRAILING_BUY_STRATEGY
+}
+
+func (t *trailingBuyStrategy) Params() map[string]float64 {
+	return t.params()
+}
+
+// state - returns the trough being tracked
+func (t *trailingBuyStrategy) state() map[string]float64 {
+	t.RLock()
+	defer t.RUnlock()
+	return t.trackingState()
+}
+
+// restoreState - carries on tracking a saved trough
+func (t *trailingBuyStrategy) restoreState(state map[string]float64) {
+	t.Lock()
+	t.restoreTracking(state)
+	t.Unlock()
+}
+
+// Description - returns a description of the strategy
+func (t *trailingBuyStrategy) Description() string {
+	return fmt.Sprintf("Trailing Buy Strategy\nTriggered when %s price as %s rises by %s from its trough - %3.2f%% of coins committed\n", t.symbol, t.as, t.retraceDescription(), t.coinPercent)
+}
+
+// Start - start the strategy running, tracking a new trough
+func (t *trailingBuyStrategy) Start() {
+	t.Lock()
+	t.isRunning = true
+	t.resetTracking()
+	t.Unlock()
+}
+
+// ConditionMet - triggers when price rises back from the lowest price seen since starting
+func (t *trailingBuyStrategy) ConditionMet(at time.Time) (bool, error) {
+
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.isRunning {
+		return false, nil
+	}
+
+	price, err := DefaultArchive.GetPriceAs(t.symbol, t.as, at)
+	if err != nil {
+		return false, fmt.Errorf("Failed to evaluate strategy %s - %s", t.id, err)
+	}
+
+	lower := func(price, trough float64) bool { return price < trough }
+
+	if t.retraced(price.Price, lower) {
+		t.IncCount()
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func crossoverFactory(strategyType StrategyType) strategyFactory {
+	return func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		fast, err := requiredDurationParam(params, "fastSeconds")
+		if err != nil {
+			return nil, err
+		}
+		slow, err := requiredDurationParam(params, "slowSeconds")
+		if err != nil {
+			return nil, err
+		}
+		interval, err := requiredDurationParam(params, "intervalSeconds")
+		if err != nil {
+			return nil, err
+		}
+		return NewCrossoverStrategy(strategyType, id, symbol, as, fast, slow, interval, coinPercent)
+	}
+}
+
+type crossoverStrategy struct {
+	baseStrategy
+	strategyType StrategyType
+	fast         time.Duration
+	slow         time.Duration
+	interval     time.Duration
+	exponential  bool
+	crossAbove   bool
+}
+
+// NewCrossoverStrategy - creates a moving average Crossover Strategy of one of the SMA/EMA cross above/below types
+func NewCrossoverStrategy(strategyType StrategyType, id string, symbol, as SymbolType, fast, slow, interval time.Duration, coinPercentage float64) (Strategy, error) {
+
+	if bs, err := newBaseStrategy(id, symbol, as, coinPercentage); err != nil {
+		return nil, err
+	} else {
+		c := &crossoverStrategy{
+			baseStrategy: *bs,
+			strategyType: strategyType,
+			fast:         fast,
+			slow:         slow,
+			interval:     interval,
+		}
+
+		switch strategyType {
+		case SMA_CROSS_ABOVE_STRATEGY:
+			c.crossAbove = true
+		case SMA_CROSS_BELOW_STRATEGY:
+		case EMA_CROSS_ABOVE_STRATEGY:
+			c.exponential = true
+			c.crossAbove = true
+		case EMA_CROSS_BELOW_STRATEGY:
+			c.exponential = true
+		default:
+			return nil, fmt.Errorf("Strategy type %q is not a crossover strategy", strategyType)
+		}
+
+		if fast >= slow {
+			return nil, fmt.Errorf("fast period must be shorter than slow period")
+		}
+		if _, err := indicatorSamples("fast", fast, interval); err != nil {
+			return nil, err
+		}
+		lookback := slow
+		if c.exponential {
+			lookback = slow * EMA_WARMUP
+		}
+		if _, err := indicatorSamples("slow", lookback, interval); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+}
+
+func (c *crossoverStrategy) Type() StrategyType {
+	return c.strategyType
+}
+
+func (c *crossoverStrategy) Params() map[string]float64 {
+	return map[string]float64{"fastSeconds": c.fast.Seconds(), "slowSeconds": c.slow.Seconds(), "intervalSeconds": c.interval.Seconds()}
+}
+
+// Description - returns a description of the strategy
+func (c *crossoverStrategy) Description() string {
+	average := "SMA"
+	if c.exponential {
+		average = "EMA"
+	}
+	direction := "below"
+	if c.crossAbove {
+		direction = "above"
+	}
+	return fmt.Sprintf("Crossover Strategy\nTriggered when %s %s %s crosses %s %s %s sampled every %s - %3.2f%% of coins committed\n", c.symbol, c.fast, average, direction, c.slow, average, c.interval, c.coinPercent)
+}
+
+// ConditionMet - triggers when the fast average crosses the slow average
+func (c *crossoverStrategy) ConditionMet(at time.Time) (bool, error) {
+
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.isRunning {
+		return false, nil
+	}
+
+	fastSamples := int(c.fast / c.interval)
+	slowSamples := int(c.slow / c.interval)
+
+	average := sma
+	count := slowSamples
+	if c.exponential {
+		average = ema
+		count = slowSamples * EMA_WARMUP
+	}
+
+	// one extra sample to compare against the previous averages
+	series, ok, err := priceSeries(c.symbol, c.as, at, c.interval, count+1)
+	if err != nil {
+		return false, fmt.Errorf("Failed to evaluate strategy %s - %s", c.id, err)
+	}
+	if !ok {
+		// not enough history yet
+		return false, nil
+	}
+
+	previous := series[:len(series)-1]
+	current := series[1:]
+
+	prevDiff := average(previous, fastSamples) - average(previous, slowSamples)
+	diff := average(current, fastSamples) - average(current, slowSamples)
+
+	crossed := false
+	if c.crossAbove {
+		crossed = prevDiff <= 0 && diff > 0
+	} else {
+		crossed = prevDiff >= 0 && diff < 0
+	}
+
+	if crossed {
+		c.IncCount()
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func rsiFactory(strategyType StrategyType) strategyFactory {
+	return func(id string, symbol, as SymbolType, coinPercent float64, params map[string]float64) (Strategy, error) {
+		period, err := requiredDurationParam(params, "periodSeconds")
+		if err != nil {
+			return nil, err
+		}
+		interval, err := requiredDurationParam(params, "intervalSeconds")
+		if err != nil {
+			return nil, err
+		}
+		threshold, err := requiredParam(params, "threshold")
+		if err != nil {
+			return nil, err
+		}
+		return NewRSIStrategy(strategyType, id, symbol, as, period, interval, threshold, coinPercent)
+	}
+}
+
+type rsiStrategy struct {
+	baseStrategy
+	strategyType StrategyType
+	period       time.Duration
+	interval     time.Duration
+	threshold    float64
+}
+
+// NewRSIStrategy - creates an RSI Strategy triggered when RSI is above or below a threshold
+func NewRSIStrategy(strategyType StrategyType, id string, symbol, as SymbolType, period, interval time.Duration, threshold, coinPercentage float64) (Strategy, error) {
+
+	if bs, err := newBaseStrategy(id, symbol, as, coinPercentage); err != nil {
+		return nil, err
+	} else {
+		if strategyType != RSI_ABOVE_STRATEGY && strategyType != RSI_BELOW_STRATEGY {
+			return nil, fmt.Errorf("Strategy type %q is not an RSI strategy", strategyType)
+		}
+		if threshold <= 0 || threshold >= 100 {
+			return nil, fmt.Errorf("threshold must be between 0 and 100")
+		}
+		if _, err := indicatorSamples("RSI", period, interval); err != nil {
+			return nil, err
+		}
+		return &rsiStrategy{
+			baseStrategy: *bs,
+			strategyType: strategyType,
+			period:       period,
+			interval:     interval,
+			threshold:    threshold,
+		}, nil
+	}
+}
+
+func (r *rsiStrategy) Type() StrategyType {
+	return r.strategyType
+}
+
+func (r *rsiStrategy) Params() map[string]float64 {
+	return map[string]float64{"periodSeconds": r.period.Seconds(), "intervalSeconds": r.interval.Seconds(), "threshold": r.threshold}
+}
+
+// Description - returns a description of the strategy
+func (r *rsiStrategy) Description() string {
+	direction := "below"
+	if r.strategyType == RSI_ABOVE_STRATEGY {
+		direction = "above"
+	}
+	return fmt.Sprintf("RSI Strategy\nTriggered when %s %s RSI sampled every %s is %s %3.2f - %3.2f%% of coins committed\n", r.symbol, r.period, r.interval, direction, r.threshold, r.coinPercent)
+}
+
+// ConditionMet - triggers when RSI is above or below the threshold
+func (r *rsiStrategy) ConditionMet(at time.Time) (bool, error) {
+
+	r.RLock()
+	defer r.RUnlock()
+
+	if !r.isRunning {
+		return false, nil
+	}
+
+	samples := int(r.period / r.interval)
+
+	series, ok, err := priceSeries(r.symbol, r.as, at, r.interval, samples+1)
+	if err != nil {
+		return false, fmt.Errorf("Failed to evaluate strategy %s - %s", r.id, err)
+	}
+	if !ok {
+		// not enough history yet
+		return false, nil
+	}
+
+	value := rsi(series, samples)
+
+	met := value < r.threshold
+	if r.strategyType == RSI_ABOVE_STRATEGY {
+		met = value > r.threshold
+	}
+
+	if met {
+		r.IncCount()
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/telecoda/teletrada/ttserver/servertime"
@@ -11,6 +12,43 @@ func strategySetup(t *testing.T) {
 	err := initMockClients(nil)
 	assert.NoError(t, err)
 }
+
+// strategyPricesSetup - replaces the default archive with hourly prices of a pair from start,
+// the default archive is restored when the test finishes
+func strategyPricesSetup(t *testing.T, symbol, as SymbolType, start time.Time, values []float64) {
+	strategySetup(t)
+
+	archive := DefaultArchive
+	t.Cleanup(func() { DefaultArchive = archive })
+	DefaultArchive = NewSymbolsArchive()
+
+	for i, value := range values {
+		assert.NoError(t, DefaultArchive.AddPrice(Price{Base: symbol, As: as, Price: value, At: start.Add(time.Duration(i) * time.Hour), Exchange: "exchange"}))
+	}
+}
+
+// assertStrategyTriggers - starts the strategies and checks the hours from start each one
+// triggers in, and that each can be recreated from its params
+func assertStrategyTriggers(t *testing.T, start time.Time, hours int, strategies []Strategy, expected map[string][]int) {
+	for _, strategy := range strategies {
+		strategy.Start()
+
+		triggered := make([]int, 0)
+		for i := 0; i < hours; i++ {
+			met, err := strategy.ConditionMet(start.Add(time.Duration(i) * time.Hour))
+			assert.NoError(t, err, strategy.ID())
+			if met {
+				triggered = append(triggered, i)
+			}
+		}
+		assert.Equal(t, expected[strategy.ID()], triggered, strategy.ID())
+		assert.Equal(t, len(expected[strategy.ID()]), strategy.TriggerCount(), strategy.ID())
+
+		copied, err := NewStrategy(strategy.Type(), strategy.ID(), strategy.Symbol(), strategy.As(), strategy.CoinPercent(), strategy.Params())
+		assert.NoError(t, err, strategy.ID())
+		assert.Equal(t, strategy.Description(), copied.Description())
+	}
+}
 func TestBaseStrategy(t *testing.T) {
 	symbol := SymbolType("test-symbol")
 	as := SymbolType("USDT")
@@ -177,3 +215,182 @@ func TestPriceBelowStrategy(t *testing.T) {
 	}
 
 }
+
+func TestPriceDropAndRiseStrategies(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	start := servertime.Now()
+	hour := time.Duration(1 * time.Hour)
+
+	symbol := SymbolType("test-symbol")
+	as := SymbolType("USDT")
+
+	// dropped 5%, risen 1%, risen 25%
+	strategyPricesSetup(t, symbol, as, start, []float64{100, 95, 96, 120})
+
+	drop, err := NewPriceDropStrategy("buy-dip", symbol, as, 4.0, hour, 50.0)
+	assert.NoError(t, err)
+	rise, err := NewPriceRiseStrategy("sell-rise", symbol, as, 20.0, hour, 50.0)
+	assert.NoError(t, err)
+
+	// not running
+	met, err := drop.ConditionMet(start.Add(hour))
+	assert.NoError(t, err)
+	assert.False(t, met)
+
+	assertStrategyTriggers(t, start, 4, []Strategy{drop, rise}, map[string][]int{
+		"buy-dip":   []int{1},
+		"sell-rise": []int{3},
+	})
+
+	// drop spread over longer than duration
+	met, err = drop.ConditionMet(start.Add(90 * time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, met)
+
+	// no prices
+	unknown, err := NewPriceDropStrategy("unknown", SymbolType("unknown"), as, 4.0, hour, 50.0)
+	assert.NoError(t, err)
+	unknown.Start()
+	_, err = unknown.ConditionMet(start)
+	assert.Error(t, err)
+
+	// invalid params
+	_, err = NewPriceDropStrategy("bad", symbol, as, 0, hour, 50.0)
+	assert.Error(t, err)
+	_, err = NewPriceDropStrategy("bad", symbol, as, 100, hour, 50.0)
+	assert.Error(t, err)
+	_, err = NewPriceRiseStrategy("bad", symbol, as, 10, 0, 50.0)
+	assert.Error(t, err)
+}
+
+func TestTrailingStrategies(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	start := servertime.Now()
+	hour := time.Duration(1 * time.Hour)
+
+	symbol := SymbolType("test-symbol")
+	as := SymbolType("USDT")
+
+	strategyPricesSetup(t, symbol, as, start, []float64{100, 110, 120, 115, 107, 100, 90, 80, 85, 95})
+
+	stopPct, err := NewTrailingStopStrategy("stop-pct", symbol, as, 10.0, 0, 50.0)
+	assert.NoError(t, err)
+	stopAmount, err := NewTrailingStopStrategy("stop-amount", symbol, as, 0, 5.0, 50.0)
+	assert.NoError(t, err)
+	buyPct, err := NewTrailingBuyStrategy("buy-pct", symbol, as, 10.0, 0, 50.0)
+	assert.NoError(t, err)
+
+	// hours each strategy is expected to trigger
+	assertStrategyTriggers(t, start, 10, []Strategy{stopPct, stopAmount, buyPct}, map[string][]int{
+		// peak 120 drops 10% at 107, then stays triggered as it keeps falling
+		"stop-pct": []int{4},
+		// peak 120 drops 5 at 115
+		"stop-amount": []int{3},
+		// trough 100 rises 10% at 110, not again when it rises from 80 as it has triggered
+		"buy-pct": []int{1},
+	})
+
+	// restarting forgets the peak and the trigger
+	stopPct.Stop()
+	stopPct.Start()
+	met, err := stopPct.ConditionMet(start.Add(9 * hour))
+	assert.NoError(t, err)
+	assert.False(t, met)
+	assert.NoError(t, DefaultArchive.AddPrice(Price{Base: symbol, As: as, Price: 80, At: start.Add(10 * hour), Exchange: "exchange"}))
+	met, err = stopPct.ConditionMet(start.Add(10 * hour))
+	assert.NoError(t, err)
+	assert.True(t, met)
+
+	// a market that keeps falling only triggers once
+	falling := SymbolType("falling-symbol")
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, DefaultArchive.AddPrice(Price{Base: falling, As: as, Price: 100 - 10*float64(i), At: start.Add(time.Duration(i) * hour), Exchange: "exchange"}))
+	}
+	stopFalling, err := NewTrailingStopStrategy("stop-falling", falling, as, 5.0, 0, 50.0)
+	assert.NoError(t, err)
+	stopFalling.Start()
+	for i := 0; i < 10; i++ {
+		_, err := stopFalling.ConditionMet(start.Add(time.Duration(i) * hour))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, stopFalling.TriggerCount())
+
+	// invalid params
+	_, err = NewTrailingStopStrategy("bad", symbol, as, 0, 0, 50.0)
+	assert.Error(t, err)
+	_, err = NewTrailingStopStrategy("bad", symbol, as, 10.0, 5.0, 50.0)
+	assert.Error(t, err)
+	_, err = NewTrailingStopStrategy("bad", symbol, as, 100.0, 0, 50.0)
+	assert.Error(t, err)
+	_, err = NewTrailingBuyStrategy("bad", symbol, as, -1.0, 0, 50.0)
+	assert.Error(t, err)
+}
+
+func TestIndicatorStrategies(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	start := servertime.Now()
+	hour := time.Duration(1 * time.Hour)
+
+	symbol := SymbolType("test-symbol")
+	as := SymbolType("USDT")
+
+	// flat, falling, rising then falling again
+	values := []float64{
+		100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100,
+		98, 96, 94, 92, 90,
+		95, 100, 105, 110, 115, 120,
+		118, 112, 104, 96,
+	}
+	strategyPricesSetup(t, symbol, as, start, values)
+
+	newCrossover := func(strategyType StrategyType) Strategy {
+		strategy, err := NewCrossoverStrategy(strategyType, string(strategyType), symbol, as, 2*hour, 4*hour, hour, 50.0)
+		assert.NoError(t, err)
+		return strategy
+	}
+	newRSI := func(strategyType StrategyType, threshold float64) Strategy {
+		strategy, err := NewRSIStrategy(strategyType, string(strategyType), symbol, as, 4*hour, hour, threshold, 50.0)
+		assert.NoError(t, err)
+		return strategy
+	}
+
+	strategies := []Strategy{
+		newCrossover(SMA_CROSS_ABOVE_STRATEGY),
+		newCrossover(SMA_CROSS_BELOW_STRATEGY),
+		newCrossover(EMA_CROSS_ABOVE_STRATEGY),
+		newCrossover(EMA_CROSS_BELOW_STRATEGY),
+		newRSI(RSI_ABOVE_STRATEGY, 70),
+		newRSI(RSI_BELOW_STRATEGY, 30),
+	}
+
+	// hours each strategy is expected to trigger, none without enough history
+	assertStrategyTriggers(t, start, len(values), strategies, map[string][]int{
+		string(SMA_CROSS_ABOVE_STRATEGY): []int{22},
+		string(SMA_CROSS_BELOW_STRATEGY): []int{16, 28},
+		string(EMA_CROSS_ABOVE_STRATEGY): []int{21},
+		string(EMA_CROSS_BELOW_STRATEGY): []int{16, 29},
+		string(RSI_ABOVE_STRATEGY):       []int{22, 23, 24, 25, 26, 27},
+		string(RSI_BELOW_STRATEGY):       []int{16, 17, 18, 19, 20, 29, 30},
+	})
+
+	// invalid params
+	_, err := NewCrossoverStrategy(SMA_CROSS_ABOVE_STRATEGY, "bad", symbol, as, 4*hour, 2*hour, hour, 50.0)
+	assert.Error(t, err)
+	_, err = NewCrossoverStrategy(PRICE_ABOVE_STRATEGY, "bad", symbol, as, 2*hour, 4*hour, hour, 50.0)
+	assert.Error(t, err)
+	_, err = NewCrossoverStrategy(SMA_CROSS_ABOVE_STRATEGY, "bad", symbol, as, 2*hour, 4*hour, 0, 50.0)
+	assert.Error(t, err)
+	_, err = NewRSIStrategy(RSI_ABOVE_STRATEGY, "bad", symbol, as, 4*hour, hour, 100, 50.0)
+	assert.Error(t, err)
+	_, err = NewRSIStrategy(PRICE_BELOW_STRATEGY, "bad", symbol, as, 4*hour, hour, 30, 50.0)
+	assert.Error(t, err)
+}
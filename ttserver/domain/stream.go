@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+/*
+
+Exchange clients that implement exchanges.PriceStreamer push prices as they
+change. Streamed prices are added to the archive as they arrive, then portfolios
+are repriced and strategies evaluated straight away instead of waiting for the
+next scheduled update. Prices that arrive together are handled as one update.
+
+The stream can tick many times a second, so only the latest of the prices of a
+pair that arrive together is kept and at most one streamed price of a pair is
+stored every update interval. Ticks of a pair within an interval of the last one
+stored are dropped, the scheduled update still polls the price at the end of it.
+
+Scheduled updates carry on polling the exchange while the stream is up so every
+trading pair still has a price every update, and they are all that is left when
+the stream drops. The stream is subscribed to again after STREAM_RETRY_DELAY,
+doubling up to STREAM_MAX_RETRY_DELAY while it keeps dropping without sending
+any prices.
+
+*/
+
+const (
+	STREAM_RETRY_DELAY     = time.Duration(5 * time.Second)
+	STREAM_MAX_RETRY_DELAY = time.Duration(5 * time.Minute)
+)
+
+// priceStream - state of the price stream from the exchange
+type priceStream struct {
+	sync.RWMutex
+	connected  bool
+	received   int       // prices received since the server started
+	lastPrice  time.Time // time of the latest price received
+	retryDelay time.Duration
+	storedAt   map[tradingPair]time.Time // time of the last streamed price of each pair stored
+}
+
+func newPriceStream() *priceStream {
+	return &priceStream{
+		retryDelay: STREAM_RETRY_DELAY,
+		storedAt:   make(map[tradingPair]time.Time),
+	}
+}
+
+// throttle - returns the latest price of each pair unless a streamed price of the pair was
+// stored within interval of it, the prices returned are recorded as stored
+func (p *priceStream) throttle(prices []Price, interval time.Duration) []Price {
+	p.Lock()
+	defer p.Unlock()
+
+	latest := make(map[tradingPair]Price)
+	for _, price := range prices {
+		pair := tradingPair{base: price.Base, as: price.As}
+		if held, ok := latest[pair]; !ok || price.At.After(held.At) {
+			latest[pair] = price
+		}
+	}
+
+	throttled := make([]Price, 0, len(latest))
+	for pair, price := range latest {
+		if storedAt, ok := p.storedAt[pair]; ok && price.At.Sub(storedAt) < interval {
+			continue
+		}
+		p.storedAt[pair] = price.At
+		throttled = append(throttled, price)
+	}
+
+	return throttled
+}
+
+func (p *priceStream) setConnected(connected bool) {
+	p.Lock()
+	defer p.Unlock()
+	p.connected = connected
+}
+
+func (p *priceStream) addReceived(count int, at time.Time) {
+	p.Lock()
+	defer p.Unlock()
+	p.received += count
+	if at.After(p.lastPrice) {
+		p.lastPrice = at
+	}
+}
+
+// streamStatus - a copy of the price stream state
+type streamStatus struct {
+	connected bool
+	received  int
+	lastPrice time.Time
+}
+
+func (p *priceStream) status() streamStatus {
+	p.RLock()
+	defer p.RUnlock()
+	return streamStatus{
+		connected: p.connected,
+		received:  p.received,
+		lastPrice: p.lastPrice,
+	}
+}
+
+// startPriceStream - subscribes to prices if the exchange client can stream them
+func (s *server) startPriceStream() {
+	streamer, ok := DefaultClient.(exchanges.PriceStreamer)
+	if !ok {
+		DefaultLogger.log(fmt.Sprintf("Exchange %s does not stream prices, polling every %s", DefaultClient.GetExchange(), s.config.UpdateFreq))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopStream = cancel
+
+	go s.streamPrices(ctx, streamer)
+}
+
+// streamPrices - receives streamed prices until ctx is done, subscribing again when the stream drops
+func (s *server) streamPrices(ctx context.Context, streamer exchanges.PriceStreamer) {
+	s.stream.RLock()
+	delay := s.stream.retryDelay
+	s.stream.RUnlock()
+
+	for {
+		prices, err := streamer.SubscribePrices(ctx)
+		if err != nil {
+			DefaultLogger.log(fmt.Sprintf("ERROR: subscribing to prices - %s", err))
+		} else {
+			s.stream.setConnected(true)
+			DefaultLogger.log("Price stream connected")
+
+			if s.receivePrices(prices) {
+				// reset backoff as the stream was working
+				s.stream.RLock()
+				delay = s.stream.retryDelay
+				s.stream.RUnlock()
+			}
+
+			s.stream.setConnected(false)
+		}
+
+		if ctx.Err() != nil {
+			DefaultLogger.log("Price stream stopped")
+			return
+		}
+
+		DefaultLogger.log(fmt.Sprintf("Price stream dropped, polling every %s and subscribing again in %s", s.config.UpdateFreq, delay))
+
+		select {
+		case <-ctx.Done():
+			DefaultLogger.log("Price stream stopped")
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > STREAM_MAX_RETRY_DELAY {
+			delay = STREAM_MAX_RETRY_DELAY
+		}
+	}
+}
+
+// receivePrices - updates with prices until the stream is closed, returns true if any were received
+func (s *server) receivePrices(prices <-chan exchanges.Price) bool {
+	received := false
+
+	for price := range prices {
+		batch := []exchanges.Price{price}
+
+		// include any other prices already waiting
+	waiting:
+		for {
+			select {
+			case next, ok := <-prices:
+				if !ok {
+					break waiting
+				}
+				batch = append(batch, next)
+			default:
+				break waiting
+			}
+		}
+
+		s.streamedUpdate(batch)
+		received = true
+	}
+
+	return received
+}
+
+// streamedUpdate - adds streamed prices to the archive then reprices portfolios and
+// evaluates strategies
+func (s *server) streamedUpdate(exPrices []exchanges.Price) {
+
+	prices := make([]Price, len(exPrices))
+	var latest time.Time
+	for i, exPrice := range exPrices {
+		prices[i] = Price{
+			Base:     SymbolType(exPrice.Base),
+			As:       SymbolType(exPrice.As),
+			Price:    exPrice.Price,
+			At:       exPrice.At,
+			Exchange: exPrice.Exchange,
+		}
+		if exPrice.At.After(latest) {
+			latest = exPrice.At
+		}
+	}
+
+	s.stream.addReceived(len(exPrices), latest)
+
+	prices = s.stream.throttle(prices, s.config.UpdateFreq)
+	if len(prices) == 0 {
+		// nothing has changed since the last update
+		return
+	}
+
+	// stored in one go, not once for every base
+	if _, err := DefaultArchive.AddAllPrices(prices); err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: adding streamed prices - %s", err))
+	}
+
+	s.updating.Lock()
+	defer s.updating.Unlock()
+
+	if err := s.repricePortfolios(); err != nil {
+		DefaultLogger.log(fmt.Sprintf("ERROR: repricing portfolios - %s", err))
+	}
+
+	now := servertime.Now()
+	s.updateRealtimeSimulations(now)
+	s.executeLiveStrategies(now)
+}
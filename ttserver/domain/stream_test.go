@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tspb "github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/telecoda/teletrada/exchanges"
+	"github.com/telecoda/teletrada/proto"
+	"github.com/telecoda/teletrada/ttserver/servertime"
+)
+
+func TestStreamedPrices(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+
+	ctx := context.Background()
+
+	current, err := DefaultArchive.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+
+	_, err = server.CreateStrategy(ctx, &proto.CreateStrategyRequest{
+		Id:          "sell-eth",
+		Type:        string(PRICE_ABOVE_STRATEGY),
+		Symbol:      string(ETH),
+		As:          string(BTC),
+		CoinPercent: 10.0,
+		Params:      map[string]float64{"abovePrice": current.Price * 2},
+	})
+	assert.NoError(t, err)
+
+	_, err = server.AttachStrategy(ctx, &proto.AttachStrategyRequest{SimulationId: LIVE_PORTFOLIO, StrategyId: "sell-eth", Slot: proto.StrategySlot_SELL})
+	assert.NoError(t, err)
+
+	server.orders.dryRun = true
+
+	// not triggered at the polled price
+	server.executeLiveStrategies(servertime.Now())
+	assert.Equal(t, 0, len(server.orders.getOrders()))
+
+	streamer, ok := DefaultClient.(exchanges.MockPriceStreamer)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	at := servertime.Now().Add(time.Second)
+	servertime.SetFakeTime(at)
+
+	streamer.ScriptPriceStream([]exchanges.Price{
+		{Base: ETH, As: BTC, Price: current.Price * 3, At: at, Exchange: exchanges.MOCK_EXCHANGE},
+		{Base: LTC, As: BTC, Price: 0.01, At: at, Exchange: exchanges.MOCK_EXCHANGE},
+	})
+
+	prices, err := streamer.SubscribePrices(ctx)
+	assert.NoError(t, err)
+	assert.True(t, server.receivePrices(prices))
+
+	latest, err := DefaultArchive.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, current.Price*3, latest.Price)
+	assert.Equal(t, at, latest.At)
+
+	// strategy triggered by the streamed price without a scheduled update
+	orders := server.orders.getOrders()
+	if assert.Equal(t, 1, len(orders)) {
+		assert.Equal(t, "sell-eth", orders[0].StrategyID)
+	}
+
+	rsp, err := server.GetStatus(ctx, &proto.GetStatusRequest{})
+	assert.NoError(t, err)
+	assert.False(t, rsp.PriceStreamConnected)
+	assert.Equal(t, int32(2), rsp.StreamedPrices)
+	lastStreamed, err := tspb.Timestamp(rsp.LastStreamedPrice)
+	assert.NoError(t, err)
+	assert.Equal(t, at, lastStreamed)
+
+	// ticks of a pair within an update interval of the last one stored are dropped
+	streamer.ScriptPriceStream([]exchanges.Price{
+		{Base: ETH, As: BTC, Price: current.Price * 4, At: at.Add(time.Second), Exchange: exchanges.MOCK_EXCHANGE},
+		{Base: ETH, As: BTC, Price: current.Price * 5, At: at.Add(2 * time.Second), Exchange: exchanges.MOCK_EXCHANGE},
+		{Base: ETH, As: BTC, Price: current.Price * 6, At: at.Add(server.config.UpdateFreq), Exchange: exchanges.MOCK_EXCHANGE},
+	})
+	prices, err = streamer.SubscribePrices(ctx)
+	assert.NoError(t, err)
+	for price := range prices {
+		server.streamedUpdate([]exchanges.Price{price})
+	}
+
+	eth, err := DefaultArchive.GetSymbol(ETH)
+	assert.NoError(t, err)
+	stored := eth.(*symbol).priceAs[BTC]
+	if assert.True(t, len(stored) > 2) {
+		stored = stored[len(stored)-2:]
+		assert.Equal(t, current.Price*3, stored[0].Price)
+		assert.Equal(t, current.Price*6, stored[1].Price)
+	}
+	assert.Equal(t, 5, server.stream.status().received)
+}
+
+func TestPriceStreamResubscribes(t *testing.T) {
+
+	servertime.UseFakeTime()
+	defer servertime.UseRealTime()
+
+	s, err := initMockServer()
+	assert.NoError(t, err)
+
+	// cast to internal type
+	server := s.(*server)
+	server.stream.retryDelay = time.Millisecond
+
+	streamer := DefaultClient.(exchanges.MockPriceStreamer)
+
+	at := servertime.Now()
+
+	// both streams drop after one price, the third stays connected
+	streamer.ScriptPriceStream([]exchanges.Price{{Base: ETH, As: BTC, Price: 0.1, At: at.Add(time.Second), Exchange: exchanges.MOCK_EXCHANGE}})
+	streamer.ScriptPriceStream([]exchanges.Price{{Base: ETH, As: BTC, Price: 0.2, At: at.Add(server.config.UpdateFreq + time.Second), Exchange: exchanges.MOCK_EXCHANGE}})
+
+	server.startPriceStream()
+
+	waitFor := func(condition func(streamStatus) bool) bool {
+		timeout := time.After(5 * time.Second)
+		for {
+			if condition(server.stream.status()) {
+				return true
+			}
+			select {
+			case <-timeout:
+				return false
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}
+
+	assert.True(t, waitFor(func(status streamStatus) bool { return status.received == 2 && status.connected }))
+
+	server.stopStream()
+	assert.True(t, waitFor(func(status streamStatus) bool { return !status.connected }))
+
+	latest, err := DefaultArchive.GetLatestPriceAs(ETH, BTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.2, latest.Price)
+}
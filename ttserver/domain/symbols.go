@@ -18,8 +18,11 @@ type Symbol interface {
 	GetAsTypes() []SymbolType
 	// Prices
 	AddPrice(price Price)
+	AddPrices(prices []Price) int
 	GetPriceAs(as SymbolType, at time.Time) (Price, error)
 	GetLatestPriceAs(as SymbolType) (Price, error)
+	GetPriceRange() (time.Time, time.Time, bool)
+	GetPriceGaps(maxGap time.Duration, until time.Time) []PriceGap
 	// Daily summary
 	AddDaySummary(sum DaySummary)
 	GetDaySummaryAs(as SymbolType) (DaySummary, error)
@@ -74,6 +77,78 @@ func (s *symbol) AddPrice(price Price) {
 	s.priceAs[price.As] = prices
 }
 
+// AddPrices - adds many prices in one go, prices at the same time as an existing
+// price are ignored. Returns the number of prices added
+func (s *symbol) AddPrices(newPrices []Price) int {
+	s.Lock()
+	defer s.Unlock()
+
+	added := 0
+	byAs := make(map[SymbolType][]Price)
+	for _, price := range newPrices {
+		byAs[price.As] = append(byAs[price.As], price)
+	}
+
+	for as, asPrices := range byAs {
+		existing := s.priceAs[as]
+		times := make(map[int64]bool, len(asPrices))
+
+		prices := existing
+		sorted := true
+		for _, price := range asPrices {
+			// only prices that aren't after the latest held can already be held,
+			// existing prices are sorted so they are searched for
+			if len(existing) > 0 && !price.At.After(existing[len(existing)-1].At) {
+				i := sort.Search(len(existing), func(i int) bool { return !existing[i].At.Before(price.At) })
+				if existing[i].At.Equal(price.At) {
+					continue
+				}
+			}
+			if times[price.At.UnixNano()] {
+				continue
+			}
+			times[price.At.UnixNano()] = true
+			if len(prices) > 0 && price.At.Before(prices[len(prices)-1].At) {
+				sorted = false
+			}
+			prices = append(prices, price)
+			added++
+		}
+
+		// sort in date order, streamed prices usually arrive in order
+		if !sorted {
+			sort.Slice(prices, func(i, j int) bool { return prices[i].At.Before(prices[j].At) })
+		}
+
+		s.priceAs[as] = prices
+	}
+
+	return added
+}
+
+// removePricesBefore - removes prices earlier than a time
+func (s *symbol) removePricesBefore(at time.Time) {
+	s.Lock()
+	defer s.Unlock()
+
+	for as, prices := range s.priceAs {
+		// prices are sorted by time
+		first := sort.Search(len(prices), func(i int) bool { return !prices[i].At.Before(at) })
+		if first > 0 {
+			s.priceAs[as] = append([]Price(nil), prices[first:]...)
+		}
+	}
+}
+
+// hasPriceBefore - returns true if a price as another symbol is held from before a time
+func (s *symbol) hasPriceBefore(as SymbolType, at time.Time) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	prices := s.priceAs[as]
+	return len(prices) > 0 && prices[0].At.Before(at)
+}
+
 func (s *symbol) AddDaySummary(sum DaySummary) {
 	s.Lock()
 	defer s.Unlock()
@@ -129,65 +204,84 @@ func (s *symbol) GetPriceAs(as SymbolType, at time.Time) (Price, error) {
 
 		}
 
-		/*
-
-			How time calcs work:
+		return priceBetween(priceBefore, priceAfter, at), nil
+	}
+}
 
-				 price 1  01:00:00 £1000.00
-				 price 2  05:00:00 £50000.00
+// priceBetween - returns the price at a time from the last price before it and the first
+// price at or after it. after is zero if there is no later price and before is after if there
+// is no earlier price
+func priceBetween(priceBefore, priceAfter Price, at time.Time) Price {
 
-				request time @ 1:00:00 get price £1000.00
-				request time @ 5:00:00 get price £5000.00
-				request time @ 2:00:00 get price £2000.00
+	/*
 
-				eg. checking a 2pm price
+		How time calcs work:
 
-				betweenPrices = afterDate - beforeDate
+			 price 1  01:00:00 £1000.00
+			 price 2  05:00:00 £50000.00
 
-				05:00:00 - 01:00:00 = 4 hours
+			request time @ 1:00:00 get price £1000.00
+			request time @ 5:00:00 get price £5000.00
+			request time @ 2:00:00 get price £2000.00
 
-				sinceBefore = priceAt - beforeDate
+			eg. checking a 2pm price
 
-				02:00:00 - 01:00:00 = 1 hour
+			betweenPrices = afterDate - beforeDate
 
-				priceChange = afterPrice - beforePrice
+			05:00:00 - 01:00:00 = 4 hours
 
-				5000.00 - 1000.00 = 4000.00
+			sinceBefore = priceAt - beforeDate
 
-				ratio = sinceBefore / betweenPrices
+			02:00:00 - 01:00:00 = 1 hour
 
-				1 hour / 4 hours = 1/4
+			priceChange = afterPrice - beforePrice
 
-				adjustedPrice = beforePrice + (priceChange * ratio)
+			5000.00 - 1000.00 = 4000.00
 
-				1000.00 + (1/4 * 4000.00) = 2000.00
+			ratio = sinceBefore / betweenPrices
 
+			1 hour / 4 hours = 1/4
 
-		*/
+			adjustedPrice = beforePrice + (priceChange * ratio)
 
-		betweenPrices := priceAfter.At.Sub(priceBefore.At)
+			1000.00 + (1/4 * 4000.00) = 2000.00
 
-		sinceBefore := at.Sub(priceBefore.At)
 
-		// before & after are the same
-		if betweenPrices == 0 {
-			priceAfter.At = at
-			return priceAfter, nil
-		}
+	*/
 
-		// adjust price to be between the two prices
-		priceChange := priceAfter.Price - priceBefore.Price
+	maxGap := getMaxPriceGap()
 
-		ratio := float64(sinceBefore.Nanoseconds()) / float64(betweenPrices.Nanoseconds())
+	// no later price, so latest price is the best we have
+	if priceAfter.At.IsZero() {
+		priceBefore.Interpolated = at.Sub(priceBefore.At) > priceGapLimit(maxGap, priceBefore.Interval)
+		priceBefore.At = at
+		return priceBefore
+	}
 
-		adjustedPrice := priceBefore.Price + (priceChange * ratio)
+	betweenPrices := priceAfter.At.Sub(priceBefore.At)
 
-		priceAdjusted := priceBefore
-		priceAdjusted.Price = adjustedPrice
-		priceAdjusted.At = at
-		return priceAdjusted, nil
+	sinceBefore := at.Sub(priceBefore.At)
 
+	// before & after are the same
+	if betweenPrices == 0 {
+		// requested time may be before the earliest price
+		priceAfter.Interpolated = priceAfter.At.Sub(at) > priceGapLimit(maxGap, priceAfter.Interval)
+		priceAfter.At = at
+		return priceAfter
 	}
+
+	// adjust price to be between the two prices
+	priceChange := priceAfter.Price - priceBefore.Price
+
+	ratio := float64(sinceBefore.Nanoseconds()) / float64(betweenPrices.Nanoseconds())
+
+	adjustedPrice := priceBefore.Price + (priceChange * ratio)
+
+	priceAdjusted := priceBefore
+	priceAdjusted.Price = adjustedPrice
+	priceAdjusted.At = at
+	priceAdjusted.Interpolated = isPriceGap(priceBefore, priceAfter, maxGap)
+	return priceAdjusted
 }
 
 // GetLatestPriceAs - returns the latest price of base symbol as another symbol
@@ -212,6 +306,33 @@ func (s *symbol) GetLatestPriceAs(as SymbolType) (Price, error) {
 	}
 }
 
+// GetPriceRange - returns the times of the earliest and latest prices held for the symbol
+func (s *symbol) GetPriceRange() (time.Time, time.Time, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	var from, to time.Time
+	found := false
+
+	for _, prices := range s.priceAs {
+		if len(prices) == 0 {
+			continue
+		}
+		// prices are sorted by time
+		first := prices[0].At
+		last := prices[len(prices)-1].At
+		if !found || first.Before(from) {
+			from = first
+		}
+		if !found || last.After(to) {
+			to = last
+		}
+		found = true
+	}
+
+	return from, to, found
+}
+
 // GetSymbolTypes returns list of available symbols
 func (s *server) GetSymbolTypes(ctx context.Context, req *proto.GetSymbolTypesRequest) (*proto.GetSymbolTypesResponse, error) {
 
@@ -71,6 +71,13 @@ func TestSymbolPrices(t *testing.T) {
 	assert.Equal(t, 10000.00, beforeUSDPrice.Price, "failed to get a price")
 	assert.Equal(t, "test_exchange", beforeUSDPrice.Exchange)
 
+	// get price after latest price
+	afterDate := today.Add(1 * time.Hour)
+	afterUSDPrice, err := symbol.GetPriceAs(USDT, afterDate)
+	assert.NoError(t, err)
+	assert.Equal(t, 20000.00, afterUSDPrice.Price, "failed to get latest price")
+	assert.Equal(t, afterDate, afterUSDPrice.At)
+
 	// get unknown symbol
 	unknown := SymbolType("unknown")
 	_, err = symbol.GetLatestPriceAs(unknown)
@@ -11,14 +11,25 @@ type tradeHistory struct {
 	trades []trade
 }
 
+type tradeSide string
+
+const (
+	BUY_TRADE  tradeSide = "BUY"
+	SELL_TRADE tradeSide = "SELL"
+)
+
 type trade struct {
-	symbol    string
-	quantity  float64
-	exchange  string
-	price     float64
-	fee       float64
-	totalCost float64
-	date      time.Time
+	side       tradeSide
+	symbol     SymbolType // symbol being bought or sold
+	as         SymbolType // symbol it was paid for with or sold into
+	quantity   float64    // quantity of symbol traded
+	exchange   string
+	price      float64 // price of symbol as the "as" symbol
+	fee        float64
+	feeSymbol  SymbolType // symbol the fee was paid in
+	totalCost  float64    // total cost (or proceeds) of the trade in "as" symbol
+	date       time.Time
+	strategyID string
 }
 
 type buy trade
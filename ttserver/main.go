@@ -6,8 +6,10 @@ import (
 	"log"
 	"net"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/telecoda/teletrada/exchanges"
 	"github.com/telecoda/teletrada/proto"
 	"github.com/telecoda/teletrada/ttserver/domain"
 	"google.golang.org/grpc"
@@ -28,17 +30,81 @@ const (
 
 type params struct {
 	useMock       bool
+	scenario      string
 	port          int
 	loadPricesDir string
 	updateFreq    time.Duration
 	verbose       bool
+	dataDir       string
+	dryRun        bool
+	repairGaps    bool
+	streamPrices  bool
+	recordDir     string
+	replayDir     string
+	replaySpeed   float64
+	// prices kept on disk
+	priceRetention time.Duration
+	archive        string
+	archiveCache   time.Duration
+	// other exchanges whose balances are tracked
+	otherExchanges string
+	// live trading risk limits
+	maxOrderSize     string
+	maxTradePercent  float64
+	maxDailyLoss     float64
+	maxOrdersPerHour int
+	// exchange requests
+	weightLimit     int
+	requestTimeout  time.Duration
+	maxRetries      int
+	retryDelay      time.Duration
+	breakerFailures int
+	breakerReset    time.Duration
+	// simulated trading costs
+	makerFee      float64
+	takerFee      float64
+	fillAsMaker   bool
+	bnbFees       bool
+	bnbDiscount   float64
+	slippage      float64
+	slippageCurve string
 }
 
 func (p *params) setup() {
 	flag.BoolVar(&p.useMock, "usemock", false, "Use mock exchange client")
+	flag.StringVar(&p.scenario, "scenario", "", "JSON file of the market the mock exchange runs, the default mock market if empty")
 	flag.BoolVar(&p.verbose, "v", false, "Verbose logging")
 	flag.DurationVar(&p.updateFreq, "updatefreq", time.Duration(60*time.Second), "Update frequency")
 	flag.IntVar(&p.port, "port", 13370, "Port for server to listen on")
+	flag.StringVar(&p.dataDir, "datadir", "", "Directory simulations, orders and prices are saved in, they are not saved if empty")
+	flag.DurationVar(&p.priceRetention, "priceretention", 0, "How long prices saved in the data directory are kept, 0 keeps them forever")
+	flag.StringVar(&p.loadPricesDir, "loadprices", "", "Directory of .json price files loaded into the archive at startup")
+	flag.StringVar(&p.archive, "archive", domain.ARCHIVE_MEMORY, "Where prices are archived, memory or bolt (a database in the data directory)")
+	flag.DurationVar(&p.archiveCache, "archivecache", time.Duration(24*time.Hour), "How long recent prices are held in memory when prices are archived in a database")
+	flag.BoolVar(&p.dryRun, "dryrun", true, "Log orders for the live portfolio instead of sending them to the exchange")
+	flag.BoolVar(&p.streamPrices, "streamprices", false, "Update as prices are pushed by the exchange as well as polling every update")
+	flag.BoolVar(&p.repairGaps, "repairgaps", false, "Backfill gaps in the price archive from exchange candles at startup")
+	flag.StringVar(&p.recordDir, "recorddir", "", "Directory exchange responses are recorded in, they are not recorded if empty")
+	flag.StringVar(&p.replayDir, "replaydir", "", "Directory of a recording to replay instead of using the exchange")
+	flag.Float64Var(&p.replaySpeed, "replayspeed", 60.0, "How many times faster than real time a recording is replayed")
+	flag.StringVar(&p.otherExchanges, "exchanges", "", "Other exchanges whose balances are tracked as well as the primary exchange eg. kraken (comma separated)")
+	flag.StringVar(&p.maxOrderSize, "maxordersize", "", "Max quantity of a symbol in one live order eg. BTC=0.5,ETH=10 (symbol=quantity)")
+	flag.Float64Var(&p.maxTradePercent, "maxtradepct", 0.0, "Max percentage of the live portfolio value in one order, 0 is unlimited")
+	flag.Float64Var(&p.maxDailyLoss, "maxdailyloss", 0.0, "Max realised loss in a day valued in BTC before the kill switch is engaged, 0 is unlimited")
+	flag.IntVar(&p.maxOrdersPerHour, "maxordersperhour", 0, "Max live orders in an hour, 0 is unlimited")
+	flag.IntVar(&p.weightLimit, "weightlimit", exchanges.BINANCE_WEIGHT_LIMIT, "Max request weight sent to the exchange a minute, 0 is unlimited")
+	flag.DurationVar(&p.requestTimeout, "requesttimeout", time.Duration(10*time.Second), "Deadline for each request to the exchange, 0 is no deadline")
+	flag.IntVar(&p.maxRetries, "maxretries", 3, "Retries of exchange requests that fail with timeouts or rate limits")
+	flag.DurationVar(&p.retryDelay, "retrydelay", time.Duration(500*time.Millisecond), "Wait before retrying an exchange request, doubled for each retry")
+	flag.IntVar(&p.breakerFailures, "breakerfailures", 5, "Exchange requests failing in a row before the circuit breaker opens, 0 disables it")
+	flag.DurationVar(&p.breakerReset, "breakerreset", time.Duration(time.Minute), "How long the circuit breaker stays open before trying the exchange again")
+	flag.Float64Var(&p.makerFee, "makerfee", 0.1, "Maker fee percentage applied to simulated trades")
+	flag.Float64Var(&p.takerFee, "takerfee", 0.1, "Taker fee percentage applied to simulated trades")
+	flag.BoolVar(&p.fillAsMaker, "fillasmaker", false, "Charge maker fees on simulated trades instead of taker fees")
+	flag.BoolVar(&p.bnbFees, "bnbfees", false, "Pay simulated trading fees in BNB")
+	flag.Float64Var(&p.bnbDiscount, "bnbdiscount", 25.0, "Discount percentage on fees paid in BNB")
+	flag.Float64Var(&p.slippage, "slippage", 0.0, "Fixed slippage in basis points applied to simulated trades")
+	flag.StringVar(&p.slippageCurve, "slippagecurve", "", "Slippage curve by trade value applied to simulated trades eg. 1000:5,10000:20 (value:bps)")
 }
 
 func main() {
@@ -46,14 +112,67 @@ func main() {
 	p.setup()
 	flag.Parse()
 
+	slippageCurve, err := domain.ParseSlippageCurve(p.slippageCurve)
+	if err != nil {
+		log.Fatalf("Invalid slippage curve: %v", err)
+	}
+
+	maxOrderSize, err := domain.ParseOrderSizeLimits(p.maxOrderSize)
+	if err != nil {
+		log.Fatalf("Invalid max order size: %v", err)
+	}
+
+	otherExchanges := make([]string, 0)
+	for _, name := range strings.Split(p.otherExchanges, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			otherExchanges = append(otherExchanges, name)
+		}
+	}
+
 	config := domain.Config{
 		UseMock:        p.useMock,
+		Scenario:       p.scenario,
 		InfluxDBName:   os.Getenv(INFLUX_DB_NAME),
 		InfluxUsername: os.Getenv(INFLUX_USERNAME),
 		InfluxPassword: os.Getenv(INFLUX_PASSWORD),
 		UpdateFreq:     p.updateFreq,
 		Verbose:        p.verbose,
 		Port:           p.port,
+		DataDir:        p.dataDir,
+		DryRun:         p.dryRun,
+		RepairGaps:     p.repairGaps,
+		StreamPrices:   p.streamPrices,
+		RecordDir:      p.recordDir,
+		ReplayDir:      p.replayDir,
+		ReplaySpeed:    p.replaySpeed,
+		Exchanges:      otherExchanges,
+		PriceRetention: p.priceRetention,
+		LoadPricesDir:  p.loadPricesDir,
+		Archive:        p.archive,
+		ArchiveCache:   p.archiveCache,
+		Risk: domain.RiskConfig{
+			MaxOrderSize:     maxOrderSize,
+			MaxTradePercent:  p.maxTradePercent,
+			MaxDailyLoss:     p.maxDailyLoss,
+			MaxOrdersPerHour: p.maxOrdersPerHour,
+		},
+		Resilience: exchanges.ResilienceConfig{
+			WeightLimit:     p.weightLimit,
+			RequestTimeout:  p.requestTimeout,
+			MaxRetries:      p.maxRetries,
+			RetryDelay:      p.retryDelay,
+			BreakerFailures: p.breakerFailures,
+			BreakerReset:    p.breakerReset,
+		},
+		Costs: domain.CostConfig{
+			MakerFeePercent:    p.makerFee,
+			TakerFeePercent:    p.takerFee,
+			FillAsMaker:        p.fillAsMaker,
+			PayFeesInBNB:       p.bnbFees,
+			BNBDiscountPercent: p.bnbDiscount,
+			SlippageBps:        p.slippage,
+			SlippageCurve:      slippageCurve,
+		},
 	}
 
 	// if no env vars, use defaults